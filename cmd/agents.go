@@ -307,12 +307,9 @@ func showOutdatedTable(agents []*registry.AgentDefinition) {
 		}
 
 		// Check for updates
-		if installed && r.current != "unknown" && result.latest != "" && result.latest != "manual install" && result.err == nil {
-			cmp, err := registry.CompareVersions(r.current, result.latest)
-			if err == nil && cmp < 0 {
-				r.hasUpdate = true
-				outdatedCount++
-			}
+		if installed && result.err == nil && isOutdated(r.current, result.latest) {
+			r.hasUpdate = true
+			outdatedCount++
 		}
 
 		rows[result.index] = r
@@ -669,6 +666,20 @@ func outputOutdatedJSON(rows []agentVersionRow) {
 }
 
 // isAgentInstalled checks if an agent CLI is available in PATH
+// isOutdated reports whether an installed version is older than the latest available one.
+// Unknown or missing versions and manually installed agents are never reported as outdated.
+func isOutdated(current, latest string) bool {
+	switch current {
+	case "", "unknown", "not installed":
+		return false
+	}
+	if latest == "" || latest == "manual install" {
+		return false
+	}
+	cmp, err := registry.CompareVersions(current, latest)
+	return err == nil && cmp < 0
+}
+
 func isAgentInstalled(command string) bool {
 	_, err := exec.LookPath(command)
 	return err == nil
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shawkym/agentpipe/pkg/conversation"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+var (
+	cleanOlderThan string
+	cleanDryRun    bool
+)
+
+var cleanCmd = &cobra.Command{
+	Use:   "clean --older-than <age>",
+	Short: "Remove old chat logs and saved states",
+	Long: `Remove chat logs and saved conversation states older than the given age
+from the default directories (~/.agentpipe/chats and ~/.agentpipe/states).
+
+Chat logs are the chat_*.log transcripts together with their per-agent log
+directories; saved states are the conversation-*.json files. Other files in
+those directories are left alone. The age is a number of days ("30d"), weeks
+("2w") or a Go duration ("12h").
+
+Examples:
+  agentpipe clean --older-than 30d --dry-run
+  agentpipe clean --older-than 2w`,
+	Args: cobra.NoArgs,
+	RunE: runClean,
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().StringVar(&cleanOlderThan, "older-than", "", "Remove files last modified longer ago than this (e.g. 30d, 2w, 12h)")
+	cleanCmd.Flags().BoolVar(&cleanDryRun, "dry-run", false, "List what would be removed without removing anything")
+	_ = cleanCmd.MarkFlagRequired("older-than")
+}
+
+// cleanCandidate is a chat log or saved state that clean may remove
+type cleanCandidate struct {
+	Path    string
+	ModTime time.Time
+	Size    int64
+	IsDir   bool
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	maxAge, err := parseAge(cleanOlderThan)
+	if err != nil {
+		return err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	stateDir, err := conversation.GetDefaultStateDir()
+	if err != nil {
+		return err
+	}
+
+	chatCandidates, err := listCleanCandidates(filepath.Join(homeDir, ".agentpipe", "chats"), isChatLogEntry)
+	if err != nil {
+		return err
+	}
+	stateCandidates, err := listCleanCandidates(stateDir, isStateEntry)
+	if err != nil {
+		return err
+	}
+
+	expired := selectExpired(append(chatCandidates, stateCandidates...), time.Now().Add(-maxAge))
+	return removeCandidates(cmd.OutOrStdout(), expired, cleanDryRun)
+}
+
+// parseAge parses an age given in days ("30d"), weeks ("2w") or as a Go duration ("12h")
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var age time.Duration
+	switch {
+	case strings.HasSuffix(s, "d") || strings.HasSuffix(s, "w"):
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid --older-than %q: expected a number of days (30d), weeks (2w) or a duration (12h)", s)
+		}
+		unit := 24 * time.Hour
+		if strings.HasSuffix(s, "w") {
+			unit *= 7
+		}
+		age = time.Duration(n) * unit
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid --older-than %q: expected a number of days (30d), weeks (2w) or a duration (12h)", s)
+		}
+		age = d
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("invalid --older-than %q: must be greater than zero", s)
+	}
+	return age, nil
+}
+
+// isChatLogEntry matches chat transcripts (chat_*.log) and their per-agent log directories
+func isChatLogEntry(name string, isDir bool) bool {
+	if !strings.HasPrefix(name, "chat_") {
+		return false
+	}
+	return isDir || strings.HasSuffix(name, ".log")
+}
+
+// isStateEntry matches saved conversation states (conversation-*.json)
+func isStateEntry(name string, isDir bool) bool {
+	return !isDir && strings.HasPrefix(name, "conversation-") && strings.HasSuffix(name, ".json")
+}
+
+// listCleanCandidates returns the entries of dir accepted by match. A missing
+// directory has nothing to clean.
+func listCleanCandidates(dir string, match func(name string, isDir bool) bool) ([]cleanCandidate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var candidates []cleanCandidate
+	for _, entry := range entries {
+		if !match(entry.Name(), entry.IsDir()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			log.WithError(err).WithField("path", filepath.Join(dir, entry.Name())).Warn("skipping file that can't be inspected")
+			continue
+		}
+		candidate := cleanCandidate{
+			Path:    filepath.Join(dir, entry.Name()),
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+			IsDir:   entry.IsDir(),
+		}
+		if candidate.IsDir {
+			candidate.Size = dirSize(candidate.Path)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// dirSize returns the total size of the regular files under dir
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// selectExpired returns the candidates last modified before cutoff, oldest first
+func selectExpired(candidates []cleanCandidate, cutoff time.Time) []cleanCandidate {
+	var expired []cleanCandidate
+	for _, c := range candidates {
+		if c.ModTime.Before(cutoff) {
+			expired = append(expired, c)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].ModTime.Before(expired[j].ModTime)
+	})
+	return expired
+}
+
+// removeCandidates removes the expired files, or only lists them on a dry run, and
+// reports what was freed
+func removeCandidates(w io.Writer, expired []cleanCandidate, dryRun bool) error {
+	if len(expired) == 0 {
+		fmt.Fprintln(w, "Nothing to clean.")
+		return nil
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+
+	var removed int
+	var freed int64
+	var failed []string
+	for _, c := range expired {
+		if !dryRun {
+			var err error
+			if c.IsDir {
+				err = os.RemoveAll(c.Path)
+			} else {
+				err = os.Remove(c.Path)
+			}
+			if err != nil {
+				log.WithError(err).WithField("path", c.Path).Warn("failed to remove file")
+				failed = append(failed, c.Path)
+				continue
+			}
+		}
+		fmt.Fprintf(w, "%s %s (%s, modified %s)\n", verb, c.Path, formatByteSize(c.Size), c.ModTime.Format("2006-01-02"))
+		removed++
+		freed += c.Size
+	}
+
+	fmt.Fprintf(w, "\n%s %d item(s), %s\n", verb, removed, formatByteSize(freed))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to remove %d item(s): %s", len(failed), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// formatByteSize renders a size in bytes using binary units
+func formatByteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "30d", want: 30 * 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "12h", want: 12 * time.Hour},
+		{in: "90m", want: 90 * time.Minute},
+		{in: "d", wantErr: true},
+		{in: "0d", wantErr: true},
+		{in: "-3d", wantErr: true},
+		{in: "soon", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAge(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseAge(%q) expected an error, got %v", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseAge(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestSelectExpired(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	candidates := []cleanCandidate{
+		{Path: "chats/chat_recent.log", ModTime: now.Add(-24 * time.Hour)},
+		{Path: "states/conversation-old.json", ModTime: now.Add(-40 * 24 * time.Hour)},
+		{Path: "chats/chat_oldest.log", ModTime: now.Add(-90 * 24 * time.Hour)},
+		{Path: "chats/chat_oldest", ModTime: now.Add(-90 * 24 * time.Hour), IsDir: true},
+		{Path: "chats/chat_boundary.log", ModTime: now.Add(-30 * 24 * time.Hour)},
+	}
+
+	expired := selectExpired(candidates, now.Add(-30*24*time.Hour))
+
+	var got []string
+	for _, c := range expired {
+		got = append(got, c.Path)
+	}
+	want := []string{"chats/chat_oldest.log", "chats/chat_oldest", "states/conversation-old.json"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v (oldest first, boundary kept), got %v", want, got)
+	}
+
+	if expired := selectExpired(nil, now); len(expired) != 0 {
+		t.Errorf("expected nothing from no candidates, got %v", expired)
+	}
+}
+
+func TestCleanEntryMatchers(t *testing.T) {
+	if !isChatLogEntry("chat_2024-01-02_15-04-05.log", false) || !isChatLogEntry("chat_2024-01-02_15-04-05", true) {
+		t.Error("expected chat transcripts and their per-agent log directories to match")
+	}
+	if isChatLogEntry("notes.txt", false) || isChatLogEntry("chat_2024.txt", false) {
+		t.Error("expected other files in the chats directory to be left alone")
+	}
+	if !isStateEntry("conversation-20240102-150405.json", false) {
+		t.Error("expected saved states to match")
+	}
+	if isStateEntry("conversation-20240102-150405.json", true) || isStateEntry("export.json", false) {
+		t.Error("expected other entries in the states directory to be left alone")
+	}
+}
+
+func TestListAndRemoveCandidates(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-60 * 24 * time.Hour)
+
+	write := func(name string, modTime time.Time) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("hello"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	oldLog := write("chat_old.log", old)
+	newLog := write("chat_new.log", time.Now())
+	unrelated := write("notes.txt", old)
+	agentDir := filepath.Join(dir, "chat_old")
+	if err := os.Mkdir(agentDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(agentDir, "alice.log"), []byte("hi"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(agentDir, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	candidates, err := listCleanCandidates(dir, isChatLogEntry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 3 {
+		t.Fatalf("expected 3 chat log entries, got %+v", candidates)
+	}
+	expired := selectExpired(candidates, time.Now().Add(-30*24*time.Hour))
+	if len(expired) != 2 {
+		t.Fatalf("expected the old log and its agent directory to expire, got %+v", expired)
+	}
+
+	var out bytes.Buffer
+	if err := removeCandidates(&out, expired, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Would remove "+oldLog) || !strings.Contains(out.String(), "Would remove 2 item(s), 7 B") {
+		t.Errorf("expected a dry-run report, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(oldLog); err != nil {
+		t.Error("expected a dry run to leave files in place")
+	}
+
+	out.Reset()
+	if err := removeCandidates(&out, expired, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Removed 2 item(s)") {
+		t.Errorf("expected a removal report, got:\n%s", out.String())
+	}
+	for _, path := range []string{oldLog, agentDir} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", path)
+		}
+	}
+	for _, path := range []string{newLog, unrelated} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("expected %s to be kept", path)
+		}
+	}
+}
+
+func TestListCleanCandidatesMissingDir(t *testing.T) {
+	candidates, err := listCleanCandidates(filepath.Join(t.TempDir(), "missing"), isStateEntry)
+	if err != nil || len(candidates) != 0 {
+		t.Errorf("expected nothing to clean in a missing directory, got %v, %v", candidates, err)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	for size, want := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	} {
+		if got := formatByteSize(size); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// redactedValue replaces secret values in rendered configs
+const redactedValue = "********"
+
+var (
+	configShowFormat  string
+	configShowSecrets bool
+)
+
+// configCmd groups configuration inspection commands
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect AgentPipe configuration",
+	Long:  `Inspect AgentPipe configuration files.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+// configShowCmd prints the fully-resolved configuration
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the resolved configuration",
+	Long: `Show the fully-resolved configuration after validation and defaults are applied.
+
+This prints exactly what a run would use, which helps debug why a conversation
+behaved unexpectedly. It accepts the same overrides as run (--agents, --mode,
+--max-turns, --var, ...) and renders the conversation template the same way.
+Secrets such as API keys, access tokens and passwords are redacted unless
+--show-secrets is passed; api_key_ref entries are shown but not resolved.
+
+Examples:
+  agentpipe config show --config examples/brainstorm.yaml
+  agentpipe config show --config config.yaml --format json
+  agentpipe config show --config config.yaml --mode reactive --var topic=pricing
+  agentpipe config show --agents claude:Alice,gemini:Bob
+  agentpipe config show --config config.yaml --show-secrets`,
+	RunE: runConfigShow,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	configShowCmd.Flags().StringVarP(&configShowFormat, "format", "f", "yaml", "Output format (yaml, json)")
+	configShowCmd.Flags().BoolVar(&configShowSecrets, "show-secrets", false, "Print secrets (API keys, tokens, passwords) instead of redacting them")
+	addConfigFlags(configShowCmd.Flags())
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	cfg, err := assembleConfig(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	output, err := renderResolvedConfig(cfg, configShowFormat, configShowSecrets)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), string(output))
+	return nil
+}
+
+// renderResolvedConfig renders a configuration as YAML or JSON.
+// Secrets are redacted unless showSecrets is true; the input config is never modified.
+func renderResolvedConfig(cfg *config.Config, format string, showSecrets bool) ([]byte, error) {
+	if !showSecrets {
+		cfg = redactConfig(cfg)
+	}
+
+	// Round-trip through YAML so JSON output uses the same field names as the config file
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	switch format {
+	case "yaml", "yml":
+		return data, nil
+	case "json":
+		var generic map[string]interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return nil, fmt.Errorf("failed to convert config: %w", err)
+		}
+		out, err := json.MarshalIndent(generic, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal config: %w", err)
+		}
+		return append(out, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported format: %s (use yaml or json)", format)
+	}
+}
+
+// redactConfig returns a copy of cfg with all secret values masked
+func redactConfig(cfg *config.Config) *config.Config {
+	redacted := *cfg
+
+	redacted.Agents = make([]agent.AgentConfig, len(cfg.Agents))
+	copy(redacted.Agents, cfg.Agents)
+	for i := range redacted.Agents {
+		redactString(&redacted.Agents[i].APIKey)
+		redactString(&redacted.Agents[i].Matrix.AccessToken)
+		redactString(&redacted.Agents[i].Matrix.Password)
+	}
+
+	redactString(&redacted.Bridge.APIKey)
+	redactString(&redacted.Matrix.AdminAccessToken)
+	redactString(&redacted.Matrix.AdminPassword)
+	redactString(&redacted.Matrix.Listener.AccessToken)
+	redactString(&redacted.Matrix.Listener.Password)
+
+	return &redacted
+}
+
+// redactString masks a non-empty secret in place
+func redactString(s *string) {
+	if *s != "" {
+		*s = redactedValue
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+func newShowTestConfig() *config.Config {
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "api-1", Type: "api", Name: "API", Model: "gpt-4o", APIKey: "sk-agent-secret", APIEndpoint: "https://example.com/v1"},
+		{ID: "claude-1", Type: "claude", Name: "Claude"},
+	}
+	cfg.Bridge.APIKey = "sk-bridge-secret"
+	cfg.Matrix.AdminPassword = "admin-secret"
+	return cfg
+}
+
+func TestRenderResolvedConfig(t *testing.T) {
+	cfg := newShowTestConfig()
+
+	tests := []struct {
+		name   string
+		format string
+		want   []string
+	}{
+		{
+			name:   "yaml",
+			format: "yaml",
+			want:   []string{"mode: round-robin", "max_turns: 10", "turn_timeout: 30s", "name: Claude"},
+		},
+		{
+			name:   "json",
+			format: "json",
+			want:   []string{`"mode": "round-robin"`, `"max_turns": 10`, `"name": "Claude"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := renderResolvedConfig(cfg, tt.format, false)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, out)
+				}
+			}
+		})
+	}
+
+	t.Run("json is valid", func(t *testing.T) {
+		out, err := renderResolvedConfig(cfg, "json", false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var parsed map[string]interface{}
+		if err := json.Unmarshal(out, &parsed); err != nil {
+			t.Fatalf("output is not valid JSON: %v", err)
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if _, err := renderResolvedConfig(cfg, "toml", false); err == nil {
+			t.Error("expected error for unsupported format")
+		}
+	})
+}
+
+func TestRenderResolvedConfigRedaction(t *testing.T) {
+	secrets := []string{"sk-agent-secret", "sk-bridge-secret", "admin-secret"}
+
+	t.Run("redacted by default", func(t *testing.T) {
+		cfg := newShowTestConfig()
+		out, err := renderResolvedConfig(cfg, "yaml", false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, secret := range secrets {
+			if strings.Contains(string(out), secret) {
+				t.Errorf("expected %q to be redacted", secret)
+			}
+		}
+		if !strings.Contains(string(out), redactedValue) {
+			t.Error("expected redaction marker in output")
+		}
+		// Original config must be untouched
+		if cfg.Agents[0].APIKey != "sk-agent-secret" || cfg.Bridge.APIKey != "sk-bridge-secret" {
+			t.Error("redaction modified the original config")
+		}
+	})
+
+	t.Run("show secrets", func(t *testing.T) {
+		out, err := renderResolvedConfig(newShowTestConfig(), "yaml", true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, secret := range secrets {
+			if !strings.Contains(string(out), secret) {
+				t.Errorf("expected %q in output with --show-secrets", secret)
+			}
+		}
+	})
+}
+
+func TestAssembleConfigAppliesRunOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `agents:
+  - id: claude-1
+    type: claude
+    name: Claude
+orchestrator:
+  initial_prompt: "Discuss {{.topic}}"
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	oldMode, oldMaxTurns, oldVars := mode, maxTurns, templateVars
+	defer func() { mode, maxTurns, templateVars = oldMode, oldMaxTurns, oldVars }()
+	mode, maxTurns, templateVars = "reactive", 3, []string{"topic=pricing"}
+
+	cfg, err := assembleConfig(path)
+	if err != nil {
+		t.Fatalf("assembleConfig() unexpected error: %v", err)
+	}
+	if cfg.Orchestrator.Mode != "reactive" || cfg.Orchestrator.MaxTurns != 3 {
+		t.Errorf("expected the --mode and --max-turns overrides, got %q and %d", cfg.Orchestrator.Mode, cfg.Orchestrator.MaxTurns)
+	}
+	if cfg.Orchestrator.InitialPrompt != "Discuss pricing" {
+		t.Errorf("expected the rendered template, got %q", cfg.Orchestrator.InitialPrompt)
+	}
+
+	if _, err := assembleConfig(""); err == nil {
+		t.Error("expected an error without --config or --agents")
+	}
+}
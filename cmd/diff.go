@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/conversation"
+)
+
+var diffContext int
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <state-a> <state-b>",
+	Short: "Compare two saved conversations",
+	Long: `Compare two saved conversation states message by message.
+
+The transcripts are aligned so shared messages line up, and the output shows
+where they diverge in a unified-diff style: messages only in the first state
+are prefixed with "-", messages only in the second with "+". Timestamps and
+metrics are ignored, which makes this useful for comparing prompt variations
+or model changes.
+
+Examples:
+  agentpipe diff run-a.json run-b.json
+  agentpipe diff run-a.json run-b.json --context 0`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().IntVarP(&diffContext, "context", "C", 2, "Number of unchanged messages to show around each change")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	stateA, err := conversation.LoadState(args[0])
+	if err != nil {
+		return err
+	}
+	stateB, err := conversation.LoadState(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := conversation.DiffMessages(stateA.Messages, stateB.Messages)
+	fmt.Fprint(cmd.OutOrStdout(), renderDiff(diff, args[0], args[1], diffContext))
+	return nil
+}
+
+// renderDiff renders a transcript diff in a unified-diff-like format.
+// Runs of unchanged messages longer than the context are collapsed.
+func renderDiff(d conversation.TranscriptDiff, nameA, nameB string, context int) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "--- %s\n", nameA)
+	fmt.Fprintf(&b, "+++ %s\n", nameB)
+
+	if d.Identical() {
+		fmt.Fprintf(&b, "Transcripts are identical (%d messages)\n", len(d.Lines))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Transcripts diverge at message %d\n", d.CommonPrefix+1)
+
+	if context < 0 {
+		context = 0
+	}
+
+	for start := 0; start < len(d.Lines); {
+		if d.Lines[start].Op != conversation.DiffEqual {
+			writeDiffMessage(&b, d.Lines[start])
+			start++
+			continue
+		}
+
+		// Find the end of this run of unchanged messages
+		end := start
+		for end < len(d.Lines) && d.Lines[end].Op == conversation.DiffEqual {
+			end++
+		}
+
+		// Keep context after the previous change and before the next one
+		head, tail := context, context
+		if start == 0 {
+			head = 0
+		}
+		if end == len(d.Lines) {
+			tail = 0
+		}
+
+		if end-start <= head+tail {
+			for _, line := range d.Lines[start:end] {
+				writeDiffMessage(&b, line)
+			}
+		} else {
+			for _, line := range d.Lines[start : start+head] {
+				writeDiffMessage(&b, line)
+			}
+			fmt.Fprintf(&b, "@@ %d unchanged messages @@\n", end-start-head-tail)
+			for _, line := range d.Lines[end-tail : end] {
+				writeDiffMessage(&b, line)
+			}
+		}
+		start = end
+	}
+
+	return b.String()
+}
+
+// writeDiffMessage writes a message with its diff marker on every content line
+func writeDiffMessage(b *strings.Builder, line conversation.DiffLine) {
+	marker := " "
+	switch line.Op {
+	case conversation.DiffRemoved:
+		marker = "-"
+	case conversation.DiffAdded:
+		marker = "+"
+	}
+
+	fmt.Fprintf(b, "%s [%s]\n", marker, diffSpeaker(line.Message))
+	for _, text := range strings.Split(strings.TrimRight(line.Message.Content, "\n"), "\n") {
+		fmt.Fprintf(b, "%s   %s\n", marker, text)
+	}
+}
+
+// diffSpeaker labels a message with its speaker and, for non-agent messages, its role
+func diffSpeaker(msg agent.Message) string {
+	if msg.Role == "" || msg.Role == "agent" {
+		return msg.AgentName
+	}
+	return fmt.Sprintf("%s (%s)", msg.AgentName, msg.Role)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/conversation"
+)
+
+func TestRenderDiff(t *testing.T) {
+	msg := func(name, content string) agent.Message {
+		return agent.Message{AgentName: name, Role: "agent", Content: content}
+	}
+	a := []agent.Message{
+		{AgentName: "HOST", Role: "system", Content: "topic"},
+		msg("Alice", "one"), msg("Bob", "two"), msg("Alice", "three"), msg("Bob", "four"),
+		msg("Alice", "original\nsecond line"),
+	}
+	b := append(append([]agent.Message(nil), a[:5]...), msg("Alice", "changed"))
+
+	out := renderDiff(conversation.DiffMessages(a, b), "a.json", "b.json", 1)
+
+	for _, want := range []string{
+		"--- a.json\n+++ b.json\n",
+		"Transcripts diverge at message 6",
+		"@@ 4 unchanged messages @@",
+		"  [Bob]\n    four\n",
+		"- [Alice]\n-   original\n-   second line\n",
+		"+ [Alice]\n+   changed\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected diff output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "topic") {
+		t.Errorf("expected leading unchanged messages to be collapsed, got:\n%s", out)
+	}
+
+	same := renderDiff(conversation.DiffMessages(a, a), "a.json", "a.json", 1)
+	if !strings.Contains(same, "Transcripts are identical (6 messages)") {
+		t.Errorf("expected identical summary, got:\n%s", same)
+	}
+}
@@ -7,19 +7,25 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/conversation"
 	"github.com/shawkym/agentpipe/pkg/export"
 )
 
 var exportCmd = &cobra.Command{
-	Use:   "export [log-file]",
+	Use:   "export [log-file | state-file]",
 	Short: "Export a conversation to different formats",
 	Long: `Export a conversation log file to JSON, Markdown, or HTML format.
 
 The export command reads a conversation log file and converts it to the specified
 format with optional metrics and timestamps.
 
+The sqlite format instead reads a saved conversation state (see --save-state)
+and appends its messages, participants, and summary to a SQLite database, so
+many conversations can be collected in one file and queried together.
+
 Examples:
   # Export to JSON
   agentpipe export ~/.agentpipe/chats/conversation_20231015.txt --format json
@@ -27,11 +33,17 @@ Examples:
   # Export to Markdown with metrics
   agentpipe export chat.txt --format markdown --metrics
 
+  # Export to Markdown with a metrics footnote on every agent message
+  agentpipe export chat.txt --format markdown --with-metrics
+
   # Export to HTML with custom title
   agentpipe export chat.txt --format html --title "Team Brainstorm"
 
   # Export latest conversation
   agentpipe export --latest --format markdown
+
+  # Add a saved conversation to a SQLite database
+  agentpipe export ~/.agentpipe/states/conversation-20231015.json --format sqlite --out db.sqlite
 `,
 	RunE: runExport,
 }
@@ -40,6 +52,7 @@ var (
 	exportFormat     string
 	exportOutput     string
 	exportMetrics    bool
+	exportFootnotes  bool
 	exportTimestamps bool
 	exportTitle      string
 	exportLatest     bool
@@ -48,15 +61,28 @@ var (
 func init() {
 	rootCmd.AddCommand(exportCmd)
 
-	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "markdown", "Export format (json, markdown, html)")
-	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout)")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "markdown", "Export format (json, markdown, html, sqlite)")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output file (default: stdout; required for sqlite)")
 	exportCmd.Flags().BoolVar(&exportMetrics, "metrics", true, "Include metrics (tokens, cost)")
+	exportCmd.Flags().BoolVar(&exportFootnotes, "with-metrics", false, "Markdown: add a metrics footnote (tokens, cost, duration, finish reason) to each agent message")
 	exportCmd.Flags().BoolVar(&exportTimestamps, "timestamps", true, "Include timestamps")
 	exportCmd.Flags().StringVar(&exportTitle, "title", "", "Conversation title")
 	exportCmd.Flags().BoolVar(&exportLatest, "latest", false, "Export the latest conversation")
+
+	// Accept --out as an alias for --output
+	exportCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "out" {
+			name = "output"
+		}
+		return pflag.NormalizedName(name)
+	})
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
+	if export.Format(strings.ToLower(exportFormat)) == export.FormatSQLite {
+		return runSQLiteExport(cmd, args)
+	}
+
 	// Determine input file
 	var inputFile string
 	if exportLatest {
@@ -96,7 +122,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	case export.FormatJSON, export.FormatMarkdown, export.FormatHTML:
 		// Valid format
 	default:
-		return fmt.Errorf("invalid format: %s (use json, markdown, or html)", exportFormat)
+		return fmt.Errorf("invalid format: %s (use json, markdown, html, or sqlite)", exportFormat)
 	}
 
 	// Set default title if not provided
@@ -109,6 +135,7 @@ func runExport(cmd *cobra.Command, args []string) error {
 	exporter := export.NewExporter(export.ExportOptions{
 		Format:            format,
 		IncludeMetrics:    exportMetrics,
+		MetricFootnotes:   exportFootnotes,
 		IncludeTimestamps: exportTimestamps,
 		Title:             title,
 	})
@@ -143,6 +170,28 @@ func runExport(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// runSQLiteExport appends a saved conversation state to a SQLite database
+func runSQLiteExport(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("state file path required for sqlite export")
+	}
+	if exportOutput == "" {
+		return fmt.Errorf("--out is required for sqlite export")
+	}
+
+	state, err := conversation.LoadState(args[0])
+	if err != nil {
+		return err
+	}
+
+	if _, err := export.ExportSQLite(exportOutput, state, args[0]); err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported %d messages to %s\n", len(state.Messages), exportOutput)
+	return nil
+}
+
 // readLogFile reads and parses a conversation log file.
 // This is a simplified implementation - in production, you'd want more robust parsing.
 func readLogFile(path string) ([]agent.Message, error) {
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readInteractivePrompt reads a multi-line initial prompt until EOF (Ctrl+D).
+// Surrounding whitespace is trimmed; an empty result means no prompt was entered.
+func readInteractivePrompt(reader *bufio.Reader, out io.Writer) (string, error) {
+	fmt.Fprintln(out, "Enter the initial prompt (press Ctrl+D on an empty line to finish):")
+
+	var b strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		b.WriteString(line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read prompt: %w", err)
+		}
+	}
+
+	return strings.TrimSpace(b.String()), nil
+}
+
+// isTerminal reports whether f is attached to an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
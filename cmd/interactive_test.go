@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadInteractivePrompt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "single line", input: "Discuss Go generics\n", want: "Discuss Go generics"},
+		{name: "multi line", input: "Debate the topic:\n\n- tabs vs spaces\n", want: "Debate the topic:\n\n- tabs vs spaces"},
+		{name: "no trailing newline", input: "  last line without newline", want: "last line without newline"},
+		{name: "empty", input: "\n\n", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			got, err := readInteractivePrompt(bufio.NewReader(strings.NewReader(tt.input)), &out)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !strings.Contains(out.String(), "Ctrl+D") {
+				t.Errorf("expected instructions to mention Ctrl+D, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestIsTerminalRegularFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if isTerminal(f) {
+		t.Error("expected a regular file not to be treated as a terminal")
+	}
+}
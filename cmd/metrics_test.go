@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+// echoAgent is a minimal agent that always replies with the same text
+type echoAgent struct {
+	agent.BaseAgent
+}
+
+func (a *echoAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	return "echo", nil
+}
+
+func (a *echoAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	_, err := io.WriteString(writer, "echo")
+	return err
+}
+
+func (a *echoAgent) IsAvailable() bool                     { return true }
+func (a *echoAgent) HealthCheck(ctx context.Context) error { return nil }
+func (a *echoAgent) GetCLIVersion() string                 { return "test" }
+
+func freePort(t *testing.T) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestStartMetricsServer(t *testing.T) {
+	port := freePort(t)
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:          orchestrator.ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(&echoAgent{BaseAgent: agent.BaseAgent{ID: "echo-1", Name: "Echo", Type: "echo"}})
+
+	stop, err := startMetricsServer(orch, port)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stop()
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/metrics", port)
+	var body string
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			data, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			body = string(data)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("metrics server did not respond: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	for _, want := range []string{"agentpipe_agent_requests_total", `agent_name="Echo"`, "agentpipe_conversation_turns_total"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected /metrics to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	stop()
+	if _, err := http.Get(url); err == nil {
+		t.Error("expected the metrics server to be stopped")
+	}
+}
+
+func TestStartMetricsServerInvalidPort(t *testing.T) {
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{}, nil)
+	for _, port := range []int{-1, 70000} {
+		if _, err := startMetricsServer(orch, port); err == nil {
+			t.Errorf("expected an error for port %d", port)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/shawkym/agentpipe/pkg/conversation"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+var reportFormat string
+
+var reportCmd = &cobra.Command{
+	Use:   "report <state-file>",
+	Short: "Print a post-mortem report for a saved conversation",
+	Long: `Print a structured report for a saved conversation state.
+
+The report lists the participants, total and per-agent metrics, response
+latency percentiles, message tags (such as warmup or drift refocus markers)
+and the generated summary, if one was saved.
+
+Examples:
+  agentpipe report ~/.agentpipe/states/conversation-20231215-143022.json
+  agentpipe report state.json --format markdown
+  agentpipe report state.json --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVarP(&reportFormat, "format", "f", "text", "Report format (text, markdown, json)")
+}
+
+// conversationReport aggregates the statistics shown by the report command
+type conversationReport struct {
+	StartedAt     time.Time           `json:"started_at"`
+	SavedAt       time.Time           `json:"saved_at"`
+	Duration      time.Duration       `json:"duration_ns"`
+	Mode          string              `json:"mode,omitempty"`
+	TotalMessages int                 `json:"total_messages"`
+	Participants  []participantReport `json:"participants"`
+	TotalTokens   int                 `json:"total_tokens"`
+	TotalCost     float64             `json:"total_cost"`
+	Latency       latencyReport       `json:"latency"`
+	Tags          map[string]int      `json:"tags"`
+	Summary       summaryReport       `json:"summary"`
+}
+
+// participantReport holds per-agent metrics
+type participantReport struct {
+	Name         string        `json:"name"`
+	Type         string        `json:"type,omitempty"`
+	Messages     int           `json:"messages"`
+	Tokens       int           `json:"tokens"`
+	Cost         float64       `json:"cost"`
+	ResponseTime time.Duration `json:"response_time_ns"`
+}
+
+// latencyReport mirrors orchestrator.LatencyStats with JSON-friendly field names
+type latencyReport struct {
+	Count int           `json:"count"`
+	P50   time.Duration `json:"p50_ns"`
+	P90   time.Duration `json:"p90_ns"`
+	P99   time.Duration `json:"p99_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// summaryReport holds the saved conversation summary
+type summaryReport struct {
+	ShortText string `json:"short_text,omitempty"`
+	Text      string `json:"text,omitempty"`
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	state, err := conversation.LoadState(args[0])
+	if err != nil {
+		return err
+	}
+
+	output, err := renderReport(buildReport(state), reportFormat)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// buildReport aggregates participant, metric, latency and tag statistics from a saved state
+func buildReport(state *conversation.State) *conversationReport {
+	report := &conversationReport{
+		StartedAt:     state.Metadata.StartedAt,
+		SavedAt:       state.SavedAt,
+		Duration:      time.Duration(state.Metadata.TotalDuration) * time.Millisecond,
+		TotalMessages: len(state.Messages),
+		Tags:          make(map[string]int),
+		Summary: summaryReport{
+			ShortText: state.Metadata.ShortText,
+			Text:      state.Metadata.Text,
+		},
+	}
+	if state.Config != nil {
+		report.Mode = state.Config.Orchestrator.Mode
+	}
+
+	// Index into report.Participants, which grows as agents are discovered
+	byName := make(map[string]int)
+	var durations []time.Duration
+
+	for _, msg := range state.Messages {
+		for tag, value := range msg.Metadata {
+			if flag, ok := value.(bool); ok && flag {
+				report.Tags[tag]++
+			}
+		}
+
+		if msg.Role != "agent" {
+			continue
+		}
+
+		idx, ok := byName[msg.AgentName]
+		if !ok {
+			idx = len(report.Participants)
+			report.Participants = append(report.Participants, participantReport{Name: msg.AgentName, Type: msg.AgentType})
+			byName[msg.AgentName] = idx
+		}
+		p := &report.Participants[idx]
+		p.Messages++
+
+		if msg.Metrics != nil {
+			p.Tokens += msg.Metrics.TotalTokens
+			p.Cost += msg.Metrics.Cost
+			p.ResponseTime += msg.Metrics.Duration
+			report.TotalTokens += msg.Metrics.TotalTokens
+			report.TotalCost += msg.Metrics.Cost
+			durations = append(durations, msg.Metrics.Duration)
+		}
+	}
+
+	// Participants that joined but never spoke still belong in the report
+	if state.Config != nil {
+		for _, a := range state.Config.Agents {
+			if _, ok := byName[a.Name]; !ok {
+				byName[a.Name] = len(report.Participants)
+				report.Participants = append(report.Participants, participantReport{Name: a.Name, Type: a.Type})
+			}
+		}
+	}
+
+	stats := orchestrator.ComputeLatencyStats(durations)
+	report.Latency = latencyReport{Count: stats.Count, P50: stats.P50, P90: stats.P90, P99: stats.P99, Max: stats.Max}
+
+	return report
+}
+
+// renderReport renders a report as text, markdown or JSON
+func renderReport(report *conversationReport, format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "text", "":
+		return renderTextReport(report), nil
+	case "markdown", "md":
+		return renderMarkdownReport(report), nil
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal report: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported format: %s (use text, markdown, or json)", format)
+	}
+}
+
+func renderTextReport(r *conversationReport) string {
+	var b strings.Builder
+	divider := strings.Repeat("=", 60)
+
+	b.WriteString("📊 Conversation Report\n")
+	b.WriteString(divider + "\n")
+	fmt.Fprintf(&b, "Started at:      %s\n", r.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "Duration:        %s\n", r.Duration.Round(time.Second))
+	if r.Mode != "" {
+		fmt.Fprintf(&b, "Mode:            %s\n", r.Mode)
+	}
+	fmt.Fprintf(&b, "Total messages:  %d\n", r.TotalMessages)
+	fmt.Fprintf(&b, "Total tokens:    %d\n", r.TotalTokens)
+	fmt.Fprintf(&b, "Total cost:      $%.4f\n", r.TotalCost)
+
+	b.WriteString("\nParticipants:\n")
+	for _, p := range r.Participants {
+		fmt.Fprintf(&b, "  - %s: %d messages, %d tokens, $%.4f, %s\n",
+			participantLabel(p), p.Messages, p.Tokens, p.Cost, formatLatency(p.ResponseTime))
+	}
+
+	b.WriteString("\nLatency:\n")
+	if r.Latency.Count == 0 {
+		b.WriteString("  No response metrics recorded\n")
+	} else {
+		fmt.Fprintf(&b, "  p50/p90/p99:   %s / %s / %s\n",
+			formatLatency(r.Latency.P50), formatLatency(r.Latency.P90), formatLatency(r.Latency.P99))
+		fmt.Fprintf(&b, "  Max:           %s\n", formatLatency(r.Latency.Max))
+	}
+
+	b.WriteString("\nTags:\n")
+	if len(r.Tags) == 0 {
+		b.WriteString("  None\n")
+	}
+	for _, tag := range sortedTags(r.Tags) {
+		fmt.Fprintf(&b, "  - %s: %d\n", tag, r.Tags[tag])
+	}
+
+	b.WriteString("\nSummary:\n")
+	b.WriteString("  " + summaryOrPlaceholder(r.Summary) + "\n")
+	b.WriteString(divider + "\n")
+
+	return b.String()
+}
+
+func renderMarkdownReport(r *conversationReport) string {
+	var b strings.Builder
+
+	b.WriteString("# Conversation Report\n\n")
+	fmt.Fprintf(&b, "- **Started at:** %s\n", r.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "- **Duration:** %s\n", r.Duration.Round(time.Second))
+	if r.Mode != "" {
+		fmt.Fprintf(&b, "- **Mode:** %s\n", r.Mode)
+	}
+	fmt.Fprintf(&b, "- **Total messages:** %d\n", r.TotalMessages)
+	fmt.Fprintf(&b, "- **Total tokens:** %d\n", r.TotalTokens)
+	fmt.Fprintf(&b, "- **Total cost:** $%.4f\n", r.TotalCost)
+
+	b.WriteString("\n## Participants\n\n")
+	b.WriteString("| Agent | Messages | Tokens | Cost | Response Time |\n")
+	b.WriteString("|-------|----------|--------|------|---------------|\n")
+	for _, p := range r.Participants {
+		fmt.Fprintf(&b, "| %s | %d | %d | $%.4f | %s |\n",
+			participantLabel(p), p.Messages, p.Tokens, p.Cost, formatLatency(p.ResponseTime))
+	}
+
+	b.WriteString("\n## Latency\n\n")
+	if r.Latency.Count == 0 {
+		b.WriteString("No response metrics recorded.\n")
+	} else {
+		fmt.Fprintf(&b, "- **p50:** %s\n", formatLatency(r.Latency.P50))
+		fmt.Fprintf(&b, "- **p90:** %s\n", formatLatency(r.Latency.P90))
+		fmt.Fprintf(&b, "- **p99:** %s\n", formatLatency(r.Latency.P99))
+		fmt.Fprintf(&b, "- **Max:** %s\n", formatLatency(r.Latency.Max))
+	}
+
+	b.WriteString("\n## Tags\n\n")
+	if len(r.Tags) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, tag := range sortedTags(r.Tags) {
+		fmt.Fprintf(&b, "- `%s`: %d\n", tag, r.Tags[tag])
+	}
+
+	b.WriteString("\n## Summary\n\n")
+	b.WriteString(summaryOrPlaceholder(r.Summary) + "\n")
+
+	return b.String()
+}
+
+// participantLabel formats an agent name with its type when known
+func participantLabel(p participantReport) string {
+	if p.Type == "" {
+		return p.Name
+	}
+	return fmt.Sprintf("%s (%s)", p.Name, p.Type)
+}
+
+// sortedTags returns tag names in a stable order
+func sortedTags(tags map[string]int) []string {
+	names := make([]string, 0, len(tags))
+	for tag := range tags {
+		names = append(names, tag)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// summaryOrPlaceholder prefers the full summary, then the short one
+func summaryOrPlaceholder(s summaryReport) string {
+	switch {
+	case s.Text != "":
+		return s.Text
+	case s.ShortText != "":
+		return s.ShortText
+	default:
+		return "No summary available"
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/conversation"
+)
+
+// writeSampleState saves a small conversation state and returns its path
+func writeSampleState(t *testing.T) string {
+	t.Helper()
+
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "claude-1", Type: "claude", Name: "Claude"},
+		{ID: "gemini-1", Type: "gemini", Name: "Gemini"},
+		{ID: "qwen-1", Type: "qwen", Name: "Quiet"},
+	}
+
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss testing"},
+		{AgentID: "claude-1", AgentName: "Claude", AgentType: "claude", Role: "agent", Content: "Hello",
+			Metadata: map[string]interface{}{"warmup": true},
+			Metrics:  &agent.ResponseMetrics{Duration: 200 * time.Millisecond, TotalTokens: 100, Cost: 0.01}},
+		{AgentID: "gemini-1", AgentName: "Gemini", AgentType: "gemini", Role: "agent", Content: "Hi",
+			Metrics: &agent.ResponseMetrics{Duration: 400 * time.Millisecond, TotalTokens: 50, Cost: 0.02}},
+		{AgentID: "claude-1", AgentName: "Claude", AgentType: "claude", Role: "agent", Content: "Bye",
+			Metrics: &agent.ResponseMetrics{Duration: 600 * time.Millisecond, TotalTokens: 25, Cost: 0.005}},
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Refocus",
+			Metadata: map[string]interface{}{"drift_refocus": true, "drift_score": 0.9}},
+	}
+
+	state := conversation.NewState(messages, cfg, time.Now().Add(-time.Minute))
+	state.Metadata.ShortText = "A short chat about testing."
+	state.Metadata.Text = "Claude and Gemini discussed testing strategies."
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := state.Save(path); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	return path
+}
+
+func TestBuildReport(t *testing.T) {
+	state, err := conversation.LoadState(writeSampleState(t))
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+
+	report := buildReport(state)
+
+	if report.TotalMessages != 5 {
+		t.Errorf("expected 5 messages, got %d", report.TotalMessages)
+	}
+	if report.TotalTokens != 175 {
+		t.Errorf("expected 175 tokens, got %d", report.TotalTokens)
+	}
+	if len(report.Participants) != 3 {
+		t.Fatalf("expected 3 participants, got %+v", report.Participants)
+	}
+	claude := report.Participants[0]
+	if claude.Name != "Claude" || claude.Messages != 2 || claude.Tokens != 125 {
+		t.Errorf("unexpected Claude stats: %+v", claude)
+	}
+	if quiet := report.Participants[2]; quiet.Name != "Quiet" || quiet.Messages != 0 {
+		t.Errorf("expected silent participant to be listed, got %+v", quiet)
+	}
+	if report.Latency.Count != 3 || report.Latency.Max != 600*time.Millisecond {
+		t.Errorf("unexpected latency stats: %+v", report.Latency)
+	}
+	if report.Tags["warmup"] != 1 || report.Tags["drift_refocus"] != 1 {
+		t.Errorf("unexpected tags: %v", report.Tags)
+	}
+	if _, ok := report.Tags["drift_score"]; ok {
+		t.Error("non-boolean metadata should not be reported as a tag")
+	}
+}
+
+func TestRenderReport(t *testing.T) {
+	state, err := conversation.LoadState(writeSampleState(t))
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	report := buildReport(state)
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{
+			format: "text",
+			want:   []string{"Conversation Report", "Participants:", "Claude (claude): 2 messages", "Latency:", "p50/p90/p99", "Tags:", "warmup: 1", "Summary:", "discussed testing strategies"},
+		},
+		{
+			format: "markdown",
+			want:   []string{"# Conversation Report", "## Participants", "| Gemini (gemini) | 1 | 50 |", "## Latency", "## Tags", "`drift_refocus`: 1", "## Summary"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := renderReport(report, tt.format)
+			if err != nil {
+				t.Fatalf("renderReport() error: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("expected %s report to contain %q, got:\n%s", tt.format, want, out)
+				}
+			}
+		})
+	}
+
+	t.Run("json", func(t *testing.T) {
+		out, err := renderReport(report, "json")
+		if err != nil {
+			t.Fatalf("renderReport() error: %v", err)
+		}
+		var decoded map[string]interface{}
+		if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+			t.Fatalf("invalid JSON report: %v", err)
+		}
+		for _, key := range []string{"participants", "latency", "tags", "summary", "total_cost"} {
+			if _, ok := decoded[key]; !ok {
+				t.Errorf("expected JSON report to contain %q", key)
+			}
+		}
+	})
+
+	if _, err := renderReport(report, "xml"); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -70,11 +70,15 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.agentpipe.yaml)")
 	rootCmd.PersistentFlags().Bool("verbose", false, "Enable verbose output")
+	rootCmd.PersistentFlags().String("log-level", "", "Log level: debug, info, warn or error (default info, or debug with --verbose)")
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "V", false, "Show version information")
 
 	if err := viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose")); err != nil {
 		fmt.Fprintf(os.Stderr, "Error binding verbose flag: %v\n", err)
 	}
+	if err := viper.BindPFlag("log-level", rootCmd.PersistentFlags().Lookup("log-level")); err != nil {
+		fmt.Fprintf(os.Stderr, "Error binding log-level flag: %v\n", err)
+	}
 }
 
 func initConfig() {
@@ -88,9 +92,10 @@ func initConfig() {
 	}
 
 	// Initialize logger first
-	level := zerolog.InfoLevel
-	if viper.GetBool("verbose") {
-		level = zerolog.DebugLevel
+	level, err := resolveLogLevel(viper.GetString("log-level"), viper.GetBool("verbose"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	if isJSONMode {
@@ -131,3 +136,15 @@ func initConfig() {
 		log.WithError(err).Debug("no config file found, using defaults")
 	}
 }
+
+// resolveLogLevel returns the level set by --log-level, falling back to debug with
+// --verbose and info otherwise. An explicit --log-level wins over --verbose.
+func resolveLogLevel(name string, verbose bool) (zerolog.Level, error) {
+	if name != "" {
+		return log.ParseLevelStrict(name)
+	}
+	if verbose {
+		return zerolog.DebugLevel, nil
+	}
+	return zerolog.InfoLevel, nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestResolveLogLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    string
+		verbose bool
+		want    zerolog.Level
+	}{
+		{name: "default", want: zerolog.InfoLevel},
+		{name: "verbose", verbose: true, want: zerolog.DebugLevel},
+		{name: "explicit level", flag: "warn", want: zerolog.WarnLevel},
+		{name: "explicit level wins over verbose", flag: "error", verbose: true, want: zerolog.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveLogLevel(tt.flag, tt.verbose)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveLogLevel(%q, %v) = %v, want %v", tt.flag, tt.verbose, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := resolveLogLevel("loud", false); err == nil || !strings.Contains(err.Error(), `"loud"`) {
+		t.Errorf("expected an error naming the invalid level, got %v", err)
+	}
+}
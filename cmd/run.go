@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -24,6 +29,7 @@ import (
 	"github.com/shawkym/agentpipe/pkg/conversation"
 	"github.com/shawkym/agentpipe/pkg/log"
 	"github.com/shawkym/agentpipe/pkg/logger"
+	"github.com/shawkym/agentpipe/pkg/metrics"
 	"github.com/shawkym/agentpipe/pkg/orchestrator"
 	"github.com/shawkym/agentpipe/pkg/tui"
 )
@@ -36,6 +42,7 @@ var (
 	turnTimeout        int
 	responseDelay      int
 	initialPrompt      string
+	promptSuffix       string
 	useTUI             bool
 	healthCheckTimeout int
 	chatLogDir         string
@@ -49,13 +56,37 @@ var (
 	noSummary          bool
 	summaryAgent       string
 	jsonOutput         bool
+	heartbeatInterval  int
+	maxAgents          int
+	agentTimeouts      []string
+	agentRates         []string
+	templateVars       []string
+	interactive        bool
+	deepHealthCheck    bool
+	checkUpdates       bool
+	explain            bool
+	prewarm            bool
+	streamResponses    bool
+	detectCollapse     bool
+	noRetries          bool
+	metricsPort        int
+	renderWidth        int
+	billingTag         string
+	dumpConfigPath     string
+	secretsFile        string
+	autosaveInterval   time.Duration
 )
 
+// defaultMaxAgents is the default upper bound on agents in a single conversation
+const defaultMaxAgents = 20
+
 var runCmd = &cobra.Command{
 	Use:   "run",
 	Short: "Start a conversation between AI agents",
 	Long: `Start a conversation between multiple AI agents. You can specify agents
-directly via command line flags or use a YAML configuration file.`,
+directly via command line flags or use a YAML configuration file.
+With --tui and neither --config nor --agents, an interactive picker lets you
+choose the agents before the conversation starts.`,
 	Run: runConversation,
 }
 
@@ -63,26 +94,53 @@ func init() {
 	rootCmd.AddCommand(runCmd)
 
 	runCmd.Flags().StringVarP(&configPath, "config", "c", "", "Path to YAML configuration file")
-	runCmd.Flags().StringSliceVarP(&agents, "agents", "a", []string{}, "Agents to use (e.g., claude:Assistant1,gemini:Assistant2)")
-	runCmd.Flags().StringVarP(&mode, "mode", "m", "round-robin", "Conversation mode (round-robin, reactive, free-form)")
-	runCmd.Flags().IntVar(&maxTurns, "max-turns", 10, "Maximum number of conversation turns")
-	runCmd.Flags().IntVar(&turnTimeout, "timeout", 30, "Turn timeout in seconds")
-	runCmd.Flags().IntVar(&responseDelay, "delay", 1, "Delay between responses in seconds")
-	runCmd.Flags().StringVarP(&initialPrompt, "prompt", "p", "", "Initial prompt to start the conversation")
+	addConfigFlags(runCmd.Flags())
+	runCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Type the initial prompt in the terminal when none is configured (non-TUI mode)")
 	runCmd.Flags().BoolVarP(&useTUI, "tui", "t", false, "Use TUI interface")
 	runCmd.Flags().Bool("skip-health-check", false, "Skip agent health checks (not recommended)")
 	runCmd.Flags().IntVar(&healthCheckTimeout, "health-check-timeout", 5, "Health check timeout in seconds")
-	runCmd.Flags().StringVar(&chatLogDir, "log-dir", "", "Directory to save chat logs (default: ~/.agentpipe/chats)")
-	runCmd.Flags().BoolVar(&disableLogging, "no-log", false, "Disable chat logging")
-	runCmd.Flags().BoolVar(&showMetrics, "metrics", false, "Show response metrics (duration, tokens, cost)")
+	runCmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "Check configured agent CLIs for updates in the background and print a notice (non-TUI mode)")
+	runCmd.Flags().BoolVar(&deepHealthCheck, "deep-health-check", false, "Also send each agent a trivial prompt to verify auth and model access (non-TUI mode)")
+	runCmd.Flags().IntVar(&metricsPort, "metrics-port", 0, "Serve Prometheus metrics on this port at /metrics during the run (requires --metrics)")
 	runCmd.Flags().BoolVar(&watchConfig, "watch-config", false, "Watch config file for changes and hot-reload (requires --config)")
 	runCmd.Flags().BoolVar(&saveState, "save-state", false, "Save conversation state on exit (to ~/.agentpipe/states)")
 	runCmd.Flags().StringVar(&stateFile, "state-file", "", "Specific file path to save conversation state")
+	runCmd.Flags().DurationVar(&autosaveInterval, "autosave-interval", 0, "Snapshot the conversation state to a rolling file at this interval during the run, e.g. 2m (non-TUI mode)")
 	runCmd.Flags().BoolVar(&streamEnabled, "stream", false, "Enable streaming to AgentPipe Web for this run (overrides config)")
 	runCmd.Flags().BoolVar(&noStream, "no-stream", false, "Disable streaming to AgentPipe Web for this run (overrides config)")
-	runCmd.Flags().BoolVar(&noSummary, "no-summary", false, "Disable conversation summary generation (overrides config)")
-	runCmd.Flags().StringVar(&summaryAgent, "summary-agent", "", "Agent to use for summary generation (default: gemini, overrides config)")
 	runCmd.Flags().BoolVar(&jsonOutput, "json", false, "Output events in JSON format (JSONL)")
+	runCmd.Flags().IntVar(&heartbeatInterval, "heartbeat-interval", int(bridge.DefaultHeartbeatInterval/time.Second), "With --json, seconds between heartbeat events while an agent is responding (0 disables)")
+	runCmd.Flags().StringVar(&secretsFile, "secrets-file", "", "File of name=key lines used to resolve agents' api_key_ref (falls back to the OS keyring)")
+	runCmd.Flags().StringVar(&dumpConfigPath, "dump-config", "", "Write the assembled configuration to this YAML file instead of running")
+	runCmd.MarkFlagsMutuallyExclusive("deep-health-check", "skip-health-check")
+}
+
+// addConfigFlags registers the flags that shape the assembled configuration, so that
+// `config show` accepts the same overrides as run
+func addConfigFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVarP(&agents, "agents", "a", []string{}, "Agents to use (e.g., claude:Assistant1,gemini:Assistant2)")
+	fs.StringVarP(&mode, "mode", "m", "round-robin", "Conversation mode (round-robin, reactive, free-form)")
+	fs.IntVar(&maxTurns, "max-turns", 10, "Maximum number of conversation turns")
+	fs.IntVar(&turnTimeout, "timeout", 30, "Turn timeout in seconds")
+	fs.IntVar(&responseDelay, "delay", 1, "Delay between responses in seconds")
+	fs.StringVarP(&initialPrompt, "prompt", "p", "", "Initial prompt to start the conversation")
+	fs.StringVar(&promptSuffix, "prompt-suffix", "", "Instruction appended to every agent turn (e.g., \"Respond in under 100 words.\")")
+	fs.BoolVar(&explain, "explain", false, "Log the exact prompt sent to each agent on its first turn")
+	fs.BoolVar(&detectCollapse, "detect-collapse", false, "Warn when agents keep giving near-identical responses and report them in the session summary")
+	fs.BoolVar(&noRetries, "no-retries", false, "Give each agent response a single attempt, without retries")
+	fs.BoolVar(&prewarm, "prewarm", false, "Open each HTTP-based agent's connection before the first turn")
+	fs.BoolVar(&streamResponses, "stream-responses", false, "Print agent replies to stderr as they are generated (agents that support streaming)")
+	fs.StringVar(&chatLogDir, "log-dir", "", "Directory to save chat logs (default: ~/.agentpipe/chats)")
+	fs.BoolVar(&disableLogging, "no-log", false, "Disable chat logging")
+	fs.BoolVar(&showMetrics, "metrics", false, "Show response metrics (duration, tokens, cost)")
+	fs.StringVar(&billingTag, "billing-tag", "", "Attribute every response's cost to this project tag (stored with message metrics, events and exports)")
+	fs.IntVar(&renderWidth, "render-width", 0, "Wrap agent responses to this many columns, e.g. when piping console output to a file (0 = no wrap)")
+	fs.BoolVar(&noSummary, "no-summary", false, "Disable conversation summary generation (overrides config)")
+	fs.StringVar(&summaryAgent, "summary-agent", "", "Agent to use for summary generation (default: gemini, overrides config)")
+	fs.StringArrayVar(&agentTimeouts, "agent-timeout", nil, "Per-agent timeout override as name=seconds (repeatable)")
+	fs.StringArrayVar(&templateVars, "var", nil, "Template variable as key=value for {{.Key}} placeholders in prompts (repeatable)")
+	fs.StringArrayVar(&agentRates, "agent-rate", nil, "Per-agent rate limit override as name=rps[:burst] (repeatable, 0 rps = unlimited)")
+	fs.IntVar(&maxAgents, "max-agents", defaultMaxAgents, "Maximum number of agents allowed in a conversation (0 disables the check)")
 }
 
 func runConversation(cobraCmd *cobra.Command, args []string) {
@@ -93,6 +151,9 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 	// If --json mode, use the globalJSONEmitter created in initConfig
 	if jsonOutput {
 		stdoutEmitter = globalJSONEmitter
+		if stdoutEmitter != nil {
+			stdoutEmitter.SetHeartbeatInterval(time.Duration(heartbeatInterval) * time.Second)
+		}
 	}
 
 	if configPath != "" {
@@ -110,21 +171,135 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 		}).Info("configuration loaded successfully")
 	} else if len(agents) > 0 {
 		log.WithField("agent_count", len(agents)).Debug("creating configuration from CLI arguments")
+		cfg, err = configFromAgentSpecs(agents)
+		if err != nil {
+			log.WithError(err).Error("failed to parse agent specification")
+			fmt.Fprintf(os.Stderr, "Error parsing agent spec: %v\n", err)
+			os.Exit(1)
+		}
+	} else if useTUI {
+		// No agents given: let the user pick them interactively
+		agentCfgs, err := tui.RunAgentPicker()
+		if errors.Is(err, tui.ErrPickerCancelled) {
+			fmt.Println("No agents selected.")
+			return
+		}
+		if err != nil {
+			log.WithError(err).Error("agent picker failed")
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		cfg = config.NewDefaultConfig()
-		for i, agentSpec := range agents {
-			agentCfg, err := parseAgentSpec(agentSpec, i)
+		cfg.Agents = agentCfgs
+		// Picked agents left without a model inherit default_models like --agents specs do
+		cfg.DefaultModels = viper.GetStringMapString("default_models")
+		cfg.ApplyDefaultModels()
+	} else {
+		log.Error("no configuration source specified (need --config or --agents)")
+		fmt.Fprintf(os.Stderr, "Error: Either --config or --agents must be specified (or use --tui to pick agents)\n")
+		os.Exit(1)
+	}
+
+	if autosaveInterval < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --autosave-interval must not be negative\n")
+		os.Exit(1)
+	}
+
+	// The template is applied with the overrides, so a template's initial_prompt counts
+	// as configured; an interactively typed prompt is then used verbatim, like --prompt
+	if err := applyConfigOverrides(cfg, configPath); err != nil {
+		log.WithError(err).Error("invalid configuration override")
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if interactive && !useTUI && cfg.Orchestrator.InitialPrompt == "" {
+		if !isTerminal(os.Stdin) {
+			log.Warn("--interactive ignored because stdin is not a terminal")
+			fmt.Fprintln(os.Stderr, "Warning: --interactive requires a terminal on stdin; starting without an initial prompt")
+		} else {
+			prompt, err := readInteractivePrompt(bufio.NewReader(os.Stdin), os.Stderr)
 			if err != nil {
-				log.WithError(err).WithField("agent_spec", agentSpec).Error("failed to parse agent specification")
-				fmt.Fprintf(os.Stderr, "Error parsing agent spec: %v\n", err)
+				log.WithError(err).Error("failed to read interactive prompt")
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
-			cfg.Agents = append(cfg.Agents, agentCfg)
+			cfg.Orchestrator.InitialPrompt = prompt
 		}
-	} else {
-		log.Error("no configuration source specified (need --config or --agents)")
-		fmt.Fprintf(os.Stderr, "Error: Either --config or --agents must be specified\n")
+	}
+
+	if dumpConfigPath != "" {
+		if err := dumpConfig(cfg, dumpConfigPath); err != nil {
+			log.WithError(err).WithField("path", dumpConfigPath).Error("failed to dump configuration")
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "✅ Configuration written to %s\n", dumpConfigPath)
+		fmt.Fprintf(os.Stderr, "   Edit it, then run: agentpipe run -c %s\n", dumpConfigPath)
+		return
+	}
+
+	// Secrets are resolved after --dump-config so written configs keep the references
+	if err := resolveSecrets(cfg, secretsFile); err != nil {
+		log.WithError(err).Error("failed to resolve agent api keys")
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := startConversation(cobraCmd, cfg, stdoutEmitter); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+}
+
+// assembleConfig builds the configuration a run would use from the config file at path,
+// or from the --agents specs when path is empty, with the command-line overrides and
+// the conversation template applied. Secrets are left unresolved.
+func assembleConfig(path string) (*config.Config, error) {
+	var cfg *config.Config
+	var err error
+	switch {
+	case path != "":
+		cfg, err = config.LoadConfig(path)
+	case len(agents) > 0:
+		cfg, err = configFromAgentSpecs(agents)
+	default:
+		err = fmt.Errorf("either --config or --agents must be specified")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := applyConfigOverrides(cfg, path); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// configFromAgentSpecs builds a default configuration with the agents given as --agents
+// specs. Agents without a model inherit default_models from ~/.agentpipe.yaml.
+func configFromAgentSpecs(specs []string) (*config.Config, error) {
+	cfg := config.NewDefaultConfig()
+	for i, agentSpec := range specs {
+		agentCfg, err := parseAgentSpec(agentSpec, i)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", agentSpec, err)
+		}
+		cfg.Agents = append(cfg.Agents, agentCfg)
+	}
+	cfg.DefaultModels = viper.GetStringMapString("default_models")
+	cfg.ApplyDefaultModels()
+	return cfg, nil
+}
+
+// applyConfigOverrides applies the command-line flags registered by addConfigFlags to
+// cfg, then renders the conversation template relative to the config file at path.
+func applyConfigOverrides(cfg *config.Config, path string) error {
+	if renderWidth < 0 {
+		return fmt.Errorf("--render-width must not be negative")
+	}
+	if err := validateAgentCount(len(cfg.Agents), maxAgents); err != nil {
+		return err
+	}
 
 	if mode != "" {
 		cfg.Orchestrator.Mode = mode
@@ -138,8 +313,61 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 	if responseDelay > 0 {
 		cfg.Orchestrator.ResponseDelay = time.Duration(responseDelay) * time.Second
 	}
-	if initialPrompt != "" {
-		cfg.Orchestrator.InitialPrompt = initialPrompt
+	if promptSuffix != "" {
+		cfg.Orchestrator.PromptSuffix = promptSuffix
+	}
+	if explain {
+		cfg.Orchestrator.Explain = true
+	}
+	if prewarm {
+		cfg.Orchestrator.Prewarm = true
+	}
+	if streamResponses {
+		cfg.Orchestrator.StreamResponses = true
+	}
+	if renderWidth > 0 {
+		cfg.Orchestrator.RenderWidth = renderWidth
+	}
+	if detectCollapse {
+		cfg.Orchestrator.CollapseCheckEnabled = true
+	}
+	if noRetries {
+		cfg.Orchestrator.RetriesDisabled = true
+	}
+	if billingTag != "" {
+		cfg.Orchestrator.BillingTag = billingTag
+	}
+
+	vars, err := parseTemplateVars(templateVars)
+	if err != nil {
+		return err
+	}
+	baseDir := "."
+	if path != "" {
+		baseDir = filepath.Dir(path)
+	}
+	// --prompt is the user's own text: it replaces the configured prompt verbatim
+	if err := cfg.ApplyTemplateWithPrompt(baseDir, vars, initialPrompt); err != nil {
+		return err
+	}
+
+	if len(agentTimeouts) > 0 {
+		overrides, err := parseAgentTimeouts(agentTimeouts)
+		if err == nil {
+			err = applyAgentTimeouts(cfg, overrides)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(agentRates) > 0 {
+		overrides, err := parseAgentRates(agentRates)
+		if err == nil {
+			err = applyAgentRates(cfg, overrides)
+		}
+		if err != nil {
+			return err
+		}
 	}
 
 	// Apply CLI overrides for logging
@@ -161,11 +389,149 @@ func runConversation(cobraCmd *cobra.Command, args []string) {
 	if summaryAgent != "" {
 		cfg.Orchestrator.Summary.Agent = summaryAgent
 	}
+	return nil
+}
+
+// dumpConfig writes the fully assembled configuration to path as a starter config file.
+// It is validated first, so the written file loads back with config.LoadConfig.
+func dumpConfig(cfg *config.Config, path string) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("cannot dump invalid configuration: %w", err)
+	}
+	return cfg.SaveConfig(path)
+}
 
-	if err := startConversation(cobraCmd, cfg, stdoutEmitter); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+// resolveSecrets fills in agents' API keys from their api_key_ref, using the secrets
+// file at path when one is given and the OS keyring otherwise.
+func resolveSecrets(cfg *config.Config, path string) error {
+	var secrets map[string]string
+	if path != "" {
+		var err error
+		if secrets, err = config.LoadSecretsFile(path); err != nil {
+			return err
+		}
+	}
+	return cfg.ResolveSecrets(secrets)
+}
+
+// validateAgentCount guards against accidentally huge panels, which multiply cost
+// and can trigger rate-limit storms. A limit of 0 or less disables the check.
+func validateAgentCount(count, limit int) error {
+	if limit <= 0 || count <= limit {
+		return nil
 	}
+	return fmt.Errorf("%d agents configured, which exceeds the limit of %d; "+
+		"if this is intentional, rerun with --max-agents %d", count, limit, count)
+}
+
+// parseAgentTimeouts parses --agent-timeout entries of the form name=seconds.
+func parseAgentTimeouts(entries []string) (map[string]time.Duration, error) {
+	overrides := make(map[string]time.Duration, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --agent-timeout %q: expected name=seconds", entry)
+		}
+		seconds, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || seconds <= 0 {
+			return nil, fmt.Errorf("invalid --agent-timeout %q: seconds must be a positive integer", entry)
+		}
+		overrides[name] = time.Duration(seconds) * time.Second
+	}
+	return overrides, nil
+}
+
+// applyAgentTimeouts sets per-agent timeouts by agent name, rejecting unknown names.
+func applyAgentTimeouts(cfg *config.Config, overrides map[string]time.Duration) error {
+	for name, timeout := range overrides {
+		found := false
+		for i := range cfg.Agents {
+			if cfg.Agents[i].Name == name {
+				cfg.Agents[i].Timeout = timeout
+				found = true
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(cfg.Agents))
+			for _, a := range cfg.Agents {
+				names = append(names, a.Name)
+			}
+			return fmt.Errorf("--agent-timeout: unknown agent %q (agents: %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// parseTemplateVars parses --var entries of the form key=value. Values may contain "=".
+func parseTemplateVars(entries []string) (map[string]string, error) {
+	vars := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", entry)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// agentRateOverride is a parsed --agent-rate entry. A zero burst keeps the configured burst.
+type agentRateOverride struct {
+	rate  float64
+	burst int
+}
+
+// parseAgentRates parses --agent-rate entries of the form name=rps or name=rps:burst.
+func parseAgentRates(entries []string) (map[string]agentRateOverride, error) {
+	overrides := make(map[string]agentRateOverride, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --agent-rate %q: expected name=rps[:burst]", entry)
+		}
+		rateStr, burstStr, hasBurst := strings.Cut(value, ":")
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			return nil, fmt.Errorf("invalid --agent-rate %q: rps must be a non-negative number", entry)
+		}
+		override := agentRateOverride{rate: rate}
+		if hasBurst {
+			burst, err := strconv.Atoi(strings.TrimSpace(burstStr))
+			if err != nil || burst <= 0 {
+				return nil, fmt.Errorf("invalid --agent-rate %q: burst must be a positive integer", entry)
+			}
+			override.burst = burst
+		}
+		overrides[name] = override
+	}
+	return overrides, nil
+}
+
+// applyAgentRates sets per-agent rate limits by agent name, rejecting unknown names.
+func applyAgentRates(cfg *config.Config, overrides map[string]agentRateOverride) error {
+	for name, override := range overrides {
+		found := false
+		for i := range cfg.Agents {
+			if cfg.Agents[i].Name == name {
+				cfg.Agents[i].RateLimit = override.rate
+				if override.burst > 0 {
+					cfg.Agents[i].RateLimitBurst = override.burst
+				}
+				found = true
+			}
+		}
+		if !found {
+			names := make([]string, 0, len(cfg.Agents))
+			for _, a := range cfg.Agents {
+				names = append(names, a.Name)
+			}
+			return fmt.Errorf("--agent-rate: unknown agent %q (agents: %s)", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
 }
 
 func parseAgentSpec(spec string, index int) (agent.AgentConfig, error) {
@@ -192,6 +558,8 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	agent.SetPromptPreviewChars(cfg.Logging.PromptPreviewChars)
+
 	// Set up config watcher if requested
 	var configWatcher *config.ConfigWatcher
 	if watchConfig && configPath != "" {
@@ -229,6 +597,7 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 
 	// Track graceful shutdown for summary display
 	gracefulShutdown := false
+	var shutdownLogger atomic.Pointer[logger.ChatLogger]
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -236,6 +605,14 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		fmt.Println("\n\n⏸️  Interrupted. Shutting down gracefully...")
 		gracefulShutdown = true
 		cancel()
+
+		// A second signal skips the rest of the shutdown, but the chat log is still flushed
+		<-sigChan
+		fmt.Println("\n⏹️  Forced exit.")
+		if chatLogger := shutdownLogger.Load(); chatLogger != nil {
+			chatLogger.Close()
+		}
+		os.Exit(130)
 	}()
 
 	if useTUI {
@@ -247,6 +624,10 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		return tui.RunEnhanced(ctx, cfg, nil, skipHealthCheck, healthCheckTimeout, configPath)
 	}
 
+	if checkUpdates {
+		startUpdateCheck(cfg, os.Stderr)
+	}
+
 	// Non-TUI mode: initialize agents here
 	agentsList := make([]agent.Agent, 0)
 
@@ -316,6 +697,35 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 				return fmt.Errorf("agent %s failed health check", agentCfg.Name)
 			}
 
+			if deepHealthCheck {
+				if verbose {
+					fmt.Printf("  Sending test prompt to %s...\n", agentCfg.Name)
+				}
+				// Probe a separate instance so stateful adapters (e.g. amp threads)
+				// don't carry the test prompt into the real conversation; it isn't registered,
+				// so the registry keeps pointing at the real agent
+				probe, probeErr := agent.NewAgent(agentCfg)
+				if probeErr == nil {
+					deepCtx, cancel := context.WithTimeout(context.Background(), agent.DefaultDeepHealthCheckTimeout)
+					err = agent.DeepHealthCheck(deepCtx, probe)
+					cancel()
+				} else {
+					err = probeErr
+				}
+
+				if err != nil {
+					log.WithFields(map[string]interface{}{
+						"agent_name": agentCfg.Name,
+						"agent_type": agentCfg.Type,
+					}).WithError(err).Error("deep health check failed")
+					fmt.Printf("  ⚠️  Deep health check failed for %s: %v\n", agentCfg.Name, err)
+					fmt.Printf("  Troubleshooting tips:\n")
+					fmt.Printf("    - Check that the %s CLI is logged in and the model %q is available to you\n", agentCfg.Type, agentCfg.Model)
+					fmt.Printf("    - Run without --deep-health-check to skip this check\n")
+					return fmt.Errorf("agent %s failed deep health check", agentCfg.Name)
+				}
+			}
+
 			if verbose {
 				fmt.Printf("  ✅ Agent %s is ready\n", agentCfg.Name)
 			}
@@ -334,14 +744,7 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		fmt.Printf("✅ All %d agents initialized successfully\n\n", len(agentsList))
 	}
 
-	orchConfig := orchestrator.OrchestratorConfig{
-		Mode:          orchestrator.ConversationMode(cfg.Orchestrator.Mode),
-		TurnTimeout:   cfg.Orchestrator.TurnTimeout,
-		MaxTurns:      cfg.Orchestrator.MaxTurns,
-		ResponseDelay: cfg.Orchestrator.ResponseDelay,
-		InitialPrompt: cfg.Orchestrator.InitialPrompt,
-		Summary:       cfg.Orchestrator.Summary,
-	}
+	orchConfig := orchestrator.ConfigFrom(cfg)
 
 	// Create logger if enabled
 	var chatLogger *logger.ChatLogger
@@ -357,6 +760,9 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 			fmt.Fprintf(os.Stderr, "Warning: Failed to create chat logger: %v\n", err)
 			// Continue without logging
 		} else {
+			chatLogger.SetPerAgentLogs(cfg.Logging.PerAgentLogs)
+			chatLogger.SetRenderWidth(cfg.Orchestrator.RenderWidth)
+			shutdownLogger.Store(chatLogger)
 			defer chatLogger.Close()
 		}
 	}
@@ -371,6 +777,35 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 	if chatLogger != nil {
 		orch.SetLogger(chatLogger)
 	}
+	// Streamed text goes to stderr so stdout keeps one formatted line per message
+	if cfg.Orchestrator.StreamResponses && !jsonOutput {
+		orch.SetStreamWriter(os.Stderr)
+	}
+
+	if metricsPort != 0 {
+		if !cfg.Logging.ShowMetrics {
+			log.WithField("metrics_port", metricsPort).Warn("--metrics-port ignored because metrics are disabled")
+			fmt.Fprintln(os.Stderr, "Warning: --metrics-port requires --metrics; not starting the metrics server")
+		} else {
+			stopMetrics, err := startMetricsServer(orch, metricsPort)
+			if err != nil {
+				return err
+			}
+			defer stopMetrics()
+			if !jsonOutput {
+				fmt.Printf("📈 Prometheus metrics at http://localhost:%d/metrics\n", metricsPort)
+			}
+		}
+	}
+
+	// Add the middleware the config asks for
+	chain, err := orchestrator.MiddlewareFrom(cfg)
+	if err != nil {
+		return err
+	}
+	for _, m := range chain {
+		orch.AddMiddleware(m)
+	}
 
 	// Capture command information for event tracking
 	commandInfo := buildCommandInfo(cmd, cfg)
@@ -416,7 +851,7 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 			return fmt.Errorf("matrix setup failed: %w", err)
 		}
 		defer matrixBridge.Close()
-		orch.AddMessageHook(matrixBridge.Send)
+		orch.AddContextMessageHook(matrixBridge.Send)
 		matrixBridge.Start(ctx, func(msg agent.Message) {
 			orch.InjectMessage(msg)
 		})
@@ -453,7 +888,25 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		orch.AddAgent(a)
 	}
 
-	err := orch.Start(ctx)
+	var autosave *conversation.Autosave
+	if autosaveInterval > 0 {
+		path, pathErr := autosavePath()
+		if pathErr != nil {
+			return fmt.Errorf("autosave setup failed: %w", pathErr)
+		}
+		startedAt := time.Now()
+		autosave = conversation.StartAutosave(ctx, path, autosaveInterval, func() *conversation.State {
+			return conversation.NewState(orch.GetMessages(), cfg, startedAt)
+		})
+		if !jsonOutput {
+			fmt.Printf("💾 Autosaving conversation state every %v to: %s\n", autosaveInterval, path)
+		}
+	}
+
+	err = orch.Start(ctx)
+	if autosave != nil {
+		autosave.Stop()
+	}
 
 	if err != nil {
 		log.WithError(err).Error("orchestrator error during conversation")
@@ -466,9 +919,13 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 		fmt.Println("\n" + strings.Repeat("=", 60))
 	}
 
-	// Save conversation state if requested
-	if saveState || stateFile != "" {
-		if saveErr := saveConversationState(orch, cfg, time.Now()); saveErr != nil {
+	// Save conversation state if requested; an autosaved run gets a final snapshot in its rolling file
+	if saveState || stateFile != "" || autosave != nil {
+		savePath := stateFile
+		if autosave != nil {
+			savePath = autosave.Path()
+		}
+		if saveErr := saveConversationState(orch, cfg, time.Now(), savePath); saveErr != nil {
 			log.WithError(saveErr).Error("failed to save conversation state")
 			fmt.Fprintf(os.Stderr, "Warning: Failed to save conversation state: %v\n", saveErr)
 		}
@@ -495,8 +952,18 @@ func startConversation(cmd *cobra.Command, cfg *config.Config, stdoutEmitter *br
 	return nil
 }
 
-// saveConversationState saves the current conversation state to a file.
-func saveConversationState(orch *orchestrator.Orchestrator, cfg *config.Config, startedAt time.Time) error {
+// autosavePath returns the rolling file --autosave-interval overwrites: --state-file
+// when given, otherwise a new file in the default state directory.
+func autosavePath() (string, error) {
+	if stateFile != "" {
+		return stateFile, nil
+	}
+	return conversation.DefaultStatePath()
+}
+
+// saveConversationState saves the current conversation state to savePath, or to a
+// new file in the default state directory when savePath is empty.
+func saveConversationState(orch *orchestrator.Orchestrator, cfg *config.Config, startedAt time.Time, savePath string) error {
 	messages := orch.GetMessages()
 	state := conversation.NewState(messages, cfg, startedAt)
 
@@ -507,17 +974,13 @@ func saveConversationState(orch *orchestrator.Orchestrator, cfg *config.Config,
 	}
 
 	// Determine save path
-	var savePath string
-	if stateFile != "" {
-		savePath = stateFile
-	} else {
+	if savePath == "" {
 		// Use default state directory
-		stateDir, err := conversation.GetDefaultStateDir()
+		var err error
+		savePath, err = conversation.DefaultStatePath()
 		if err != nil {
-			return fmt.Errorf("failed to get state directory: %w", err)
+			return err
 		}
-
-		savePath = filepath.Join(stateDir, conversation.GenerateStateFileName())
 	}
 
 	// Save state
@@ -593,10 +1056,103 @@ func printSessionSummary(orch *orchestrator.Orchestrator, cfg *config.Config) {
 		fmt.Printf("Total Cost:          $%.4f\n", totalCost)
 	}
 
+	if reason := orch.GetEndReason(); reason != "" {
+		fmt.Printf("Ended By:            %s\n", reason)
+	}
+
+	if stats := orch.GetStats(); stats.MessagesPerMinute > 0 {
+		fmt.Printf("Throughput:          %.1f messages/min\n", stats.MessagesPerMinute)
+	}
+
+	if latency := orch.GetLatencyStats(); latency.Count > 0 {
+		fmt.Printf("Latency p50/p90/p99: %s / %s / %s\n",
+			formatLatency(latency.P50), formatLatency(latency.P90), formatLatency(latency.P99))
+		fmt.Printf("Latency Max:         %s\n", formatLatency(latency.Max))
+	}
+
+	if cfg.Orchestrator.CollapseCheckEnabled {
+		fmt.Print(formatModeCollapse(orch.GetModeCollapse()))
+	}
+
+	if cfg.Orchestrator.Referee.Enabled {
+		fmt.Print(formatRefereeScores(orch.GetRefereeScores()))
+	}
+
+	if vote := orch.GetVoteResult(); vote != nil {
+		fmt.Print(formatVoteResult(vote))
+	}
+
+	// Summary generation is reported on its own so it doesn't skew the conversation totals above
+	if summary := orch.GetSummary(); summary != nil {
+		fmt.Print(formatSummaryMetrics(summary))
+	}
+
 	fmt.Println(strings.Repeat("=", 60))
 	fmt.Println("Session ended. All messages logged.")
 }
 
+// formatRefereeScores renders each agent's average referee score.
+func formatRefereeScores(scores []orchestrator.AgentScore) string {
+	if len(scores) == 0 {
+		return "Referee Scores:      none recorded\n"
+	}
+	var b strings.Builder
+	b.WriteString("Referee Scores:\n")
+	for _, s := range scores {
+		fmt.Fprintf(&b, "  %s: %.1f/10 over %d responses\n", s.AgentName, s.Average, s.Count)
+	}
+	return b.String()
+}
+
+// formatVoteResult renders the end-of-conversation vote tally and outcome.
+func formatVoteResult(vote *bridge.VoteResult) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Vote:                %s\n", orchestrator.DescribeVote(vote))
+	for _, t := range vote.Tally {
+		fmt.Fprintf(&b, "  %s: %d\n", t.AgentName, t.Votes)
+	}
+	return b.String()
+}
+
+// formatSummaryMetrics renders the cost of generating the conversation summary.
+func formatSummaryMetrics(summary *bridge.SummaryMetadata) string {
+	var b strings.Builder
+	model := summary.Model
+	if model == "" {
+		model = summary.AgentType
+	}
+	fmt.Fprintf(&b, "Summary (%s):\n", model)
+	fmt.Fprintf(&b, "  Time:              %s\n", formatLatency(time.Duration(summary.DurationMs)*time.Millisecond))
+	if summary.TotalTokens > 0 {
+		fmt.Fprintf(&b, "  Tokens:            %d (%d in / %d out)\n", summary.TotalTokens, summary.InputTokens, summary.OutputTokens)
+	}
+	if summary.Cost > 0 {
+		fmt.Fprintf(&b, "  Cost:              $%.4f\n", summary.Cost)
+	}
+	return b.String()
+}
+
+// formatModeCollapse renders the agent pairs found giving near-identical responses.
+func formatModeCollapse(pairs []orchestrator.SimilarPair) string {
+	if len(pairs) == 0 {
+		return "Mode Collapse:       none detected\n"
+	}
+	var b strings.Builder
+	b.WriteString("Mode Collapse:\n")
+	for _, p := range pairs {
+		fmt.Fprintf(&b, "  %s ~ %s: %.0f%% similar over %d rounds\n", p.AgentA, p.AgentB, p.Similarity*100, p.Rounds)
+	}
+	return b.String()
+}
+
+// formatLatency renders a response latency as milliseconds below one second, seconds otherwise.
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
 // determineShouldStream determines if streaming should be enabled based on CLI flags.
 // Priority: --no-stream > --stream > config file setting
 func determineShouldStream(streamEnabled, noStream bool) bool {
@@ -659,3 +1215,28 @@ func buildCommandInfo(cmd *cobra.Command, cfg *config.Config) *bridge.CommandInf
 		Options:        options,
 	}
 }
+
+// startMetricsServer serves Prometheus metrics for orch on port until the returned
+// function is called, which shuts the server down.
+func startMetricsServer(orch *orchestrator.Orchestrator, port int) (func(), error) {
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid --metrics-port %d: must be between 1 and 65535", port)
+	}
+
+	server := metrics.NewServer(metrics.ServerConfig{Addr: fmt.Sprintf(":%d", port)})
+	orch.SetMetrics(server.GetMetrics())
+
+	go func() {
+		if err := server.Start(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Stop(ctx); err != nil {
+			log.WithError(err).Warn("failed to stop metrics server")
+		}
+	}, nil
+}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/shawkym/agentpipe/internal/bridge"
 	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
 )
 
 func TestParseAgentSpec(t *testing.T) {
@@ -511,3 +518,326 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateAgentCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		count   int
+		limit   int
+		wantErr bool
+	}{
+		{name: "below limit", count: 3, limit: 20},
+		{name: "exactly at limit", count: 20, limit: 20},
+		{name: "over limit", count: 21, limit: 20, wantErr: true},
+		{name: "limit disabled", count: 100, limit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAgentCount(tt.count, tt.limit)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateAgentCount(%d, %d) error = %v, wantErr %v", tt.count, tt.limit, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "--max-agents 21") {
+				t.Errorf("expected error to suggest the override, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseAgentTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string]time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "single entry",
+			entries: []string{"Claude=90"},
+			want:    map[string]time.Duration{"Claude": 90 * time.Second},
+		},
+		{
+			name:    "multiple entries with spaces",
+			entries: []string{"Claude = 90", "Slow Agent=300"},
+			want:    map[string]time.Duration{"Claude": 90 * time.Second, "Slow Agent": 300 * time.Second},
+		},
+		{name: "missing separator", entries: []string{"Claude90"}, wantErr: true},
+		{name: "missing name", entries: []string{"=90"}, wantErr: true},
+		{name: "non-numeric seconds", entries: []string{"Claude=fast"}, wantErr: true},
+		{name: "zero seconds", entries: []string{"Claude=0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAgentTimeouts(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAgentTimeouts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseAgentTimeouts() = %v, want %v", got, tt.want)
+			}
+			for name, timeout := range tt.want {
+				if got[name] != timeout {
+					t.Errorf("timeout for %s = %v, want %v", name, got[name], timeout)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyAgentTimeouts(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "claude-1", Type: "claude", Name: "Claude"},
+		{ID: "gemini-1", Type: "gemini", Name: "Gemini", Timeout: 20 * time.Second},
+	}
+
+	if err := applyAgentTimeouts(cfg, map[string]time.Duration{"Claude": 90 * time.Second}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Agents[0].Timeout != 90*time.Second {
+		t.Errorf("expected Claude timeout to be overridden, got %v", cfg.Agents[0].Timeout)
+	}
+	if cfg.Agents[1].Timeout != 20*time.Second {
+		t.Errorf("expected Gemini timeout to be untouched, got %v", cfg.Agents[1].Timeout)
+	}
+
+	timeouts := cfg.AgentTimeouts()
+	if timeouts["claude-1"] != 90*time.Second || timeouts["gemini-1"] != 20*time.Second {
+		t.Errorf("unexpected orchestrator timeouts: %v", timeouts)
+	}
+
+	err := applyAgentTimeouts(cfg, map[string]time.Duration{"Nobody": time.Second})
+	if err == nil || !strings.Contains(err.Error(), `unknown agent "Nobody"`) {
+		t.Errorf("expected unknown agent error, got %v", err)
+	}
+}
+
+func TestParseTemplateVars(t *testing.T) {
+	got, err := parseTemplateVars([]string{"Topic=rate limits", " Audience = SREs", "Query=a=b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"Topic": "rate limits", "Audience": " SREs", "Query": "a=b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTemplateVars() = %v, want %v", got, want)
+	}
+
+	for _, entry := range []string{"Topic", "=value"} {
+		if _, err := parseTemplateVars([]string{entry}); err == nil {
+			t.Errorf("expected an error for %q", entry)
+		}
+	}
+}
+
+func TestParseAgentRates(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string]agentRateOverride
+		wantErr bool
+	}{
+		{
+			name:    "rate and burst",
+			entries: []string{"Claude=0.5:3"},
+			want:    map[string]agentRateOverride{"Claude": {rate: 0.5, burst: 3}},
+		},
+		{
+			name:    "rate only with spaces",
+			entries: []string{"Slow Agent = 2", "Free=0"},
+			want:    map[string]agentRateOverride{"Slow Agent": {rate: 2}, "Free": {rate: 0}},
+		},
+		{name: "missing separator", entries: []string{"Claude2"}, wantErr: true},
+		{name: "missing name", entries: []string{"=2"}, wantErr: true},
+		{name: "non-numeric rate", entries: []string{"Claude=fast"}, wantErr: true},
+		{name: "negative rate", entries: []string{"Claude=-1"}, wantErr: true},
+		{name: "empty burst", entries: []string{"Claude=1:"}, wantErr: true},
+		{name: "zero burst", entries: []string{"Claude=1:0"}, wantErr: true},
+		{name: "non-numeric burst", entries: []string{"Claude=1:many"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAgentRates(tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAgentRates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAgentRates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyAgentRates(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "claude-1", Type: "claude", Name: "Claude", RateLimit: 5, RateLimitBurst: 4},
+		{ID: "gemini-1", Type: "gemini", Name: "Gemini", RateLimit: 1, RateLimitBurst: 2},
+	}
+
+	err := applyAgentRates(cfg, map[string]agentRateOverride{
+		"Claude": {rate: 0.5},
+		"Gemini": {rate: 0.2, burst: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Agents[0].RateLimit != 0.5 || cfg.Agents[0].RateLimitBurst != 4 {
+		t.Errorf("expected Claude rate 0.5 with configured burst 4, got %v/%d", cfg.Agents[0].RateLimit, cfg.Agents[0].RateLimitBurst)
+	}
+	if cfg.Agents[1].RateLimit != 0.2 || cfg.Agents[1].RateLimitBurst != 1 {
+		t.Errorf("expected Gemini rate 0.2 burst 1, got %v/%d", cfg.Agents[1].RateLimit, cfg.Agents[1].RateLimitBurst)
+	}
+
+	err = applyAgentRates(cfg, map[string]agentRateOverride{"Nobody": {rate: 1}})
+	if err == nil || !strings.Contains(err.Error(), `unknown agent "Nobody"`) {
+		t.Errorf("expected unknown agent error, got %v", err)
+	}
+}
+
+func TestFormatModeCollapse(t *testing.T) {
+	if got := formatModeCollapse(nil); got != "Mode Collapse:       none detected\n" {
+		t.Errorf("unexpected output without pairs: %q", got)
+	}
+
+	got := formatModeCollapse([]orchestrator.SimilarPair{{AgentA: "Alice", AgentB: "Bob", Similarity: 0.914, Rounds: 3}})
+	want := "Mode Collapse:\n  Alice ~ Bob: 91% similar over 3 rounds\n"
+	if got != want {
+		t.Errorf("formatModeCollapse() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatRefereeScores(t *testing.T) {
+	if got := formatRefereeScores(nil); got != "Referee Scores:      none recorded\n" {
+		t.Errorf("unexpected output without scores: %q", got)
+	}
+
+	got := formatRefereeScores([]orchestrator.AgentScore{{AgentName: "Alice", Average: 7.5, Count: 4}})
+	want := "Referee Scores:\n  Alice: 7.5/10 over 4 responses\n"
+	if got != want {
+		t.Errorf("formatRefereeScores() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatVoteResult(t *testing.T) {
+	got := formatVoteResult(&bridge.VoteResult{
+		Votes:  []bridge.Vote{{Voter: "Alice", Choice: "Bob"}},
+		Tally:  []bridge.VoteCount{{AgentName: "Alice", Votes: 0}, {AgentName: "Bob", Votes: 1}},
+		Winner: "Bob",
+	})
+	want := "Vote:                Bob wins with 1 of 1 votes\n  Alice: 0\n  Bob: 1\n"
+	if got != want {
+		t.Errorf("formatVoteResult() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatSummaryMetrics(t *testing.T) {
+	out := formatSummaryMetrics(&bridge.SummaryMetadata{
+		AgentType:    "gemini",
+		Model:        "gemini-2.0-flash",
+		InputTokens:  2500,
+		OutputTokens: 150,
+		TotalTokens:  2650,
+		Cost:         0.002,
+		DurationMs:   1200,
+	})
+
+	for _, want := range []string{
+		"Summary (gemini-2.0-flash):",
+		"Time:              1.2s",
+		"Tokens:            2650 (2500 in / 150 out)",
+		"Cost:              $0.0020",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected summary metrics to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Total") {
+		t.Errorf("summary metrics should not be labelled as conversation totals, got:\n%s", out)
+	}
+
+	// Without a model the agent type identifies the summarizer
+	out = formatSummaryMetrics(&bridge.SummaryMetadata{AgentType: "gemini", DurationMs: 40})
+	if !strings.Contains(out, "Summary (gemini):") || !strings.Contains(out, "40ms") {
+		t.Errorf("unexpected output without model: %s", out)
+	}
+	if strings.Contains(out, "Tokens") || strings.Contains(out, "Cost") {
+		t.Errorf("expected zero-valued metrics to be omitted, got: %s", out)
+	}
+}
+
+func TestDumpConfigRoundTrip(t *testing.T) {
+	// Assemble a config the way `run --agents ... --mode ...` does
+	cfg := config.NewDefaultConfig()
+	for i, spec := range []string{"claude:Alice", "gemini:gemini-2.5-pro:Bob"} {
+		agentCfg, err := parseAgentSpec(spec, i)
+		if err != nil {
+			t.Fatalf("parseAgentSpec(%q) error = %v", spec, err)
+		}
+		cfg.Agents = append(cfg.Agents, agentCfg)
+	}
+	cfg.Orchestrator.Mode = "reactive"
+	cfg.Orchestrator.MaxTurns = 6
+	cfg.Orchestrator.TurnTimeout = 45 * time.Second
+	cfg.Orchestrator.ResponseDelay = 2 * time.Second
+	cfg.Orchestrator.InitialPrompt = "Compare {{ templating }} engines: Go's vs. Jinja"
+	cfg.Orchestrator.PromptSuffix = "Respond in under 100 words."
+	cfg.Orchestrator.Summary.Enabled = false
+	cfg.Logging.ShowMetrics = true
+	if err := applyAgentTimeouts(cfg, map[string]time.Duration{"Bob": 90 * time.Second}); err != nil {
+		t.Fatalf("applyAgentTimeouts error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "starter.yaml")
+	if err := dumpConfig(cfg, path); err != nil {
+		t.Fatalf("dumpConfig error = %v", err)
+	}
+
+	loaded, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig error = %v", err)
+	}
+	// Loading fills in max_tokens, as it does for any config file, and reads unset
+	// collections back as empty rather than nil
+	wantAgents := append([]agent.AgentConfig(nil), cfg.Agents...)
+	for i := range wantAgents {
+		wantAgents[i].MaxTokens = 2000
+	}
+	for i := range loaded.Agents {
+		if len(loaded.Agents[i].CustomSettings) == 0 {
+			loaded.Agents[i].CustomSettings = nil
+		}
+		if len(loaded.Agents[i].Tools) == 0 {
+			loaded.Agents[i].Tools = nil
+		}
+	}
+	if !reflect.DeepEqual(loaded.Agents, wantAgents) {
+		t.Errorf("agents differ after round trip:\n got %+v\nwant %+v", loaded.Agents, wantAgents)
+	}
+	if !reflect.DeepEqual(loaded.Orchestrator, cfg.Orchestrator) {
+		t.Errorf("orchestrator settings differ after round trip:\n got %+v\nwant %+v", loaded.Orchestrator, cfg.Orchestrator)
+	}
+	if !reflect.DeepEqual(loaded.Logging, cfg.Logging) {
+		t.Errorf("logging settings differ after round trip:\n got %+v\nwant %+v", loaded.Logging, cfg.Logging)
+	}
+}
+
+func TestDumpConfigRejectsInvalidConfig(t *testing.T) {
+	cfg := config.NewDefaultConfig() // no agents
+	path := filepath.Join(t.TempDir(), "starter.yaml")
+
+	if err := dumpConfig(cfg, path); err == nil || !strings.Contains(err.Error(), "at least one agent") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if _, err := config.LoadConfig(path); err == nil {
+		t.Error("expected no file to be written for an invalid config")
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shawkym/agentpipe/internal/registry"
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// outdatedAgent is a configured agent CLI with a newer version available
+type outdatedAgent struct {
+	name    string
+	current string
+	latest  string
+}
+
+// versionLookup returns the installed and latest versions of an agent CLI
+type versionLookup func(def *registry.AgentDefinition) (current, latest string, err error)
+
+// registryVersionLookup looks up versions the same way as 'agents list --outdated'
+func registryVersionLookup(def *registry.AgentDefinition) (string, string, error) {
+	if !isAgentInstalled(def.Command) {
+		return "not installed", "", nil
+	}
+	current := registry.GetInstalledVersion(def.Command)
+	if def.PackageManager == "" {
+		return current, "manual install", nil
+	}
+	latest, err := def.GetLatestVersion()
+	return current, latest, err
+}
+
+// findOutdatedAgents checks each distinct agent type against the registry and returns
+// the ones with updates available. Types without a registry entry (e.g. API agents) are skipped.
+func findOutdatedAgents(agentTypes []string, lookup versionLookup) []outdatedAgent {
+	var outdated []outdatedAgent
+	seen := make(map[string]bool)
+
+	for _, agentType := range agentTypes {
+		def, err := registry.GetByName(agentType)
+		if err != nil {
+			def, err = registry.GetByCommand(agentType)
+		}
+		if err != nil || seen[def.Name] {
+			continue
+		}
+		seen[def.Name] = true
+
+		current, latest, err := lookup(def)
+		if err != nil {
+			log.WithField("agent", def.Name).WithError(err).Debug("update check failed")
+			continue
+		}
+		if isOutdated(current, latest) {
+			outdated = append(outdated, outdatedAgent{name: def.Name, current: current, latest: latest})
+		}
+	}
+
+	return outdated
+}
+
+// formatOutdatedNotice renders a one-line notice listing outdated agent CLIs
+func formatOutdatedNotice(outdated []outdatedAgent) string {
+	parts := make([]string, len(outdated))
+	for i, a := range outdated {
+		parts[i] = fmt.Sprintf("%s %s → %s", a.name, a.current, a.latest)
+	}
+	return fmt.Sprintf("⬆️  Agent updates available: %s (run 'agentpipe agents upgrade <agent>')", strings.Join(parts, ", "))
+}
+
+// startUpdateCheck checks the configured agents for updates in the background and
+// prints a notice to w if any are outdated. It never blocks the conversation.
+func startUpdateCheck(cfg *config.Config, w io.Writer) {
+	agentTypes := make([]string, len(cfg.Agents))
+	for i, agentCfg := range cfg.Agents {
+		agentTypes[i] = agentCfg.Type
+	}
+
+	go func() {
+		if outdated := findOutdatedAgents(agentTypes, registryVersionLookup); len(outdated) > 0 {
+			fmt.Fprintln(w, formatOutdatedNotice(outdated))
+		}
+	}()
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/internal/registry"
+)
+
+func TestIsOutdated(t *testing.T) {
+	tests := []struct {
+		current string
+		latest  string
+		want    bool
+	}{
+		{"1.0.0", "1.2.0", true},
+		{"v2.0.10", "2.0.9", false},
+		{"1.2.0", "1.2.0", false},
+		{"unknown", "1.2.0", false},
+		{"not installed", "1.2.0", false},
+		{"", "1.2.0", false},
+		{"1.0.0", "manual install", false},
+		{"1.0.0", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := isOutdated(tt.current, tt.latest); got != tt.want {
+			t.Errorf("isOutdated(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestFindOutdatedAgents(t *testing.T) {
+	versions := map[string][2]string{
+		"Claude": {"1.0.0", "1.1.0"},
+		"Gemini": {"0.9.0", "0.9.0"},
+		"Codex":  {"not installed", ""},
+	}
+	lookups := 0
+	lookup := func(def *registry.AgentDefinition) (string, string, error) {
+		lookups++
+		if def.Name == "Qwen" {
+			return "", "", errors.New("registry unreachable")
+		}
+		v := versions[def.Name]
+		return v[0], v[1], nil
+	}
+
+	// Duplicate types are checked once; unknown types such as API agents are skipped
+	outdated := findOutdatedAgents([]string{"claude", "claude", "gemini", "codex", "qwen", "api"}, lookup)
+
+	if lookups != 4 {
+		t.Errorf("expected 4 version lookups, got %d", lookups)
+	}
+	if len(outdated) != 1 || outdated[0].name != "Claude" {
+		t.Fatalf("expected only Claude to be outdated, got %+v", outdated)
+	}
+
+	notice := formatOutdatedNotice(outdated)
+	if !strings.Contains(notice, "Claude 1.0.0 → 1.1.0") || strings.Contains(notice, "\n") {
+		t.Errorf("unexpected notice: %q", notice)
+	}
+}
@@ -3,29 +3,53 @@ package bridge
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 )
 
+// errClientClosed is returned for events sent in batching mode after Close, when
+// there is no flush loop left to deliver them
+var errClientClosed = errors.New("bridge client is closed")
+
 // Client is an HTTP client for sending streaming events to AgentPipe Web
 type Client struct {
 	config           *Config
 	httpClient       *http.Client
 	suppressWarnings bool // Set to true after first failure to avoid spamming warnings
+
+	// Batching state (only used when config.BatchingEnabled())
+	batchMu   sync.Mutex     // guards pending and closed
+	pending   []*Event       // events waiting to be flushed
+	closed    bool           // set by Close; later events are rejected
+	flushMu   sync.Mutex     // serializes flushes so batches are sent in order
+	stopFlush chan struct{}  // closed by Close to stop the flush loop
+	flushWG   sync.WaitGroup // tracks the flush loop and in-flight async flushes
+	closeOnce sync.Once
 }
 
 // NewClient creates a new bridge client with the given configuration
+// When batching is enabled, a background loop flushes pending events every FlushIntervalMs.
 func NewClient(config *Config) *Client {
-	return &Client{
+	c := &Client{
 		config: config,
 		httpClient: &http.Client{
 			Timeout: time.Duration(config.TimeoutMs) * time.Millisecond,
 		},
 		suppressWarnings: false,
 	}
+
+	if config.BatchingEnabled() {
+		c.stopFlush = make(chan struct{})
+		c.flushWG.Add(1)
+		go c.flushLoop()
+	}
+
+	return c
 }
 
 // getEndpointURL returns the full API endpoint URL by appending /api/ingest to the base URL
@@ -35,17 +59,21 @@ func (c *Client) getEndpointURL() string {
 
 // SendEvent sends an event to the streaming endpoint with retry logic
 // Returns an error if all retry attempts fail, but logs errors instead of failing the conversation
+// In batching mode the event is queued behind any pending events and the batch is flushed immediately.
 func (c *Client) SendEvent(event *Event) error {
 	if !c.config.Enabled {
 		return nil // Silently skip if streaming is disabled
 	}
 
-	// Validate that we have an API key
-	if c.config.APIKey == "" {
-		if c.config.LogLevel == "debug" {
-			fmt.Fprintln(os.Stderr, "Debug: Streaming enabled but no API key configured")
+	if c.config.BatchingEnabled() {
+		if _, err := c.enqueue(event, false); err != nil {
+			return err
 		}
-		return fmt.Errorf("streaming enabled but no API key configured")
+		return c.Flush()
+	}
+
+	if err := c.checkAPIKey(); err != nil {
+		return err
 	}
 
 	// Serialize event to JSON
@@ -54,6 +82,23 @@ func (c *Client) SendEvent(event *Event) error {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	return c.sendWithRetry(body, string(event.Type)+" event")
+}
+
+// checkAPIKey validates that we have an API key to send events with
+func (c *Client) checkAPIKey() error {
+	if c.config.APIKey == "" {
+		if c.config.LogLevel == "debug" {
+			fmt.Fprintln(os.Stderr, "Debug: Streaming enabled but no API key configured")
+		}
+		return fmt.Errorf("streaming enabled but no API key configured")
+	}
+	return nil
+}
+
+// sendWithRetry posts a request body with exponential backoff between attempts
+// description is only used for debug output (e.g., "message.created event")
+func (c *Client) sendWithRetry(body []byte, description string) error {
 	// Retry logic with exponential backoff
 	var lastErr error
 	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
@@ -74,7 +119,7 @@ func (c *Client) SendEvent(event *Event) error {
 		err := c.sendRequest(body)
 		if err == nil {
 			if c.config.LogLevel == "debug" {
-				fmt.Fprintf(os.Stderr, "Debug: Successfully sent %s event\n", event.Type)
+				fmt.Fprintf(os.Stderr, "Debug: Successfully sent %s\n", description)
 			}
 			return nil // Success
 		}
@@ -137,7 +182,25 @@ func (c *Client) sendRequest(body []byte) error {
 
 // SendEventAsync sends an event asynchronously in a goroutine (non-blocking)
 // Errors are logged at debug level but do not block or fail the conversation
+// In batching mode the event is buffered and sent once the batch is full or the flush interval elapses.
 func (c *Client) SendEventAsync(event *Event) {
+	if c.config.Enabled && c.config.BatchingEnabled() {
+		flush, err := c.enqueue(event, true)
+		if err != nil {
+			if c.config.LogLevel == "debug" {
+				fmt.Fprintf(os.Stderr, "Debug: Dropped %s event: %v\n", event.Type, err)
+			}
+			return
+		}
+		if flush {
+			go func() {
+				defer c.flushWG.Done()
+				c.flushAndLog()
+			}()
+		}
+		return
+	}
+
 	go func() {
 		if err := c.SendEvent(event); err != nil {
 			// Log at debug level only to avoid cluttering output
@@ -148,6 +211,102 @@ func (c *Client) SendEventAsync(event *Event) {
 	}()
 }
 
+// enqueue appends an event to the pending batch. With schedule set it reports whether
+// the batch is now full, in which case it has registered the flush the caller must run
+// with flushWG, so Close waits for it. Events are rejected with errClientClosed once
+// Close has been called.
+func (c *Client) enqueue(event *Event, schedule bool) (bool, error) {
+	c.batchMu.Lock()
+	defer c.batchMu.Unlock()
+	if c.closed {
+		return false, errClientClosed
+	}
+	c.pending = append(c.pending, event)
+	if schedule && len(c.pending) >= c.config.BatchSize {
+		c.flushWG.Add(1)
+		return true, nil
+	}
+	return false, nil
+}
+
+// Flush sends all pending events in a single request
+// It is a no-op when nothing is buffered. Events are sent as a JSON array in the order they were queued.
+func (c *Client) Flush() error {
+	c.flushMu.Lock()
+	defer c.flushMu.Unlock()
+
+	// Take the buffer while holding flushMu so batches go out in queue order
+	c.batchMu.Lock()
+	events := c.pending
+	c.pending = nil
+	c.batchMu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+	if err := c.checkAPIKey(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event batch: %w", err)
+	}
+
+	return c.sendWithRetry(body, fmt.Sprintf("batch of %d events", len(events)))
+}
+
+// flushAndLog flushes pending events, logging failures at debug level
+func (c *Client) flushAndLog() {
+	if err := c.Flush(); err != nil && c.config.LogLevel == "debug" {
+		fmt.Fprintf(os.Stderr, "Debug: Batch flush error: %v\n", err)
+	}
+}
+
+// flushLoop periodically flushes partially filled batches until Close is called
+func (c *Client) flushLoop() {
+	defer c.flushWG.Done()
+
+	interval := time.Duration(c.config.FlushIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.flushAndLog()
+		case <-c.stopFlush:
+			return
+		}
+	}
+}
+
+// Close stops the background flush loop and sends any remaining buffered events
+// Events sent after Close are rejected rather than queued where nothing would flush them.
+// It is safe to call multiple times and is a no-op when batching is disabled.
+func (c *Client) Close() error {
+	if !c.config.BatchingEnabled() {
+		return nil
+	}
+
+	var err error
+	c.closeOnce.Do(func() {
+		c.batchMu.Lock()
+		c.closed = true
+		c.batchMu.Unlock()
+
+		close(c.stopFlush)
+		c.flushWG.Wait()
+		if c.config.Enabled {
+			err = c.Flush()
+		}
+	})
+	return err
+}
+
 // httpError represents an HTTP error response
 type httpError struct {
 	statusCode int
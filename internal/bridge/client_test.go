@@ -2,9 +2,12 @@ package bridge
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -393,3 +396,160 @@ func TestIsClientError(t *testing.T) {
 		t.Error("Expected false for non-httpError")
 	}
 }
+
+func TestBatching_ReducesRequestsAndPreservesOrder(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	var received []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Event
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("Failed to decode batch: %v", err)
+		}
+
+		mu.Lock()
+		requests++
+		for _, event := range batch {
+			data, _ := event.Data.(map[string]interface{})
+			received = append(received, data["content"].(string))
+		}
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:         true,
+		URL:             server.URL,
+		APIKey:          "sk_test",
+		TimeoutMs:       5000,
+		RetryAttempts:   0,
+		BatchSize:       5,
+		FlushIntervalMs: 60000, // Rely on size and Close, not the ticker
+	}
+
+	client := NewClient(config)
+
+	const total = 12
+	for i := 0; i < total; i++ {
+		client.SendEventAsync(&Event{
+			Type:      EventMessageCreated,
+			Timestamp: UTCTime{time.Now()},
+			Data:      MessageCreatedData{Content: fmt.Sprintf("msg-%d", i)},
+		})
+	}
+
+	// Close flushes the remaining 2 events
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// Size-triggered flushes run asynchronously and may pick up more than one
+	// batch worth of events, so allow fewer requests but never one per event
+	if requests < 1 || requests > 3 {
+		t.Errorf("Expected 1-3 batched requests for %d events, got %d", total, requests)
+	}
+	if len(received) != total {
+		t.Fatalf("Expected %d events, got %d", total, len(received))
+	}
+	for i, content := range received {
+		if expected := fmt.Sprintf("msg-%d", i); content != expected {
+			t.Errorf("Event %d out of order: expected %s, got %s", i, expected, content)
+		}
+	}
+}
+
+func TestBatching_FlushInterval(t *testing.T) {
+	receivedChan := make(chan int, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []Event
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		receivedChan <- len(batch)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:         true,
+		URL:             server.URL,
+		APIKey:          "sk_test",
+		TimeoutMs:       5000,
+		BatchSize:       100,
+		FlushIntervalMs: 20,
+	}
+
+	client := NewClient(config)
+	defer client.Close()
+
+	for i := 0; i < 3; i++ {
+		client.SendEventAsync(&Event{Type: EventBridgeTest, Timestamp: UTCTime{time.Now()}})
+	}
+
+	select {
+	case n := <-receivedChan:
+		if n != 3 {
+			t.Errorf("Expected 3 events in flushed batch, got %d", n)
+		}
+	case <-time.After(1 * time.Second):
+		t.Error("Timeout: Expected partial batch to be flushed by interval")
+	}
+}
+
+func TestBatching_NoAPIKey(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{
+		Enabled:         true,
+		URL:             server.URL,
+		BatchSize:       5,
+		FlushIntervalMs: 60000,
+	})
+	defer client.Close()
+
+	err := client.SendEvent(&Event{Type: EventBridgeTest, Timestamp: UTCTime{time.Now()}})
+	if err == nil || !strings.Contains(err.Error(), "no API key") {
+		t.Errorf("Expected 'no API key' error from a batched send, got: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("Expected no requests without an API key, got %d", requests)
+	}
+}
+
+func TestBatching_RejectsEventsAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(&Config{
+		Enabled:         true,
+		URL:             server.URL,
+		APIKey:          "sk_test",
+		TimeoutMs:       5000,
+		BatchSize:       5,
+		FlushIntervalMs: 60000,
+	})
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	event := &Event{Type: EventBridgeTest, Timestamp: UTCTime{time.Now()}}
+	if err := client.SendEvent(event); !errors.Is(err, errClientClosed) {
+		t.Errorf("Expected a closed client to reject events, got: %v", err)
+	}
+	client.SendEventAsync(event)
+	if len(client.pending) != 0 {
+		t.Errorf("Expected no events to be queued after Close, got %d", len(client.pending))
+	}
+}
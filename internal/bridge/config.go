@@ -15,17 +15,28 @@ type Config struct {
 	TimeoutMs     int    `mapstructure:"timeout_ms"`
 	RetryAttempts int    `mapstructure:"retry_attempts"`
 	LogLevel      string `mapstructure:"log_level"`
+	// BatchSize enables batching when greater than 1: events are buffered and
+	// sent together once this many are pending
+	BatchSize int `mapstructure:"batch_size"`
+	// FlushIntervalMs is how often a partially filled batch is flushed (default: 1000)
+	FlushIntervalMs int `mapstructure:"flush_interval_ms"`
+}
+
+// BatchingEnabled reports whether events should be buffered and sent in batches.
+func (c *Config) BatchingEnabled() bool {
+	return c.BatchSize > 1
 }
 
 // LoadConfig loads bridge configuration from viper, environment variables, and defaults
 // Precedence: environment variables > viper config > defaults
 func LoadConfig() *Config {
 	config := &Config{
-		Enabled:       false, // Disabled by default
-		URL:           getDefaultURL(),
-		TimeoutMs:     10000,
-		RetryAttempts: 3,
-		LogLevel:      "info",
+		Enabled:         false, // Disabled by default
+		URL:             getDefaultURL(),
+		TimeoutMs:       10000,
+		RetryAttempts:   3,
+		LogLevel:        "info",
+		FlushIntervalMs: 1000,
 	}
 
 	// Load from viper config file if available
@@ -47,6 +58,12 @@ func LoadConfig() *Config {
 	if viper.IsSet("bridge.log_level") {
 		config.LogLevel = viper.GetString("bridge.log_level")
 	}
+	if viper.IsSet("bridge.batch_size") {
+		config.BatchSize = viper.GetInt("bridge.batch_size")
+	}
+	if viper.IsSet("bridge.flush_interval_ms") {
+		config.FlushIntervalMs = viper.GetInt("bridge.flush_interval_ms")
+	}
 
 	// Override with environment variables (highest priority)
 	if enabled := os.Getenv("AGENTPIPE_STREAM_ENABLED"); enabled == "true" || enabled == "1" {
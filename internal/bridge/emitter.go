@@ -69,10 +69,13 @@ func (e *Emitter) saveEventLocally(event *Event) {
 
 // Close closes the emitter and flushes any buffered events
 func (e *Emitter) Close() error {
+	clientErr := e.client.Close()
 	if e.eventStore != nil {
-		return e.eventStore.Close()
+		if err := e.eventStore.Close(); err != nil {
+			return err
+		}
 	}
-	return nil
+	return clientErr
 }
 
 // EmitConversationStarted emits a conversation.started event
@@ -112,6 +115,8 @@ func (e *Emitter) EmitMessageCreated(
 	inputTokens int,
 	outputTokens int,
 	cost float64,
+	billingTag string,
+	team string,
 	duration time.Duration,
 ) {
 	e.sequenceNumber++
@@ -133,6 +138,8 @@ func (e *Emitter) EmitMessageCreated(
 			Cost:           cost,
 			Model:          model,
 			DurationMs:     duration.Milliseconds(),
+			BillingTag:     billingTag,
+			Team:           team,
 		},
 	}
 	e.saveEventLocally(event)
@@ -143,6 +150,7 @@ func (e *Emitter) EmitMessageCreated(
 // Uses synchronous send to ensure the event is fully sent before program exit
 func (e *Emitter) EmitConversationCompleted(
 	status string,
+	endReason string,
 	totalMessages int,
 	totalTurns int,
 	totalTokens int,
@@ -156,6 +164,7 @@ func (e *Emitter) EmitConversationCompleted(
 		Data: ConversationCompletedData{
 			ConversationID:  e.conversationID,
 			Status:          status,
+			EndReason:       endReason,
 			TotalMessages:   totalMessages,
 			TotalTurns:      totalTurns,
 			TotalTokens:     totalTokens,
@@ -191,6 +200,70 @@ func (e *Emitter) EmitConversationError(
 	_ = e.client.SendEvent(event)
 }
 
+// EmitSummaryStarted emits a summary.started event
+// Uses synchronous send since summaries are generated while the conversation is shutting down
+func (e *Emitter) EmitSummaryStarted(agentType string) {
+	event := &Event{
+		Type:      EventSummaryStarted,
+		Timestamp: UTCTime{time.Now()},
+		Data: SummaryStartedData{
+			ConversationID: e.conversationID,
+			AgentType:      agentType,
+		},
+	}
+	e.saveEventLocally(event)
+	_ = e.client.SendEvent(event)
+}
+
+// EmitSummaryGenerated emits a summary.generated event
+// Uses synchronous send since summaries are generated while the conversation is shutting down
+func (e *Emitter) EmitSummaryGenerated(summary *SummaryMetadata) {
+	if summary == nil {
+		return
+	}
+	event := &Event{
+		Type:      EventSummaryGenerated,
+		Timestamp: UTCTime{time.Now()},
+		Data: SummaryGeneratedData{
+			ConversationID:  e.conversationID,
+			SummaryMetadata: *summary,
+		},
+	}
+	e.saveEventLocally(event)
+	_ = e.client.SendEvent(event)
+}
+
+// EmitSummaryCompleted emits a summary.completed event, with errorMessage when generation
+// failed. The summary itself goes out beforehand in summary.generated.
+// Uses synchronous send since summaries are generated while the conversation is shutting down
+func (e *Emitter) EmitSummaryCompleted(errorMessage string) {
+	event := &Event{
+		Type:      EventSummaryCompleted,
+		Timestamp: UTCTime{time.Now()},
+		Data:      newSummaryCompletedData(e.conversationID, errorMessage),
+	}
+	e.saveEventLocally(event)
+	_ = e.client.SendEvent(event)
+}
+
+// EmitVoteCompleted emits a vote.completed event
+// Uses synchronous send since votes are cast while the conversation is shutting down
+func (e *Emitter) EmitVoteCompleted(result *VoteResult) {
+	if result == nil {
+		return
+	}
+	event := &Event{
+		Type:      EventVoteCompleted,
+		Timestamp: UTCTime{time.Now()},
+		Data: VoteCompletedData{
+			ConversationID: e.conversationID,
+			VoteResult:     *result,
+		},
+	}
+	e.saveEventLocally(event)
+	_ = e.client.SendEvent(event)
+}
+
 // emitBridgeConnected emits a bridge.connected event to announce the connection
 // This is called automatically when the emitter is created
 func (e *Emitter) emitBridgeConnected() {
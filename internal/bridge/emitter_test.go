@@ -170,8 +170,8 @@ func TestEmitMessageCreated(t *testing.T) {
 	emitter := NewEmitter(config, "0.2.4")
 
 	// Emit two messages to test sequence numbering
-	emitter.EmitMessageCreated("claude-0", "claude", "Claude", "Hello", "claude-sonnet-4", 1, 100, 50, 50, 0.001, 1234*time.Millisecond)
-	emitter.EmitMessageCreated("gemini-0", "gemini", "Gemini", "Hi", "gemini-pro", 1, 80, 40, 40, 0.0008, 987*time.Millisecond)
+	emitter.EmitMessageCreated("claude-0", "claude", "Claude", "Hello", "claude-sonnet-4", 1, 100, 50, 50, 0.001, "acme", "", 1234*time.Millisecond)
+	emitter.EmitMessageCreated("gemini-0", "gemini", "Gemini", "Hi", "gemini-pro", 1, 80, 40, 40, 0.0008, "", "", 987*time.Millisecond)
 
 	// Collect all three events (bridge.connected + two messages)
 	events := collectEvents(t, receivedEvents, 3)
@@ -224,6 +224,9 @@ func verifyMessageEvent(t *testing.T, event *Event) {
 		if data["agent_name"] != "Claude" {
 			t.Errorf("Expected agent_name='Claude' for seq 1, got %v", data["agent_name"])
 		}
+		if data["billing_tag"] != "acme" {
+			t.Errorf("Expected billing_tag='acme' for seq 1, got %v", data["billing_tag"])
+		}
 	} else if seqNum == 2 {
 		if data["content"] != "Hi" {
 			t.Errorf("Expected content='Hi' for seq 2, got %v", data["content"])
@@ -231,6 +234,9 @@ func verifyMessageEvent(t *testing.T, event *Event) {
 		if data["agent_name"] != "Gemini" {
 			t.Errorf("Expected agent_name='Gemini' for seq 2, got %v", data["agent_name"])
 		}
+		if _, ok := data["billing_tag"]; ok {
+			t.Errorf("Expected no billing_tag for an untagged message, got %v", data["billing_tag"])
+		}
 	} else {
 		t.Errorf("Unexpected sequence number: %d", seqNum)
 	}
@@ -282,7 +288,7 @@ func TestEmitConversationCompleted(t *testing.T) {
 		DurationMs:   1200,
 	}
 
-	emitter.EmitConversationCompleted("completed", 20, 10, 3000, 0.03, 300*time.Second, summary)
+	emitter.EmitConversationCompleted("completed", "max_turns", 20, 10, 3000, 0.03, 300*time.Second, summary)
 
 	// Collect both events (bridge.connected and conversation.completed)
 	events := collectEvents(t, receivedEvents, 2)
@@ -307,6 +313,10 @@ func TestEmitConversationCompleted(t *testing.T) {
 		t.Errorf("Expected status=completed, got %v", data["status"])
 	}
 
+	if data["end_reason"] != "max_turns" {
+		t.Errorf("Expected end_reason=max_turns, got %v", data["end_reason"])
+	}
+
 	if data["total_messages"].(float64) != 20 {
 		t.Errorf("Expected total_messages=20, got %v", data["total_messages"])
 	}
@@ -316,6 +326,146 @@ func TestEmitConversationCompleted(t *testing.T) {
 	}
 }
 
+func TestEmitSummaryGenerated(t *testing.T) {
+	receivedEvents := make(chan *Event, 10)
+
+	// Create mock server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		receivedEvents <- &event
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:       true,
+		URL:           server.URL,
+		APIKey:        "sk_test",
+		TimeoutMs:     5000,
+		RetryAttempts: 3,
+		LogLevel:      "debug",
+	}
+
+	emitter := NewEmitter(config, "0.2.4")
+
+	emitter.EmitSummaryGenerated(&SummaryMetadata{
+		ShortText:    "Test summary.",
+		Text:         "Test summary of the conversation",
+		AgentType:    "gemini",
+		Model:        "gemini-2.0-flash",
+		InputTokens:  2500,
+		OutputTokens: 150,
+		TotalTokens:  2650,
+		Cost:         0.002,
+		DurationMs:   1200,
+	})
+	// A nil summary emits nothing
+	emitter.EmitSummaryGenerated(nil)
+
+	events := collectEvents(t, receivedEvents, 2)
+
+	event := events[1]
+	if event.Type != EventSummaryGenerated {
+		t.Errorf("Expected second event type=%s, got %s", EventSummaryGenerated, event.Type)
+	}
+
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected data to be a map")
+	}
+
+	if data["conversation_id"] != emitter.GetConversationID() {
+		t.Errorf("Expected conversation_id=%s, got %v", emitter.GetConversationID(), data["conversation_id"])
+	}
+	if data["total_tokens"].(float64) != 2650 {
+		t.Errorf("Expected total_tokens=2650, got %v", data["total_tokens"])
+	}
+	if data["cost"].(float64) != 0.002 {
+		t.Errorf("Expected cost=0.002, got %v", data["cost"])
+	}
+	if data["duration_ms"].(float64) != 1200 {
+		t.Errorf("Expected duration_ms=1200, got %v", data["duration_ms"])
+	}
+	if data["model"] != "gemini-2.0-flash" {
+		t.Errorf("Expected model=gemini-2.0-flash, got %v", data["model"])
+	}
+
+	select {
+	case extra := <-receivedEvents:
+		t.Errorf("Expected no event for a nil summary, got %s", extra.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestEmitVoteCompleted(t *testing.T) {
+	receivedEvents := make(chan *Event, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event Event
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		receivedEvents <- &event
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	config := &Config{
+		Enabled:       true,
+		URL:           server.URL,
+		APIKey:        "sk_test",
+		TimeoutMs:     5000,
+		RetryAttempts: 3,
+		LogLevel:      "debug",
+	}
+
+	emitter := NewEmitter(config, "0.2.4")
+
+	emitter.EmitVoteCompleted(&VoteResult{
+		Votes:  []Vote{{Voter: "Alice", Choice: "Bob"}, {Voter: "Carol", Choice: "Bob"}},
+		Tally:  []VoteCount{{AgentName: "Alice", Votes: 0}, {AgentName: "Bob", Votes: 2}, {AgentName: "Carol", Votes: 0}},
+		Winner: "Bob",
+	})
+	// A nil result emits nothing
+	emitter.EmitVoteCompleted(nil)
+
+	events := collectEvents(t, receivedEvents, 2)
+
+	event := events[1]
+	if event.Type != EventVoteCompleted {
+		t.Errorf("Expected second event type=%s, got %s", EventVoteCompleted, event.Type)
+	}
+
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected data to be a map")
+	}
+	if data["conversation_id"] != emitter.GetConversationID() {
+		t.Errorf("Expected conversation_id=%s, got %v", emitter.GetConversationID(), data["conversation_id"])
+	}
+	if data["winner"] != "Bob" {
+		t.Errorf("Expected winner=Bob, got %v", data["winner"])
+	}
+	if votes, ok := data["votes"].([]interface{}); !ok || len(votes) != 2 {
+		t.Errorf("Expected 2 votes, got %v", data["votes"])
+	}
+
+	select {
+	case extra := <-receivedEvents:
+		t.Errorf("Expected no event for a nil result, got %s", extra.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestEmitConversationError(t *testing.T) {
 	receivedEvents := make(chan *Event, 10)
 
@@ -393,19 +543,19 @@ func TestSequenceNumbering(t *testing.T) {
 	}
 
 	// After first message, should be 1
-	emitter.EmitMessageCreated("claude-0", "claude", "Claude", "msg1", "model", 1, 100, 50, 50, 0.001, 1*time.Second)
+	emitter.EmitMessageCreated("claude-0", "claude", "Claude", "msg1", "model", 1, 100, 50, 50, 0.001, "", "", 1*time.Second)
 	if emitter.sequenceNumber != 1 {
 		t.Errorf("Expected sequence_number=1 after first message, got %d", emitter.sequenceNumber)
 	}
 
 	// After second message, should be 2
-	emitter.EmitMessageCreated("gemini-0", "gemini", "Gemini", "msg2", "model", 1, 100, 50, 50, 0.001, 1*time.Second)
+	emitter.EmitMessageCreated("gemini-0", "gemini", "Gemini", "msg2", "model", 1, 100, 50, 50, 0.001, "", "", 1*time.Second)
 	if emitter.sequenceNumber != 2 {
 		t.Errorf("Expected sequence_number=2 after second message, got %d", emitter.sequenceNumber)
 	}
 
 	// After third message, should be 3
-	emitter.EmitMessageCreated("claude-1", "claude", "Claude", "msg3", "model", 2, 100, 50, 50, 0.001, 1*time.Second)
+	emitter.EmitMessageCreated("claude-1", "claude", "Claude", "msg3", "model", 2, 100, 50, 50, 0.001, "", "", 1*time.Second)
 	if emitter.sequenceNumber != 3 {
 		t.Errorf("Expected sequence_number=3 after third message, got %d", emitter.sequenceNumber)
 	}
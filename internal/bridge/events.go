@@ -19,10 +19,20 @@ const (
 	EventConversationCompleted EventType = "conversation.completed"
 	// EventConversationError is emitted when an error occurs during the conversation
 	EventConversationError EventType = "conversation.error"
+	// EventSummaryGenerated is emitted when the summary agent produces a conversation summary
+	EventSummaryGenerated EventType = "summary.generated"
+	// EventSummaryStarted is emitted when summary generation begins
+	EventSummaryStarted EventType = "summary.started"
+	// EventSummaryCompleted is emitted when summary generation ends, whether or not it succeeded
+	EventSummaryCompleted EventType = "summary.completed"
 	// EventBridgeTest is emitted when testing the bridge connection
 	EventBridgeTest EventType = "bridge.test"
 	// EventLogEntry is emitted for log messages (messages, errors, system messages)
 	EventLogEntry EventType = "log.entry"
+	// EventHeartbeat is emitted periodically while an agent is working on its turn
+	EventHeartbeat EventType = "heartbeat"
+	// EventVoteCompleted is emitted when the end-of-conversation vote has been tallied
+	EventVoteCompleted EventType = "vote.completed"
 )
 
 // UTCTime wraps time.Time to ensure JSON marshaling always uses UTC with Z suffix
@@ -83,6 +93,8 @@ type AgentParticipant struct {
 	Name       string `json:"name,omitempty"`        // Display name of the agent
 	Prompt     string `json:"prompt,omitempty"`      // System prompt for the agent
 	CLIVersion string `json:"cli_version,omitempty"` // Version of the agent CLI
+	Avatar     string `json:"avatar,omitempty"`      // Emoji or image URL for the agent
+	Color      string `json:"color,omitempty"`       // Display color (ANSI 256 code or hex)
 }
 
 // MessageCreatedData contains data for message.created events
@@ -101,6 +113,8 @@ type MessageCreatedData struct {
 	Cost           float64 `json:"cost,omitempty"`
 	Model          string  `json:"model,omitempty"`
 	DurationMs     int64   `json:"duration_ms,omitempty"`
+	BillingTag     string  `json:"billing_tag,omitempty"` // Project the cost is attributed to
+	Team           string  `json:"team,omitempty"`        // Team whose private scratchpad the message was posted to
 }
 
 // SummaryMetadata contains information about the AI-generated conversation summary
@@ -119,7 +133,8 @@ type SummaryMetadata struct {
 // ConversationCompletedData contains data for conversation.completed events
 type ConversationCompletedData struct {
 	ConversationID  string           `json:"conversation_id"`
-	Status          string           `json:"status"` // "completed", "interrupted", "error"
+	Status          string           `json:"status"`               // "completed", "interrupted", "error"
+	EndReason       string           `json:"end_reason,omitempty"` // Condition that ended a completed conversation, e.g. "max_turns"
 	TotalMessages   int              `json:"total_messages,omitempty"`
 	TotalTurns      int              `json:"total_turns,omitempty"`
 	TotalTokens     int              `json:"total_tokens,omitempty"`     // Includes summary tokens
@@ -128,6 +143,62 @@ type ConversationCompletedData struct {
 	Summary         *SummaryMetadata `json:"summary,omitempty"`          // AI-generated conversation summary with metadata
 }
 
+// SummaryGeneratedData contains data for summary.generated events.
+// The summary's tokens, cost and duration are reported separately from the conversation totals.
+type SummaryGeneratedData struct {
+	ConversationID string `json:"conversation_id"`
+	SummaryMetadata
+}
+
+// SummaryStartedData contains data for summary.started events
+type SummaryStartedData struct {
+	ConversationID string `json:"conversation_id"`
+	AgentType      string `json:"agent_type"` // Summary agent type (or name/ID when reused)
+}
+
+// SummaryCompletedData contains data for summary.completed events.
+// It only closes the summary.started bracket; the summary itself is in summary.generated.
+type SummaryCompletedData struct {
+	ConversationID string `json:"conversation_id"`
+	Status         string `json:"status"`          // "generated" or "failed"
+	Error          string `json:"error,omitempty"` // Why generation failed
+}
+
+// newSummaryCompletedData builds summary.completed data; generation failed when errorMessage is set
+func newSummaryCompletedData(conversationID string, errorMessage string) SummaryCompletedData {
+	if errorMessage != "" {
+		return SummaryCompletedData{ConversationID: conversationID, Status: "failed", Error: errorMessage}
+	}
+	return SummaryCompletedData{ConversationID: conversationID, Status: "generated"}
+}
+
+// Vote is a single ballot in the end-of-conversation vote
+type Vote struct {
+	Voter  string `json:"voter"`  // Name of the agent or judge that voted
+	Choice string `json:"choice"` // Name of the participant voted for
+}
+
+// VoteCount is the number of votes a participant received
+type VoteCount struct {
+	AgentName string `json:"agent_name"`
+	Votes     int    `json:"votes"`
+}
+
+// VoteResult contains the tallied end-of-conversation vote
+type VoteResult struct {
+	Votes    []Vote      `json:"votes"`               // Valid ballots, in the order they were cast
+	Tally    []VoteCount `json:"tally"`               // Votes per participant, in order of appearance
+	Winner   string      `json:"winner,omitempty"`    // Empty when no votes were cast or the vote is a draw
+	Tied     []string    `json:"tied,omitempty"`      // Participants that shared the most votes
+	TieBreak string      `json:"tie_break,omitempty"` // How a tie was broken (e.g. "referee_score")
+}
+
+// VoteCompletedData contains data for vote.completed events
+type VoteCompletedData struct {
+	ConversationID string `json:"conversation_id"`
+	VoteResult
+}
+
 // ConversationErrorData contains data for conversation.error events
 type ConversationErrorData struct {
 	ConversationID string `json:"conversation_id"`
@@ -161,9 +232,18 @@ type LogEntryData struct {
 	Metadata       map[string]interface{} `json:"metadata,omitempty"` // Additional context
 }
 
+// HeartbeatData contains data for heartbeat events
+type HeartbeatData struct {
+	ConversationID string  `json:"conversation_id"`
+	AgentID        string  `json:"agent_id"`
+	AgentName      string  `json:"agent_name"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"` // Time since the agent's turn started
+}
+
 // LogEntryMetrics contains metrics for log entries (if applicable)
 type LogEntryMetrics struct {
 	DurationSeconds float64 `json:"duration_seconds,omitempty"`
 	TotalTokens     int     `json:"total_tokens,omitempty"`
 	Cost            float64 `json:"cost,omitempty"`
+	BillingTag      string  `json:"billing_tag,omitempty"`
 }
@@ -24,6 +24,8 @@ func TestEventJSONSerialization(t *testing.T) {
 			Name:       "Claude",
 			Prompt:     "You are a helpful assistant",
 			CLIVersion: "1.2.0",
+			Avatar:     "🤖",
+			Color:      "63",
 		},
 	}
 
@@ -96,6 +98,9 @@ func TestEventJSONSerialization(t *testing.T) {
 	if participantMap["cli_version"] != "1.2.0" {
 		t.Errorf("Expected cli_version=1.2.0, got %v", participantMap["cli_version"])
 	}
+	if participantMap["avatar"] != "🤖" || participantMap["color"] != "63" {
+		t.Errorf("Expected avatar and color in participant, got %v / %v", participantMap["avatar"], participantMap["color"])
+	}
 }
 
 func TestMessageCreatedEvent(t *testing.T) {
@@ -26,10 +26,13 @@ type BridgeEmitter interface {
 		inputTokens int,
 		outputTokens int,
 		cost float64,
+		billingTag string,
+		team string,
 		duration time.Duration,
 	)
 	EmitConversationCompleted(
 		status string,
+		endReason string,
 		totalMessages int,
 		totalTurns int,
 		totalTokens int,
@@ -38,5 +41,16 @@ type BridgeEmitter interface {
 		summary *SummaryMetadata,
 	)
 	EmitConversationError(errorMessage string, errorType string, agentType string)
+	EmitSummaryStarted(agentType string)
+	EmitSummaryGenerated(summary *SummaryMetadata)
+	EmitSummaryCompleted(errorMessage string)
+	EmitVoteCompleted(result *VoteResult)
 	Close() error
 }
+
+// TurnObserver is implemented by emitters that track in-flight agent turns,
+// e.g. to emit heartbeats while waiting on a slow agent.
+type TurnObserver interface {
+	AgentTurnStarted(agentID, agentName string)
+	AgentTurnEnded(agentID string)
+}
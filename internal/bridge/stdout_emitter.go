@@ -3,6 +3,7 @@ package bridge
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sync"
 	"time"
@@ -15,16 +16,27 @@ import (
 type StdoutEmitter struct {
 	conversationID string
 	sequenceNum    int
-	mu             sync.Mutex
+	mu             sync.Mutex // guards sequenceNum and serializes writes to out
 	version        string
+	out            io.Writer
+
+	heartbeatMu       sync.Mutex
+	heartbeatInterval time.Duration            // 0 disables heartbeats
+	activeTurns       map[string]chan struct{} // stop channels of in-flight turns' heartbeats
 }
 
+// DefaultHeartbeatInterval is how often heartbeat events are emitted while an agent works
+const DefaultHeartbeatInterval = 10 * time.Second
+
 // NewStdoutEmitter creates a new stdout emitter
 func NewStdoutEmitter(version string) *StdoutEmitter {
 	emitter := &StdoutEmitter{
-		conversationID: uuid.New().String(),
-		sequenceNum:    0,
-		version:        version,
+		conversationID:    uuid.New().String(),
+		sequenceNum:       0,
+		version:           version,
+		out:               os.Stdout,
+		heartbeatInterval: DefaultHeartbeatInterval,
+		activeTurns:       make(map[string]chan struct{}),
 	}
 
 	// Emit bridge.connected event immediately
@@ -49,10 +61,83 @@ func (e *StdoutEmitter) emitEvent(event Event) error {
 	defer e.mu.Unlock()
 
 	// Write to stdout with newline for JSONL format
-	fmt.Fprintln(os.Stdout, string(jsonData))
+	fmt.Fprintln(e.out, string(jsonData))
 	return nil
 }
 
+// SetHeartbeatInterval sets how often heartbeat events are emitted while an agent is
+// working on its turn. Zero or less disables heartbeats. Turns already in flight keep
+// their interval.
+func (e *StdoutEmitter) SetHeartbeatInterval(interval time.Duration) {
+	e.heartbeatMu.Lock()
+	defer e.heartbeatMu.Unlock()
+	e.heartbeatInterval = interval
+}
+
+// AgentTurnStarted starts emitting heartbeat events for the agent until AgentTurnEnded,
+// so consumers can tell a long agent call from a hang.
+func (e *StdoutEmitter) AgentTurnStarted(agentID, agentName string) {
+	e.heartbeatMu.Lock()
+	defer e.heartbeatMu.Unlock()
+
+	if e.heartbeatInterval <= 0 {
+		return
+	}
+	if stop, ok := e.activeTurns[agentID]; ok {
+		close(stop)
+	}
+	stop := make(chan struct{})
+	e.activeTurns[agentID] = stop
+
+	go e.runHeartbeat(agentID, agentName, e.heartbeatInterval, stop)
+}
+
+// AgentTurnEnded stops the agent's heartbeat events.
+func (e *StdoutEmitter) AgentTurnEnded(agentID string) {
+	e.heartbeatMu.Lock()
+	defer e.heartbeatMu.Unlock()
+
+	if stop, ok := e.activeTurns[agentID]; ok {
+		close(stop)
+		delete(e.activeTurns, agentID)
+	}
+}
+
+// runHeartbeat emits a heartbeat event every interval until stop is closed
+func (e *StdoutEmitter) runHeartbeat(agentID, agentName string, interval time.Duration, stop <-chan struct{}) {
+	started := time.Now()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// Holding heartbeatMu means AgentTurnEnded can't return while a heartbeat is
+			// being written, so none trails the turn's own events
+			e.heartbeatMu.Lock()
+			select {
+			case <-stop:
+				e.heartbeatMu.Unlock()
+				return
+			default:
+			}
+			_ = e.emitEvent(Event{
+				Type:      EventHeartbeat,
+				Timestamp: UTCTime{Time: time.Now()},
+				Data: HeartbeatData{
+					ConversationID: e.conversationID,
+					AgentID:        agentID,
+					AgentName:      agentName,
+					ElapsedSeconds: time.Since(started).Seconds(),
+				},
+			})
+			e.heartbeatMu.Unlock()
+		}
+	}
+}
+
 // emitBridgeConnected emits the bridge.connected event with system info
 func (e *StdoutEmitter) emitBridgeConnected() {
 	sysInfo := CollectSystemInfo(e.version)
@@ -108,6 +193,8 @@ func (e *StdoutEmitter) EmitMessageCreated(
 	inputTokens int,
 	outputTokens int,
 	cost float64,
+	billingTag string,
+	team string,
 	duration time.Duration,
 ) {
 	e.mu.Lock()
@@ -130,6 +217,8 @@ func (e *StdoutEmitter) EmitMessageCreated(
 		Cost:           cost,
 		Model:          model,
 		DurationMs:     duration.Milliseconds(),
+		BillingTag:     billingTag,
+		Team:           team,
 	}
 
 	event := Event{
@@ -141,14 +230,22 @@ func (e *StdoutEmitter) EmitMessageCreated(
 	_ = e.emitEvent(event)
 }
 
-// Close is a no-op for StdoutEmitter (no resources to clean up)
+// Close stops any in-flight heartbeats
 func (e *StdoutEmitter) Close() error {
+	e.heartbeatMu.Lock()
+	defer e.heartbeatMu.Unlock()
+
+	for agentID, stop := range e.activeTurns {
+		close(stop)
+		delete(e.activeTurns, agentID)
+	}
 	return nil
 }
 
 // EmitConversationCompleted emits a conversation.completed event
 func (e *StdoutEmitter) EmitConversationCompleted(
 	status string,
+	endReason string,
 	totalMessages int,
 	totalTurns int,
 	totalTokens int,
@@ -159,6 +256,7 @@ func (e *StdoutEmitter) EmitConversationCompleted(
 	data := ConversationCompletedData{
 		ConversationID:  e.conversationID,
 		Status:          status,
+		EndReason:       endReason,
 		TotalMessages:   totalMessages,
 		TotalTurns:      totalTurns,
 		TotalTokens:     totalTokens,
@@ -194,6 +292,74 @@ func (e *StdoutEmitter) EmitConversationError(errorMessage string, errorType str
 	_ = e.emitEvent(event)
 }
 
+// EmitSummaryStarted emits a summary.started event
+func (e *StdoutEmitter) EmitSummaryStarted(agentType string) {
+	data := SummaryStartedData{
+		ConversationID: e.conversationID,
+		AgentType:      agentType,
+	}
+
+	event := Event{
+		Type:      EventSummaryStarted,
+		Timestamp: UTCTime{Time: time.Now()},
+		Data:      data,
+	}
+
+	_ = e.emitEvent(event)
+}
+
+// EmitSummaryGenerated emits a summary.generated event
+func (e *StdoutEmitter) EmitSummaryGenerated(summary *SummaryMetadata) {
+	if summary == nil {
+		return
+	}
+
+	data := SummaryGeneratedData{
+		ConversationID:  e.conversationID,
+		SummaryMetadata: *summary,
+	}
+
+	event := Event{
+		Type:      EventSummaryGenerated,
+		Timestamp: UTCTime{Time: time.Now()},
+		Data:      data,
+	}
+
+	_ = e.emitEvent(event)
+}
+
+// EmitSummaryCompleted emits a summary.completed event, with errorMessage when generation
+// failed. The summary itself goes out beforehand in summary.generated.
+func (e *StdoutEmitter) EmitSummaryCompleted(errorMessage string) {
+	event := Event{
+		Type:      EventSummaryCompleted,
+		Timestamp: UTCTime{Time: time.Now()},
+		Data:      newSummaryCompletedData(e.conversationID, errorMessage),
+	}
+
+	_ = e.emitEvent(event)
+}
+
+// EmitVoteCompleted emits a vote.completed event
+func (e *StdoutEmitter) EmitVoteCompleted(result *VoteResult) {
+	if result == nil {
+		return
+	}
+
+	data := VoteCompletedData{
+		ConversationID: e.conversationID,
+		VoteResult:     *result,
+	}
+
+	event := Event{
+		Type:      EventVoteCompleted,
+		Timestamp: UTCTime{Time: time.Now()},
+		Data:      data,
+	}
+
+	_ = e.emitEvent(event)
+}
+
 // EmitLogEntry emits a log.entry event for log messages
 func (e *StdoutEmitter) EmitLogEntry(
 	level string,
@@ -0,0 +1,156 @@
+package bridge
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent writes and reads
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func newTestStdoutEmitter(out *lockedBuffer, interval time.Duration) *StdoutEmitter {
+	return &StdoutEmitter{
+		conversationID:    "conv-1",
+		out:               out,
+		heartbeatInterval: interval,
+		activeTurns:       make(map[string]chan struct{}),
+	}
+}
+
+// heartbeatEvents decodes the JSON lines in out, failing on any line that isn't valid JSON
+func heartbeatEvents(t *testing.T, out string) []HeartbeatData {
+	t.Helper()
+	var beats []HeartbeatData
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line == "" {
+			continue
+		}
+		var event struct {
+			Type EventType     `json:"type"`
+			Data HeartbeatData `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if event.Type == EventHeartbeat {
+			beats = append(beats, event.Data)
+		}
+	}
+	return beats
+}
+
+func TestStdoutEmitterHeartbeatDuringSlowTurn(t *testing.T) {
+	out := &lockedBuffer{}
+	e := newTestStdoutEmitter(out, 10*time.Millisecond)
+
+	e.AgentTurnStarted("agent-1", "Slowpoke")
+	time.Sleep(55 * time.Millisecond) // a slow agent call
+	e.AgentTurnEnded("agent-1")
+	e.EmitConversationError("done", "test", "mock")
+
+	beats := heartbeatEvents(t, out.String())
+	if len(beats) < 2 {
+		t.Fatalf("expected several heartbeats during the slow turn, got %d:\n%s", len(beats), out.String())
+	}
+	for i, beat := range beats {
+		if beat.AgentID != "agent-1" || beat.AgentName != "Slowpoke" || beat.ConversationID != "conv-1" {
+			t.Errorf("unexpected heartbeat %+v", beat)
+		}
+		if i > 0 && beat.ElapsedSeconds <= beats[i-1].ElapsedSeconds {
+			t.Errorf("expected elapsed time to grow, got %v after %v", beat.ElapsedSeconds, beats[i-1].ElapsedSeconds)
+		}
+	}
+
+	// Nothing follows the turn's end, so the last line is the real event
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if !strings.Contains(lines[len(lines)-1], `"conversation.error"`) {
+		t.Errorf("expected no heartbeat after the turn ended, last line: %s", lines[len(lines)-1])
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := len(heartbeatEvents(t, out.String())); got != len(beats) {
+		t.Errorf("expected heartbeats to stop after the turn, got %d more", got-len(beats))
+	}
+}
+
+func TestStdoutEmitterHeartbeatDisabled(t *testing.T) {
+	out := &lockedBuffer{}
+	e := newTestStdoutEmitter(out, 10*time.Millisecond)
+	e.SetHeartbeatInterval(0)
+
+	e.AgentTurnStarted("agent-1", "Slowpoke")
+	time.Sleep(35 * time.Millisecond)
+	e.AgentTurnEnded("agent-1")
+
+	if out.String() != "" {
+		t.Errorf("expected no heartbeats when disabled, got:\n%s", out.String())
+	}
+}
+
+func TestStdoutEmitterCloseStopsHeartbeats(t *testing.T) {
+	out := &lockedBuffer{}
+	e := newTestStdoutEmitter(out, 5*time.Millisecond)
+
+	e.AgentTurnStarted("agent-1", "Stuck")
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	before := out.String()
+	time.Sleep(25 * time.Millisecond)
+	if out.String() != before {
+		t.Errorf("expected no heartbeats after Close, got:\n%s", out.String())
+	}
+}
+
+func TestStdoutEmitterSummaryEvents(t *testing.T) {
+	out := &lockedBuffer{}
+	e := newTestStdoutEmitter(out, 0)
+
+	e.EmitSummaryStarted("gemini")
+	e.EmitSummaryCompleted("")
+	e.EmitSummaryCompleted("summary agent timed out")
+
+	type summaryEvent struct {
+		Type EventType            `json:"type"`
+		Data SummaryCompletedData `json:"data"`
+	}
+	var events []summaryEvent
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var event summaryEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 3 || events[0].Type != EventSummaryStarted || events[1].Type != EventSummaryCompleted || events[2].Type != EventSummaryCompleted {
+		t.Fatalf("unexpected events:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), `"agent_type":"gemini"`) {
+		t.Errorf("expected summary.started to name the summary agent:\n%s", out.String())
+	}
+	if done := events[1].Data; done.Status != "generated" || done.Error != "" || strings.Contains(out.String(), `"summary"`) {
+		t.Errorf("unexpected successful summary.completed data: %+v", done)
+	}
+	if failed := events[2].Data; failed.Status != "failed" || failed.Error != "summary agent timed out" {
+		t.Errorf("unexpected failed summary.completed data: %+v", failed)
+	}
+}
@@ -19,6 +19,8 @@ import (
 const (
 	defaultSyncTimeout = 30 * time.Second
 	sendQueueSize      = 200
+	// sendTimeout bounds delivering one message, including rate-limit waits and retries
+	sendTimeout = 2 * time.Minute
 )
 
 // Bridge mirrors AgentPipe conversations to a Matrix room and ingests room input.
@@ -140,33 +142,39 @@ func (b *Bridge) Close() {
 	}
 }
 
-// Send enqueues a message to be sent to Matrix.
-func (b *Bridge) Send(msg agent.Message) {
+// Send enqueues a message to be sent to Matrix. It has the signature of an
+// orchestrator ContextMessageHook: when the queue is full it waits for room until
+// ctx is done, then drops the message.
+func (b *Bridge) Send(ctx context.Context, msg agent.Message) {
 	if b == nil {
 		return
 	}
 
 	select {
 	case b.sendQueue <- msg:
-	default:
-		log.WithField("agent_id", msg.AgentID).Warn("matrix send queue full, dropping message")
+	case <-ctx.Done():
+		log.WithField("agent_id", msg.AgentID).WithError(ctx.Err()).Warn("matrix send queue full, dropping message")
 	}
 }
 
+// sendLoop delivers queued messages until ctx is done. Each delivery is bounded by
+// sendTimeout and cancelled along with ctx, so a stalled homeserver can't block shutdown.
 func (b *Bridge) sendLoop(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case msg := <-b.sendQueue:
-			if err := b.sendMessage(msg); err != nil {
+			sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+			if err := b.sendMessage(sendCtx, msg); err != nil {
 				log.WithError(err).WithField("agent_id", msg.AgentID).Warn("matrix send failed")
 			}
+			cancel()
 		}
 	}
 }
 
-func (b *Bridge) sendMessage(msg agent.Message) error {
+func (b *Bridge) sendMessage(ctx context.Context, msg agent.Message) error {
 	if msg.Content == "" {
 		return nil
 	}
@@ -196,7 +204,7 @@ func (b *Bridge) sendMessage(msg agent.Message) error {
 	}
 
 	body := formatMessageBody(msg)
-	return client.SendMessage(b.roomID, body)
+	return client.SendMessage(ctx, b.roomID, body)
 }
 
 func (b *Bridge) autoProvision(agents []agent.AgentConfig) error {
@@ -0,0 +1,83 @@
+package matrix
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestSendMessageStopsWaitingOnRateLimitWhenCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"errcode":"M_LIMIT_EXCEEDED","retry_after_ms":60000}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token", "@agent:example.com", time.Second, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := client.SendMessage(ctx, "!room:example.com", "hello")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the send to stop at the context deadline, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the rate-limit wait to be cut short, took %v", elapsed)
+	}
+}
+
+func TestSendMessageCancelsInFlightRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "token", "@agent:example.com", time.Minute, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- client.SendMessage(ctx, "!room:example.com", "hello") }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected a cancelled send, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("send did not return after its context was cancelled")
+	}
+}
+
+func TestBridgeSendWaitsForQueueUntilContextDone(t *testing.T) {
+	bridge := &Bridge{sendQueue: make(chan agent.Message, 1)}
+
+	bridge.Send(context.Background(), agent.Message{AgentID: "a", Content: "first"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	bridge.Send(ctx, agent.Message{AgentID: "a", Content: "second"})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected a full queue to give up at the context deadline, took %v", elapsed)
+	}
+
+	if len(bridge.sendQueue) != 1 || (<-bridge.sendQueue).Content != "first" {
+		t.Error("expected only the first message to be queued")
+	}
+}
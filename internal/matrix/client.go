@@ -2,6 +2,7 @@ package matrix
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -278,8 +279,8 @@ func extractRoomDomain(room string) string {
 	return ""
 }
 
-// SendMessage sends a text message to the given room.
-func (c *Client) SendMessage(roomID, body string) error {
+// SendMessage sends a text message to the given room, giving up once ctx is done.
+func (c *Client) SendMessage(ctx context.Context, roomID, body string) error {
 	if roomID == "" {
 		return fmt.Errorf("room ID is required")
 	}
@@ -305,9 +306,11 @@ func (c *Client) SendMessage(roomID, body string) error {
 	var lastErr error
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		waitForPacer(c.pacer, "send")
+		if err := c.pacer.Wait(ctx, "send"); err != nil {
+			return fmt.Errorf("send cancelled: %w", err)
+		}
 		retryable := false
-		req, err := http.NewRequest("PUT", endpoint, bytes.NewReader(data))
+		req, err := http.NewRequestWithContext(ctx, "PUT", endpoint, bytes.NewReader(data))
 		if err != nil {
 			return fmt.Errorf("failed to create send request: %w", err)
 		}
@@ -329,7 +332,9 @@ func (c *Client) SendMessage(roomID, body string) error {
 			if resp.StatusCode == http.StatusTooManyRequests {
 				retryAfter := capRetryAfter(parseRetryAfter(resp, bodyBytes))
 				if retryAfter > 0 && attempt < maxRetries {
-					sleepWithPacer(c.pacer, "send", "retry_after", retryAfter)
+					if err := sleepWithPacerContext(ctx, c.pacer, "send", "retry_after", retryAfter); err != nil {
+						return fmt.Errorf("send cancelled: %w", err)
+					}
 					continue
 				}
 			}
@@ -341,7 +346,9 @@ func (c *Client) SendMessage(roomID, body string) error {
 		if attempt < maxRetries {
 			if retryable {
 				backoff := time.Duration(1<<attempt) * time.Second
-				sleepWithPacer(c.pacer, "send", "backoff", backoff)
+				if err := sleepWithPacerContext(ctx, c.pacer, "send", "backoff", backoff); err != nil {
+					return fmt.Errorf("send cancelled: %w", err)
+				}
 				continue
 			}
 			return lastErr
@@ -1,6 +1,7 @@
 package matrix
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -81,8 +82,14 @@ func shouldRetryStatus(status int) bool {
 }
 
 func sleepWithPacer(pacer *Pacer, call, reason string, d time.Duration) {
+	_ = sleepWithPacerContext(context.Background(), pacer, call, reason, d)
+}
+
+// sleepWithPacerContext is sleepWithPacer returning early with the context's error
+// once ctx is done
+func sleepWithPacerContext(ctx context.Context, pacer *Pacer, call, reason string, d time.Duration) error {
 	if d <= 0 {
-		return
+		return nil
 	}
 	if reason == "retry_after" && pacer != nil {
 		pacer.Pause(d)
@@ -92,7 +99,15 @@ func sleepWithPacer(pacer *Pacer, call, reason string, d time.Duration) {
 		"reason":  reason,
 		"wait_ms": d.Milliseconds(),
 	}).Info("matrix api wait")
-	time.Sleep(d)
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func sleepWithLog(call, reason string, d time.Duration) {
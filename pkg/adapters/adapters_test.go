@@ -2,9 +2,12 @@ package adapters
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 )
@@ -550,3 +553,170 @@ func TestConversationFormatting(t *testing.T) {
 		}
 	})
 }
+
+func TestBuildPromptIncludesMemory(t *testing.T) {
+	dir := t.TempDir()
+	memoryPath := filepath.Join(dir, "claude-memory.md")
+	if err := os.WriteFile(memoryPath, []byte("The team chose PostgreSQL last time."), 0600); err != nil {
+		t.Fatalf("failed to write memory file: %v", err)
+	}
+
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Pick a database"},
+	}
+
+	t.Run("memory file present", func(t *testing.T) {
+		c := &ClaudeAgent{}
+		c.Name = "Claude"
+		c.Config = agent.AgentConfig{Prompt: "You are an architect", MemoryFile: memoryPath}
+
+		prompt := c.buildPrompt(messages, true)
+		if !strings.Contains(prompt, memorySectionHeader) {
+			t.Error("expected memory section header in prompt")
+		}
+		if !strings.Contains(prompt, "The team chose PostgreSQL last time.") {
+			t.Error("expected memory contents in prompt")
+		}
+		if strings.Index(prompt, "YOUR MEMORY") > strings.Index(prompt, "YOUR TASK") {
+			t.Error("expected memory section before the task")
+		}
+	})
+
+	t.Run("missing memory file", func(t *testing.T) {
+		c := &ClaudeAgent{}
+		c.Name = "Claude"
+		c.Config = agent.AgentConfig{MemoryFile: filepath.Join(dir, "missing.md")}
+
+		prompt := c.buildPrompt(messages, true)
+		if strings.Contains(prompt, memorySectionHeader) {
+			t.Error("expected no memory section when the file is missing")
+		}
+		if !strings.Contains(prompt, "Pick a database") {
+			t.Error("expected prompt to still include the task")
+		}
+	})
+
+	t.Run("api system prompt", func(t *testing.T) {
+		a := &APIAgent{}
+		a.Name = "API"
+		a.Config = agent.AgentConfig{Prompt: "Be concise", MemoryFile: memoryPath}
+
+		history := a.buildConversationHistory(messages)
+		if len(history) == 0 || history[0].Role != "system" {
+			t.Fatal("expected a system message first")
+		}
+		if !strings.Contains(history[0].Content, "Be concise") || !strings.Contains(history[0].Content, "PostgreSQL") {
+			t.Errorf("expected prompt and memory in system message, got %q", history[0].Content)
+		}
+	})
+}
+
+func TestPromptSuffixInOutgoingPrompt(t *testing.T) {
+	const suffix = "Respond in under 100 words."
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Pick a database"},
+		{AgentID: "other", AgentName: "Other", Role: "agent", Content: "PostgreSQL"},
+	}
+
+	t.Run("amp", func(t *testing.T) {
+		a := &AmpAgent{}
+		a.Name = "Amp"
+		a.SetPromptSuffix(suffix)
+
+		for _, initial := range []bool{true, false} {
+			prompt := a.buildPrompt(messages, initial)
+			if !strings.HasSuffix(prompt, "\n\n"+suffix) {
+				t.Errorf("expected prompt (initial=%v) to end with suffix, got %q", initial, prompt)
+			}
+		}
+	})
+
+	t.Run("amp without suffix", func(t *testing.T) {
+		a := &AmpAgent{}
+		a.Name = "Amp"
+		if prompt := a.buildPrompt(messages, true); strings.Contains(prompt, suffix) {
+			t.Error("expected no suffix when none is set")
+		}
+	})
+
+	t.Run("api", func(t *testing.T) {
+		a := &APIAgent{}
+		a.Name = "API"
+		a.SetPromptSuffix(suffix)
+
+		history := a.buildConversationHistory(messages)
+		last := history[len(history)-1]
+		if last.Role != "user" || !strings.HasPrefix(last.Content, "Other: PostgreSQL") || !strings.HasSuffix(last.Content, suffix) {
+			t.Errorf("expected suffix appended to the last user message, got %+v", last)
+		}
+	})
+
+	t.Run("openrouter", func(t *testing.T) {
+		o := &OpenRouterAgent{}
+		o.Name = "Router"
+		o.Config = agent.AgentConfig{Prompt: "Be concise"}
+		o.SetPromptSuffix(suffix)
+
+		// With only the system prompt, the suffix becomes its own user message
+		history := o.buildConversationHistory(nil)
+		if len(history) != 2 || history[1].Role != "user" || history[1].Content != suffix {
+			t.Errorf("expected suffix as a separate user message, got %+v", history)
+		}
+	})
+}
+
+func TestRoleReminderInOutgoingPrompt(t *testing.T) {
+	// The orchestrator sends role reminders as a trailing HOST system message
+	const reminder = "Reminder of your role in this conversation (stay consistent with it): You are a pirate."
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Plan the heist"},
+		{AgentID: "other", AgentName: "Other", Role: "agent", Content: "Let's go at night"},
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: reminder},
+	}
+
+	t.Run("amp", func(t *testing.T) {
+		a := &AmpAgent{}
+		a.Name = "Amp"
+		for _, initial := range []bool{true, false} {
+			if prompt := a.buildPrompt(messages, initial); !strings.Contains(prompt, reminder) {
+				t.Errorf("expected prompt (initial=%v) to include the reminder, got %q", initial, prompt)
+			}
+		}
+	})
+
+	t.Run("api", func(t *testing.T) {
+		a := &APIAgent{}
+		a.Name = "API"
+		history := a.buildConversationHistory(messages)
+		if last := history[len(history)-1]; !strings.Contains(last.Content, reminder) {
+			t.Errorf("expected the last message to carry the reminder, got %+v", last)
+		}
+	})
+}
+
+func TestSanitizeUTF8(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		want         string
+		wantReplaced bool
+	}{
+		{"valid ascii", "hello", "hello", false},
+		{"valid multibyte", "héllo 世界 🦊", "héllo 世界 🦊", false},
+		{"lone continuation byte", "ok\x80ok", "ok�ok", true},
+		{"truncated sequence", "caf\xc3", "caf�", true},
+		{"binary run", "start\xff\xfe\x00\xc0\xafend", "start�\x00�end", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, replaced := sanitizeUTF8(tt.input)
+			if got != tt.want || replaced != tt.wantReplaced {
+				t.Errorf("sanitizeUTF8(%q) = (%q, %v), want (%q, %v)", tt.input, got, replaced, tt.want, tt.wantReplaced)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("sanitizeUTF8(%q) returned invalid UTF-8 %q", tt.input, got)
+			}
+		})
+	}
+}
@@ -111,10 +111,11 @@ func (a *AiderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to aider CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := a.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&a.BaseAgent, a.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := a.buildPrompt(relevantMessages, true)
+	a.RecordPrompt(prompt)
 
 	// Build command args - Aider uses --message for non-interactive mode
 	args := []string{
@@ -137,6 +138,13 @@ func (a *AiderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("aider", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": a.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("aider authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": a.Name,
@@ -172,10 +180,11 @@ func (a *AiderAgent) StreamMessage(ctx context.Context, messages []agent.Message
 	}).Debug("starting aider streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := a.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&a.BaseAgent, a.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := a.buildPrompt(relevantMessages, true)
+	a.RecordPrompt(prompt)
 
 	// Build command args for streaming mode
 	args := []string{
@@ -261,6 +270,7 @@ func (a *AiderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		prompt.WriteString(a.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, a.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -318,6 +328,7 @@ func (a *AiderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		}
 	}
 
+	writePromptSuffix(&prompt, a.PromptSuffix())
 	return prompt.String()
 }
 
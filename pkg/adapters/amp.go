@@ -20,14 +20,21 @@ const (
 	ampStreamTimeout = 60 * time.Second
 	ampReadDeadline  = 55 * time.Second
 	ampHealthTimeout = 5 * time.Second
+
+	// ampStreamSendSetting is the custom_settings key that makes SendMessage use the streaming path
+	ampStreamSendSetting = "stream_send_message"
 )
 
 // AmpAgent represents the Amp coding agent adapter
 type AmpAgent struct {
 	agent.BaseAgent
-	execPath       string
-	threadID       string // Current Amp thread ID for conversation continuity
-	lastMessageIdx int    // Index of last message sent to Amp (for incremental updates)
+	execPath      string
+	threadID      string          // Current Amp thread ID for conversation continuity
+	sent          map[string]bool // Keys of the messages already sent to Amp (for incremental updates)
+	streamSend    bool            // SendMessage collects output through the streaming path
+	runner        CommandRunner   // Executes amp commands (defaults to os/exec)
+	toolSteps     []agent.Message // Tool steps parsed from the last --stream-json response
+	promptUpdated bool            // The system prompt changed after the thread was created
 }
 
 // NewAmpAgent creates a new Amp agent instance
@@ -55,16 +62,67 @@ func (a *AmpAgent) Initialize(config agent.AgentConfig) error {
 	}
 	a.execPath = path
 
+	if enabled, ok := config.CustomSettings[ampStreamSendSetting].(bool); ok {
+		a.streamSend = enabled
+	}
+
 	log.WithFields(map[string]interface{}{
-		"agent_id":   a.ID,
-		"agent_name": a.Name,
-		"exec_path":  path,
-		"model":      a.Config.Model,
+		"agent_id":    a.ID,
+		"agent_name":  a.Name,
+		"exec_path":   path,
+		"model":       a.Config.Model,
+		"stream_send": a.streamSend,
 	}).Info("amp agent initialized successfully")
 
 	return nil
 }
 
+// UpdatePrompt replaces the agent's system prompt. Amp keeps the earlier instructions in
+// its server-side thread, so the next message to an existing thread also says that they
+// have been replaced.
+func (a *AmpAgent) UpdatePrompt(prompt string) {
+	a.BaseAgent.UpdatePrompt(prompt)
+	if a.threadID != "" {
+		a.promptUpdated = true
+	}
+}
+
+// Reset forgets the current thread so the next message starts a new one, letting the
+// agent be reused for another conversation
+func (a *AmpAgent) Reset() {
+	a.threadID = ""
+	a.sent = nil
+	a.toolSteps = nil
+	a.promptUpdated = false
+}
+
+// SetCommandRunner replaces the runner used to execute amp commands.
+// Intended for tests; pass nil to restore the default os/exec runner.
+func (a *AmpAgent) SetCommandRunner(runner CommandRunner) {
+	a.runner = runner
+}
+
+// commandRunner returns the configured runner or the default os/exec runner
+func (a *AmpAgent) commandRunner() CommandRunner {
+	if a.runner == nil {
+		return execRunner{}
+	}
+	return a.runner
+}
+
+// SupportsStreaming reports whether the orchestrator's streaming mode may use StreamMessage
+// in place of SendMessage, which is the case when custom_settings.stream_send_message is set.
+func (a *AmpAgent) SupportsStreaming() bool {
+	return a.streamSend
+}
+
+// TakeToolSteps returns the tool steps parsed from the last streamed response and clears them
+func (a *AmpAgent) TakeToolSteps() []agent.Message {
+	steps := a.toolSteps
+	a.toolSteps = nil
+	return steps
+}
+
 // IsAvailable checks if the Amp CLI is available in the system PATH
 func (a *AmpAgent) IsAvailable() bool {
 	_, err := exec.LookPath("amp")
@@ -90,8 +148,7 @@ func (a *AmpAgent) HealthCheck(ctx context.Context) error {
 	defer cancel()
 
 	// Check if amp CLI responds to --help flag
-	cmd := exec.CommandContext(healthCtx, a.execPath, "--help")
-	output, err := cmd.CombinedOutput()
+	output, err := a.commandRunner().Run(healthCtx, nil, a.execPath, "--help")
 
 	if err != nil {
 		log.WithField("agent_name", a.Name).WithError(err).Error("amp health check failed: CLI not responding to --help")
@@ -128,17 +185,21 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 		"agent_name":    a.Name,
 		"message_count": len(messages),
 		"thread_id":     a.threadID,
-		"last_msg_idx":  a.lastMessageIdx,
+		"sent_messages": len(a.sent),
 	}).Debug("sending message to amp CLI")
 
 	// Get only new messages that haven't been sent to Amp yet
 	// IMPORTANT: Filter out this agent's own messages since Amp maintains them in the thread
-	newMessages := a.filterRelevantMessages(messages[a.lastMessageIdx:])
+	newMessages := a.filterRelevantMessages(a.unsentMessages(messages))
 	if len(newMessages) == 0 {
 		log.WithField("agent_name", a.Name).Debug("no new messages to send (all filtered)")
 		return "", nil
 	}
 
+	if a.streamSend {
+		return a.sendMessageViaStream(ctx, messages)
+	}
+
 	var output string
 	var err error
 	startTime := time.Now()
@@ -146,7 +207,7 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	if a.threadID == "" {
 		// Create a new thread with the initial conversation context
 		// For initial thread, send ALL messages except this agent's own
-		allRelevantMessages := a.filterRelevantMessages(messages)
+		allRelevantMessages := fitFramedContextWindow(&a.BaseAgent, a.filterRelevantMessages(messages))
 		output, err = a.createThread(ctx, allRelevantMessages, newMessages)
 	} else {
 		// Continue existing thread with just the new messages from OTHER agents
@@ -164,8 +225,10 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 		return "", err
 	}
 
-	// Update the index of last sent message
-	a.lastMessageIdx = len(messages)
+	output = sanitizeOutput(a.Name, output)
+
+	a.markSent(messages)
+	a.promptUpdated = false
 
 	log.WithFields(map[string]interface{}{
 		"agent_name":    a.Name,
@@ -177,6 +240,52 @@ func (a *AmpAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	return output, nil
 }
 
+// sendMessageViaStream runs SendMessage over the --stream-json path and returns the
+// collected response. In the orchestrator's streaming mode StreamMessage is called
+// directly instead (see SupportsStreaming), so the chunks reach the stream writer.
+func (a *AmpAgent) sendMessageViaStream(ctx context.Context, messages []agent.Message) (string, error) {
+	var collected strings.Builder
+	if err := a.StreamMessage(ctx, messages, &collected); err != nil {
+		return "", err
+	}
+
+	return collected.String(), nil
+}
+
+// ampMessageKey identifies a message across requests. The orchestrator may trim the
+// history it sends and adds per-turn directives that are never stored, so a message's
+// position in the slice says nothing about whether Amp has already seen it. Identical
+// messages from the same agent within the same second count as one.
+func ampMessageKey(msg agent.Message) string {
+	return fmt.Sprintf("%d|%s|%s", msg.Timestamp, msg.AgentID, msg.Content)
+}
+
+// unsentMessages returns the messages that haven't been sent to Amp yet, in order
+func (a *AmpAgent) unsentMessages(messages []agent.Message) []agent.Message {
+	unsent := make([]agent.Message, 0, len(messages))
+	for _, msg := range messages {
+		if !a.sent[ampMessageKey(msg)] {
+			unsent = append(unsent, msg)
+		}
+	}
+	return unsent
+}
+
+// markSent records messages as sent to Amp's thread. The orchestrator's private
+// directives are left out: they are added afresh whenever they are due, so a repeated
+// reminder must reach the thread again.
+func (a *AmpAgent) markSent(messages []agent.Message) {
+	if a.sent == nil {
+		a.sent = make(map[string]bool, len(messages))
+	}
+	for _, msg := range messages {
+		if msg.Metadata["directive"] == true {
+			continue
+		}
+		a.sent[ampMessageKey(msg)] = true
+	}
+}
+
 // filterRelevantMessages filters out this agent's own messages
 // Since Amp maintains thread context server-side, we should NOT send:
 // 1. This agent's own responses (Amp already knows what it said)
@@ -216,19 +325,21 @@ func (a *AmpAgent) createThread(ctx context.Context, allMessages, newMessages []
 
 	// Create an empty thread first, then send the initial request as thread continue
 	// This avoids the issue of amp thread new not returning a response
-	cmd := exec.CommandContext(ctx, a.execPath, "thread", "new")
-	cmd.Stdin = strings.NewReader("") // Empty thread creation
-
-	output, err := cmd.CombinedOutput()
+	// Empty stdin for thread creation
+	output, err := a.commandRunner().Run(ctx, strings.NewReader(""), a.execPath, "thread", "new")
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if authErr := authError("amp", output); authErr != nil {
+			log.WithField("agent_name", a.Name).WithError(err).Error("amp authentication failed")
+			return "", authErr
+		}
+		if code, ok := exitCode(err); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": a.Name,
-				"exit_code":  exitErr.ExitCode(),
+				"exit_code":  code,
 			}).WithError(err).Error("amp thread new failed")
-			return "", fmt.Errorf("amp thread new failed (exit code %d): %s", exitErr.ExitCode(), string(output))
+			return "", fmt.Errorf("amp thread new failed (exit code %d): %s", code, sanitizeOutput(a.Name, string(output)))
 		}
-		return "", fmt.Errorf("amp thread new failed: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("amp thread new failed: %w\nOutput: %s", err, sanitizeOutput(a.Name, string(output)))
 	}
 
 	// Parse output to extract thread ID
@@ -245,6 +356,7 @@ func (a *AmpAgent) createThread(ctx context.Context, allMessages, newMessages []
 	// 3. Messages from OTHER agents (excluding this agent's own responses)
 	// NOTE: allMessages is already filtered by caller to exclude this agent's messages
 	prompt := a.buildPrompt(allMessages, true) // isInitialThread = true
+	a.RecordPrompt(prompt)
 
 	log.WithFields(map[string]interface{}{
 		"agent_name":      a.Name,
@@ -252,33 +364,22 @@ func (a *AmpAgent) createThread(ctx context.Context, allMessages, newMessages []
 		"full_prompt_len": len(prompt),
 	}).Debug("amp thread context prepared")
 
-	// Log a preview of what we're sending for debugging
-	if len(prompt) > 0 {
-		previewLen := 500
-		if len(prompt) < previewLen {
-			previewLen = len(prompt)
-		}
-		log.WithFields(map[string]interface{}{
-			"agent_name":   a.Name,
-			"prompt_start": prompt[:previewLen],
-		}).Debug("amp initial prompt preview")
-	}
-
 	// Now send the initial request as thread continue
-	continueCmd := exec.CommandContext(ctx, a.execPath, "thread", "continue", a.threadID)
-	continueCmd.Stdin = strings.NewReader(prompt)
-
-	continueOutput, err := continueCmd.CombinedOutput()
+	continueOutput, err := a.commandRunner().Run(ctx, strings.NewReader(prompt), a.execPath, "thread", "continue", a.threadID)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if authErr := authError("amp", continueOutput); authErr != nil {
+			log.WithField("agent_name", a.Name).WithError(err).Error("amp authentication failed")
+			return "", authErr
+		}
+		if code, ok := exitCode(err); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": a.Name,
 				"thread_id":  a.threadID,
-				"exit_code":  exitErr.ExitCode(),
+				"exit_code":  code,
 			}).WithError(err).Error("amp thread continue failed with initial request")
-			return "", fmt.Errorf("amp thread continue failed (exit code %d): %s", exitErr.ExitCode(), string(continueOutput))
+			return "", fmt.Errorf("amp thread continue failed (exit code %d): %s", code, sanitizeOutput(a.Name, string(continueOutput)))
 		}
-		return "", fmt.Errorf("amp thread continue failed: %w\nOutput: %s", err, string(continueOutput))
+		return "", fmt.Errorf("amp thread continue failed: %w\nOutput: %s", err, sanitizeOutput(a.Name, string(continueOutput)))
 	}
 
 	return string(continueOutput), nil
@@ -296,22 +397,24 @@ func (a *AmpAgent) continueThread(ctx context.Context, newMessages []agent.Messa
 	// Amp maintains the full conversation context server-side in the thread
 	// We only need to send the delta (new messages since last interaction)
 	prompt := a.buildPrompt(newMessages, false) // isInitialThread = false
+	a.RecordPrompt(prompt)
 
 	// Continue thread: amp thread continue {thread_id}
-	cmd := exec.CommandContext(ctx, a.execPath, "thread", "continue", a.threadID)
-	cmd.Stdin = strings.NewReader(prompt)
-
-	output, err := cmd.CombinedOutput()
+	output, err := a.commandRunner().Run(ctx, strings.NewReader(prompt), a.execPath, "thread", "continue", a.threadID)
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if authErr := authError("amp", output); authErr != nil {
+			log.WithField("agent_name", a.Name).WithError(err).Error("amp authentication failed")
+			return "", authErr
+		}
+		if code, ok := exitCode(err); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": a.Name,
 				"thread_id":  a.threadID,
-				"exit_code":  exitErr.ExitCode(),
+				"exit_code":  code,
 			}).WithError(err).Error("amp thread continue failed")
-			return "", fmt.Errorf("amp thread continue failed (exit code %d): %s", exitErr.ExitCode(), string(output))
+			return "", fmt.Errorf("amp thread continue failed (exit code %d): %s", code, sanitizeOutput(a.Name, string(output)))
 		}
-		return "", fmt.Errorf("amp thread continue failed: %w\nOutput: %s", err, string(output))
+		return "", fmt.Errorf("amp thread continue failed: %w\nOutput: %s", err, sanitizeOutput(a.Name, string(output)))
 	}
 
 	return string(output), nil
@@ -327,28 +430,30 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		"agent_name":    a.Name,
 		"message_count": len(messages),
 		"thread_id":     a.threadID,
-		"last_msg_idx":  a.lastMessageIdx,
+		"sent_messages": len(a.sent),
 		"timeout":       ampStreamTimeout.String(),
 	}).Debug("starting amp streaming message")
 
 	// Get only new messages that haven't been sent to Amp yet
 	// IMPORTANT: Filter out this agent's own messages since Amp maintains them in the thread
-	newMessages := a.filterRelevantMessages(messages[a.lastMessageIdx:])
+	newMessages := a.filterRelevantMessages(a.unsentMessages(messages))
 	if len(newMessages) == 0 {
 		log.WithField("agent_name", a.Name).Debug("no new messages to stream (all filtered)")
 		return nil
 	}
 
+	a.toolSteps = nil
+
 	// Create a context with timeout for streaming
 	streamCtx, cancel := context.WithTimeout(ctx, ampStreamTimeout)
 	defer cancel()
 
-	var cmd *exec.Cmd
+	var args []string
 	var prompt string
 
 	if a.threadID == "" {
 		// For initial thread, send ALL messages except this agent's own
-		allRelevantMessages := a.filterRelevantMessages(messages)
+		allRelevantMessages := fitFramedContextWindow(&a.BaseAgent, a.filterRelevantMessages(messages))
 
 		// Count system messages to verify initial prompt is included
 		systemMsgCount := 0
@@ -370,6 +475,7 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		// IMPORTANT: Build prompt with proper structure
 		// Agent setup and role come FIRST, then conversation context
 		prompt = a.buildPrompt(allRelevantMessages, true) // isInitialThread = true
+		a.RecordPrompt(prompt)
 
 		log.WithFields(map[string]interface{}{
 			"agent_name":      a.Name,
@@ -377,20 +483,8 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 			"full_prompt_len": len(prompt),
 		}).Debug("amp streaming thread context prepared")
 
-		// Log a preview of what we're sending for debugging
-		if len(prompt) > 0 {
-			previewLen := 300
-			if len(prompt) < previewLen {
-				previewLen = len(prompt)
-			}
-			log.WithFields(map[string]interface{}{
-				"agent_name":   a.Name,
-				"prompt_start": prompt[:previewLen],
-			}).Debug("amp initial streaming prompt preview")
-		}
-
 		// Use --stream-json with thread new
-		cmd = exec.CommandContext(streamCtx, a.execPath, "thread", "new", "--stream-json")
+		args = []string{"thread", "new", "--stream-json"}
 	} else {
 		// Continue existing thread with just new messages
 		log.WithFields(map[string]interface{}{
@@ -401,28 +495,18 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 		}).Debug("continuing amp thread with new messages only")
 
 		prompt = a.buildPrompt(newMessages, false) // isInitialThread = false
+		a.RecordPrompt(prompt)
 		// Use --stream-json with thread continue
-		cmd = exec.CommandContext(streamCtx, a.execPath, "thread", "continue", a.threadID, "--stream-json")
+		args = []string{"thread", "continue", a.threadID, "--stream-json"}
 	}
 
-	cmd.Stdin = strings.NewReader(prompt)
-
-	stdout, err := cmd.StdoutPipe()
+	proc, err := a.commandRunner().Stream(streamCtx, strings.NewReader(prompt), a.execPath, args...)
 	if err != nil {
-		log.WithField("agent_name", a.Name).WithError(err).Error("failed to create stdout pipe")
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
-	}
-
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		log.WithField("agent_name", a.Name).WithError(err).Error("failed to create stderr pipe")
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
-	}
-
-	if err := cmd.Start(); err != nil {
 		log.WithField("agent_name", a.Name).WithError(err).Error("failed to start amp process")
 		return fmt.Errorf("failed to start amp: %w", err)
 	}
+	stdout := proc.Stdout()
+	stderr := proc.Stderr()
 
 	// Read stderr in background to capture any errors
 	var stderrBuf strings.Builder
@@ -445,6 +529,7 @@ func (a *AmpAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 	hasOutput := false
 	scanner := bufio.NewScanner(stdout)
 	var streamedContent strings.Builder
+	invalidUTF8 := false
 	isFirstLine := a.threadID == "" // Track if we need to extract thread ID from first line
 
 	// Set a deadline for reading
@@ -465,33 +550,32 @@ scanLoop:
 
 			// If this is a new thread, first line should be the thread ID
 			if isFirstLine {
-				// Try to extract thread ID from first line
-				var threadInfo struct {
-					ThreadID string `json:"thread_id"`
-					ID       string `json:"id"`
-				}
-				if err := json.Unmarshal([]byte(line), &threadInfo); err == nil {
-					if threadInfo.ThreadID != "" {
-						a.threadID = threadInfo.ThreadID
-						log.WithFields(map[string]interface{}{
-							"agent_name": a.Name,
-							"thread_id":  a.threadID,
-						}).Info("amp thread created from streaming")
-					} else if threadInfo.ID != "" {
-						a.threadID = threadInfo.ID
-						log.WithFields(map[string]interface{}{
-							"agent_name": a.Name,
-							"thread_id":  a.threadID,
-						}).Info("amp thread created from streaming")
-					}
-				}
 				isFirstLine = false
-				// Don't write thread ID line to output, continue to next line
-				continue
+				if threadID, ok := extractAmpThreadID(line); ok {
+					a.threadID = threadID
+					log.WithFields(map[string]interface{}{
+						"agent_name": a.Name,
+						"thread_id":  a.threadID,
+					}).Info("amp thread created from streaming")
+					// Don't write thread ID line to output, continue to next line
+					continue
+				}
 			}
 
-			// Parse the JSON line and extract text content
-			if text := a.parseJSONLine(line); text != "" {
+			// Structured events carry tool steps alongside any text blocks
+			text, steps, structured := parseAmpStreamEvent(line)
+			for _, step := range steps {
+				a.toolSteps = append(a.toolSteps, a.toolStepMessage(step))
+			}
+			if !structured {
+				// Parse the JSON line and extract text content
+				text = a.parseJSONLine(line)
+			}
+			if clean, replaced := sanitizeUTF8(text); replaced {
+				text = clean
+				invalidUTF8 = true
+			}
+			if text != "" {
 				_, _ = fmt.Fprint(writer, text)
 				streamedContent.WriteString(text)
 				hasOutput = true
@@ -499,12 +583,16 @@ scanLoop:
 		}
 	}
 
+	if invalidUTF8 {
+		warnInvalidUTF8(a.Name, streamedContent.Len())
+	}
+
 	if err := scanner.Err(); err != nil {
 		log.WithField("agent_name", a.Name).WithError(err).Error("error reading amp streaming output")
 		return fmt.Errorf("error reading output: %w", err)
 	}
 
-	if err := cmd.Wait(); err != nil {
+	if err := proc.Wait(); err != nil {
 		// Only log as error if we didn't get any output
 		if !hasOutput {
 			log.WithField("agent_name", a.Name).WithError(err).Error("amp streaming execution failed")
@@ -522,13 +610,13 @@ scanLoop:
 			"stderr":     stderrOutput,
 		}).Error("amp produced no output")
 		if stderrOutput != "" {
-			return fmt.Errorf("amp produced no output. Stderr: %s", stderrOutput)
+			return fmt.Errorf("amp produced no output. Stderr: %s", sanitizeOutput(a.Name, stderrOutput))
 		}
 		return fmt.Errorf("amp produced no output")
 	}
 
-	// Update the index of last sent message
-	a.lastMessageIdx = len(messages)
+	a.markSent(messages)
+	a.promptUpdated = false
 
 	duration := time.Since(startTime)
 	log.WithFields(map[string]interface{}{
@@ -558,9 +646,14 @@ func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) s
 		prompt.WriteString(a.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, a.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
+	if a.promptUpdated && !isInitialThread {
+		prompt.WriteString("IMPORTANT: Your role and instructions have been updated. Follow the setup above from now on; it replaces any earlier instructions in this thread.\n\n")
+	}
+
 	// PART 2: CONVERSATION CONTEXT (after role is established)
 	if isInitialThread && len(messages) > 0 {
 		// When agent comes online for the first time, deliver ALL existing messages
@@ -634,9 +727,41 @@ func (a *AmpAgent) buildPrompt(messages []agent.Message, isInitialThread bool) s
 		prompt.WriteString(fmt.Sprintf("Start the conversation as %s.", a.Name))
 	}
 
+	writePromptSuffix(&prompt, a.PromptSuffix())
 	return prompt.String()
 }
 
+// extractAmpThreadID extracts a thread ID from the first line of streaming output.
+// Amp may print either a JSON object ({"thread_id": ...} or {"id": ...}) or a bare ID
+// such as "T-1234", matching the plain output of "amp thread new".
+func extractAmpThreadID(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", false
+	}
+
+	var threadInfo struct {
+		ThreadID string `json:"thread_id"`
+		ID       string `json:"id"`
+		Type     string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(line), &threadInfo); err == nil {
+		if threadInfo.ThreadID != "" {
+			return threadInfo.ThreadID, true
+		}
+		// Typed events (e.g. tool_use) carry their own IDs, which aren't thread IDs
+		if threadInfo.ID != "" && threadInfo.Type == "" {
+			return threadInfo.ID, true
+		}
+		return "", false
+	}
+
+	if strings.HasPrefix(line, "T-") && !strings.ContainsAny(line, " \t") {
+		return line, true
+	}
+	return "", false
+}
+
 // parseJSONLine parses a single JSON line from amp --stream-json output
 func (a *AmpAgent) parseJSONLine(line string) string {
 	if line == "" {
@@ -681,6 +806,137 @@ func (a *AmpAgent) parseJSONLine(line string) string {
 	return ""
 }
 
+// ampToolStep is a tool call or tool result parsed from amp --stream-json output
+type ampToolStep struct {
+	Kind    string // "tool_use" or "tool_result"
+	Name    string
+	ID      string
+	Content string
+	IsError bool
+}
+
+// ampContentBlock is one entry of a stream-json message's content array
+type ampContentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// parseAmpStreamEvent parses tool steps from an amp --stream-json line.
+// It understands both flat events ({"type":"tool_use",...}) and message envelopes
+// ({"type":"assistant","message":{"content":[...]}}), returning any text blocks found
+// in an envelope. structured is false when the line is neither, in which case the
+// caller should fall back to parseJSONLine.
+func parseAmpStreamEvent(line string) (text string, steps []ampToolStep, structured bool) {
+	var event struct {
+		ampContentBlock
+		Message *struct {
+			Content []ampContentBlock `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		return "", nil, false
+	}
+
+	if step, ok := ampToolStepFromBlock(event.ampContentBlock); ok {
+		return "", []ampToolStep{step}, true
+	}
+
+	if event.Message == nil || len(event.Message.Content) == 0 {
+		return "", nil, false
+	}
+
+	var textBuilder strings.Builder
+	for _, block := range event.Message.Content {
+		if block.Type == "text" {
+			textBuilder.WriteString(block.Text)
+			continue
+		}
+		if step, ok := ampToolStepFromBlock(block); ok {
+			steps = append(steps, step)
+		}
+	}
+	return textBuilder.String(), steps, true
+}
+
+// ampToolStepFromBlock converts a tool_use or tool_result block to a tool step
+func ampToolStepFromBlock(block ampContentBlock) (ampToolStep, bool) {
+	switch block.Type {
+	case "tool_use":
+		content := block.Name
+		if input := strings.TrimSpace(string(block.Input)); input != "" && input != "null" {
+			content += " " + input
+		}
+		return ampToolStep{Kind: block.Type, Name: block.Name, ID: block.ID, Content: content}, true
+	case "tool_result":
+		return ampToolStep{
+			Kind:    block.Type,
+			Name:    block.Name,
+			ID:      block.ToolUseID,
+			Content: ampToolResultText(block.Content),
+			IsError: block.IsError,
+		}, true
+	default:
+		return ampToolStep{}, false
+	}
+}
+
+// ampToolResultText flattens tool_result content, which is either a string or a list of text blocks
+func ampToolResultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+
+	var blocks []ampContentBlock
+	if err := json.Unmarshal(raw, &blocks); err == nil {
+		parts := make([]string, 0, len(blocks))
+		for _, block := range blocks {
+			if block.Text != "" {
+				parts = append(parts, block.Text)
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	return string(raw)
+}
+
+// toolStepMessage converts a parsed tool step into a "tool" role message from this agent
+func (a *AmpAgent) toolStepMessage(step ampToolStep) agent.Message {
+	metadata := map[string]interface{}{
+		"tool_step": step.Kind,
+	}
+	if step.Name != "" {
+		metadata["tool_name"] = step.Name
+	}
+	if step.ID != "" {
+		metadata["tool_id"] = step.ID
+	}
+	if step.IsError {
+		metadata["tool_error"] = true
+	}
+
+	return agent.Message{
+		AgentID:   a.ID,
+		AgentName: a.Name,
+		AgentType: a.Type,
+		Content:   step.Content,
+		Timestamp: time.Now().Unix(),
+		Role:      "tool",
+		Metadata:  metadata,
+	}
+}
+
 func init() {
 	agent.RegisterFactory("amp", NewAmpAgent)
 }
@@ -0,0 +1,759 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+// fakeAmpScript emulates the amp CLI thread commands used by AmpAgent
+const fakeAmpScript = `#!/bin/sh
+cat > /dev/null
+case "$*" in
+  "thread new --stream-json")
+    echo '{"thread_id":"T-stream"}'
+    echo '{"type":"text","content":"Hello "}'
+    echo '{"type":"text","content":"from "}'
+    echo '{"type":"text","content":"stream"}'
+    ;;
+  "thread continue "*" --stream-json")
+    echo '{"type":"text","content":"continued "}'
+    echo '{"type":"text","content":"stream"}'
+    ;;
+  "thread new")
+    echo "T-plain"
+    ;;
+  "thread continue "*)
+    echo "plain reply"
+    ;;
+esac
+`
+
+func newFakeAmpAgent(t *testing.T, streamSend bool) *AmpAgent {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake amp script requires a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "amp")
+	if err := os.WriteFile(path, []byte(fakeAmpScript), 0755); err != nil {
+		t.Fatalf("failed to write fake amp script: %v", err)
+	}
+
+	a := &AmpAgent{execPath: path, streamSend: streamSend}
+	a.ID = "amp-1"
+	a.Name = "Amp"
+	return a
+}
+
+func ampTestMessages() []agent.Message {
+	return []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss testing"},
+		{AgentID: "claude-1", AgentName: "Claude", Role: "agent", Content: "Tests are good"},
+	}
+}
+
+// chunkRecorder records each write separately to verify incremental delivery
+type chunkRecorder struct {
+	chunks []string
+}
+
+func (c *chunkRecorder) Write(p []byte) (int, error) {
+	c.chunks = append(c.chunks, string(p))
+	return len(p), nil
+}
+
+func TestAmpSendMessageViaStream(t *testing.T) {
+	a := newFakeAmpAgent(t, true)
+
+	messages := ampTestMessages()
+	output, err := a.SendMessage(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output != "Hello from stream" {
+		t.Errorf("expected collected output %q, got %q", "Hello from stream", output)
+	}
+	if a.threadID != "T-stream" {
+		t.Errorf("expected thread ID T-stream, got %q", a.threadID)
+	}
+
+	// Second call continues the thread
+	messages = append(messages, agent.Message{AgentID: "amp-1", AgentName: "Amp", Role: "agent", Content: output},
+		agent.Message{AgentID: "claude-1", AgentName: "Claude", Role: "agent", Content: "Agreed"})
+	output, err = a.SendMessage(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("unexpected error on continue: %v", err)
+	}
+	if output != "continued stream" {
+		t.Errorf("expected %q, got %q", "continued stream", output)
+	}
+}
+
+func TestAmpStreamsThroughOrchestrator(t *testing.T) {
+	runner := &fakeRunner{results: map[string]fakeResult{
+		"thread new --stream-json": {output: `{"thread_id":"T-orch"}` + "\n" +
+			`{"type":"text","content":"Hello "}` + "\n" +
+			`{"type":"text","content":"from "}` + "\n" +
+			`{"type":"text","content":"stream"}` + "\n"},
+	}}
+	a := newRunnerAmpAgent(runner)
+	a.streamSend = true
+
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:            orchestrator.ModeRoundRobin,
+		MaxTurns:        1,
+		TurnTimeout:     time.Second,
+		ResponseDelay:   time.Millisecond,
+		InitialPrompt:   "Discuss testing",
+		StreamResponses: true,
+	}, nil)
+	progress := &chunkRecorder{}
+	orch.SetStreamWriter(progress)
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(progress.chunks) != 3 {
+		t.Errorf("expected 3 incremental chunks on the stream writer, got %d: %q", len(progress.chunks), progress.chunks)
+	}
+	var response string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			response = msg.Content
+		}
+	}
+	if response != "Hello from stream" {
+		t.Errorf("expected the streamed text to become the response, got %q", response)
+	}
+
+	a.streamSend = false
+	if a.SupportsStreaming() {
+		t.Error("expected streaming to require stream_send_message")
+	}
+}
+
+// countingClaude answers every turn with a new numbered point instead of running the CLI
+type countingClaude struct {
+	*ClaudeAgent
+	turns int
+}
+
+func (c *countingClaude) IsAvailable() bool { return true }
+
+func (c *countingClaude) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	c.turns++
+	return fmt.Sprintf("Point %d.", c.turns), nil
+}
+
+func newCountingClaude() *countingClaude {
+	c := NewClaudeAgent().(*ClaudeAgent)
+	c.ID = "claude-1"
+	c.Name = "Claude"
+	c.Type = "claude"
+	return &countingClaude{ClaudeAgent: c}
+}
+
+// ampReplies returns the content of every response an agent gave in the conversation
+func ampReplies(orch *orchestrator.Orchestrator, agentID string) []string {
+	var replies []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" && msg.AgentID == agentID {
+			replies = append(replies, msg.Content)
+		}
+	}
+	return replies
+}
+
+func TestAmpWithTrimmedHistory(t *testing.T) {
+	runner := &fakeRunner{results: map[string]fakeResult{
+		"thread new":             {output: "T-trim\n"},
+		"thread continue T-trim": {output: "Amp reply"},
+	}}
+	a := newRunnerAmpAgent(runner)
+
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:               orchestrator.ModeRoundRobin,
+		MaxTurns:           4,
+		TurnTimeout:        time.Second,
+		ResponseDelay:      time.Millisecond,
+		InitialPrompt:      "Discuss testing",
+		MaxHistoryMessages: 3,
+	}, nil)
+	orch.AddAgent(newCountingClaude())
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replies := ampReplies(orch, a.ID)
+	if len(replies) != 4 {
+		t.Fatalf("expected 4 Amp replies, got %q", replies)
+	}
+	for i, reply := range replies {
+		if reply != "Amp reply" {
+			t.Errorf("expected Amp to get new messages on turn %d, got reply %q", i+1, reply)
+		}
+	}
+	// Each later turn sends only what Amp hasn't seen: Claude's latest point
+	last := runner.stdins[len(runner.stdins)-1]
+	if !strings.Contains(last, "Point 4.") || strings.Contains(last, "Point 3.") {
+		t.Errorf("expected only the newest message in the last prompt, got:\n%s", last)
+	}
+}
+
+func TestAmpGetsRoleRemindersWithTrimmedHistory(t *testing.T) {
+	var runners []*fakeRunner
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:                 orchestrator.ModeRoundRobin,
+		MaxTurns:             6,
+		TurnTimeout:          time.Second,
+		ResponseDelay:        time.Millisecond,
+		InitialPrompt:        "Discuss testing",
+		MaxHistoryMessages:   3,
+		RoleReminderInterval: 3,
+	}, nil)
+	for i, name := range []string{"Amp", "Ampere"} {
+		runner := &fakeRunner{numbered: true, results: map[string]fakeResult{
+			"thread new":                {output: "T-" + name + "\n"},
+			"thread continue T-" + name: {output: name + " reply"},
+		}}
+		a := newRunnerAmpAgent(runner)
+		a.ID = fmt.Sprintf("amp-%d", i+1)
+		a.Name = name
+		a.Config.Prompt = "You are " + name + ", a skeptical reviewer."
+		runners = append(runners, runner)
+		orch.AddAgent(a)
+	}
+
+	// The per-turn reminders are never stored, so the requests change length between
+	// turns; this used to panic with an out-of-range slice in Amp
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, reply := range ampReplies(orch, "amp-1") {
+		if !strings.HasPrefix(reply, "Amp reply ") {
+			t.Errorf("expected every Amp turn to send something new, got reply %q", reply)
+		}
+	}
+	reminders := 0
+	for _, prompt := range runners[0].stdins {
+		if strings.Contains(prompt, "a skeptical reviewer") && strings.Contains(prompt, "Reminder of your role") {
+			reminders++
+		}
+	}
+	if reminders < 2 {
+		t.Errorf("expected each due role reminder to reach the Amp thread, got %d", reminders)
+	}
+}
+
+func TestAmpSendMessagePlain(t *testing.T) {
+	a := newFakeAmpAgent(t, false)
+
+	output, err := a.SendMessage(context.Background(), ampTestMessages())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.TrimSpace(output) != "plain reply" {
+		t.Errorf("expected plain reply, got %q", output)
+	}
+	if a.threadID != "T-plain" {
+		t.Errorf("expected thread ID T-plain, got %q", a.threadID)
+	}
+}
+
+func TestExtractAmpThreadID(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOK bool
+	}{
+		{`{"thread_id":"T-abc"}`, "T-abc", true},
+		{`{"id":"T-def"}`, "T-def", true},
+		{"T-1234-5678", "T-1234-5678", true},
+		{`{"type":"text","content":"hi"}`, "", false},
+		{`{"type":"tool_use","id":"toolu_1","name":"Bash"}`, "", false},
+		{"Hello there", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := extractAmpThreadID(tt.line)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("extractAmpThreadID(%q) = (%q, %v), want (%q, %v)", tt.line, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+// fakeExitError mimics *exec.ExitError for a non-zero exit
+type fakeExitError struct {
+	code int
+}
+
+func (e *fakeExitError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+func (e *fakeExitError) ExitCode() int { return e.code }
+
+// fakeResult is a canned command result
+type fakeResult struct {
+	output string
+	err    error
+}
+
+// fakeRunner returns canned results keyed by the space-joined arguments
+type fakeRunner struct {
+	results  map[string]fakeResult
+	calls    []string
+	stdins   []string
+	numbered bool // append the call number to each output, so repeated replies differ
+}
+
+func (f *fakeRunner) record(stdin io.Reader, args []string) fakeResult {
+	key := strings.Join(args, " ")
+	f.calls = append(f.calls, key)
+	if stdin != nil {
+		data, _ := io.ReadAll(stdin)
+		f.stdins = append(f.stdins, string(data))
+	}
+	res := f.results[key]
+	if f.numbered && args[0] == "thread" && args[1] == "continue" {
+		res.output = fmt.Sprintf("%s %d", res.output, len(f.calls))
+	}
+	return res
+}
+
+func (f *fakeRunner) Run(ctx context.Context, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	res := f.record(stdin, args)
+	return []byte(res.output), res.err
+}
+
+func (f *fakeRunner) Stream(ctx context.Context, stdin io.Reader, name string, args ...string) (StreamProcess, error) {
+	res := f.record(stdin, args)
+	return &fakeProcess{stdout: strings.NewReader(res.output), err: res.err}, nil
+}
+
+type fakeProcess struct {
+	stdout io.Reader
+	err    error
+}
+
+func (p *fakeProcess) Stdout() io.Reader { return p.stdout }
+func (p *fakeProcess) Stderr() io.Reader { return strings.NewReader("") }
+func (p *fakeProcess) Wait() error       { return p.err }
+
+func newRunnerAmpAgent(runner CommandRunner) *AmpAgent {
+	a := &AmpAgent{execPath: "amp"}
+	a.ID = "amp-1"
+	a.Name = "Amp"
+	a.SetCommandRunner(runner)
+	return a
+}
+
+func TestAmpCommandRunner(t *testing.T) {
+	t.Run("success extracts thread ID", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new":             {output: "T-fake\n"},
+			"thread continue T-fake": {output: "first reply"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		output, err := a.SendMessage(context.Background(), ampTestMessages())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if output != "first reply" {
+			t.Errorf("expected %q, got %q", "first reply", output)
+		}
+		if a.threadID != "T-fake" {
+			t.Errorf("expected thread ID T-fake, got %q", a.threadID)
+		}
+		if len(runner.calls) != 2 || runner.calls[1] != "thread continue T-fake" {
+			t.Errorf("unexpected command sequence: %v", runner.calls)
+		}
+		if !strings.Contains(runner.stdins[1], "Discuss testing") {
+			t.Error("expected initial prompt to be sent on stdin")
+		}
+	})
+
+	t.Run("non-zero exit", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new": {output: "internal error", err: &fakeExitError{code: 2}},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		_, err := a.SendMessage(context.Background(), ampTestMessages())
+		if err == nil {
+			t.Fatal("expected error for non-zero exit")
+		}
+		if agent.IsAuthError(err) {
+			t.Errorf("expected a generic failure, got auth error %v", err)
+		}
+		if !strings.Contains(err.Error(), "exit code 2") || !strings.Contains(err.Error(), "internal error") {
+			t.Errorf("expected exit code and output in error, got %v", err)
+		}
+		if a.threadID != "" {
+			t.Errorf("expected no thread ID after failure, got %q", a.threadID)
+		}
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new": {output: "Error: Not logged in. Run 'amp login' first.", err: &fakeExitError{code: 1}},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		_, err := a.SendMessage(context.Background(), ampTestMessages())
+		var authErr *agent.AuthError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("expected *agent.AuthError, got %T: %v", err, err)
+		}
+		if authErr.AgentType != "amp" {
+			t.Errorf("expected agent type amp, got %q", authErr.AgentType)
+		}
+		if !strings.Contains(err.Error(), "please authenticate the amp CLI") {
+			t.Errorf("expected authentication hint in error, got %v", err)
+		}
+	})
+
+	t.Run("auth failure on continue", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new":             {output: "T-fake\n"},
+			"thread continue T-fake": {output: "401 Unauthorized: invalid API key", err: &fakeExitError{code: 1}},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		if _, err := a.SendMessage(context.Background(), ampTestMessages()); !agent.IsAuthError(err) {
+			t.Errorf("expected auth error, got %v", err)
+		}
+	})
+
+	t.Run("streaming extracts thread ID", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new --stream-json": {output: "{\"id\":\"T-json\"}\n{\"content\":\"streamed\"}\n"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		var buf strings.Builder
+		if err := a.StreamMessage(context.Background(), ampTestMessages(), &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != "streamed" {
+			t.Errorf("expected %q, got %q", "streamed", buf.String())
+		}
+		if a.threadID != "T-json" {
+			t.Errorf("expected thread ID T-json, got %q", a.threadID)
+		}
+	})
+
+	t.Run("streaming non-zero exit without output", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new --stream-json": {output: "", err: &fakeExitError{code: 1}},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		if err := a.StreamMessage(context.Background(), ampTestMessages(), io.Discard); err == nil {
+			t.Fatal("expected error when amp exits non-zero without output")
+		}
+	})
+}
+
+func TestParseAmpStreamEvent(t *testing.T) {
+	tests := []struct {
+		name           string
+		line           string
+		wantText       string
+		wantSteps      []ampToolStep
+		wantStructured bool
+	}{
+		{
+			name:           "flat tool_use",
+			line:           `{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"cmd":"ls"}}`,
+			wantSteps:      []ampToolStep{{Kind: "tool_use", Name: "Bash", ID: "toolu_1", Content: `Bash {"cmd":"ls"}`}},
+			wantStructured: true,
+		},
+		{
+			name:           "flat tool_result",
+			line:           `{"type":"tool_result","tool_use_id":"toolu_1","content":"main.go"}`,
+			wantSteps:      []ampToolStep{{Kind: "tool_result", ID: "toolu_1", Content: "main.go"}},
+			wantStructured: true,
+		},
+		{
+			name: "assistant envelope with text and tool call",
+			line: `{"type":"assistant","message":{"content":[{"type":"text","text":"Let me check. "},` +
+				`{"type":"tool_use","id":"toolu_2","name":"Read","input":{"path":"go.mod"}}]}}`,
+			wantText:       "Let me check. ",
+			wantSteps:      []ampToolStep{{Kind: "tool_use", Name: "Read", ID: "toolu_2", Content: `Read {"path":"go.mod"}`}},
+			wantStructured: true,
+		},
+		{
+			name: "user envelope with block tool result",
+			line: `{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"toolu_2",` +
+				`"content":[{"type":"text","text":"module example"}],"is_error":true}]}}`,
+			wantSteps:      []ampToolStep{{Kind: "tool_result", ID: "toolu_2", Content: "module example", IsError: true}},
+			wantStructured: true,
+		},
+		{
+			name:           "plain text event falls back",
+			line:           `{"type":"text","content":"hello"}`,
+			wantStructured: false,
+		},
+		{
+			name:           "non-JSON falls back",
+			line:           "plain output",
+			wantStructured: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, steps, structured := parseAmpStreamEvent(tt.line)
+			if structured != tt.wantStructured {
+				t.Fatalf("structured = %v, want %v", structured, tt.wantStructured)
+			}
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+			if len(steps) != len(tt.wantSteps) {
+				t.Fatalf("got %d steps, want %d: %+v", len(steps), len(tt.wantSteps), steps)
+			}
+			for i := range steps {
+				if steps[i] != tt.wantSteps[i] {
+					t.Errorf("step %d = %+v, want %+v", i, steps[i], tt.wantSteps[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAmpStreamRecordsToolSteps(t *testing.T) {
+	output := `{"thread_id":"T-tools"}` + "\n" +
+		`{"type":"assistant","message":{"content":[{"type":"tool_use","id":"toolu_1","name":"Bash","input":{"cmd":"ls"}}]}}` + "\n" +
+		`{"type":"user","message":{"content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"main.go"}]}}` + "\n" +
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"There is one file."}]}}` + "\n"
+	runner := &fakeRunner{results: map[string]fakeResult{
+		"thread new --stream-json": {output: output},
+	}}
+	a := newRunnerAmpAgent(runner)
+
+	var buf strings.Builder
+	if err := a.StreamMessage(context.Background(), ampTestMessages(), &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "There is one file." {
+		t.Errorf("expected only text in the response, got %q", buf.String())
+	}
+
+	steps := a.TakeToolSteps()
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 tool steps, got %d", len(steps))
+	}
+	for _, step := range steps {
+		if step.Role != "tool" || step.AgentID != "amp-1" || step.AgentName != "Amp" {
+			t.Errorf("unexpected tool step message: %+v", step)
+		}
+	}
+	if steps[0].Metadata["tool_name"] != "Bash" || steps[0].Metadata["tool_step"] != "tool_use" {
+		t.Errorf("unexpected tool_use metadata: %v", steps[0].Metadata)
+	}
+	if steps[1].Content != "main.go" || steps[1].Metadata["tool_step"] != "tool_result" {
+		t.Errorf("unexpected tool_result step: %+v", steps[1])
+	}
+
+	if len(a.TakeToolSteps()) != 0 {
+		t.Error("expected TakeToolSteps to clear recorded steps")
+	}
+}
+
+func TestAmpInvalidUTF8Output(t *testing.T) {
+	t.Run("plain", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new":            {output: "T-bin\n"},
+			"thread continue T-bin": {output: "partial reply\xff\xfe\x80 then crash"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		output, err := a.SendMessage(context.Background(), ampTestMessages())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !utf8.ValidString(output) {
+			t.Errorf("expected valid UTF-8, got %q", output)
+		}
+		if output != "partial reply\uFFFD then crash" {
+			t.Errorf("expected invalid bytes replaced, got %q", output)
+		}
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new --stream-json": {output: `{"thread_id":"T-bin"}` + "\n" +
+				`{"type":"text","content":"fine "}` + "\n" +
+				"core dumped \xde\xad\xbe\xef\n"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		var buf strings.Builder
+		if err := a.StreamMessage(context.Background(), ampTestMessages(), &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !utf8.ValidString(buf.String()) {
+			t.Errorf("expected valid UTF-8, got %q", buf.String())
+		}
+		if !strings.HasPrefix(buf.String(), "fine core dumped ") || !strings.Contains(buf.String(), "\uFFFD") {
+			t.Errorf("expected invalid bytes replaced, got %q", buf.String())
+		}
+	})
+
+	t.Run("error output", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new": {output: "segfault \xff\xfe", err: &fakeExitError{code: 139}},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		_, err := a.SendMessage(context.Background(), ampTestMessages())
+		if err == nil {
+			t.Fatal("expected error for non-zero exit")
+		}
+		if !utf8.ValidString(err.Error()) {
+			t.Errorf("expected a valid UTF-8 error message, got %q", err.Error())
+		}
+	})
+}
+
+func TestAmpResetStartsNewThread(t *testing.T) {
+	runner := &fakeRunner{results: map[string]fakeResult{
+		"thread new":              {output: "T-first\n"},
+		"thread continue T-first": {output: "first conversation"},
+	}}
+	a := newRunnerAmpAgent(runner)
+
+	if _, err := a.SendMessage(context.Background(), ampTestMessages()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.threadID != "T-first" || len(a.sent) == 0 {
+		t.Fatalf("expected conversation state after the first message, got thread %q with %d sent", a.threadID, len(a.sent))
+	}
+
+	var resetter agent.Resetter = a
+	resetter.Reset()
+	if a.threadID != "" || len(a.sent) != 0 || a.promptUpdated || a.toolSteps != nil {
+		t.Errorf("expected Reset to clear conversation state, got %+v", a)
+	}
+
+	// The next conversation gets a thread of its own, with its full context
+	runner.results["thread new"] = fakeResult{output: "T-second\n"}
+	runner.results["thread continue T-second"] = fakeResult{output: "second conversation"}
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss deployment"}}
+	output, err := a.SendMessage(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output != "second conversation" || a.threadID != "T-second" {
+		t.Errorf("expected a new thread, got output %q thread %q", output, a.threadID)
+	}
+	if last := runner.stdins[len(runner.stdins)-1]; !strings.Contains(last, "Discuss deployment") {
+		t.Errorf("expected the new conversation's prompt to be sent, got %q", last)
+	}
+}
+
+func TestAmpDeepHealthCheck(t *testing.T) {
+	t.Run("valid response", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new":             {output: "T-fake\n"},
+			"thread continue T-fake": {output: "OK"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		if err := agent.DeepHealthCheck(context.Background(), a); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(runner.stdins) < 2 || !strings.Contains(runner.stdins[1], agent.DeepHealthCheckPrompt) {
+			t.Errorf("expected the health check prompt to be sent, got stdins %q", runner.stdins)
+		}
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new":             {output: "T-fake\n"},
+			"thread continue T-fake": {output: "  \n"},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		err := agent.DeepHealthCheck(context.Background(), a)
+		if !errors.Is(err, agent.ErrEmptyHealthResponse) {
+			t.Fatalf("expected ErrEmptyHealthResponse, got %v", err)
+		}
+	})
+
+	t.Run("auth failure", func(t *testing.T) {
+		runner := &fakeRunner{results: map[string]fakeResult{
+			"thread new": {output: "Error: not logged in", err: errors.New("exit status 1")},
+		}}
+		a := newRunnerAmpAgent(runner)
+
+		err := agent.DeepHealthCheck(context.Background(), a)
+		if !agent.IsAuthError(err) {
+			t.Fatalf("expected an auth error, got %v", err)
+		}
+	})
+}
+
+func TestAmpUpdatePromptNotifiesExistingThread(t *testing.T) {
+	runner := &fakeRunner{results: map[string]fakeResult{
+		"thread new":             {output: "T-fake\n"},
+		"thread continue T-fake": {output: "reply"},
+	}}
+	a := newRunnerAmpAgent(runner)
+	messages := ampTestMessages()
+
+	// Before a thread exists the new prompt is simply part of the initial setup
+	a.UpdatePrompt("You are a supporter.")
+	if a.promptUpdated {
+		t.Error("expected no update notice before the thread is created")
+	}
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(runner.stdins[1], "You are a supporter.") {
+		t.Error("expected the initial prompt to include the system prompt")
+	}
+
+	a.UpdatePrompt("Now act as a critic.")
+	messages = append(messages, agent.Message{AgentID: "claude-1", AgentName: "Claude", Role: "agent", Content: "Thoughts?"})
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated := runner.stdins[len(runner.stdins)-1]
+	if !strings.Contains(updated, "Now act as a critic.") {
+		t.Errorf("expected the continuation to carry the new prompt, got %q", updated)
+	}
+	if strings.Contains(updated, "You are a supporter.") {
+		t.Error("expected the old prompt to be replaced")
+	}
+	if !strings.Contains(updated, "instructions have been updated") {
+		t.Errorf("expected the continuation to announce the prompt change, got %q", updated)
+	}
+
+	messages = append(messages, agent.Message{AgentID: "claude-1", AgentName: "Claude", Role: "agent", Content: "And now?"})
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(runner.stdins[len(runner.stdins)-1], "instructions have been updated") {
+		t.Error("expected the prompt change to be announced only once")
+	}
+}
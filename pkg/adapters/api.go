@@ -18,9 +18,12 @@ const defaultAPIModel = "auto"
 // APIAgent is a custom OpenAI-compatible API agent configured via endpoint + token.
 type APIAgent struct {
 	agent.BaseAgent
-	client      *client.OpenAICompatClient
-	apiKey      string
-	apiEndpoint string
+	client       *client.OpenAICompatClient
+	apiKey       string
+	apiEndpoint  string
+	toolSteps    []agent.Message // Tool calls from the last response
+	finishReason string          // Finish reason of the last response
+	reasoning    string          // Separate reasoning of the last response
 }
 
 // NewAPIAgent creates a new API agent instance.
@@ -41,12 +44,12 @@ func (a *APIAgent) Initialize(config agent.AgentConfig) error {
 	if config.APIEndpoint == "" {
 		return fmt.Errorf("api_endpoint must be specified for api agent")
 	}
-	if config.APIKey == "" {
+	if config.EffectiveAPIKey() == "" {
 		return fmt.Errorf("api_key must be specified for api agent")
 	}
 
 	a.apiEndpoint = config.APIEndpoint
-	a.apiKey = config.APIKey
+	a.apiKey = config.EffectiveAPIKey()
 
 	if a.Config.Model == "" {
 		a.Config.Model = defaultAPIModel
@@ -78,6 +81,14 @@ func (a *APIAgent) GetCLIVersion() string {
 	return "N/A (API)"
 }
 
+// Prewarm opens the connection to the API endpoint ahead of the first turn.
+func (a *APIAgent) Prewarm(ctx context.Context) error {
+	if a.client == nil {
+		return fmt.Errorf("api agent not initialized")
+	}
+	return a.client.Prewarm(ctx)
+}
+
 // HealthCheck performs a health check by making a test API request.
 func (a *APIAgent) HealthCheck(ctx context.Context) error {
 	if a.client == nil {
@@ -102,6 +113,29 @@ func (a *APIAgent) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// TakeToolSteps returns the tool calls from the last response and clears them
+func (a *APIAgent) TakeToolSteps() []agent.Message {
+	steps := a.toolSteps
+	a.toolSteps = nil
+	return steps
+}
+
+// SupportsStreaming reports whether StreamMessage can stand in for SendMessage.
+// Streamed responses carry no tool calls, so agents with tools don't stream.
+func (a *APIAgent) SupportsStreaming() bool {
+	return len(a.Config.Tools) == 0
+}
+
+// LastFinishReason returns the provider's finish reason for the last response
+func (a *APIAgent) LastFinishReason() string {
+	return a.finishReason
+}
+
+// LastReasoning returns the model's separate reasoning for the last response
+func (a *APIAgent) LastReasoning() string {
+	return a.reasoning
+}
+
 // SendMessage sends a message to the configured API and returns the response.
 func (a *APIAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
 	if len(messages) == 0 {
@@ -122,6 +156,12 @@ func (a *APIAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	if a.Config.MaxTokens > 0 {
 		req.MaxTokens = &a.Config.MaxTokens
 	}
+	a.RecordPrompt(explainRequest(req))
+	applyToolConfig(&req, a.Config)
+	applyResponseFormat(&req, a.Config)
+	a.toolSteps = nil
+	a.finishReason = ""
+	a.reasoning = ""
 
 	startTime := time.Now()
 	resp, err := a.client.CreateChatCompletion(ctx, req)
@@ -141,6 +181,9 @@ func (a *APIAgent) SendMessage(ctx context.Context, messages []agent.Message) (s
 	}
 
 	content := resp.Choices[0].Message.Content
+	a.toolSteps = toolCallMessages(&a.BaseAgent, resp.Choices[0].Message.ToolCalls)
+	a.finishReason = resp.Choices[0].FinishReason
+	a.reasoning = strings.TrimSpace(resp.Choices[0].Message.ReasoningText())
 
 	if resp.Usage != nil {
 		cost := utils.EstimateCost(a.Config.Model, resp.Usage.PromptTokens, resp.Usage.CompletionTokens)
@@ -184,9 +227,15 @@ func (a *APIAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 	if a.Config.MaxTokens > 0 {
 		req.MaxTokens = &a.Config.MaxTokens
 	}
+	a.RecordPrompt(explainRequest(req))
+	applyResponseFormat(&req, a.Config)
+	a.finishReason = ""
+	a.reasoning = ""
 
 	startTime := time.Now()
-	usage, err := a.client.CreateChatCompletionStream(ctx, req, writer)
+	collector := &reasoningCollector{Writer: writer}
+	usage, err := a.client.CreateChatCompletionStream(ctx, req, collector)
+	a.reasoning = strings.TrimSpace(collector.reasoning.String())
 	duration := time.Since(startTime)
 	if err != nil {
 		log.WithFields(map[string]interface{}{
@@ -221,12 +270,13 @@ func (a *APIAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 
 // buildConversationHistory converts AgentPipe messages to OpenAI API format.
 func (a *APIAgent) buildConversationHistory(messages []agent.Message) []client.ChatCompletionMessage {
+	messages = fitContextWindow(&a.BaseAgent, messages)
 	apiMessages := make([]client.ChatCompletionMessage, 0)
 
-	if a.Config.Prompt != "" {
+	if systemPrompt := systemPromptWithMemory(a.Config.Prompt, a.Memory()); systemPrompt != "" {
 		apiMessages = append(apiMessages, client.ChatCompletionMessage{
 			Role:    "system",
-			Content: a.Config.Prompt,
+			Content: systemPrompt,
 		})
 	}
 
@@ -258,7 +308,7 @@ func (a *APIAgent) buildConversationHistory(messages []agent.Message) []client.C
 		})
 	}
 
-	return apiMessages
+	return appendPromptSuffix(apiMessages, a.PromptSuffix())
 }
 
 func init() {
@@ -0,0 +1,235 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/client"
+)
+
+func TestAPIAgentToolsPassthrough(t *testing.T) {
+	var received client.ChatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"choices":[{"index":0,"finish_reason":"tool_calls","message":{"role":"assistant",
+			"content":"Checking the weather.","tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]}}]}`)
+	}))
+	defer server.Close()
+
+	a := NewAPIAgent()
+	err := a.Initialize(agent.AgentConfig{
+		ID:          "api-1",
+		Name:        "Weather",
+		Type:        "api",
+		Model:       "gpt-4o",
+		APIEndpoint: server.URL,
+		APIKey:      "sk-test",
+		Tools: []agent.ToolSchema{{
+			Name:        "get_weather",
+			Description: "Look up the weather",
+			Parameters:  map[string]interface{}{"type": "object"},
+		}},
+		ToolChoice: "get_weather",
+	})
+	if err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+
+	response, err := a.SendMessage(context.Background(), []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Weather in Paris?"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response != "Checking the weather." {
+		t.Errorf("unexpected response: %q", response)
+	}
+
+	if len(received.Tools) != 1 || received.Tools[0].Type != "function" || received.Tools[0].Function.Name != "get_weather" {
+		t.Errorf("expected tools in the request, got %+v", received.Tools)
+	}
+	choice, ok := received.ToolChoice.(map[string]interface{})
+	if !ok || choice["type"] != "function" {
+		t.Errorf("expected a named function tool_choice, got %#v", received.ToolChoice)
+	}
+
+	reporter, ok := a.(agent.ToolStepReporter)
+	if !ok {
+		t.Fatal("expected APIAgent to report tool steps")
+	}
+	steps := reporter.TakeToolSteps()
+	if len(steps) != 1 {
+		t.Fatalf("expected 1 tool step, got %d", len(steps))
+	}
+	step := steps[0]
+	if step.Role != "tool" || step.AgentName != "Weather" {
+		t.Errorf("unexpected tool step: %+v", step)
+	}
+	if step.Metadata["tool_name"] != "get_weather" || step.Metadata["tool_id"] != "call_1" {
+		t.Errorf("expected tool call metadata, got %v", step.Metadata)
+	}
+	args, ok := step.Metadata["tool_arguments"].(map[string]interface{})
+	if !ok || args["city"] != "Paris" {
+		t.Errorf("expected parsed tool arguments, got %#v", step.Metadata["tool_arguments"])
+	}
+	if len(reporter.TakeToolSteps()) != 0 {
+		t.Error("expected tool steps to be cleared after taking them")
+	}
+
+	if got := a.(agent.FinishReasonReporter).LastFinishReason(); got != "tool_calls" {
+		t.Errorf("expected finish reason tool_calls, got %q", got)
+	}
+}
+
+func TestApplyToolConfigWithoutTools(t *testing.T) {
+	req := client.ChatCompletionRequest{}
+	applyToolConfig(&req, agent.AgentConfig{ToolChoice: "auto"})
+	if req.Tools != nil || req.ToolChoice != nil {
+		t.Errorf("expected no tool fields without configured tools, got %+v", req)
+	}
+}
+
+func TestApplyResponseFormat(t *testing.T) {
+	schema := map[string]interface{}{"type": "object"}
+
+	req := client.ChatCompletionRequest{}
+	applyResponseFormat(&req, agent.AgentConfig{ResponseSchema: schema})
+	if req.ResponseFormat != nil {
+		t.Errorf("expected no response_format unless structured output is enabled, got %+v", req.ResponseFormat)
+	}
+
+	applyResponseFormat(&req, agent.AgentConfig{
+		ResponseSchema: schema,
+		CustomSettings: map[string]interface{}{structuredOutputSetting: true},
+	})
+	if req.ResponseFormat == nil || req.ResponseFormat.Type != "json_schema" ||
+		req.ResponseFormat.JSONSchema == nil || req.ResponseFormat.JSONSchema.Schema["type"] != "object" {
+		t.Errorf("expected a json_schema response_format, got %+v", req.ResponseFormat)
+	}
+}
+
+func TestAPIAgentExplainCapturesPrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"Hi."}}]}`)
+	}))
+	defer server.Close()
+
+	a := NewAPIAgent()
+	if err := a.Initialize(agent.AgentConfig{
+		ID:          "api-1",
+		Name:        "Explainer",
+		Type:        "api",
+		Model:       "gpt-4o",
+		Prompt:      "You are terse.",
+		APIEndpoint: server.URL,
+		APIKey:      "sk-test",
+	}); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss tabs versus spaces"}}
+
+	explainer, ok := a.(agent.PromptExplainer)
+	if !ok {
+		t.Fatal("expected APIAgent to implement PromptExplainer")
+	}
+
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := explainer.GetLastPrompt(); got != "" {
+		t.Errorf("expected no captured prompt without explain, got %q", got)
+	}
+
+	explainer.SetExplain(true)
+	if _, err := a.SendMessage(context.Background(), messages); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := explainer.GetLastPrompt()
+	for _, want := range []string{"You are terse.", "Discuss tabs versus spaces"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected captured prompt to contain %q, got %q", want, got)
+		}
+	}
+}
+
+func TestAPIAgentSupportsStreaming(t *testing.T) {
+	a := &APIAgent{}
+	if !a.SupportsStreaming() {
+		t.Error("expected an api agent without tools to support streaming")
+	}
+	a.Config.Tools = []agent.ToolSchema{{Name: "get_weather"}}
+	if a.SupportsStreaming() {
+		t.Error("expected an api agent with tools to fall back to buffered responses")
+	}
+}
+
+func TestAPIAgentSeparatesReasoning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.ChatCompletionRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"Four.","reasoning":"2 plus 2 is 4."}}]}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, strings.Join([]string{
+			`data: {"choices":[{"index":0,"delta":{"reasoning_content":"2 plus 2 "}}]}`,
+			``,
+			`data: {"choices":[{"index":0,"delta":{"reasoning_content":"is 4."}}]}`,
+			``,
+			`data: {"choices":[{"index":0,"delta":{"content":"Four."}}]}`,
+			``,
+			`data: [DONE]`,
+			``,
+		}, "\n"))
+	}))
+	defer server.Close()
+
+	a := NewAPIAgent()
+	if err := a.Initialize(agent.AgentConfig{
+		ID:          "api-1",
+		Name:        "Thinker",
+		Type:        "api",
+		Model:       "reasoner",
+		APIEndpoint: server.URL,
+		APIKey:      "sk-test",
+	}); err != nil {
+		t.Fatalf("failed to initialize: %v", err)
+	}
+	messages := []agent.Message{{AgentID: "host", AgentName: "HOST", Role: "system", Content: "What is 2 plus 2?"}}
+	reporter, ok := a.(agent.ReasoningReporter)
+	if !ok {
+		t.Fatal("expected APIAgent to implement ReasoningReporter")
+	}
+
+	var streamed strings.Builder
+	if err := a.StreamMessage(context.Background(), messages, &streamed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if streamed.String() != "Four." {
+		t.Errorf("expected only the answer to be streamed, got %q", streamed.String())
+	}
+	if got := reporter.LastReasoning(); got != "2 plus 2 is 4." {
+		t.Errorf("expected the streamed reasoning, got %q", got)
+	}
+
+	response, err := a.SendMessage(context.Background(), messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response != "Four." || reporter.LastReasoning() != "2 plus 2 is 4." {
+		t.Errorf("expected separate answer and reasoning, got %q / %q", response, reporter.LastReasoning())
+	}
+}
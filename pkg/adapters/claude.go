@@ -112,10 +112,11 @@ func (c *ClaudeAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to claude CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -134,6 +135,13 @@ func (c *ClaudeAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("claude", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": c.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("claude authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": c.Name,
@@ -169,10 +177,11 @@ func (c *ClaudeAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	}).Debug("starting claude streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -256,6 +265,7 @@ func (c *ClaudeAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -313,6 +323,7 @@ func (c *ClaudeAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
@@ -105,10 +105,11 @@ func (c *CodexAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to codex CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args - use 'exec' subcommand for non-interactive mode
 	args := []string{"exec"}
@@ -146,12 +147,12 @@ func (c *CodexAgent) SendMessage(ctx context.Context, messages []agent.Message)
 			}).WithError(err).Error("codex model not found")
 			return "", fmt.Errorf("codex model not found - check model name in config: %s", c.Config.Model)
 		}
-		if strings.Contains(outputStr, "401") || strings.Contains(outputStr, "unauthorized") {
+		if strings.Contains(outputStr, "401") || agent.LooksLikeAuthFailure(outputStr) {
 			log.WithFields(map[string]interface{}{
 				"agent_name": c.Name,
 				"duration":   duration.String(),
 			}).WithError(err).Error("codex authentication failed")
-			return "", fmt.Errorf("codex authentication failed - check API keys")
+			return "", &agent.AuthError{AgentType: "codex", Hint: "check API keys", Output: outputStr}
 		}
 		if strings.Contains(outputStr, "terminal") || strings.Contains(outputStr, "tty") {
 			log.WithFields(map[string]interface{}{
@@ -213,6 +214,7 @@ func (c *CodexAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -266,6 +268,7 @@ func (c *CodexAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
@@ -275,10 +278,11 @@ func (c *CodexAgent) StreamMessage(ctx context.Context, messages []agent.Message
 	}
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args - use 'exec' subcommand for non-interactive mode
 	args := []string{"exec"}
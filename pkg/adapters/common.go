@@ -3,8 +3,57 @@ package adapters
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/client"
+	"github.com/shawkym/agentpipe/pkg/log"
 )
 
+// memorySectionHeader introduces an agent's long-term notes in its prompt
+const memorySectionHeader = "YOUR MEMORY (notes from previous sessions):\n"
+
+// writeMemorySection appends the agent's memory notes to a prompt, if any
+func writeMemorySection(prompt *strings.Builder, memory string) {
+	if memory == "" {
+		return
+	}
+	prompt.WriteString("\n")
+	prompt.WriteString(memorySectionHeader)
+	prompt.WriteString(memory)
+	prompt.WriteString("\n")
+}
+
+// systemPromptWithMemory combines a system prompt with the agent's memory notes
+func systemPromptWithMemory(systemPrompt, memory string) string {
+	if memory == "" {
+		return systemPrompt
+	}
+	return strings.TrimSpace(systemPrompt + "\n\n" + memorySectionHeader + memory)
+}
+
+// writePromptSuffix appends the per-turn instruction suffix to a prompt, if any
+func writePromptSuffix(prompt *strings.Builder, suffix string) {
+	if suffix == "" {
+		return
+	}
+	prompt.WriteString("\n\n")
+	prompt.WriteString(suffix)
+}
+
+// appendPromptSuffix adds the per-turn instruction suffix to the last chat message,
+// or as a new user message if the conversation ends with something else
+func appendPromptSuffix(messages []client.ChatCompletionMessage, suffix string) []client.ChatCompletionMessage {
+	if suffix == "" {
+		return messages
+	}
+	if n := len(messages); n > 0 && messages[n-1].Role == "user" {
+		messages[n-1].Content += "\n\n" + suffix
+		return messages
+	}
+	return append(messages, client.ChatCompletionMessage{Role: "user", Content: suffix})
+}
+
 // BuildAgentPrompt creates a standard prompt for multi-agent conversations
 func BuildAgentPrompt(agentName string, customPrompt string, conversation string) string {
 	var prompt strings.Builder
@@ -25,3 +74,39 @@ func BuildAgentPrompt(agentName string, customPrompt string, conversation string
 
 	return prompt.String()
 }
+
+// authError returns an *agent.AuthError when the output of a failed CLI
+// invocation shows the CLI is not authenticated, or nil otherwise
+func authError(agentType string, output []byte) error {
+	if !agent.LooksLikeAuthFailure(string(output)) {
+		return nil
+	}
+	return &agent.AuthError{AgentType: agentType, Output: string(output)}
+}
+
+// sanitizeUTF8 replaces each run of invalid UTF-8 bytes in s with the Unicode
+// replacement character, reporting whether anything was replaced
+func sanitizeUTF8(s string) (string, bool) {
+	if utf8.ValidString(s) {
+		return s, false
+	}
+	return strings.ToValidUTF8(s, string(utf8.RuneError)), true
+}
+
+// sanitizeOutput makes CLI output safe for logs and the TUI. Invalid UTF-8 usually means
+// the CLI crashed while dumping binary data, so a warning is logged when any is replaced.
+func sanitizeOutput(agentName, output string) string {
+	clean, replaced := sanitizeUTF8(output)
+	if replaced {
+		warnInvalidUTF8(agentName, len(output))
+	}
+	return clean
+}
+
+// warnInvalidUTF8 logs that an agent's output contained invalid UTF-8
+func warnInvalidUTF8(agentName string, size int) {
+	log.WithFields(map[string]interface{}{
+		"agent_name":  agentName,
+		"output_size": size,
+	}).Warn("agent output contained invalid UTF-8; replaced invalid bytes")
+}
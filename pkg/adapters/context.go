@@ -0,0 +1,138 @@
+package adapters
+
+import (
+	"strings"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+	"github.com/shawkym/agentpipe/pkg/utils"
+)
+
+// promptFraming approximates the fixed text the CLI adapters' buildPrompt wraps around
+// the conversation: section headers, rulers and the closing instruction
+var promptFraming = strings.Join([]string{
+	"AGENT SETUP:", strings.Repeat("=", 60),
+	"You are '' participating in a multi-agent conversation.",
+	"YOUR ROLE AND INSTRUCTIONS:", strings.Repeat("=", 60),
+	"YOUR TASK - PLEASE RESPOND TO THIS:", strings.Repeat("=", 60), strings.Repeat("=", 60),
+	"CONVERSATION SO FAR:", strings.Repeat("-", 60), strings.Repeat("-", 60),
+	"Now respond to the task above as . Provide a direct, thoughtful answer.",
+}, "\n")
+
+// lineFraming is the timestamp buildPrompt puts in front of each conversation line
+const lineFraming = "[15:04:05] "
+
+// contextWindow returns the agent's context window in tokens: the configured
+// context_window, else the known window of its configured model, else 0 (unknown)
+func contextWindow(b *agent.BaseAgent) int {
+	if b.Config.ContextWindow > 0 {
+		return b.Config.ContextWindow
+	}
+	return utils.ContextWindow(b.Config.Model)
+}
+
+// messageTokens estimates the tokens a message takes up in a prompt, including the
+// speaker label adapters put in front of it
+func messageTokens(msg agent.Message) int {
+	return utils.EstimateTokens(msg.AgentName + ": " + msg.Content)
+}
+
+// framedMessageTokens estimates the tokens a message takes up as a line of a CLI
+// adapter's prompt, including its timestamp and speaker label
+func framedMessageTokens(msg agent.Message) int {
+	return utils.EstimateTokens(lineFraming + msg.AgentName + ": " + msg.Content)
+}
+
+// isInitialPrompt reports whether msg is the orchestrator's opening prompt, matched
+// the same way buildPrompt picks the task it shows the agent
+func isInitialPrompt(msg agent.Message) bool {
+	return msg.Role == "system" && (msg.AgentID == "system" || msg.AgentID == "host" || msg.AgentName == "System" || msg.AgentName == "HOST")
+}
+
+// fitContextWindow drops the oldest non-pinned messages until the prompt assembled from
+// the agent's system prompt, memory and messages fits its context window, leaving room
+// for MaxTokens of output. The latest message and the initial prompt are always kept.
+// Messages are returned unchanged when the window is unknown or already large enough.
+func fitContextWindow(b *agent.BaseAgent, messages []agent.Message) []agent.Message {
+	overhead := utils.EstimateTokens(systemPromptWithMemory(b.Config.Prompt, b.Memory()))
+	return fitMessages(b, messages, overhead, messageTokens)
+}
+
+// fitFramedContextWindow is fitContextWindow for the CLI adapters, whose buildPrompt
+// wraps the messages in section headers, timestamps and the prompt suffix; that
+// framing counts against the window too.
+func fitFramedContextWindow(b *agent.BaseAgent, messages []agent.Message) []agent.Message {
+	overhead := utils.EstimateTokens(promptFraming + b.Name + b.Name)
+	overhead += utils.EstimateTokens(systemPromptWithMemory(b.Config.Prompt, b.Memory()))
+	overhead += utils.EstimateTokens(b.PromptSuffix())
+	return fitMessages(b, messages, overhead, framedMessageTokens)
+}
+
+// fitMessages trims messages to the agent's window given the tokens the rest of the
+// prompt takes up and how to count each message. The first time an agent's history is
+// trimmed it is logged as a warning; later turns log at debug level.
+func fitMessages(b *agent.BaseAgent, messages []agent.Message, overhead int, tokens func(agent.Message) int) []agent.Message {
+	window := contextWindow(b)
+	if window <= 0 || len(messages) == 0 {
+		return messages
+	}
+
+	budget := window - overhead
+	if b.Config.MaxTokens > 0 && b.Config.MaxTokens < window {
+		budget -= b.Config.MaxTokens
+	}
+
+	total := 0
+	for _, msg := range messages {
+		total += tokens(msg)
+	}
+	if total <= budget {
+		return messages
+	}
+
+	// Mark the oldest droppable messages until the rest fit
+	drop := make([]bool, len(messages))
+	dropped := 0
+	seenInitialPrompt := false
+	for i := 0; i < len(messages)-1 && total > budget; i++ {
+		if !seenInitialPrompt && isInitialPrompt(messages[i]) {
+			seenInitialPrompt = true
+			continue
+		}
+		if messages[i].Pinned {
+			continue
+		}
+		drop[i] = true
+		dropped++
+		total -= tokens(messages[i])
+	}
+
+	fields := map[string]interface{}{
+		"agent_name":       b.Name,
+		"model":            b.Config.Model,
+		"context_window":   window,
+		"estimated_tokens": total,
+		"dropped_messages": dropped,
+	}
+	logger := log.WithFields(fields)
+	report := logger.Debug
+	if b.MarkContextWarned() {
+		report = logger.Warn
+	}
+	if total > budget {
+		report("prompt exceeds the model's context window even after trimming history")
+	} else {
+		report("prompt would exceed the model's context window, dropped oldest messages")
+	}
+	if dropped == 0 {
+		return messages
+	}
+
+	fitted := make([]agent.Message, 0, len(messages)-dropped)
+	for i, msg := range messages {
+		if !drop[i] {
+			fitted = append(fitted, msg)
+		}
+	}
+	return fitted
+}
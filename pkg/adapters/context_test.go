@@ -0,0 +1,163 @@
+package adapters
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+	"github.com/shawkym/agentpipe/pkg/utils"
+)
+
+func contextMessages() []agent.Message {
+	long := strings.Repeat("lorem ipsum dolor sit amet ", 20)
+	return []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss the roadmap.", Pinned: true},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Content: "first " + long},
+		{AgentID: "b", AgentName: "Bob", Role: "agent", Content: "second " + long},
+		{AgentID: "a", AgentName: "Alice", Role: "agent", Content: "third " + long},
+		{AgentID: "b", AgentName: "Bob", Role: "agent", Content: "fourth " + long},
+	}
+}
+
+func contents(messages []agent.Message) []string {
+	var result []string
+	for _, msg := range messages {
+		result = append(result, strings.Fields(msg.Content)[0])
+	}
+	return result
+}
+
+func TestContextWindowLookup(t *testing.T) {
+	configured := &agent.BaseAgent{Config: agent.AgentConfig{Model: "gpt-4o", ContextWindow: 4096}}
+	if got := contextWindow(configured); got != 4096 {
+		t.Errorf("expected the configured window to win, got %d", got)
+	}
+	known := &agent.BaseAgent{Config: agent.AgentConfig{Model: "gpt-4o"}}
+	if got := contextWindow(known); got != 128000 {
+		t.Errorf("expected gpt-4o's window from the provider registry, got %d", got)
+	}
+	if got := contextWindow(&agent.BaseAgent{}); got != 0 {
+		t.Errorf("expected an unknown window without a model, got %d", got)
+	}
+}
+
+func TestFitContextWindowTrimsOldest(t *testing.T) {
+	messages := contextMessages()
+	// Room for the pinned prompt and the two most recent messages only
+	window := messageTokens(messages[0]) + messageTokens(messages[3]) + messageTokens(messages[4])
+	b := &agent.BaseAgent{Name: "Carol", Config: agent.AgentConfig{ContextWindow: window}}
+
+	fitted := fitContextWindow(b, messages)
+	if got := strings.Join(contents(fitted), ","); got != "Discuss,third,fourth" {
+		t.Errorf("expected the oldest unpinned messages to be dropped, got %s", got)
+	}
+	if len(messages) != 5 {
+		t.Error("expected the caller's messages to be left alone")
+	}
+}
+
+func TestFitContextWindowReservesPromptAndOutput(t *testing.T) {
+	messages := contextMessages()
+	total := 0
+	for _, msg := range messages {
+		total += messageTokens(msg)
+	}
+
+	fits := &agent.BaseAgent{Config: agent.AgentConfig{ContextWindow: total}}
+	if fitted := fitContextWindow(fits, messages); len(fitted) != len(messages) {
+		t.Errorf("expected a prompt that fits to be sent whole, got %d messages", len(fitted))
+	}
+
+	withOutput := &agent.BaseAgent{Config: agent.AgentConfig{ContextWindow: total, MaxTokens: 10}}
+	if fitted := fitContextWindow(withOutput, messages); len(fitted) != len(messages)-1 {
+		t.Errorf("expected room for max_tokens to drop one message, got %d messages", len(fitted))
+	}
+
+	withPrompt := &agent.BaseAgent{Config: agent.AgentConfig{ContextWindow: total, Prompt: "You are a careful reviewer."}}
+	if fitted := fitContextWindow(withPrompt, messages); len(fitted) != len(messages)-1 {
+		t.Errorf("expected the system prompt to count against the window, got %d messages", len(fitted))
+	}
+}
+
+func TestFitContextWindowKeepsLatestAndPinned(t *testing.T) {
+	messages := contextMessages()
+	b := &agent.BaseAgent{Config: agent.AgentConfig{ContextWindow: 1}}
+
+	fitted := fitContextWindow(b, messages)
+	if got := strings.Join(contents(fitted), ","); got != "Discuss,fourth" {
+		t.Errorf("expected pinned and latest messages to survive a tiny window, got %s", got)
+	}
+}
+
+func TestFitContextWindowKeepsUnpinnedInitialPrompt(t *testing.T) {
+	messages := contextMessages()
+	messages[0].Pinned = false
+	b := &agent.BaseAgent{Config: agent.AgentConfig{ContextWindow: 1}}
+
+	fitted := fitContextWindow(b, messages)
+	if got := strings.Join(contents(fitted), ","); got != "Discuss,fourth" {
+		t.Errorf("expected the initial prompt to survive trimming, got %s", got)
+	}
+}
+
+func TestFitFramedContextWindowCountsFraming(t *testing.T) {
+	messages := contextMessages()
+	total := 0
+	for _, msg := range messages {
+		total += messageTokens(msg)
+	}
+
+	b := &agent.BaseAgent{Name: "Carol", Config: agent.AgentConfig{ContextWindow: total}}
+	if fitted := fitContextWindow(b, messages); len(fitted) != len(messages) {
+		t.Fatalf("expected the bare messages to fit, got %d messages", len(fitted))
+	}
+	if fitted := fitFramedContextWindow(b, messages); len(fitted) == len(messages) {
+		t.Error("expected the prompt framing to count against the window")
+	}
+
+	c := NewClaudeAgent().(*ClaudeAgent)
+	c.Name = "Carol"
+	c.Config.ContextWindow = total
+	fitted := fitFramedContextWindow(&c.BaseAgent, messages)
+	if got, prompt := len(fitted), utils.EstimateTokens(c.buildPrompt(fitted, true)); prompt > total {
+		t.Errorf("expected the framed prompt to fit the window, got %d tokens from %d messages", prompt, got)
+	}
+}
+
+func TestFitContextWindowWarnsOnce(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetGlobalLogger(log.NewWithLevel(&buf, zerolog.DebugLevel))
+	defer log.SetGlobalLogger(log.New(os.Stderr))
+
+	b := &agent.BaseAgent{Name: "Carol", Config: agent.AgentConfig{ContextWindow: 1}}
+	for i := 0; i < 3; i++ {
+		fitContextWindow(b, contextMessages())
+	}
+	if got := strings.Count(buf.String(), `"level":"warn"`); got != 1 {
+		t.Errorf("expected one warning per agent, got %d:\n%s", got, buf.String())
+	}
+	if got := strings.Count(buf.String(), `"level":"debug"`); got != 2 {
+		t.Errorf("expected later trims to log at debug level, got %d", got)
+	}
+}
+
+func TestAPIAgentHistoryFitsContextWindow(t *testing.T) {
+	messages := contextMessages()
+	window := messageTokens(messages[0]) + messageTokens(messages[4])
+	a := &APIAgent{}
+	a.Name = "Carol"
+	a.Config = agent.AgentConfig{ContextWindow: window}
+
+	history := a.buildConversationHistory(messages)
+	if len(history) != 2 {
+		t.Fatalf("expected the pinned prompt and latest message, got %+v", history)
+	}
+	if !strings.Contains(history[1].Content, "fourth") {
+		t.Errorf("expected the latest message to be kept, got %q", history[1].Content)
+	}
+}
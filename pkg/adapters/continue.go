@@ -111,10 +111,11 @@ func (c *ContinueAgent) SendMessage(ctx context.Context, messages []agent.Messag
 	}).Debug("sending message to continue CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{"-p", prompt}
@@ -135,6 +136,13 @@ func (c *ContinueAgent) SendMessage(ctx context.Context, messages []agent.Messag
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("continue", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": c.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("continue authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": c.Name,
@@ -175,10 +183,11 @@ func (c *ContinueAgent) StreamMessage(ctx context.Context, messages []agent.Mess
 	}).Debug("starting continue streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{"-p", prompt}
@@ -312,6 +321,7 @@ func (c *ContinueAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -369,6 +379,7 @@ func (c *ContinueAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
@@ -104,10 +104,11 @@ func (c *CopilotAgent) SendMessage(ctx context.Context, messages []agent.Message
 	}).Debug("sending message to copilot CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Use non-interactive mode with -p/--prompt flag
 	args := []string{"-p", prompt}
@@ -135,7 +136,7 @@ func (c *CopilotAgent) SendMessage(ctx context.Context, messages []agent.Message
 				"agent_name": c.Name,
 				"duration":   duration.String(),
 			}).WithError(err).Error("copilot authentication failed")
-			return "", fmt.Errorf("copilot authentication failed - please run 'copilot' and use '/login' command")
+			return "", &agent.AuthError{AgentType: "copilot", Hint: "run 'copilot' and use the '/login' command", Output: outputStr}
 		}
 		if strings.Contains(outputStr, "subscription") {
 			log.WithFields(map[string]interface{}{
@@ -175,10 +176,11 @@ func (c *CopilotAgent) StreamMessage(ctx context.Context, messages []agent.Messa
 	}
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Use non-interactive mode with -p/--prompt flag
 	args := []string{"-p", prompt}
@@ -244,6 +246,7 @@ func (c *CopilotAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -297,6 +300,7 @@ func (c *CopilotAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
@@ -111,10 +111,11 @@ func (c *CrushAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to crush CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args - Crush accepts prompt via stdin or as argument
 	// Using stdin for consistency with other adapters
@@ -134,6 +135,13 @@ func (c *CrushAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("crush", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": c.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("crush authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": c.Name,
@@ -173,10 +181,11 @@ func (c *CrushAgent) StreamMessage(ctx context.Context, messages []agent.Message
 	}).Debug("starting crush streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -265,6 +274,7 @@ func (c *CrushAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -322,6 +332,7 @@ func (c *CrushAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
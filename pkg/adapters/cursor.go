@@ -174,10 +174,11 @@ func (c *CursorAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	}).Debug("starting cursor streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := c.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&c.BaseAgent, c.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := c.buildPrompt(relevantMessages, true)
+	c.RecordPrompt(prompt)
 
 	// Create a context with timeout for streaming
 	// cursor-agent needs more time to respond (typically 10-15 seconds)
@@ -339,6 +340,7 @@ func (c *CursorAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		prompt.WriteString(c.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, c.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -392,6 +394,7 @@ func (c *CursorAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		}
 	}
 
+	writePromptSuffix(&prompt, c.PromptSuffix())
 	return prompt.String()
 }
 
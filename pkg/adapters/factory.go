@@ -111,10 +111,11 @@ func (f *FactoryAgent) SendMessage(ctx context.Context, messages []agent.Message
 	}).Debug("sending message to droid CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := f.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&f.BaseAgent, f.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := f.buildPrompt(relevantMessages, true)
+	f.RecordPrompt(prompt)
 
 	// Build command args for droid exec (non-interactive mode)
 	args := []string{"exec"}
@@ -139,6 +140,13 @@ func (f *FactoryAgent) SendMessage(ctx context.Context, messages []agent.Message
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("factory", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": f.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("factory authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": f.Name,
@@ -174,10 +182,11 @@ func (f *FactoryAgent) StreamMessage(ctx context.Context, messages []agent.Messa
 	}).Debug("starting factory streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := f.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&f.BaseAgent, f.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := f.buildPrompt(relevantMessages, true)
+	f.RecordPrompt(prompt)
 
 	// Build command args for droid exec
 	args := []string{"exec"}
@@ -266,6 +275,7 @@ func (f *FactoryAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 		prompt.WriteString(f.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, f.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -323,6 +333,7 @@ func (f *FactoryAgent) buildPrompt(messages []agent.Message, isInitialSession bo
 		}
 	}
 
+	writePromptSuffix(&prompt, f.PromptSuffix())
 	return prompt.String()
 }
 
@@ -114,10 +114,11 @@ func (g *GeminiAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to gemini CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := g.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&g.BaseAgent, g.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := g.buildPrompt(relevantMessages, true)
+	g.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -169,7 +170,7 @@ func (g *GeminiAgent) SendMessage(ctx context.Context, messages []agent.Message)
 					"agent_name": g.Name,
 					"duration":   duration.String(),
 				}).WithError(err).Error("gemini authentication failed")
-				return "", fmt.Errorf("gemini authentication failed - check API keys")
+				return "", &agent.AuthError{AgentType: "gemini", Hint: "check API keys", Output: outputStr}
 			}
 
 			if exitErr, ok := err.(*exec.ExitError); ok {
@@ -273,10 +274,11 @@ func (g *GeminiAgent) StreamMessage(ctx context.Context, messages []agent.Messag
 	}
 
 	// Filter out this agent's own messages
-	relevantMessages := g.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&g.BaseAgent, g.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := g.buildPrompt(relevantMessages, true)
+	g.RecordPrompt(prompt)
 
 	// Build command with model flag if specified
 	args := []string{}
@@ -346,6 +348,7 @@ func (g *GeminiAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		prompt.WriteString(g.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, g.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -399,6 +402,7 @@ func (g *GeminiAgent) buildPrompt(messages []agent.Message, isInitialSession boo
 		}
 	}
 
+	writePromptSuffix(&prompt, g.PromptSuffix())
 	return prompt.String()
 }
 
@@ -111,10 +111,11 @@ func (g *GroqAgent) SendMessage(ctx context.Context, messages []agent.Message) (
 	}).Debug("sending message to groq CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := g.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&g.BaseAgent, g.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := g.buildPrompt(relevantMessages, true)
+	g.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -138,6 +139,13 @@ func (g *GroqAgent) SendMessage(ctx context.Context, messages []agent.Message) (
 	duration := time.Since(startTime)
 
 	if err != nil {
+		if authErr := authError("groq", output); authErr != nil {
+			log.WithFields(map[string]interface{}{
+				"agent_name": g.Name,
+				"duration":   duration.String(),
+			}).WithError(err).Error("groq authentication failed")
+			return "", authErr
+		}
 		if exitErr, ok := err.(*exec.ExitError); ok {
 			log.WithFields(map[string]interface{}{
 				"agent_name": g.Name,
@@ -177,10 +185,11 @@ func (g *GroqAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 	}).Debug("starting groq streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := g.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&g.BaseAgent, g.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := g.buildPrompt(relevantMessages, true)
+	g.RecordPrompt(prompt)
 
 	// Build command args
 	args := []string{}
@@ -274,6 +283,7 @@ func (g *GroqAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 		prompt.WriteString(g.Config.Prompt)
 		prompt.WriteString("\n")
 	}
+	writeMemorySection(&prompt, g.Memory())
 	prompt.WriteString(strings.Repeat("=", 60))
 	prompt.WriteString("\n\n")
 
@@ -331,6 +341,7 @@ func (g *GroqAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 		}
 	}
 
+	writePromptSuffix(&prompt, g.PromptSuffix())
 	return prompt.String()
 }
 
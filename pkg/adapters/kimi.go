@@ -108,10 +108,11 @@ func (k *KimiAgent) SendMessage(ctx context.Context, messages []agent.Message) (
 	}).Debug("sending message to kimi")
 
 	// Filter out this agent's own messages
-	relevantMessages := k.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&k.BaseAgent, k.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := k.buildPrompt(relevantMessages)
+	k.RecordPrompt(prompt)
 
 	// Kimi is an interactive tool without a traditional non-interactive API mode
 	// However, we can attempt to send input via stdin with the --help flag replaced by interactive mode
@@ -137,7 +138,7 @@ func (k *KimiAgent) SendMessage(ctx context.Context, messages []agent.Message) (
 				"agent_name": k.Name,
 				"duration":   duration.String(),
 			}).WithError(err).Error("kimi authentication failed")
-			return "", fmt.Errorf("kimi not authenticated - please run 'kimi' and use '.set_api_key' command to authenticate with Moonshot AI")
+			return "", &agent.AuthError{AgentType: "kimi", Hint: "run 'kimi' and use the '.set_api_key' command to authenticate with Moonshot AI", Output: outputStr}
 		}
 
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -221,6 +222,7 @@ func (k *KimiAgent) buildPrompt(messages []agent.Message) string {
 		prompt.WriteString(k.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, k.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -268,6 +270,7 @@ func (k *KimiAgent) buildPrompt(messages []agent.Message) string {
 		}
 	}
 
+	writePromptSuffix(&prompt, k.PromptSuffix())
 	return prompt.String()
 }
 
@@ -104,20 +104,11 @@ func (o *OpenCodeAgent) SendMessage(ctx context.Context, messages []agent.Messag
 	}).Debug("sending message to opencode CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := o.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&o.BaseAgent, o.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := o.buildPrompt(relevantMessages, true)
-
-	// Log prompt preview for debugging
-	promptPreview := prompt
-	if len(promptPreview) > 300 {
-		promptPreview = promptPreview[:300] + "..."
-	}
-	log.WithFields(map[string]interface{}{
-		"agent_name":     o.Name,
-		"prompt_preview": promptPreview,
-	}).Debug("opencode prompt preview")
+	o.RecordPrompt(prompt)
 
 	// Build command args - use 'run' subcommand for non-interactive mode
 	args := []string{"run"}
@@ -150,7 +141,7 @@ func (o *OpenCodeAgent) SendMessage(ctx context.Context, messages []agent.Messag
 				"agent_name": o.Name,
 				"duration":   duration.String(),
 			}).WithError(err).Error("opencode authentication failed")
-			return "", fmt.Errorf("opencode authentication failed - run 'opencode auth login' to authenticate")
+			return "", &agent.AuthError{AgentType: "opencode", Hint: "run 'opencode auth login'", Output: outputStr}
 		}
 
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -203,6 +194,7 @@ func (o *OpenCodeAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		prompt.WriteString(o.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, o.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -256,6 +248,7 @@ func (o *OpenCodeAgent) buildPrompt(messages []agent.Message, isInitialSession b
 		}
 	}
 
+	writePromptSuffix(&prompt, o.PromptSuffix())
 	return prompt.String()
 }
 
@@ -265,10 +258,11 @@ func (o *OpenCodeAgent) StreamMessage(ctx context.Context, messages []agent.Mess
 	}
 
 	// Filter out this agent's own messages
-	relevantMessages := o.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&o.BaseAgent, o.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := o.buildPrompt(relevantMessages, true)
+	o.RecordPrompt(prompt)
 
 	// Build command args - use 'run' subcommand for non-interactive mode
 	args := []string{"run"}
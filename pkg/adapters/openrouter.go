@@ -18,8 +18,11 @@ import (
 // OpenRouterAgent is an API-based agent that uses OpenRouter's unified API.
 type OpenRouterAgent struct {
 	agent.BaseAgent
-	client *client.OpenAICompatClient
-	apiKey string
+	client       *client.OpenAICompatClient
+	apiKey       string
+	toolSteps    []agent.Message // Tool calls from the last response
+	finishReason string          // Finish reason of the last response
+	reasoning    string          // Separate reasoning of the last response
 }
 
 // NewOpenRouterAgent creates a new OpenRouter agent instance.
@@ -47,7 +50,7 @@ func (o *OpenRouterAgent) Initialize(config agent.AgentConfig) error {
 	}
 
 	// Get API key from config or environment
-	apiKey := config.APIKey
+	apiKey := config.EffectiveAPIKey()
 	if apiKey == "" {
 		apiKey = os.Getenv("OPENROUTER_API_KEY")
 	}
@@ -104,6 +107,14 @@ func (o *OpenRouterAgent) GetCLIVersion() string {
 	return "N/A (API)"
 }
 
+// Prewarm opens the connection to OpenRouter ahead of the first turn.
+func (o *OpenRouterAgent) Prewarm(ctx context.Context) error {
+	if o.client == nil {
+		return fmt.Errorf("openrouter agent not initialized")
+	}
+	return o.client.Prewarm(ctx)
+}
+
 // HealthCheck performs a health check by making a test API request.
 func (o *OpenRouterAgent) HealthCheck(ctx context.Context) error {
 	if o.client == nil {
@@ -132,6 +143,29 @@ func (o *OpenRouterAgent) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// TakeToolSteps returns the tool calls from the last response and clears them
+func (o *OpenRouterAgent) TakeToolSteps() []agent.Message {
+	steps := o.toolSteps
+	o.toolSteps = nil
+	return steps
+}
+
+// SupportsStreaming reports whether StreamMessage can stand in for SendMessage.
+// Streamed responses carry no tool calls, so agents with tools don't stream.
+func (o *OpenRouterAgent) SupportsStreaming() bool {
+	return len(o.Config.Tools) == 0
+}
+
+// LastFinishReason returns the provider's finish reason for the last response
+func (o *OpenRouterAgent) LastFinishReason() string {
+	return o.finishReason
+}
+
+// LastReasoning returns the model's separate reasoning for the last response
+func (o *OpenRouterAgent) LastReasoning() string {
+	return o.reasoning
+}
+
 // SendMessage sends a message to OpenRouter and returns the response.
 func (o *OpenRouterAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
 	if len(messages) == 0 {
@@ -160,6 +194,12 @@ func (o *OpenRouterAgent) SendMessage(ctx context.Context, messages []agent.Mess
 	if o.Config.MaxTokens > 0 {
 		req.MaxTokens = &o.Config.MaxTokens
 	}
+	o.RecordPrompt(explainRequest(req))
+	applyToolConfig(&req, o.Config)
+	applyResponseFormat(&req, o.Config)
+	o.toolSteps = nil
+	o.finishReason = ""
+	o.reasoning = ""
 
 	// Send request
 	startTime := time.Now()
@@ -181,6 +221,9 @@ func (o *OpenRouterAgent) SendMessage(ctx context.Context, messages []agent.Mess
 	}
 
 	content := resp.Choices[0].Message.Content
+	o.toolSteps = toolCallMessages(&o.BaseAgent, resp.Choices[0].Message.ToolCalls)
+	o.finishReason = resp.Choices[0].FinishReason
+	o.reasoning = strings.TrimSpace(resp.Choices[0].Message.ReasoningText())
 
 	// Log metrics
 	if resp.Usage != nil {
@@ -233,10 +276,16 @@ func (o *OpenRouterAgent) StreamMessage(ctx context.Context, messages []agent.Me
 	if o.Config.MaxTokens > 0 {
 		req.MaxTokens = &o.Config.MaxTokens
 	}
+	o.RecordPrompt(explainRequest(req))
+	applyResponseFormat(&req, o.Config)
+	o.finishReason = ""
+	o.reasoning = ""
 
 	// Send streaming request
 	startTime := time.Now()
-	usage, err := o.client.CreateChatCompletionStream(ctx, req, writer)
+	collector := &reasoningCollector{Writer: writer}
+	usage, err := o.client.CreateChatCompletionStream(ctx, req, collector)
+	o.reasoning = strings.TrimSpace(collector.reasoning.String())
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -269,13 +318,14 @@ func (o *OpenRouterAgent) StreamMessage(ctx context.Context, messages []agent.Me
 
 // buildConversationHistory converts AgentPipe messages to OpenAI API format.
 func (o *OpenRouterAgent) buildConversationHistory(messages []agent.Message) []client.ChatCompletionMessage {
+	messages = fitContextWindow(&o.BaseAgent, messages)
 	apiMessages := make([]client.ChatCompletionMessage, 0)
 
 	// Add system prompt if configured
-	if o.Config.Prompt != "" {
+	if systemPrompt := systemPromptWithMemory(o.Config.Prompt, o.Memory()); systemPrompt != "" {
 		apiMessages = append(apiMessages, client.ChatCompletionMessage{
 			Role:    "system",
-			Content: o.Config.Prompt,
+			Content: systemPrompt,
 		})
 	}
 
@@ -314,7 +364,7 @@ func (o *OpenRouterAgent) buildConversationHistory(messages []agent.Message) []c
 		})
 	}
 
-	return apiMessages
+	return appendPromptSuffix(apiMessages, o.PromptSuffix())
 }
 
 // intPtr returns a pointer to an int value.
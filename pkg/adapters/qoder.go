@@ -109,10 +109,11 @@ func (q *QoderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 	}).Debug("sending message to qodercli")
 
 	// Filter out this agent's own messages
-	relevantMessages := q.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&q.BaseAgent, q.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := q.buildPrompt(relevantMessages, true)
+	q.RecordPrompt(prompt)
 
 	// Build command args for non-interactive mode
 	args := []string{"--print"} // Non-interactive print mode
@@ -144,7 +145,7 @@ func (q *QoderAgent) SendMessage(ctx context.Context, messages []agent.Message)
 				"agent_name": q.Name,
 				"duration":   duration.String(),
 			}).WithError(err).Error("qoder authentication failed")
-			return "", fmt.Errorf("qodercli not authenticated - please run 'qodercli' and use '/login' command")
+			return "", &agent.AuthError{AgentType: "qoder", Hint: "run 'qodercli' and use the '/login' command", Output: outputStr}
 		}
 
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -182,10 +183,11 @@ func (q *QoderAgent) StreamMessage(ctx context.Context, messages []agent.Message
 	}).Debug("starting qoder streaming message")
 
 	// Filter out this agent's own messages
-	relevantMessages := q.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&q.BaseAgent, q.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := q.buildPrompt(relevantMessages, true)
+	q.RecordPrompt(prompt)
 
 	// Build command args for streaming mode
 	args := []string{"--print"}
@@ -267,6 +269,7 @@ func (q *QoderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		prompt.WriteString(q.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, q.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -317,6 +320,7 @@ func (q *QoderAgent) buildPrompt(messages []agent.Message, isInitialSession bool
 		}
 	}
 
+	writePromptSuffix(&prompt, q.PromptSuffix())
 	return prompt.String()
 }
 
@@ -108,10 +108,11 @@ func (q *QwenAgent) SendMessage(ctx context.Context, messages []agent.Message) (
 	}).Debug("sending message to qwen CLI")
 
 	// Filter out this agent's own messages
-	relevantMessages := q.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&q.BaseAgent, q.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := q.buildPrompt(relevantMessages, true)
+	q.RecordPrompt(prompt)
 
 	// Qwen uses -p/--prompt for non-interactive mode
 	args := []string{}
@@ -150,10 +151,11 @@ func (q *QwenAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 	}
 
 	// Filter out this agent's own messages
-	relevantMessages := q.filterRelevantMessages(messages)
+	relevantMessages := fitFramedContextWindow(&q.BaseAgent, q.filterRelevantMessages(messages))
 
 	// Build prompt with structured format
 	prompt := q.buildPrompt(relevantMessages, true)
+	q.RecordPrompt(prompt)
 
 	// Qwen uses -p/--prompt for non-interactive mode
 	// Note: Streaming might not be directly supported, fallback to regular execution
@@ -201,6 +203,7 @@ func (q *QwenAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 		prompt.WriteString(q.Config.Prompt)
 		prompt.WriteString("\n\n")
 	}
+	writeMemorySection(&prompt, q.Memory())
 
 	// PART 2: CONVERSATION CONTEXT
 	if len(messages) > 0 {
@@ -254,6 +257,7 @@ func (q *QwenAgent) buildPrompt(messages []agent.Message, isInitialSession bool)
 		}
 	}
 
+	writePromptSuffix(&prompt, q.PromptSuffix())
 	return prompt.String()
 }
 
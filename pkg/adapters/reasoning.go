@@ -0,0 +1,18 @@
+package adapters
+
+import (
+	"io"
+	"strings"
+)
+
+// reasoningCollector passes a streamed answer through to its writer and keeps the
+// model's separate reasoning, which the client hands over through WriteReasoning
+type reasoningCollector struct {
+	io.Writer
+	reasoning strings.Builder
+}
+
+// WriteReasoning implements client.ReasoningWriter
+func (c *reasoningCollector) WriteReasoning(text string) {
+	c.reasoning.WriteString(text)
+}
@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// CommandRunner executes external CLI commands for adapters.
+// It exists so adapters can be tested with canned output instead of real binaries.
+type CommandRunner interface {
+	// Run executes the command with the given stdin and returns its combined output
+	Run(ctx context.Context, stdin io.Reader, name string, args ...string) ([]byte, error)
+	// Stream starts the command with the given stdin and returns a handle for reading its output
+	Stream(ctx context.Context, stdin io.Reader, name string, args ...string) (StreamProcess, error)
+}
+
+// StreamProcess is a running command whose output is consumed incrementally.
+type StreamProcess interface {
+	// Stdout returns the command's standard output
+	Stdout() io.Reader
+	// Stderr returns the command's standard error
+	Stderr() io.Reader
+	// Wait waits for the command to exit
+	Wait() error
+}
+
+// execRunner is the default CommandRunner backed by os/exec.
+type execRunner struct{}
+
+// Run executes the command via exec.CommandContext and returns its combined output
+func (execRunner) Run(ctx context.Context, stdin io.Reader, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = stdin
+	return cmd.CombinedOutput()
+}
+
+// Stream starts the command via exec.CommandContext with piped stdout and stderr
+func (execRunner) Stream(ctx context.Context, stdin io.Reader, name string, args ...string) (StreamProcess, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = stdin
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	return &execProcess{cmd: cmd, stdout: stdout, stderr: stderr}, nil
+}
+
+// execProcess adapts a started exec.Cmd to StreamProcess
+type execProcess struct {
+	cmd    *exec.Cmd
+	stdout io.Reader
+	stderr io.Reader
+}
+
+func (p *execProcess) Stdout() io.Reader { return p.stdout }
+func (p *execProcess) Stderr() io.Reader { return p.stderr }
+func (p *execProcess) Wait() error       { return p.cmd.Wait() }
+
+// exitCode returns the process exit code carried by err, if any.
+// Works with *exec.ExitError as well as test doubles exposing ExitCode().
+func exitCode(err error) (int, bool) {
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode(), true
+	}
+	return 0, false
+}
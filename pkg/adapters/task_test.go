@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+// promptRecordingClaude builds the prompt the Claude CLI would receive instead of running it
+type promptRecordingClaude struct {
+	*ClaudeAgent
+	prompts []string
+}
+
+func (p *promptRecordingClaude) IsAvailable() bool { return true }
+
+func (p *promptRecordingClaude) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	p.prompts = append(p.prompts, p.buildPrompt(p.filterRelevantMessages(messages), true))
+	return "Noted.", nil
+}
+
+func newPromptRecordingClaude(id, name string) *promptRecordingClaude {
+	c := NewClaudeAgent().(*ClaudeAgent)
+	c.ID = id
+	c.Name = name
+	c.Type = "claude"
+	return &promptRecordingClaude{ClaudeAgent: c}
+}
+
+// taskSection returns the text of the prompt's "YOUR TASK" section
+func taskSection(t *testing.T, prompt string) string {
+	t.Helper()
+	const header = "YOUR TASK - PLEASE RESPOND TO THIS:\n"
+	start := strings.Index(prompt, header)
+	if start < 0 {
+		t.Fatalf("expected a task section in the prompt:\n%s", prompt)
+	}
+	task := prompt[start+len(header):]
+	if end := strings.Index(task, "CONVERSATION SO FAR:"); end >= 0 {
+		task = task[:end]
+	}
+	return task
+}
+
+func runTaskConversation(t *testing.T, cfg orchestrator.OrchestratorConfig) string {
+	t.Helper()
+	cfg.Mode = orchestrator.ModeRoundRobin
+	cfg.MaxTurns = 1
+	cfg.TurnTimeout = time.Second
+	cfg.ResponseDelay = time.Millisecond
+
+	orch := orchestrator.NewOrchestrator(cfg, io.Discard)
+	claude := newPromptRecordingClaude("claude-1", "Claude")
+	orch.AddAgent(claude)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(claude.prompts) == 0 {
+		t.Fatal("expected the agent to be prompted")
+	}
+	return claude.prompts[0]
+}
+
+func TestStyleGuideIsNotTheTask(t *testing.T) {
+	prompt := runTaskConversation(t, orchestrator.OrchestratorConfig{
+		InitialPrompt: "Should we adopt a monorepo?",
+		StyleGuide:    "Be blunt; no flattery.",
+	})
+
+	task := taskSection(t, prompt)
+	if !strings.Contains(task, "Should we adopt a monorepo?") {
+		t.Errorf("expected the initial prompt to be the task, got %q", task)
+	}
+	if strings.Contains(task, "Be blunt") {
+		t.Errorf("expected the style guide outside the task, got %q", task)
+	}
+	if !strings.Contains(prompt, "Be blunt; no flattery.") {
+		t.Error("expected the style guide to still reach the agent")
+	}
+}
+
+func TestScenarioIsNotTheTask(t *testing.T) {
+	prompt := runTaskConversation(t, orchestrator.OrchestratorConfig{
+		InitialPrompt: "The keeper hears a knock at the door.",
+		Scenario:      "Scenario for all participants.\nLocation: A lighthouse",
+	})
+
+	task := taskSection(t, prompt)
+	if !strings.Contains(task, "The keeper hears a knock at the door.") {
+		t.Errorf("expected the initial prompt to be the task, got %q", task)
+	}
+	if strings.Contains(task, "Location: A lighthouse") {
+		t.Errorf("expected the scenario outside the task, got %q", task)
+	}
+	if !strings.Contains(prompt, "Location: A lighthouse") {
+		t.Error("expected the scenario to still reach the agent")
+	}
+}
@@ -0,0 +1,115 @@
+package adapters
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/client"
+)
+
+// applyToolConfig adds the agent's configured tool schemas and tool choice to a request
+func applyToolConfig(req *client.ChatCompletionRequest, config agent.AgentConfig) {
+	if len(config.Tools) == 0 {
+		return
+	}
+
+	req.Tools = make([]client.Tool, 0, len(config.Tools))
+	for _, tool := range config.Tools {
+		req.Tools = append(req.Tools, client.Tool{
+			Type: "function",
+			Function: client.ToolFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+
+	switch config.ToolChoice {
+	case "":
+	case "auto", "none", "required":
+		req.ToolChoice = config.ToolChoice
+	default:
+		// Any other value names the function the model must call
+		req.ToolChoice = map[string]interface{}{
+			"type":     "function",
+			"function": map[string]string{"name": config.ToolChoice},
+		}
+	}
+}
+
+// structuredOutputSetting is the custom_settings key that sends an agent's response_schema
+// to the provider as response_format, for providers that support structured output
+const structuredOutputSetting = "structured_output"
+
+// applyResponseFormat asks the provider for output matching the agent's response schema,
+// when the agent has one and structured output is enabled in its custom settings
+func applyResponseFormat(req *client.ChatCompletionRequest, config agent.AgentConfig) {
+	if len(config.ResponseSchema) == 0 {
+		return
+	}
+	if enabled, _ := config.CustomSettings[structuredOutputSetting].(bool); !enabled {
+		return
+	}
+	req.ResponseFormat = &client.ResponseFormat{
+		Type: "json_schema",
+		JSONSchema: &client.JSONSchema{
+			Name:   "response",
+			Schema: config.ResponseSchema,
+		},
+	}
+}
+
+// toolCallMessages converts tool calls from an API response into "tool" role messages
+// from the given agent. The call's name, ID and arguments are kept as structured metadata.
+func toolCallMessages(base *agent.BaseAgent, calls []client.ToolCall) []agent.Message {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	steps := make([]agent.Message, 0, len(calls))
+	for _, call := range calls {
+		metadata := map[string]interface{}{
+			"tool_step": "tool_call",
+			"tool_name": call.Function.Name,
+		}
+		if call.ID != "" {
+			metadata["tool_id"] = call.ID
+		}
+
+		args := strings.TrimSpace(call.Function.Arguments)
+		var parsed map[string]interface{}
+		if args != "" && json.Unmarshal([]byte(args), &parsed) == nil {
+			metadata["tool_arguments"] = parsed
+		} else if args != "" {
+			metadata["tool_arguments"] = args
+		}
+
+		content := call.Function.Name
+		if args != "" {
+			content += " " + args
+		}
+
+		steps = append(steps, agent.Message{
+			AgentID:   base.ID,
+			AgentName: base.Name,
+			AgentType: base.Type,
+			Content:   content,
+			Timestamp: time.Now().Unix(),
+			Role:      "tool",
+			Metadata:  metadata,
+		})
+	}
+	return steps
+}
+
+// explainRequest renders the messages of a chat request as indented JSON for explain mode
+func explainRequest(req client.ChatCompletionRequest) string {
+	data, err := json.MarshalIndent(req.Messages, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
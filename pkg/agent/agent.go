@@ -7,7 +7,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
+
+	"github.com/shawkym/agentpipe/pkg/log"
 )
 
 // Message represents a single message in an agent conversation.
@@ -23,10 +27,21 @@ type Message struct {
 	Content string
 	// Timestamp is the Unix timestamp when the message was created
 	Timestamp int64
-	// Role indicates the message type: "agent", "user", or "system"
+	// Role indicates the message type: "agent", "user", "system", or "tool"
+	// (an intermediate tool step that precedes an agent's response)
 	Role string
 	// Metrics contains optional performance and cost metrics for agent responses
 	Metrics *ResponseMetrics
+	// Metadata holds optional annotations for front-ends (e.g., "warmup": true)
+	Metadata map[string]interface{}
+	// Pinned messages are always sent to agents, even when history trimming would drop them
+	Pinned bool
+	// Reasoning is the thinking a reasoning model returned apart from its answer, which
+	// is the Content. It is kept for display and never sent back to agents.
+	Reasoning string
+	// Team restricts the message to that team's private scratchpad; only agents on the
+	// team are sent it. Empty means the public channel everyone sees.
+	Team string
 }
 
 // ResponseMetrics captures performance and cost information for an agent response.
@@ -44,6 +59,16 @@ type ResponseMetrics struct {
 	Model string
 	// Cost is the estimated monetary cost of the API call in USD
 	Cost float64
+	// CumulativeTokens is the conversation's running token total including this response
+	CumulativeTokens int
+	// CumulativeCost is the conversation's running cost in USD including this response
+	CumulativeCost float64
+	// FinishReason is why the model stopped generating (e.g. "stop", "length"), when the
+	// agent reports it (see FinishReasonReporter)
+	FinishReason string
+	// BillingTag attributes the cost to a project or client when costs are aggregated
+	// across runs (empty = untagged)
+	BillingTag string
 }
 
 // AgentConfig defines the configuration for creating and initializing an agent.
@@ -57,6 +82,10 @@ type AgentConfig struct {
 	Name string `yaml:"name"`
 	// Prompt is the system prompt that defines the agent's behavior
 	Prompt string `yaml:"prompt"`
+	// PromptFile is a file holding the system prompt, an alternative to Prompt for long
+	// role descriptions. It is read into Prompt when the config is loaded; a relative
+	// path is resolved against the config file's directory.
+	PromptFile string `yaml:"prompt_file,omitempty"`
 	// Announcement is the message shown when the agent joins
 	Announcement string `yaml:"announcement"`
 	// Model is the specific model to use (e.g., "claude-sonnet-4.5")
@@ -65,6 +94,9 @@ type AgentConfig struct {
 	Temperature float64 `yaml:"temperature"`
 	// MaxTokens limits the length of generated responses
 	MaxTokens int `yaml:"max_tokens"`
+	// ContextWindow overrides the model's context window in tokens, which is otherwise
+	// looked up in the provider registry (0 = look it up)
+	ContextWindow int `yaml:"context_window,omitempty"`
 	// RateLimit is the maximum requests per second for this agent (0 = unlimited)
 	RateLimit float64 `yaml:"rate_limit"`
 	// RateLimitBurst is the maximum burst size for rate limiting (default: 1)
@@ -73,10 +105,58 @@ type AgentConfig struct {
 	CustomSettings map[string]interface{} `yaml:"custom_settings"`
 	// APIKey is an optional API key for API-based agents (overrides env vars)
 	APIKey string `yaml:"api_key"`
+	// APIKeyRef names an API key to resolve from the secrets file or OS keyring
+	// instead of writing it in the config (e.g. "openrouter")
+	APIKeyRef string `yaml:"api_key_ref,omitempty"`
+	// ResolvedAPIKey is the key APIKeyRef resolved to. It is only kept in memory and
+	// never written out with the config, e.g. in saved conversation states.
+	ResolvedAPIKey string `json:"-" yaml:"-"`
 	// APIEndpoint is an optional API endpoint for API-based agents
 	APIEndpoint string `yaml:"api_endpoint"`
 	// Matrix defines optional Matrix (Synapse) user mapping for this agent
 	Matrix MatrixUserConfig `yaml:"matrix"`
+	// MemoryFile is an optional file of long-term notes included in the agent's prompt each turn
+	MemoryFile string `yaml:"memory_file"`
+	// MinTurnsBetweenResponses is how many other messages must follow this agent's
+	// last response before it may respond again in free-form mode (0 = no cooldown)
+	MinTurnsBetweenResponses int `yaml:"min_turns_between_responses"`
+	// Timeout overrides the orchestrator turn timeout for this agent (0 = use turn_timeout)
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxTurns caps how many times this agent may respond in a conversation (0 = no limit)
+	MaxTurns int `yaml:"max_turns,omitempty"`
+	// Team groups the agent with others for team-vs-team conversations; teammates share
+	// a private scratchpad other teams can't see (empty = no team)
+	Team string `yaml:"team,omitempty"`
+	// Avatar is an optional emoji or image URL front-ends show next to the agent's name
+	Avatar string `yaml:"avatar"`
+	// Color is an optional display color (ANSI 256 code like "212" or hex like "#ff87d7")
+	Color string `yaml:"color"`
+	// Tools are function schemas passed to API-based agents that support tool calling (ignored by CLI agents)
+	Tools []ToolSchema `yaml:"tools"`
+	// ToolChoice controls tool use for API-based agents: "auto", "none", "required", or a tool name
+	ToolChoice string `yaml:"tool_choice"`
+	// ResponseSchema is an optional JSON Schema the agent's responses must match, for
+	// structured output. Off-schema responses are retried with a reminder of the schema.
+	ResponseSchema map[string]interface{} `yaml:"response_schema,omitempty"`
+}
+
+// EffectiveAPIKey returns the API key the agent should use: APIKey, or else the key
+// APIKeyRef resolved to
+func (c AgentConfig) EffectiveAPIKey() string {
+	if c.APIKey != "" {
+		return c.APIKey
+	}
+	return c.ResolvedAPIKey
+}
+
+// ToolSchema describes a function an API-based agent's model may call.
+type ToolSchema struct {
+	// Name is the function name the model calls
+	Name string `yaml:"name"`
+	// Description tells the model what the function does
+	Description string `yaml:"description"`
+	// Parameters is the JSON Schema of the function's arguments
+	Parameters map[string]interface{} `yaml:"parameters"`
 }
 
 // MatrixUserConfig defines credentials for a Matrix user account.
@@ -123,6 +203,89 @@ type Agent interface {
 	GetPrompt() string
 }
 
+// ToolStepReporter is implemented by agents that surface intermediate tool steps.
+// The orchestrator stores the steps as "tool" role messages ahead of the agent's
+// response; they are never sent back to agents and don't count as turns.
+type ToolStepReporter interface {
+	// TakeToolSteps returns the tool steps recorded during the last response and clears them
+	TakeToolSteps() []Message
+}
+
+// Streamer is implemented by agents whose StreamMessage can stand in for SendMessage.
+// Agents that don't implement it get buffered responses in streaming mode.
+type Streamer interface {
+	// SupportsStreaming reports whether StreamMessage writes the response incrementally
+	// and returns the same content SendMessage would
+	SupportsStreaming() bool
+}
+
+// FinishReasonReporter is implemented by agents that know why the model stopped
+// generating their last response, as reported by the provider.
+type FinishReasonReporter interface {
+	// LastFinishReason returns the finish reason of the last response, or "" if unknown
+	LastFinishReason() string
+}
+
+// ReasoningReporter is implemented by agents whose model can return its reasoning
+// separately from the final answer.
+type ReasoningReporter interface {
+	// LastReasoning returns the reasoning of the last response, or "" if there was none
+	LastReasoning() string
+}
+
+// PromptSuffixSetter is implemented by agents that append an orchestrator-provided
+// instruction (e.g. "Respond in under 100 words.") to every turn's prompt.
+type PromptSuffixSetter interface {
+	SetPromptSuffix(suffix string)
+}
+
+// PromptUpdater is implemented by agents whose system prompt can be replaced mid-conversation,
+// e.g. to switch an agent to a different persona. The new prompt applies from the next turn.
+type PromptUpdater interface {
+	UpdatePrompt(prompt string)
+}
+
+// PromptExplainer is implemented by agents that can capture the exact prompt they send,
+// for debugging prompt construction (see the run command's --explain flag).
+type PromptExplainer interface {
+	SetExplain(enabled bool)
+	GetLastPrompt() string
+}
+
+// Prewarmer is implemented by agents that can open their connection ahead of the
+// first turn (DNS, TCP and TLS), so that turn isn't slowed down by a cold start.
+type Prewarmer interface {
+	Prewarm(ctx context.Context) error
+}
+
+// Resetter is implemented by agents that keep per-conversation state, such as a
+// server-side thread, so an instance reused for another conversation starts fresh.
+type Resetter interface {
+	Reset()
+}
+
+// MemoryUpdater is implemented by agents that distill long-term notes into their memory
+// file (see AppendMemory). The orchestrator calls UpdateMemory after each
+// successful turn with the agent's final response.
+type MemoryUpdater interface {
+	UpdateMemory(ctx context.Context, response string) error
+}
+
+// ResponseCooldown is implemented by agents with a free-form response cooldown
+// (min_turns_between_responses). Agents without it have no cooldown.
+type ResponseCooldown interface {
+	// GetMinTurnsBetweenResponses returns the free-form response cooldown in messages
+	GetMinTurnsBetweenResponses() int
+}
+
+// Appearance is implemented by agents that carry display metadata for front-ends.
+type Appearance interface {
+	// GetAvatar returns the agent's avatar (emoji or URL), or "" if none is configured
+	GetAvatar() string
+	// GetColor returns the agent's configured display color, or "" if none is configured
+	GetColor() string
+}
+
 // BaseAgent provides a default implementation of common Agent interface methods.
 // Agent implementations can embed BaseAgent to avoid reimplementing basic functionality.
 type BaseAgent struct {
@@ -136,6 +299,14 @@ type BaseAgent struct {
 	Config AgentConfig
 	// Announcement is the custom join message
 	Announcement string
+
+	promptSuffix string
+
+	explain       bool
+	explainLogged bool
+	lastPrompt    string
+
+	contextWarned atomic.Bool
 }
 
 // GetID returns the unique identifier of the agent.
@@ -178,11 +349,104 @@ func (b *BaseAgent) GetRateLimitBurst() int {
 	return 1 // Default burst size
 }
 
+// GetMinTurnsBetweenResponses returns how many other messages must follow the
+// agent's last response before it responds again in free-form mode.
+func (b *BaseAgent) GetMinTurnsBetweenResponses() int {
+	return b.Config.MinTurnsBetweenResponses
+}
+
 // GetPrompt returns the system prompt for the agent.
 func (b *BaseAgent) GetPrompt() string {
 	return b.Config.Prompt
 }
 
+// UpdatePrompt replaces the agent's system prompt; adapters read it when building each turn.
+// Like the rest of the agent, it must not be called while a turn is in progress; the
+// orchestrator applies Orchestrator.SetAgentPrompt updates between turns.
+func (b *BaseAgent) UpdatePrompt(prompt string) {
+	b.Config.Prompt = prompt
+}
+
+// Prewarm does nothing by default; HTTP-based agents override it to open their connection early.
+func (b *BaseAgent) Prewarm(ctx context.Context) error {
+	return nil
+}
+
+// Reset does nothing by default; agents holding per-conversation state override it.
+func (b *BaseAgent) Reset() {}
+
+// GetAvatar returns the agent's configured avatar (emoji or URL), if any.
+func (b *BaseAgent) GetAvatar() string {
+	return b.Config.Avatar
+}
+
+// GetColor returns the agent's configured display color, if any.
+func (b *BaseAgent) GetColor() string {
+	return b.Config.Color
+}
+
+// Memory returns the contents of the agent's memory file.
+// Returns an empty string if no memory file is configured or it does not exist yet.
+func (b *BaseAgent) Memory() string {
+	return ReadMemory(b.Config.MemoryFile)
+}
+
+// SetPromptSuffix sets the instruction appended to every turn's prompt.
+func (b *BaseAgent) SetPromptSuffix(suffix string) {
+	b.promptSuffix = suffix
+}
+
+// PromptSuffix returns the instruction appended to every turn's prompt, if any.
+func (b *BaseAgent) PromptSuffix() string {
+	return b.promptSuffix
+}
+
+// SetExplain turns prompt capture on or off. While on, RecordPrompt keeps each prompt
+// for GetLastPrompt and logs the first one in full.
+func (b *BaseAgent) SetExplain(enabled bool) {
+	b.explain = enabled
+}
+
+// GetLastPrompt returns the most recent prompt recorded while explain mode was on.
+func (b *BaseAgent) GetLastPrompt() string {
+	return b.lastPrompt
+}
+
+// RecordPrompt is called by adapters with the exact prompt they are about to send.
+// It logs a preview of the prompt at debug level (see SetPromptPreviewChars) and,
+// when explain mode is on, keeps the prompt for GetLastPrompt.
+func (b *BaseAgent) RecordPrompt(prompt string) {
+	if maxChars := PromptPreviewChars(); maxChars > 0 {
+		log.WithFields(map[string]interface{}{
+			"agent_name":     b.Name,
+			"agent_type":     b.Type,
+			"prompt_length":  utf8.RuneCountInString(prompt),
+			"prompt_preview": PromptPreview(prompt, maxChars),
+		}).Debug("prompt preview")
+	}
+
+	if !b.explain {
+		return
+	}
+	b.lastPrompt = prompt
+
+	if !b.explainLogged {
+		b.explainLogged = true
+		log.WithFields(map[string]interface{}{
+			"agent_name": b.Name,
+			"agent_type": b.Type,
+			"prompt":     prompt,
+		}).Info("explain: first prompt sent to agent")
+	}
+}
+
+// MarkContextWarned records that the agent was warned about overflowing its context
+// window and reports whether this was the first warning, so adapters can log it once
+// per agent instead of on every turn of a long conversation.
+func (b *BaseAgent) MarkContextWarned() bool {
+	return !b.contextWarned.Swap(true)
+}
+
 // Announce returns the agent's announcement message.
 // If a custom announcement is set, it is returned; otherwise,
 // a default message is generated using the agent's name.
@@ -47,3 +47,52 @@ func TestResponseMetrics(t *testing.T) {
 		t.Errorf("Expected Cost to be 0.001, got %f", metrics.Cost)
 	}
 }
+
+func TestBaseAgentRecordPrompt(t *testing.T) {
+	b := &BaseAgent{Name: "Alice", Type: "claude"}
+
+	b.RecordPrompt("ignored while explain is off")
+	if got := b.GetLastPrompt(); got != "" {
+		t.Errorf("expected no captured prompt by default, got %q", got)
+	}
+
+	b.SetExplain(true)
+	b.RecordPrompt("first prompt")
+	b.RecordPrompt("second prompt")
+	if got := b.GetLastPrompt(); got != "second prompt" {
+		t.Errorf("expected the latest prompt, got %q", got)
+	}
+}
+
+func TestBaseAgentUpdatePrompt(t *testing.T) {
+	b := &BaseAgent{}
+	if err := b.Initialize(AgentConfig{ID: "a-1", Name: "Alice", Type: "claude", Prompt: "You are a supporter."}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updater PromptUpdater = b
+	updater.UpdatePrompt("Now act as a critic.")
+	if got := b.GetPrompt(); got != "Now act as a critic." {
+		t.Errorf("expected the updated prompt, got %q", got)
+	}
+}
+
+func TestBaseAgentResponseCooldown(t *testing.T) {
+	var cooldown ResponseCooldown = &BaseAgent{Config: AgentConfig{MinTurnsBetweenResponses: 3}}
+	if got := cooldown.GetMinTurnsBetweenResponses(); got != 3 {
+		t.Errorf("expected the configured cooldown, got %d", got)
+	}
+}
+
+func TestBaseAgentMarkContextWarned(t *testing.T) {
+	b := &BaseAgent{Name: "Claude"}
+	if !b.MarkContextWarned() {
+		t.Error("expected the first warning to be reported as first")
+	}
+	if b.MarkContextWarned() {
+		t.Error("expected later warnings not to be reported as first")
+	}
+	if other := (&BaseAgent{Name: "Gemini"}); !other.MarkContextWarned() {
+		t.Error("expected each agent to keep its own warned flag")
+	}
+}
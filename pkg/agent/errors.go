@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AuthError indicates that an agent's CLI or API rejected its credentials.
+// Authentication failures will not resolve by retrying, so the orchestrator
+// treats them as non-retryable and reports them immediately.
+type AuthError struct {
+	// AgentType is the type of agent whose CLI failed to authenticate (e.g. "claude")
+	AgentType string
+	// Hint optionally describes how to authenticate (e.g. "run 'copilot' and use '/login'")
+	Hint string
+	// Output holds the raw CLI output that revealed the failure, if any
+	Output string
+}
+
+func (e *AuthError) Error() string {
+	msg := fmt.Sprintf("%s authentication failed - please authenticate the %s CLI", e.AgentType, e.AgentType)
+	if e.Hint != "" {
+		msg += " (" + e.Hint + ")"
+	}
+	return msg
+}
+
+// IsAuthError reports whether err is, or wraps, an AuthError.
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+	return errors.As(err, &authErr)
+}
+
+// authFailurePatterns are lowercase fragments that CLIs print when they are
+// not logged in or were given missing or invalid credentials. They are phrased
+// as errors so that output merely mentioning API keys or authorization (e.g. an
+// agent's reply about auth code) is not mistaken for a failure.
+var authFailurePatterns = []string{
+	"not logged in",
+	"not authenticated",
+	"unauthenticated",
+	"401 unauthorized",
+	"error: unauthorized",
+	"authentication failed",
+	"authentication required",
+	"please log in",
+	"please login",
+	"login required",
+	"invalid credentials",
+	"invalid api key",
+	"invalid api_key",
+	"incorrect api key",
+	"missing api key",
+	"no api key",
+	"api key not found",
+	"api key is missing",
+	"api key not set",
+	"api_key environment variable",
+	"api_key is not set",
+}
+
+// LooksLikeAuthFailure reports whether CLI output from a failed invocation
+// indicates an authentication problem rather than a generic failure.
+func LooksLikeAuthFailure(output string) bool {
+	lower := strings.ToLower(output)
+	for _, pattern := range authFailurePatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
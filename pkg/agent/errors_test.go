@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLooksLikeAuthFailure(t *testing.T) {
+	tests := []struct {
+		output string
+		want   bool
+	}{
+		{"Error: Not logged in. Please run `claude login`", true},
+		{"401 Unauthorized", true},
+		{"Invalid API key provided", true},
+		{"OPENAI_API_KEY environment variable is missing", true},
+		{"status: UNAUTHENTICATED", true},
+		{"Login required to continue", true},
+		{"connection reset by peer", false},
+		{"model not found", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := LooksLikeAuthFailure(tt.output); got != tt.want {
+			t.Errorf("LooksLikeAuthFailure(%q) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestLooksLikeAuthFailureIgnoresMentions(t *testing.T) {
+	tests := []string{
+		"Store the API key in an environment variable rather than in the repo.",
+		"The handler returns 403 when the user is unauthorized to edit the doc.",
+		"Renamed api_key to apiKey in the client config",
+		"Error: rate limit exceeded for this api key's organization, retry later",
+	}
+
+	for _, output := range tests {
+		if LooksLikeAuthFailure(output) {
+			t.Errorf("LooksLikeAuthFailure(%q) = true, want false", output)
+		}
+	}
+}
+
+func TestAuthError(t *testing.T) {
+	err := &AuthError{AgentType: "copilot", Hint: "run 'copilot' and use the '/login' command"}
+	if !strings.Contains(err.Error(), "please authenticate the copilot CLI") {
+		t.Errorf("expected authentication instructions, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "/login") {
+		t.Errorf("expected hint in message, got %q", err.Error())
+	}
+
+	wrapped := fmt.Errorf("turn failed: %w", err)
+	if !IsAuthError(wrapped) {
+		t.Error("expected wrapped AuthError to be detected")
+	}
+	if IsAuthError(fmt.Errorf("timeout")) {
+		t.Error("expected plain error not to be an auth error")
+	}
+}
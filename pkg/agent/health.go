@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DeepHealthCheckPrompt is the trivial prompt sent by DeepHealthCheck
+const DeepHealthCheckPrompt = "This is a connectivity check. Reply with OK and nothing else."
+
+// DefaultDeepHealthCheckTimeout bounds a deep health check when the context has no deadline.
+// It is longer than a CLI --help check because it waits for a real model response.
+const DefaultDeepHealthCheckTimeout = 30 * time.Second
+
+// ErrEmptyHealthResponse is returned by DeepHealthCheck when the agent answers with nothing
+var ErrEmptyHealthResponse = errors.New("agent returned an empty response")
+
+// DeepHealthCheck verifies that an agent can actually answer a prompt, not just that its
+// CLI is installed. It sends DeepHealthCheckPrompt and expects a non-empty reply, which
+// catches authentication and model problems that HealthCheck misses.
+func DeepHealthCheck(ctx context.Context, a Agent) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultDeepHealthCheckTimeout)
+		defer cancel()
+	}
+
+	messages := []Message{{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   DeepHealthCheckPrompt,
+		Timestamp: time.Now().Unix(),
+		Role:      "system",
+	}}
+
+	response, err := a.SendMessage(ctx, messages)
+	if err != nil {
+		return fmt.Errorf("deep health check failed: %w", err)
+	}
+	if strings.TrimSpace(response) == "" {
+		return fmt.Errorf("deep health check failed: %w", ErrEmptyHealthResponse)
+	}
+	return nil
+}
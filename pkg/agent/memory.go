@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadMemory returns the trimmed contents of an agent memory file.
+// A missing path or unreadable file yields an empty memory rather than an error,
+// so agents can start without any notes.
+func ReadMemory(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// AppendMemory appends a single line to an agent memory file, creating it if needed.
+// Newlines within the line are collapsed so each entry stays on one line.
+func AppendMemory(path string, line string) error {
+	if path == "" {
+		return nil
+	}
+
+	line = strings.Join(strings.Fields(line), " ")
+	if line == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open memory file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("failed to write memory file: %w", err)
+	}
+
+	return nil
+}
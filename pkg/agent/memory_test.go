@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadMemory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "memory.md")
+	if err := os.WriteFile(path, []byte("\n- Prefers Go\n- Dislikes YAML\n\n"), 0600); err != nil {
+		t.Fatalf("failed to write memory file: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{name: "existing file", path: path, want: "- Prefers Go\n- Dislikes YAML"},
+		{name: "missing file", path: filepath.Join(dir, "missing.md"), want: ""},
+		{name: "no file configured", path: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReadMemory(tt.path); got != tt.want {
+				t.Errorf("ReadMemory() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.md")
+
+	if err := AppendMemory(path, "first note"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := AppendMemory(path, "second\nnote"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := AppendMemory(path, "   "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := ReadMemory(path); got != "first note\nsecond note" {
+		t.Errorf("unexpected memory contents: %q", got)
+	}
+
+	if err := AppendMemory("", "ignored"); err != nil {
+		t.Errorf("expected no error without a memory file, got %v", err)
+	}
+}
+
+func TestBaseAgentMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.md")
+	if err := os.WriteFile(path, []byte("remember this"), 0600); err != nil {
+		t.Fatalf("failed to write memory file: %v", err)
+	}
+
+	b := &BaseAgent{}
+	if err := b.Initialize(AgentConfig{ID: "a", Name: "A", MemoryFile: path}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := b.Memory(); got != "remember this" {
+		t.Errorf("Memory() = %q, want %q", got, "remember this")
+	}
+
+}
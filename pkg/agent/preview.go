@@ -0,0 +1,50 @@
+package agent
+
+import "sync/atomic"
+
+// DefaultPromptPreviewChars is how many leading characters of an outgoing prompt
+// adapters log at debug level when no other limit is configured.
+const DefaultPromptPreviewChars = 500
+
+var promptPreviewChars atomic.Int64
+
+func init() {
+	promptPreviewChars.Store(DefaultPromptPreviewChars)
+}
+
+// SetPromptPreviewChars sets how many leading characters of outgoing prompts are
+// logged at debug level. 0 restores DefaultPromptPreviewChars and a negative value
+// disables prompt previews.
+func SetPromptPreviewChars(n int) {
+	if n == 0 {
+		n = DefaultPromptPreviewChars
+	}
+	promptPreviewChars.Store(int64(n))
+}
+
+// PromptPreviewChars returns the current prompt preview length; negative means disabled.
+func PromptPreviewChars() int {
+	return int(promptPreviewChars.Load())
+}
+
+// PromptPreview returns at most maxChars leading characters of prompt, followed by
+// "..." when it was cut short. Truncation never splits a multi-byte character.
+// A maxChars of 0 or less returns an empty string.
+func PromptPreview(prompt string, maxChars int) string {
+	if maxChars <= 0 {
+		return ""
+	}
+	if len(prompt) <= maxChars {
+		// Byte length bounds the character count, so no truncation is needed
+		return prompt
+	}
+
+	count := 0
+	for i := range prompt {
+		if count == maxChars {
+			return prompt[:i] + "..."
+		}
+		count++
+	}
+	return prompt
+}
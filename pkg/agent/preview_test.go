@@ -0,0 +1,52 @@
+package agent
+
+import "testing"
+
+func TestPromptPreview(t *testing.T) {
+	tests := []struct {
+		name     string
+		prompt   string
+		maxChars int
+		want     string
+	}{
+		{"short prompt is unchanged", "hello", 10, "hello"},
+		{"exact length is unchanged", "hello", 5, "hello"},
+		{"long prompt is truncated", "hello world", 5, "hello..."},
+		{"multi-byte characters are not split", "héllo wörld", 4, "héll..."},
+		{"multi-byte prompt within limit", "héllo", 5, "héllo"},
+		{"zero disables", "hello", 0, ""},
+		{"negative disables", "hello", -1, ""},
+		{"empty prompt", "", 5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PromptPreview(tt.prompt, tt.maxChars); got != tt.want {
+				t.Errorf("PromptPreview(%q, %d) = %q, want %q", tt.prompt, tt.maxChars, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPromptPreviewChars(t *testing.T) {
+	defer SetPromptPreviewChars(DefaultPromptPreviewChars)
+
+	if got := PromptPreviewChars(); got != DefaultPromptPreviewChars {
+		t.Errorf("expected default of %d, got %d", DefaultPromptPreviewChars, got)
+	}
+
+	SetPromptPreviewChars(120)
+	if got := PromptPreviewChars(); got != 120 {
+		t.Errorf("expected 120, got %d", got)
+	}
+
+	SetPromptPreviewChars(0)
+	if got := PromptPreviewChars(); got != DefaultPromptPreviewChars {
+		t.Errorf("expected 0 to restore the default, got %d", got)
+	}
+
+	SetPromptPreviewChars(-1)
+	if got := PromptPreviewChars(); got >= 0 {
+		t.Errorf("expected a negative value to disable previews, got %d", got)
+	}
+}
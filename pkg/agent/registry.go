@@ -24,7 +24,10 @@ func RegisterFactory(agentType string, factory Factory) {
 	defaultRegistry.factories[agentType] = factory
 }
 
-func CreateAgent(config AgentConfig) (Agent, error) {
+// NewAgent builds and initializes an agent from its type's factory without registering
+// it, for throwaway instances such as health-check probes that must not replace the
+// registered agent with the same ID
+func NewAgent(config AgentConfig) (Agent, error) {
 	defaultRegistry.mu.RLock()
 	factory, ok := defaultRegistry.factories[config.Type]
 	defaultRegistry.mu.RUnlock()
@@ -37,6 +40,22 @@ func CreateAgent(config AgentConfig) (Agent, error) {
 	if err := agent.Initialize(config); err != nil {
 		return nil, fmt.Errorf("failed to initialize agent: %w", err)
 	}
+	return agent, nil
+}
+
+// HasFactory reports whether an adapter is registered for agentType
+func HasFactory(agentType string) bool {
+	defaultRegistry.mu.RLock()
+	defer defaultRegistry.mu.RUnlock()
+	_, ok := defaultRegistry.factories[agentType]
+	return ok
+}
+
+func CreateAgent(config AgentConfig) (Agent, error) {
+	agent, err := NewAgent(config)
+	if err != nil {
+		return nil, err
+	}
 
 	defaultRegistry.mu.Lock()
 	defaultRegistry.agents[config.ID] = agent
@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"context"
+	"io"
+	"testing"
+)
+
+// probeAgent is a minimal Agent for registry tests
+type probeAgent struct {
+	BaseAgent
+}
+
+func (p *probeAgent) IsAvailable() bool                     { return true }
+func (p *probeAgent) HealthCheck(ctx context.Context) error { return nil }
+func (p *probeAgent) GetCLIVersion() string                 { return "1.0.0" }
+func (p *probeAgent) SendMessage(ctx context.Context, messages []Message) (string, error) {
+	return "ok", nil
+}
+func (p *probeAgent) StreamMessage(ctx context.Context, messages []Message, w io.Writer) error {
+	return nil
+}
+
+func TestNewAgentDoesNotRegister(t *testing.T) {
+	RegisterFactory("registry-test", func() Agent { return &probeAgent{} })
+	defer ClearAgents()
+
+	config := AgentConfig{ID: "registry-test-1", Type: "registry-test", Name: "Probe"}
+	registered, err := CreateAgent(config)
+	if err != nil {
+		t.Fatalf("CreateAgent() unexpected error: %v", err)
+	}
+
+	probe, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("NewAgent() unexpected error: %v", err)
+	}
+	if probe == registered || probe.GetName() != "Probe" {
+		t.Errorf("expected a separate, initialized instance, got %v", probe)
+	}
+	if got, _ := GetAgent("registry-test-1"); got != registered {
+		t.Error("expected the registry to keep the agent created with CreateAgent")
+	}
+
+	if _, err := NewAgent(AgentConfig{Type: "no-such-type"}); err == nil {
+		t.Error("expected an unknown type to fail")
+	}
+}
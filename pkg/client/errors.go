@@ -0,0 +1,48 @@
+package client
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// rateLimitPatterns are lowercase fragments that providers and agent CLIs use to
+// report rate limiting or exhausted quotas.
+var rateLimitPatterns = []string{
+	"rate limit",
+	"rate-limit",
+	"ratelimit",
+	"rate_limit",
+	"too many requests",
+	"resource_exhausted",
+	"resource exhausted",
+	"quota exceeded",
+	"exceeded your current quota",
+	"usage limit",
+	"throttl",
+}
+
+// statusTooManyRequests matches an HTTP 429 status in error text, e.g. "HTTP 429" or "status code: 429"
+var statusTooManyRequests = regexp.MustCompile(`\b(http|status|code|error)\W{0,3}429\b`)
+
+// IsRateLimitError reports whether err indicates that a provider rate-limited the
+// request: an APIError with status 429, or an error whose message carries a known
+// rate-limit phrase or a 429 status (as printed by agent CLIs).
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == 429 {
+		return true
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range rateLimitPatterns {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return statusTooManyRequests.MatchString(msg)
+}
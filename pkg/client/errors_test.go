@@ -0,0 +1,40 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsRateLimitError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"api error 429", &APIError{StatusCode: 429, Message: "slow down"}, true},
+		{"wrapped api error 429", fmt.Errorf("request failed: %w", &APIError{StatusCode: 429}), true},
+		{"api error 500", &APIError{StatusCode: 500, Message: "internal error"}, false},
+		{"openai", errors.New("Rate limit reached for gpt-4o in organization org-123 on tokens per min"), true},
+		{"openai code", errors.New(`{"error":{"code":"rate_limit_exceeded"}}`), true},
+		{"anthropic", errors.New(`{"type":"error","error":{"type":"rate_limit_error","message":"Number of requests has exceeded your rate limit"}}`), true},
+		{"gemini", errors.New("Error 429: RESOURCE_EXHAUSTED: Quota exceeded for quota metric"), true},
+		{"openai quota", errors.New("You exceeded your current quota, please check your plan and billing details"), true},
+		{"claude cli", errors.New("Claude AI usage limit reached|1735689600"), true},
+		{"http reason phrase", errors.New("429 Too Many Requests"), true},
+		{"cli status", errors.New("request failed with status code: 429"), true},
+		{"bedrock", errors.New("ThrottlingException: Rate exceeded"), true},
+		{"unrelated 429", errors.New("processed 429 tokens"), false},
+		{"timeout", errors.New("context deadline exceeded"), false},
+		{"auth", errors.New("HTTP 401: invalid api key"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRateLimitError(tt.err); got != tt.want {
+				t.Errorf("IsRateLimitError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -45,14 +46,70 @@ type ChatCompletionRequest struct {
 	Temperature *float64                `json:"temperature,omitempty"`
 	MaxTokens   *int                    `json:"max_tokens,omitempty"`
 	Stream      bool                    `json:"stream,omitempty"`
+	// Tools lists the functions the model may call
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice is "auto", "none", "required", or an object naming a specific function
+	ToolChoice interface{} `json:"tool_choice,omitempty"`
+	// ResponseFormat asks the model for structured output matching a JSON schema
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 	// Provider-specific fields
 	Provider map[string]interface{} `json:"provider,omitempty"`
 }
 
 // ChatCompletionMessage represents a message in the conversation.
 type ChatCompletionMessage struct {
-	Role    string `json:"role"`    // "system", "user", or "assistant"
-	Content string `json:"content"` // The message content
+	Role      string     `json:"role"`                 // "system", "user", or "assistant"
+	Content   string     `json:"content"`              // The message content
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"` // Function calls requested by the model
+	// Reasoning models return their thinking apart from the answer, under either name
+	Reasoning        string `json:"reasoning,omitempty"`
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// ReasoningText returns the model's separate reasoning, or "" if it sent none.
+func (m ChatCompletionMessage) ReasoningText() string {
+	if m.ReasoningContent != "" {
+		return m.ReasoningContent
+	}
+	return m.Reasoning
+}
+
+// ResponseFormat is the OpenAI structured output setting, e.g. {"type": "json_schema", ...}.
+type ResponseFormat struct {
+	Type       string      `json:"type"` // "json_schema"
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+// JSONSchema names the schema a structured response must match.
+type JSONSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+
+// Tool describes a function the model may call, in the OpenAI tools format.
+type Tool struct {
+	Type     string       `json:"type"` // Always "function"
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction is the schema of a callable function.
+type ToolFunction struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"` // JSON Schema for the arguments
+}
+
+// ToolCall is a function call requested by the model in a response.
+type ToolCall struct {
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction names the called function and carries its JSON-encoded arguments.
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // ChatCompletionResponse represents the response from the chat completions endpoint.
@@ -119,8 +176,16 @@ type ChatCompletionStreamChoice struct {
 
 // ChatCompletionMessageDelta represents incremental message content in streaming.
 type ChatCompletionMessageDelta struct {
-	Role    string `json:"role,omitempty"`
-	Content string `json:"content,omitempty"`
+	Role             string `json:"role,omitempty"`
+	Content          string `json:"content,omitempty"`
+	Reasoning        string `json:"reasoning,omitempty"`
+	ReasoningContent string `json:"reasoning_content,omitempty"`
+}
+
+// ReasoningWriter is implemented by stream writers that want a reasoning model's
+// thinking, which is streamed apart from the answer and never written as content.
+type ReasoningWriter interface {
+	WriteReasoning(text string)
 }
 
 // CreateChatCompletion sends a non-streaming chat completion request.
@@ -255,32 +320,59 @@ func (c *OpenAICompatClient) prepareStreamRequest(ctx context.Context, req ChatC
 	return httpReq, nil
 }
 
+// maxPendingChunkSize caps how much of a split stream chunk is buffered while
+// waiting for the rest of it.
+const maxPendingChunkSize = 1 << 20
+
 // processStreamResponse reads and processes an SSE stream response.
+// A data payload that is cut off mid-JSON is held and joined with the following
+// lines of the same event, so chunks split oddly by providers aren't lost.
 func (c *OpenAICompatClient) processStreamResponse(body io.Reader, writer io.Writer) (*ChatCompletionUsage, error) {
 	scanner := bufio.NewScanner(body)
 	var usage *ChatCompletionUsage
+	var pending strings.Builder // partial JSON payload awaiting the rest of its event
 
 	for scanner.Scan() {
 		line := scanner.Text()
 
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, ":") {
+		// A blank line ends the event; anything still incomplete is invalid
+		if line == "" {
+			if pending.Len() > 0 {
+				log.WithField("data", pending.String()).Warn("discarding incomplete stream chunk")
+				pending.Reset()
+			}
 			continue
 		}
 
-		// SSE format: "data: {...}"
-		if !strings.HasPrefix(line, "data: ") {
+		// Skip comments
+		if strings.HasPrefix(line, ":") {
 			continue
 		}
 
-		data := strings.TrimPrefix(line, "data: ")
+		// SSE format: "data: {...}"; a pending payload may continue on the next line
+		var data string
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data = strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
+		case pending.Len() > 0:
+			data = line
+		default:
+			continue
+		}
 
 		// OpenAI sends "[DONE]" to signal end of stream
-		if data == "[DONE]" {
+		if pending.Len() == 0 && data == "[DONE]" {
 			break
 		}
 
-		streamUsage, err := c.processStreamChunk(data, writer)
+		pending.WriteString(data)
+		payload := pending.String()
+		if incompleteJSON(payload) && pending.Len() < maxPendingChunkSize {
+			continue
+		}
+		pending.Reset()
+
+		streamUsage, err := c.processStreamChunk(payload, writer)
 		if err != nil {
 			return usage, err
 		}
@@ -295,9 +387,20 @@ func (c *OpenAICompatClient) processStreamResponse(body io.Reader, writer io.Wri
 		return usage, fmt.Errorf("error reading stream: %w", err)
 	}
 
+	if pending.Len() > 0 {
+		log.WithField("data", pending.String()).Warn("stream ended with an incomplete chunk")
+	}
+
 	return usage, nil
 }
 
+// incompleteJSON reports whether data is valid JSON that was cut off before its end.
+func incompleteJSON(data string) bool {
+	var raw json.RawMessage
+	err := json.NewDecoder(strings.NewReader(data)).Decode(&raw)
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
+
 // processStreamChunk parses and processes a single SSE chunk.
 func (c *OpenAICompatClient) processStreamChunk(data string, writer io.Writer) (*ChatCompletionUsage, error) {
 	var chunk ChatCompletionStreamChunk
@@ -306,9 +409,23 @@ func (c *OpenAICompatClient) processStreamChunk(data string, writer io.Writer) (
 		return nil, nil // Non-fatal error, continue processing
 	}
 
+	if len(chunk.Choices) == 0 {
+		return chunk.Usage, nil
+	}
+	delta := chunk.Choices[0].Delta
+
+	// Reasoning goes only to writers that ask for it
+	reasoning := delta.ReasoningContent
+	if reasoning == "" {
+		reasoning = delta.Reasoning
+	}
+	if rw, ok := writer.(ReasoningWriter); ok && reasoning != "" {
+		rw.WriteReasoning(reasoning)
+	}
+
 	// Write delta content to writer
-	if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
-		if _, writeErr := writer.Write([]byte(chunk.Choices[0].Delta.Content)); writeErr != nil {
+	if delta.Content != "" {
+		if _, writeErr := writer.Write([]byte(delta.Content)); writeErr != nil {
 			return nil, fmt.Errorf("failed to write stream content: %w", writeErr)
 		}
 	}
@@ -330,6 +447,27 @@ func (c *OpenAICompatClient) HealthCheck(ctx context.Context) error {
 	return err
 }
 
+// Prewarm opens a connection to the API with a cheap GET of the models list, so the
+// first completion request doesn't pay for DNS and TLS setup. Any HTTP response counts
+// as success; only transport errors are returned.
+func (c *OpenAICompatClient) Prewarm(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create prewarm request: %w", err)
+	}
+	c.setHeaders(httpReq)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("prewarm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection goes back to the pool for the first real turn
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
 // doRequest performs the actual HTTP request for non-streaming completions.
 func (c *OpenAICompatClient) doRequest(
 	ctx context.Context,
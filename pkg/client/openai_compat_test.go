@@ -33,6 +33,35 @@ func TestNewOpenAICompatClient(t *testing.T) {
 	}
 }
 
+func TestPrewarm(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodGet || r.URL.Path != "/models" {
+			t.Errorf("Expected GET /models, got %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-api-key" {
+			t.Errorf("Expected Authorization Bearer test-api-key, got %s", r.Header.Get("Authorization"))
+		}
+		// Endpoints without a models list still warm the connection
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewOpenAICompatClient(server.URL, "test-api-key")
+	if err := client.Prewarm(context.Background()); err != nil {
+		t.Fatalf("Expected any HTTP response to count as success, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 prewarm request, got %d", requests)
+	}
+
+	server.Close()
+	if err := client.Prewarm(context.Background()); err == nil {
+		t.Error("Expected an error when the endpoint is unreachable")
+	}
+}
+
 func TestCreateChatCompletion_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Verify request method and path
@@ -297,6 +326,120 @@ func TestCreateChatCompletionStream_Success(t *testing.T) {
 	}
 }
 
+func TestProcessStreamResponse_SplitChunk(t *testing.T) {
+	client := NewOpenAICompatClient("https://api.example.com/v1", "test-api-key")
+
+	stream := strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+		``,
+		// One chunk split across two lines, with the remainder unprefixed
+		`data: {"choices":[{"index":0,"delta":{"content":", wor`,
+		`ld"}}]}`,
+		``,
+		// One chunk split across two data lines of the same event
+		`data: {"choices":[{"index":0,"delta":{"con`,
+		`data: tent":"!"}}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	var out strings.Builder
+	usage, err := client.processStreamResponse(strings.NewReader(stream), &out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "Hello, world!" {
+		t.Errorf("expected split chunks to be recovered, got %q", out.String())
+	}
+	if usage == nil || usage.TotalTokens != 5 {
+		t.Errorf("expected usage from the recovered chunk, got %+v", usage)
+	}
+}
+
+func TestProcessStreamResponse_DiscardsInvalidChunk(t *testing.T) {
+	client := NewOpenAICompatClient("https://api.example.com/v1", "test-api-key")
+
+	stream := strings.Join([]string{
+		// Truncated chunk whose event ends before it is complete
+		`data: {"choices":[{"index":0,"delta":{"content":"lost`,
+		``,
+		// Clearly invalid chunk
+		`data: {not json}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":"kept"}}]}`,
+		``,
+	}, "\n")
+
+	var out strings.Builder
+	if _, err := client.processStreamResponse(strings.NewReader(stream), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "kept" {
+		t.Errorf("expected only the valid chunk to be written, got %q", out.String())
+	}
+}
+
+// reasoningBuffer collects streamed content and reasoning separately
+type reasoningBuffer struct {
+	strings.Builder
+	reasoning strings.Builder
+}
+
+func (b *reasoningBuffer) WriteReasoning(text string) {
+	b.reasoning.WriteString(text)
+}
+
+func TestProcessStreamResponse_SeparatesReasoning(t *testing.T) {
+	client := NewOpenAICompatClient("https://api.example.com/v1", "test-api-key")
+
+	stream := strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"role":"assistant","reasoning_content":"The user wants "}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"reasoning":"a greeting."}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":"Hello"}}]}`,
+		``,
+		`data: {"choices":[{"index":0,"delta":{"content":" there!"}}]}`,
+		``,
+		`data: [DONE]`,
+		``,
+	}, "\n")
+
+	var out reasoningBuffer
+	if _, err := client.processStreamResponse(strings.NewReader(stream), &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "Hello there!" {
+		t.Errorf("expected only the answer as content, got %q", out.String())
+	}
+	if out.reasoning.String() != "The user wants a greeting." {
+		t.Errorf("expected the reasoning to be collected separately, got %q", out.reasoning.String())
+	}
+
+	// A plain writer gets the answer without the reasoning
+	var plain strings.Builder
+	if _, err := client.processStreamResponse(strings.NewReader(stream), &plain); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plain.String() != "Hello there!" {
+		t.Errorf("expected the reasoning to be left out of plain writers, got %q", plain.String())
+	}
+}
+
+func TestChatCompletionMessageReasoningText(t *testing.T) {
+	var msg ChatCompletionMessage
+	if err := json.Unmarshal([]byte(`{"role":"assistant","content":"42","reasoning_content":"6 times 7"}`), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Content != "42" || msg.ReasoningText() != "6 times 7" {
+		t.Errorf("expected separate content and reasoning, got %q / %q", msg.Content, msg.ReasoningText())
+	}
+	if got := (ChatCompletionMessage{Reasoning: "via reasoning"}).ReasoningText(); got != "via reasoning" {
+		t.Errorf("expected the reasoning field to be used, got %q", got)
+	}
+}
+
 func TestShouldRetry(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -435,3 +578,65 @@ func TestCreateChatCompletion_ContextCancellation(t *testing.T) {
 		t.Errorf("Expected context error, got: %v", err)
 	}
 }
+
+func TestChatCompletionRequest_ToolsSerialization(t *testing.T) {
+	req := ChatCompletionRequest{
+		Model:    "gpt-4o",
+		Messages: []ChatCompletionMessage{{Role: "user", Content: "Weather in Paris?"}},
+		Tools: []Tool{{
+			Type: "function",
+			Function: ToolFunction{
+				Name:        "get_weather",
+				Description: "Look up the weather",
+				Parameters: map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"city": map[string]interface{}{"type": "string"}},
+				},
+			},
+		}},
+		ToolChoice: "auto",
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+	for _, want := range []string{
+		`"tools":[{"type":"function","function":{"name":"get_weather","description":"Look up the weather","parameters":{`,
+		`"tool_choice":"auto"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("expected request JSON to contain %s, got %s", want, body)
+		}
+	}
+
+	// Requests without tools keep the fields out of the payload
+	plain, _ := json.Marshal(ChatCompletionRequest{Model: "gpt-4o"})
+	if strings.Contains(string(plain), "tool") {
+		t.Errorf("expected no tool fields without tools, got %s", plain)
+	}
+}
+
+func TestCreateChatCompletion_ToolCalls(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"id":"chatcmpl-tools","choices":[{"index":0,"finish_reason":"tool_calls",
+			"message":{"role":"assistant","content":null,"tool_calls":[
+				{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]}}]}`)
+	}))
+	defer server.Close()
+
+	client := NewOpenAICompatClient(server.URL, "test-api-key")
+	resp, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{Model: "gpt-4o"})
+	if err != nil {
+		t.Fatalf("CreateChatCompletion failed: %v", err)
+	}
+
+	calls := resp.Choices[0].Message.ToolCalls
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(calls))
+	}
+	if calls[0].ID != "call_1" || calls[0].Function.Name != "get_weather" || calls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("unexpected tool call: %+v", calls[0])
+	}
+}
@@ -6,6 +6,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -28,6 +30,26 @@ type Config struct {
 	Bridge BridgeConfig `yaml:"bridge"`
 	// Matrix defines Matrix (Synapse) room integration settings
 	Matrix MatrixConfig `yaml:"matrix"`
+	// TUI defines terminal UI display settings
+	TUI TUIConfig `yaml:"tui,omitempty"`
+	// Middleware lists registered middleware names to apply to messages, in order
+	Middleware []string `yaml:"middleware,omitempty"`
+	// ContentValidation rejects agent responses that break length or substring rules
+	ContentValidation ContentValidationConfig `yaml:"content_validation,omitempty"`
+	// StripPreamble removes boilerplate opening sentences ("As an AI language model, ...")
+	// from agent responses
+	StripPreamble StripPreambleConfig `yaml:"strip_preamble,omitempty"`
+	// DefaultModels maps an agent type to the model used by agents of that type that don't set one
+	DefaultModels map[string]string `yaml:"default_models,omitempty"`
+	// StyleGuide describes the tone and style expected of every agent. It is posted once,
+	// after the initial prompt, and kept in every agent's history.
+	StyleGuide string `yaml:"style_guide,omitempty"`
+	// Scenario describes a shared setting for role-play conversations. It is composed into
+	// a single system message posted after the initial prompt (see ScenarioConfig.Message).
+	Scenario ScenarioConfig `yaml:"scenario,omitempty"`
+	// Template is the path of a conversation template file (see Template) supplying the
+	// initial prompt and agent prompts. Relative paths are resolved against the config file.
+	Template string `yaml:"template,omitempty"`
 }
 
 // OrchestratorConfig defines how the orchestrator manages conversations.
@@ -36,14 +58,82 @@ type OrchestratorConfig struct {
 	Mode string `yaml:"mode"`
 	// MaxTurns is the maximum number of conversation turns (0 = unlimited)
 	MaxTurns int `yaml:"max_turns"`
+	// WarmupTurns is the number of initial turns that don't count toward MaxTurns
+	WarmupTurns int `yaml:"warmup_turns"`
 	// TurnTimeout is the maximum time an agent has to respond
 	TurnTimeout time.Duration `yaml:"turn_timeout"`
 	// ResponseDelay is the pause between agent responses
 	ResponseDelay time.Duration `yaml:"response_delay"`
+	// StartupDelay is a one-time pause after the initial prompt, before the first agent turn
+	StartupDelay time.Duration `yaml:"startup_delay,omitempty"`
 	// InitialPrompt is an optional starting prompt for the conversation
 	InitialPrompt string `yaml:"initial_prompt"`
+	// KickoffPrompt is a one-time directive given only to the agent taking the first turn
+	KickoffPrompt string `yaml:"kickoff_prompt,omitempty"`
+	// RoleReminderInterval privately reminds each agent of its own prompt every N turns (0 = off)
+	RoleReminderInterval int `yaml:"role_reminder_interval,omitempty"`
+	// DevilsAdvocateInterval asks the next speaker to challenge the prevailing view every N turns (0 = off)
+	DevilsAdvocateInterval int `yaml:"devils_advocate_interval,omitempty"`
+	// ClosingRound gives every agent a final closing statement once max_turns is reached
+	ClosingRound bool `yaml:"closing_round,omitempty"`
+	// PromptSuffix is appended to every agent's per-turn instruction
+	PromptSuffix string `yaml:"prompt_suffix,omitempty"`
+	// SuppressAnnouncements skips the "X has joined the conversation" message for each agent
+	SuppressAnnouncements bool `yaml:"suppress_announcements,omitempty"`
+	// Explain logs the full prompt each agent receives on its first turn
+	Explain bool `yaml:"explain,omitempty"`
+	// Prewarm opens each agent's connection before the first turn (HTTP-based agents only)
+	Prewarm bool `yaml:"prewarm,omitempty"`
+	// RenderWidth wraps agent responses in console output to this many columns (0 = no wrap)
+	RenderWidth int `yaml:"render_width,omitempty"`
+	// StreamResponses shows replies from agents that support streaming (api, openrouter) as they are generated
+	StreamResponses bool `yaml:"stream_responses,omitempty"`
+	// RetryableErrors limits retries to these error types ("timeout", "rate_limit", "5xx") or message substrings; auth failures are never retried
+	RetryableErrors []string `yaml:"retryable_errors,omitempty"`
+	// MaxTotalRetries caps retry attempts across the whole conversation (0 = unlimited)
+	MaxTotalRetries int `yaml:"max_total_retries,omitempty"`
+	// RetriesDisabled gives every agent response a single attempt, with no retries
+	RetriesDisabled bool `yaml:"retries_disabled,omitempty"`
+	// DriftCheckEnabled injects a refocus directive when the conversation drifts from the initial prompt
+	DriftCheckEnabled bool `yaml:"drift_check_enabled,omitempty"`
+	// DriftCheckInterval is the number of agent turns between drift checks (default: 5)
+	DriftCheckInterval int `yaml:"drift_check_interval,omitempty"`
+	// DriftThreshold is the drift score (0-1) that triggers a refocus directive (default: 0.8)
+	DriftThreshold float64 `yaml:"drift_threshold,omitempty"`
+	// CollapseCheckEnabled warns when agents keep giving near-identical responses and
+	// reports such pairs in the session summary
+	CollapseCheckEnabled bool `yaml:"collapse_check_enabled,omitempty"`
+	// CollapseThreshold is the mean response similarity (0-1) reported as mode collapse (default: 0.8)
+	CollapseThreshold float64 `yaml:"collapse_threshold,omitempty"`
+	// MaxConsecutiveFailures aborts the conversation after this many failed responses in a row (0 = disabled)
+	MaxConsecutiveFailures int `yaml:"max_consecutive_failures,omitempty"`
+	// MaxEmptyPasses ends a free-form conversation after this many passes with no successful response (default: 3)
+	MaxEmptyPasses int `yaml:"max_empty_passes,omitempty"`
+	// RejectSchemaMismatch fails the turn of an agent whose response still breaks its
+	// response_schema after retries, instead of keeping the response flagged
+	RejectSchemaMismatch bool `yaml:"reject_schema_mismatch,omitempty"`
+	// MaxHistoryMessages limits how many messages are sent to an agent each turn (0 = unlimited)
+	MaxHistoryMessages int `yaml:"max_history_messages,omitempty"`
+	// TrimStrategy decides which messages are dropped past MaxHistoryMessages: "oldest" (default) or "importance"
+	TrimStrategy string `yaml:"trim_strategy,omitempty"`
+	// SelectionStrategy picks the next speaker in reactive mode:
+	// "random" (default), "round-robin", "weighted", "least-recent" or "mention"
+	SelectionStrategy string `yaml:"selection_strategy,omitempty"`
+	// SelectionWeights maps agent IDs to relative weights for the "weighted" strategy (default weight: 1)
+	SelectionWeights map[string]float64 `yaml:"selection_weights,omitempty"`
+	// MentionRouting gives the next reactive or free-form turn to an agent addressed as "@Name" or "Name, ..."
+	MentionRouting bool `yaml:"mention_routing,omitempty"`
 	// Summary defines conversation summary generation settings
 	Summary SummaryConfig `yaml:"summary"`
+	// Referee defines optional per-response scoring by a referee agent
+	Referee RefereeConfig `yaml:"referee,omitempty"`
+	// Voting defines an optional end-of-conversation vote for the most convincing participant
+	Voting VotingConfig `yaml:"voting,omitempty"`
+	// BillingTag is recorded with every response's cost so spending can be attributed to
+	// a project when aggregating across runs (e.g. in a SQLite export)
+	BillingTag string `yaml:"billing_tag,omitempty"`
+	// EndConditions end the conversation early when any of them is met
+	EndConditions EndConditionsConfig `yaml:"end_conditions,omitempty"`
 }
 
 // SummaryConfig defines conversation summary generation behavior.
@@ -52,6 +142,37 @@ type SummaryConfig struct {
 	Enabled bool `yaml:"enabled"`
 	// Agent is the agent type to use for summary generation (default: "gemini")
 	Agent string `yaml:"agent"`
+	// ReuseAgent uses a conversation agent matching Agent (by type, name or ID)
+	// instead of creating a fresh summary agent
+	ReuseAgent bool `yaml:"reuse_agent,omitempty"`
+	// Timeout bounds summary generation, including after the conversation is cancelled (default: 30s)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// RefereeConfig defines referee scoring behavior. When enabled, a referee agent scores
+// every agent response from 0 to 10; each scoring is an extra model call, so it is opt-in.
+type RefereeConfig struct {
+	// Enabled turns on referee scoring (default: false)
+	Enabled bool `yaml:"enabled"`
+	// Agent is the agent type to use as referee
+	Agent string `yaml:"agent"`
+	// ReuseAgent uses a conversation agent matching Agent (by type, name or ID)
+	// instead of creating a fresh referee agent
+	ReuseAgent bool `yaml:"reuse_agent,omitempty"`
+	// Timeout bounds each scoring call (default: 30s)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// VotingConfig defines the end-of-conversation vote. When enabled, every agent votes for
+// the most convincing other participant, or a single judge agent votes when Judge is set.
+type VotingConfig struct {
+	// Enabled turns on the vote once a conversation completes (default: false)
+	Enabled bool `yaml:"enabled"`
+	// Judge is an agent type, or the name or ID of a conversation agent, that casts the
+	// only vote instead of the participants
+	Judge string `yaml:"judge,omitempty"`
+	// Timeout bounds each ballot (default: 30s)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
 }
 
 // LoggingConfig defines conversation logging behavior.
@@ -64,6 +185,165 @@ type LoggingConfig struct {
 	LogFormat string `yaml:"log_format"`
 	// ShowMetrics determines if token/cost metrics are logged
 	ShowMetrics bool `yaml:"show_metrics"`
+	// PerAgentLogs also writes each agent's messages to its own <agent>.log file
+	PerAgentLogs bool `yaml:"per_agent_logs,omitempty"`
+	// PromptPreviewChars is how many leading characters of each outgoing prompt adapters
+	// log at debug level (0 uses the default of 500, negative disables previews)
+	PromptPreviewChars int `yaml:"prompt_preview_chars,omitempty"`
+}
+
+// TUIConfig defines terminal UI display settings.
+type TUIConfig struct {
+	// TimeFormat is a Go reference-time layout for message timestamps, or "relative"
+	// for ages such as "3s ago" (default: "15:04:05")
+	TimeFormat string `yaml:"time_format,omitempty"`
+}
+
+// EndConditionsConfig lists conditions that end a conversation as soon as any one of
+// them is met, alongside max_turns. Zero values disable a condition.
+type EndConditionsConfig struct {
+	// TokenBudget ends the conversation once agent responses have used this many tokens
+	TokenBudget int `yaml:"token_budget,omitempty"`
+	// StopPhrases end the conversation when an agent response contains one of them (case-insensitive)
+	StopPhrases []string `yaml:"stop_phrases,omitempty"`
+	// IdleTimeout ends the conversation when no agent has responded successfully for this long
+	IdleTimeout time.Duration `yaml:"idle_timeout,omitempty"`
+	// ConsensusPhrase ends the conversation once every agent's latest response contains it
+	// (case-insensitive), e.g. "I agree"
+	ConsensusPhrase string `yaml:"consensus_phrase,omitempty"`
+}
+
+// ContentValidationConfig holds rules every agent response must satisfy.
+// A response that breaks a rule fails the agent's turn. Zero values disable a rule.
+type ContentValidationConfig struct {
+	// MinLength is the minimum response length in characters
+	MinLength int `yaml:"min_length,omitempty"`
+	// MaxLength is the maximum response length in characters
+	MaxLength int `yaml:"max_length,omitempty"`
+	// Required lists substrings every response must contain (case-insensitive)
+	Required []string `yaml:"required,omitempty"`
+	// Forbidden lists substrings no response may contain (case-insensitive)
+	Forbidden []string `yaml:"forbidden,omitempty"`
+}
+
+// Enabled reports whether any content validation rule is set.
+func (c ContentValidationConfig) Enabled() bool {
+	return c.MinLength > 0 || c.MaxLength > 0 || len(c.Required) > 0 || len(c.Forbidden) > 0
+}
+
+// StripPreambleConfig configures removal of a boilerplate opening sentence from agent
+// responses. Only a single leading sentence that starts with one of the phrases is removed.
+type StripPreambleConfig struct {
+	// Enabled turns preamble stripping on
+	Enabled bool `yaml:"enabled"`
+	// Phrases are the openers to strip, matched case-insensitively at the start of a
+	// response (default: middleware.DefaultPreamblePhrases)
+	Phrases []string `yaml:"phrases,omitempty"`
+}
+
+// ScenarioConfig is the common ground shared by every agent in a narrative or role-play
+// conversation, where each agent plays a character. Empty fields are left out.
+type ScenarioConfig struct {
+	// Location is where the scene takes place
+	Location string `yaml:"location,omitempty"`
+	// Situation is what is happening as the conversation opens
+	Situation string `yaml:"situation,omitempty"`
+	// Constraints are rules of the setting every character must respect
+	Constraints []string `yaml:"constraints,omitempty"`
+}
+
+// Message composes the scenario into a single system message, or returns "" when no
+// field is set.
+func (s ScenarioConfig) Message() string {
+	if s.Location == "" && s.Situation == "" && len(s.Constraints) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("Scenario for all participants. Stay in character within this shared setting.")
+	if s.Location != "" {
+		b.WriteString("\nLocation: " + s.Location)
+	}
+	if s.Situation != "" {
+		b.WriteString("\nSituation: " + s.Situation)
+	}
+	if len(s.Constraints) > 0 {
+		b.WriteString("\nConstraints:")
+		for _, c := range s.Constraints {
+			b.WriteString("\n- " + c)
+		}
+	}
+	return b.String()
+}
+
+const (
+	// DefaultTimeFormat is the default TUI timestamp layout
+	DefaultTimeFormat = "15:04:05"
+	// RelativeTimeFormat shows message ages ("3s ago") instead of clock times
+	RelativeTimeFormat = "relative"
+)
+
+// AgentTimeouts returns the per-agent timeout overrides keyed by agent ID.
+// Agents without an override are omitted.
+func (c *Config) AgentTimeouts() map[string]time.Duration {
+	timeouts := make(map[string]time.Duration)
+	for _, a := range c.Agents {
+		if a.Timeout > 0 {
+			timeouts[a.ID] = a.Timeout
+		}
+	}
+	return timeouts
+}
+
+// AgentMaxTurns returns the per-agent turn quotas keyed by agent ID.
+// Agents without a quota are omitted.
+func (c *Config) AgentMaxTurns() map[string]int {
+	quotas := make(map[string]int)
+	for _, a := range c.Agents {
+		if a.MaxTurns > 0 {
+			quotas[a.ID] = a.MaxTurns
+		}
+	}
+	return quotas
+}
+
+// AgentTeams returns the agents' teams keyed by agent ID. Agents without a team are omitted.
+func (c *Config) AgentTeams() map[string]string {
+	teams := make(map[string]string)
+	for _, a := range c.Agents {
+		if a.Team != "" {
+			teams[a.ID] = a.Team
+		}
+	}
+	return teams
+}
+
+// AgentResponseSchemas returns the agents' response schemas keyed by agent ID.
+// Agents without a schema are omitted.
+func (c *Config) AgentResponseSchemas() map[string]map[string]interface{} {
+	schemas := make(map[string]map[string]interface{})
+	for _, a := range c.Agents {
+		if len(a.ResponseSchema) > 0 {
+			schemas[a.ID] = a.ResponseSchema
+		}
+	}
+	return schemas
+}
+
+// ValidateTimeFormat rejects layouts that contain no Go reference-time elements,
+// such as "HH:MM:SS", which would render every timestamp as the literal layout.
+func ValidateTimeFormat(layout string) error {
+	if layout == "" || layout == RelativeTimeFormat {
+		return nil
+	}
+
+	// Any real layout element changes when formatting a time other than the reference time
+	sample := time.Date(2001, time.March, 4, 7, 8, 9, 0, time.UTC)
+	if sample.Format(layout) == layout {
+		return fmt.Errorf("invalid tui.time_format %q: use a Go reference-time layout such as %q, or %q",
+			layout, DefaultTimeFormat, RelativeTimeFormat)
+	}
+	return nil
 }
 
 // BridgeConfig defines streaming bridge configuration for real-time conversation updates.
@@ -80,6 +360,10 @@ type BridgeConfig struct {
 	RetryAttempts int `yaml:"retry_attempts"`
 	// LogLevel is the logging level for bridge operations: "debug", "info", "warn", "error" (default: "info")
 	LogLevel string `yaml:"log_level"`
+	// BatchSize buffers events and sends them together once this many are pending (0 or 1 = no batching)
+	BatchSize int `yaml:"batch_size,omitempty"`
+	// FlushIntervalMs is how often a partial batch is flushed in milliseconds (default: 1000)
+	FlushIntervalMs int `yaml:"flush_interval_ms,omitempty"`
 }
 
 // MatrixConfig defines Matrix (Synapse) integration settings.
@@ -154,6 +438,9 @@ func NewDefaultConfig() *Config {
 			RateLimit:      floatPtr(1.0),
 			RateLimitBurst: intPtr(1),
 		},
+		TUI: TUIConfig{
+			TimeFormat: DefaultTimeFormat,
+		},
 	}
 }
 
@@ -171,6 +458,10 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	if err := config.loadPromptFiles(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
@@ -180,6 +471,38 @@ func LoadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
+// loadPromptFiles reads each agent's prompt_file into its prompt, resolving relative
+// paths against baseDir. The file replaces prompt_file, so the loaded config reads as
+// if the prompt had been written inline.
+func (c *Config) loadPromptFiles(baseDir string) error {
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		if a.PromptFile == "" {
+			continue
+		}
+		if a.Prompt != "" {
+			return fmt.Errorf("agent %s sets both prompt and prompt_file; use only one", a.ID)
+		}
+
+		path := a.PromptFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt_file for agent %s: %w", a.ID, err)
+		}
+		prompt := strings.TrimSpace(string(data))
+		if prompt == "" {
+			return fmt.Errorf("prompt_file %s for agent %s is empty", path, a.ID)
+		}
+
+		a.Prompt = prompt
+		a.PromptFile = ""
+	}
+	return nil
+}
+
 // SaveConfig writes the configuration to a YAML file.
 // The file is created with 0600 permissions (read/write for owner only).
 func (c *Config) SaveConfig(path string) error {
@@ -197,13 +520,14 @@ func (c *Config) SaveConfig(path string) error {
 
 // Validate checks the configuration for errors.
 // It ensures at least one agent is configured, all required fields are present,
-// agent IDs are unique, and the orchestration mode is valid.
+// agent IDs and names are unique, and the orchestration mode is valid.
 func (c *Config) Validate() error {
 	if len(c.Agents) == 0 {
 		return fmt.Errorf("at least one agent must be configured")
 	}
 
 	agentIDs := make(map[string]bool)
+	agentNames := make(map[string]bool)
 	for _, agent := range c.Agents {
 		if agent.ID == "" {
 			return fmt.Errorf("agent ID cannot be empty")
@@ -218,15 +542,26 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("duplicate agent ID: %s", agent.ID)
 		}
 		agentIDs[agent.ID] = true
+		// Colors, filters and per-agent stats are keyed on name, so names must be unique too
+		if agentNames[agent.Name] {
+			return fmt.Errorf("duplicate agent name: %s", agent.Name)
+		}
+		agentNames[agent.Name] = true
 
 		if agent.Type == "api" {
 			if agent.APIEndpoint == "" {
 				return fmt.Errorf("api_endpoint is required for api agent %s", agent.ID)
 			}
-			if agent.APIKey == "" {
-				return fmt.Errorf("api_key is required for api agent %s", agent.ID)
+			if agent.APIKey == "" && agent.APIKeyRef == "" {
+				return fmt.Errorf("api_key or api_key_ref is required for api agent %s", agent.ID)
 			}
 		}
+		if agent.MaxTurns < 0 {
+			return fmt.Errorf("invalid max_turns for agent %s: %d (must not be negative)", agent.ID, agent.MaxTurns)
+		}
+		if agent.APIKey != "" && agent.APIKeyRef != "" {
+			return fmt.Errorf("agent %s sets both api_key and api_key_ref; use only one", agent.ID)
+		}
 	}
 
 	validModes := map[string]bool{
@@ -239,6 +574,61 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid orchestrator mode: %s", c.Orchestrator.Mode)
 	}
 
+	if c.Orchestrator.TrimStrategy != "" && c.Orchestrator.TrimStrategy != "oldest" && c.Orchestrator.TrimStrategy != "importance" {
+		return fmt.Errorf("invalid trim_strategy: %s (must be \"oldest\" or \"importance\")", c.Orchestrator.TrimStrategy)
+	}
+
+	validSelectionStrategies := map[string]bool{
+		"random":       true,
+		"round-robin":  true,
+		"weighted":     true,
+		"least-recent": true,
+		"mention":      true,
+	}
+	if c.Orchestrator.SelectionStrategy != "" && !validSelectionStrategies[c.Orchestrator.SelectionStrategy] {
+		return fmt.Errorf("invalid selection_strategy: %s (must be random, round-robin, weighted, least-recent or mention)", c.Orchestrator.SelectionStrategy)
+	}
+	for id, w := range c.Orchestrator.SelectionWeights {
+		if w < 0 {
+			return fmt.Errorf("invalid selection_weights for %s: weight must not be negative", id)
+		}
+	}
+
+	if c.Orchestrator.RenderWidth < 0 {
+		return fmt.Errorf("invalid render_width: %d (must not be negative)", c.Orchestrator.RenderWidth)
+	}
+	if c.Orchestrator.StartupDelay < 0 {
+		return fmt.Errorf("invalid startup_delay: %v (must not be negative)", c.Orchestrator.StartupDelay)
+	}
+
+	ec := c.Orchestrator.EndConditions
+	if ec.TokenBudget < 0 {
+		return fmt.Errorf("invalid end_conditions: token_budget %d must not be negative", ec.TokenBudget)
+	}
+	if ec.IdleTimeout < 0 {
+		return fmt.Errorf("invalid end_conditions: idle_timeout %v must not be negative", ec.IdleTimeout)
+	}
+
+	if c.Orchestrator.MaxTotalRetries < 0 {
+		return fmt.Errorf("invalid max_total_retries: %d (must not be negative)", c.Orchestrator.MaxTotalRetries)
+	}
+
+	if c.Orchestrator.Referee.Enabled && c.Orchestrator.Referee.Agent == "" {
+		return fmt.Errorf("invalid referee: agent is required when referee is enabled")
+	}
+
+	if err := ValidateTimeFormat(c.TUI.TimeFormat); err != nil {
+		return err
+	}
+
+	cv := c.ContentValidation
+	if cv.MinLength < 0 || cv.MaxLength < 0 {
+		return fmt.Errorf("invalid content_validation: lengths must not be negative")
+	}
+	if cv.MaxLength > 0 && cv.MinLength > cv.MaxLength {
+		return fmt.Errorf("invalid content_validation: min_length %d exceeds max_length %d", cv.MinLength, cv.MaxLength)
+	}
+
 	if c.Matrix.Enabled {
 		adminToken := c.Matrix.AdminAccessToken
 		if adminToken == "" {
@@ -279,6 +669,19 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ApplyDefaultModels sets the model of every agent that doesn't specify one
+// from DefaultModels, keyed by agent type. Explicit agent models always win.
+func (c *Config) ApplyDefaultModels() {
+	for i := range c.Agents {
+		if c.Agents[i].Model != "" {
+			continue
+		}
+		if model := c.DefaultModels[c.Agents[i].Type]; model != "" {
+			c.Agents[i].Model = model
+		}
+	}
+}
+
 // nolint:gocyclo // Config defaults are inherently sequential; complexity is acceptable for readability
 func (c *Config) applyDefaults() {
 	if c.Version == "" {
@@ -301,6 +704,8 @@ func (c *Config) applyDefaults() {
 		c.Orchestrator.ResponseDelay = 1 * time.Second
 	}
 
+	c.ApplyDefaultModels()
+
 	// Summary defaults
 	// Note: Enabled defaults to true (opt-out with --no-summary)
 	if c.Orchestrator.Summary.Agent == "" {
@@ -376,6 +781,11 @@ func (c *Config) applyDefaults() {
 		c.Matrix.RateLimitBurst = intPtr(1)
 	}
 
+	// TUI defaults
+	if c.TUI.TimeFormat == "" {
+		c.TUI.TimeFormat = DefaultTimeFormat
+	}
+
 	for i := range c.Agents {
 		// Only apply temperature default if not explicitly set (< 0 means not set)
 		// Allow 0 as a valid temperature for deterministic outputs
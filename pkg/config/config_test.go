@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -62,6 +64,17 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "duplicate agent ID",
 		},
+		{
+			name: "duplicate agent names",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent"},
+					{ID: "agent2", Type: "gemini", Name: "Agent"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "duplicate agent name: Agent",
+		},
 		{
 			name: "invalid mode",
 			config: &Config{
@@ -75,6 +88,126 @@ func TestConfigValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid orchestrator mode",
 		},
+		{
+			name: "invalid trim strategy",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					TrimStrategy: "newest",
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid trim_strategy",
+		},
+		{
+			name: "negative max total retries",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					MaxTotalRetries: -1,
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid max_total_retries",
+		},
+		{
+			name: "negative agent max turns",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1", MaxTurns: -1},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid max_turns for agent agent1",
+		},
+		{
+			name: "negative startup delay",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					StartupDelay: -time.Second,
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid startup_delay",
+		},
+		{
+			name: "negative token budget",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					EndConditions: EndConditionsConfig{TokenBudget: -1},
+				},
+			},
+			wantErr: true,
+			errMsg:  "token_budget -1 must not be negative",
+		},
+		{
+			name: "negative idle timeout",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					EndConditions: EndConditionsConfig{IdleTimeout: -time.Second},
+				},
+			},
+			wantErr: true,
+			errMsg:  "idle_timeout -1s must not be negative",
+		},
+		{
+			name: "referee without agent",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				Orchestrator: OrchestratorConfig{
+					Referee: RefereeConfig{Enabled: true},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid referee",
+		},
+		{
+			name: "invalid tui time format",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				TUI: TUIConfig{TimeFormat: "HH:MM:SS"},
+			},
+			wantErr: true,
+			errMsg:  "invalid tui.time_format",
+		},
+		{
+			name: "relative tui time format",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				TUI: TUIConfig{TimeFormat: "relative"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "content validation min above max",
+			config: &Config{
+				Agents: []agent.AgentConfig{
+					{ID: "agent1", Type: "claude", Name: "Agent 1"},
+				},
+				ContentValidation: ContentValidationConfig{MinLength: 50, MaxLength: 10},
+			},
+			wantErr: true,
+			errMsg:  "min_length 50 exceeds max_length 10",
+		},
 		{
 			name: "valid config",
 			config: &Config{
@@ -103,3 +236,209 @@ func TestConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultModels(t *testing.T) {
+	configContent := `
+default_models:
+  claude: claude-sonnet-4.5
+  gemini: gemini-2.5-pro
+agents:
+  - id: a1
+    type: claude
+    name: Alice
+  - id: a2
+    type: claude
+    name: Bob
+    model: claude-opus-4
+  - id: a3
+    type: gemini
+    name: Carol
+  - id: a4
+    type: codex
+    name: Dave
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	want := map[string]string{
+		"Alice": "claude-sonnet-4.5", // inherits the claude default
+		"Bob":   "claude-opus-4",     // explicit model wins over the default
+		"Carol": "gemini-2.5-pro",
+		"Dave":  "", // no default for codex
+	}
+	for _, a := range cfg.Agents {
+		if a.Model != want[a.Name] {
+			t.Errorf("agent %s: expected model %q, got %q", a.Name, want[a.Name], a.Model)
+		}
+	}
+}
+
+func TestApplyDefaultModelsWithoutDefaults(t *testing.T) {
+	cfg := &Config{Agents: []agent.AgentConfig{{ID: "a1", Type: "claude", Name: "Alice"}}}
+	cfg.ApplyDefaultModels()
+	if cfg.Agents[0].Model != "" {
+		t.Errorf("expected model to stay empty without defaults, got %q", cfg.Agents[0].Model)
+	}
+}
+
+func TestScenarioMessage(t *testing.T) {
+	configContent := `
+scenario:
+  location: A lighthouse on a remote island
+  situation: A storm has cut off the radio
+  constraints:
+    - No one can leave until morning
+    - Magic does not exist
+agents:
+  - id: a1
+    type: claude
+    name: Keeper
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	want := "Scenario for all participants. Stay in character within this shared setting.\n" +
+		"Location: A lighthouse on a remote island\n" +
+		"Situation: A storm has cut off the radio\n" +
+		"Constraints:\n" +
+		"- No one can leave until morning\n" +
+		"- Magic does not exist"
+	if got := cfg.Scenario.Message(); got != want {
+		t.Errorf("Message() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := (ScenarioConfig{Situation: "A heist goes wrong"}).Message(); strings.Contains(got, "Location") || !strings.Contains(got, "Situation: A heist goes wrong") {
+		t.Errorf("expected only the situation to be included, got %q", got)
+	}
+	if got := (ScenarioConfig{}).Message(); got != "" {
+		t.Errorf("expected no message for an empty scenario, got %q", got)
+	}
+}
+
+func TestPromptFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "roles"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "roles", "skeptic.md"), []byte("You are a skeptic.\nQuestion every claim.\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	absPrompt := filepath.Join(t.TempDir(), "optimist.md")
+	if err := os.WriteFile(absPrompt, []byte("You are an optimist."), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	configContent := `
+agents:
+  - id: a1
+    type: claude
+    name: Skeptic
+    prompt_file: roles/skeptic.md
+  - id: a2
+    type: claude
+    name: Optimist
+    prompt_file: ` + absPrompt + `
+  - id: a3
+    type: claude
+    name: Inline
+    prompt: Be brief.
+`
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	want := []string{"You are a skeptic.\nQuestion every claim.", "You are an optimist.", "Be brief."}
+	for i, prompt := range want {
+		if cfg.Agents[i].Prompt != prompt {
+			t.Errorf("agent %s: expected prompt %q, got %q", cfg.Agents[i].ID, prompt, cfg.Agents[i].Prompt)
+		}
+		if cfg.Agents[i].PromptFile != "" {
+			t.Errorf("agent %s: expected prompt_file to be consumed, got %q", cfg.Agents[i].ID, cfg.Agents[i].PromptFile)
+		}
+	}
+
+	// The loaded config must still pass validation when checked again
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("loaded config failed validation: %v", err)
+	}
+}
+
+func TestPromptFileErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "role.md"), []byte("Role"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "blank.md"), []byte(" \n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		agent   string
+		wantErr string
+	}{
+		{name: "prompt and prompt_file", agent: "prompt: Inline\n    prompt_file: role.md", wantErr: "sets both prompt and prompt_file"},
+		{name: "missing file", agent: "prompt_file: missing.md", wantErr: "failed to read prompt_file for agent a1"},
+		{name: "empty file", agent: "prompt_file: blank.md", wantErr: "is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configContent := "agents:\n  - id: a1\n    type: claude\n    name: Alice\n    " + tt.agent + "\n"
+			configPath := filepath.Join(dir, "config.yaml")
+			if err := os.WriteFile(configPath, []byte(configContent), 0600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(configPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAgentResponseSchemas(t *testing.T) {
+	schema := map[string]interface{}{"type": "object"}
+	cfg := &Config{Agents: []agent.AgentConfig{
+		{ID: "judge", ResponseSchema: schema},
+		{ID: "chatter"},
+	}}
+
+	schemas := cfg.AgentResponseSchemas()
+	if len(schemas) != 1 || schemas["judge"]["type"] != "object" {
+		t.Errorf("expected only the judge's schema, got %v", schemas)
+	}
+}
+
+func TestAgentTeams(t *testing.T) {
+	cfg := &Config{Agents: []agent.AgentConfig{
+		{ID: "red-1", Team: "red"},
+		{ID: "blue-1", Team: "blue"},
+		{ID: "referee"},
+	}}
+
+	teams := cfg.AgentTeams()
+	if len(teams) != 2 || teams["red-1"] != "red" || teams["blue-1"] != "blue" {
+		t.Errorf("expected the red and blue agents' teams only, got %v", teams)
+	}
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+const (
+	// keyringService is the service name API keys are stored under in the OS keyring
+	keyringService = "agentpipe"
+	// keyringTimeout bounds a single keyring lookup, which may wait on an unlock prompt
+	keyringTimeout = 10 * time.Second
+)
+
+// errSecretNotFound is returned by keyring lookups for names the keyring doesn't hold
+var errSecretNotFound = errors.New("not found")
+
+// keyringLookup reads a named secret from the OS keyring; replaced in tests
+var keyringLookup = lookupKeyring
+
+// LoadSecretsFile reads a secrets file of key=value lines. Blank lines and lines
+// starting with # are skipped, and values may be wrapped in single or double quotes.
+func LoadSecretsFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open secrets file: %w", err)
+	}
+	defer f.Close()
+
+	secrets := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The line is not included in errors, since it may hold a secret
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid secrets file %s: line %d is not key=value", path, lineNum)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		secrets[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+	return secrets, nil
+}
+
+// ResolveSecrets sets the resolved API key of every agent that names one with
+// api_key_ref, looking the name up in secrets (from LoadSecretsFile, may be nil) and
+// then in the OS keyring. An unresolvable reference is an error. Resolved keys are
+// kept in AgentConfig.ResolvedAPIKey, which is never logged or serialized.
+func (c *Config) ResolveSecrets(secrets map[string]string) error {
+	for i := range c.Agents {
+		a := &c.Agents[i]
+		if a.APIKeyRef == "" {
+			continue
+		}
+
+		source := "secrets file"
+		key, ok := secrets[a.APIKeyRef]
+		if !ok {
+			source = "keyring"
+			var err error
+			key, err = keyringLookup(a.APIKeyRef)
+			if err != nil {
+				where := "the OS keyring"
+				if secrets != nil {
+					where = "the secrets file or the OS keyring"
+				}
+				return fmt.Errorf("api_key_ref %q for agent %s was not found in %s (keyring: %v)",
+					a.APIKeyRef, a.ID, where, err)
+			}
+		}
+		if key == "" {
+			return fmt.Errorf("api_key_ref %q for agent %s resolved to an empty key", a.APIKeyRef, a.ID)
+		}
+		a.ResolvedAPIKey = key
+
+		log.WithFields(map[string]interface{}{
+			"agent_id":    a.ID,
+			"api_key_ref": a.APIKeyRef,
+			"source":      source,
+		}).Debug("resolved agent api key")
+	}
+	return nil
+}
+
+// lookupKeyring reads a secret stored under the agentpipe service in the OS keyring,
+// using the macOS security tool or libsecret's secret-tool elsewhere.
+func lookupKeyring(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), keyringTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", keyringService, "-a", name, "-w")
+	case "windows":
+		return "", fmt.Errorf("OS keyring lookup is not supported on %s", runtime.GOOS)
+	default:
+		cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", keyringService, "account", name)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", errSecretNotFound
+		}
+		return "", fmt.Errorf("failed to run %s: %w", cmd.Args[0], err)
+	}
+	secret := strings.TrimRight(string(out), "\r\n")
+	if secret == "" {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// stubKeyring replaces the OS keyring with entries for the duration of the test
+func stubKeyring(t *testing.T, entries map[string]string) {
+	t.Helper()
+	orig := keyringLookup
+	keyringLookup = func(name string) (string, error) {
+		if secret, ok := entries[name]; ok {
+			return secret, nil
+		}
+		return "", errSecretNotFound
+	}
+	t.Cleanup(func() { keyringLookup = orig })
+}
+
+func TestLoadSecretsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.env")
+	content := "# API keys\n\nopenrouter=sk-or-123\n  openai = \"sk-openai-456\"  \nlocal='with=equals'\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	secrets, err := LoadSecretsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{
+		"openrouter": "sk-or-123",
+		"openai":     "sk-openai-456",
+		"local":      "with=equals",
+	}
+	if len(secrets) != len(want) {
+		t.Fatalf("expected %d secrets, got %d", len(want), len(secrets))
+	}
+	for k, v := range want {
+		if secrets[k] != v {
+			t.Errorf("secret %s = %q, want %q", k, secrets[k], v)
+		}
+	}
+}
+
+func TestLoadSecretsFileInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.env")
+	if err := os.WriteFile(path, []byte("openrouter=sk-or-123\nsk-leaked-value\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadSecretsFile(path)
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected an error naming line 2, got %v", err)
+	}
+	if strings.Contains(err.Error(), "sk-leaked-value") {
+		t.Errorf("error must not include the line's content: %v", err)
+	}
+
+	if _, err := LoadSecretsFile(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("expected an error for a missing secrets file")
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	stubKeyring(t, map[string]string{"openai": "sk-from-keyring", "openrouter": "sk-keyring-loses"})
+
+	cfg := &Config{Agents: []agent.AgentConfig{
+		{ID: "router", Type: "openrouter", APIKeyRef: "openrouter"},
+		{ID: "gpt", Type: "api", APIKeyRef: "openai"},
+		{ID: "inline", Type: "api", APIKey: "sk-inline"},
+		{ID: "cli", Type: "claude"},
+	}}
+
+	if err := cfg.ResolveSecrets(map[string]string{"openrouter": "sk-from-file"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"sk-from-file", "sk-from-keyring", "sk-inline", ""}
+	for i, key := range want {
+		if got := cfg.Agents[i].EffectiveAPIKey(); got != key {
+			t.Errorf("agent %s api key = %q, want %q", cfg.Agents[i].ID, got, key)
+		}
+	}
+	// Resolved keys stay out of the serializable api_key
+	if cfg.Agents[0].APIKey != "" || cfg.Agents[1].APIKey != "" {
+		t.Errorf("expected resolved keys to stay out of APIKey, got %q and %q", cfg.Agents[0].APIKey, cfg.Agents[1].APIKey)
+	}
+}
+
+func TestResolveSecretsMissingReference(t *testing.T) {
+	stubKeyring(t, nil)
+
+	tests := []struct {
+		name    string
+		secrets map[string]string
+		wantMsg string
+	}{
+		{name: "without secrets file", wantMsg: `api_key_ref "openrouter" for agent router was not found in the OS keyring`},
+		{name: "with secrets file", secrets: map[string]string{"other": "sk-other"}, wantMsg: "not found in the secrets file or the OS keyring"},
+		{name: "empty value", secrets: map[string]string{"openrouter": ""}, wantMsg: "resolved to an empty key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Agents: []agent.AgentConfig{{ID: "router", Type: "openrouter", APIKeyRef: "openrouter"}}}
+			err := cfg.ResolveSecrets(tt.secrets)
+			if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantMsg, err)
+			}
+			if strings.Contains(err.Error(), "sk-other") {
+				t.Errorf("error must not include secret values: %v", err)
+			}
+		})
+	}
+}
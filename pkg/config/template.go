@@ -0,0 +1,174 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is a reusable conversation scaffold referenced by a config's template field.
+// Its prompts may contain text/template placeholders such as {{.Topic}}, filled from
+// variables given with the run command's --var flag.
+type Template struct {
+	// InitialPrompt is used when the config doesn't set orchestrator.initial_prompt
+	InitialPrompt string `yaml:"initial_prompt"`
+	// AgentPrompts maps agent IDs to prompts, used for agents that don't set their own
+	AgentPrompts map[string]string `yaml:"agent_prompts,omitempty"`
+}
+
+// LoadTemplate reads a conversation template file.
+func LoadTemplate(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("failed to parse template file: %w", err)
+	}
+	return &t, nil
+}
+
+// ApplyTemplate fills in prompts from the template file named by c.Template, if any,
+// then renders the initial prompt and every agent prompt with vars. A relative template
+// path is resolved against baseDir (usually the config file's directory). Prompts set in
+// the config take precedence over the template's. Nothing is rendered when the config
+// references no template and no vars are given, so literal braces in plain configs are safe.
+func (c *Config) ApplyTemplate(baseDir string, vars map[string]string) error {
+	return c.ApplyTemplateWithPrompt(baseDir, vars, "")
+}
+
+// ApplyTemplateWithPrompt is ApplyTemplate for an initial prompt the user typed, e.g.
+// with --prompt. A non-empty prompt becomes the initial prompt as literal text: it takes
+// precedence over the config's and the template's and is never rendered, so braces in it
+// are kept as written.
+func (c *Config) ApplyTemplateWithPrompt(baseDir string, vars map[string]string, prompt string) error {
+	if prompt != "" {
+		c.Orchestrator.InitialPrompt = prompt
+	}
+
+	if c.Template != "" {
+		path := c.Template
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		t, err := LoadTemplate(path)
+		if err != nil {
+			return err
+		}
+		if c.Orchestrator.InitialPrompt == "" {
+			c.Orchestrator.InitialPrompt = t.InitialPrompt
+		}
+		for i := range c.Agents {
+			if c.Agents[i].Prompt == "" {
+				c.Agents[i].Prompt = t.AgentPrompts[c.Agents[i].ID]
+			}
+		}
+	} else if len(vars) == 0 {
+		return nil
+	}
+
+	if prompt == "" {
+		rendered, err := RenderPrompt("initial_prompt", c.Orchestrator.InitialPrompt, vars)
+		if err != nil {
+			return err
+		}
+		c.Orchestrator.InitialPrompt = rendered
+	}
+
+	for i := range c.Agents {
+		rendered, err := RenderPrompt("prompt for agent "+c.Agents[i].ID, c.Agents[i].Prompt, vars)
+		if err != nil {
+			return err
+		}
+		c.Agents[i].Prompt = rendered
+	}
+	return nil
+}
+
+// RenderPrompt executes text as a text/template with vars as its data. Every variable the
+// template references must be provided; the error lists all missing ones.
+func RenderPrompt(name, text string, vars map[string]string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template in %s: %w", name, err)
+	}
+
+	if missing := missingVariables(tmpl, vars); len(missing) > 0 {
+		return "", fmt.Errorf("missing template variables in %s: %s (set them with --var key=value)",
+			name, strings.Join(missing, ", "))
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// missingVariables returns the sorted names of top-level fields ({{.Name}}) that tmpl
+// references but vars doesn't provide.
+func missingVariables(tmpl *template.Template, vars map[string]string) []string {
+	seen := make(map[string]bool)
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			seen[n.Ident[0]] = true
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		}
+	}
+	walk(tmpl.Tree.Root)
+
+	var missing []string
+	for name := range seen {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		vars    map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "no placeholders", text: "Plain prompt", want: "Plain prompt"},
+		{
+			name: "all variables provided",
+			text: "Discuss {{.Topic}} for {{.Audience}}.",
+			vars: map[string]string{"Topic": "caching", "Audience": "new hires"},
+			want: "Discuss caching for new hires.",
+		},
+		{
+			name: "conditional on a variable",
+			text: "Topic: {{.Topic}}{{if .Extra}} ({{.Extra}}){{end}}",
+			vars: map[string]string{"Topic": "queues", "Extra": "brief"},
+			want: "Topic: queues (brief)",
+		},
+		{
+			name:    "missing variables are all listed",
+			text:    "Discuss {{.Topic}} for {{.Audience}} in {{.Language}}.",
+			vars:    map[string]string{"Topic": "caching"},
+			wantErr: "missing template variables in initial_prompt: Audience, Language",
+		},
+		{name: "invalid template", text: "Discuss {{.Topic", wantErr: "invalid template in initial_prompt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderPrompt("initial_prompt", tt.text, tt.vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderPrompt() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderPrompt() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RenderPrompt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templateYAML := `initial_prompt: "Debate {{.Topic}} for an audience of {{.Audience}}."
+agent_prompts:
+  pro: "Argue for {{.Topic}}."
+  con: "Argue against {{.Topic}}."
+`
+	if err := os.WriteFile(filepath.Join(dir, "debate.yaml"), []byte(templateYAML), 0600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	newConfig := func() *Config {
+		cfg := NewDefaultConfig()
+		cfg.Template = "debate.yaml"
+		cfg.Agents = []agent.AgentConfig{
+			{ID: "pro", Type: "claude", Name: "Pro"},
+			{ID: "con", Type: "gemini", Name: "Con", Prompt: "Play devil's advocate on {{.Topic}}."},
+		}
+		return cfg
+	}
+
+	cfg := newConfig()
+	if err := cfg.ApplyTemplate(dir, map[string]string{"Topic": "tabs", "Audience": "editors"}); err != nil {
+		t.Fatalf("ApplyTemplate() unexpected error: %v", err)
+	}
+	if cfg.Orchestrator.InitialPrompt != "Debate tabs for an audience of editors." {
+		t.Errorf("unexpected initial prompt %q", cfg.Orchestrator.InitialPrompt)
+	}
+	if cfg.Agents[0].Prompt != "Argue for tabs." {
+		t.Errorf("expected template prompt for pro, got %q", cfg.Agents[0].Prompt)
+	}
+	if cfg.Agents[1].Prompt != "Play devil's advocate on tabs." {
+		t.Errorf("expected the config prompt to win for con, got %q", cfg.Agents[1].Prompt)
+	}
+
+	err := newConfig().ApplyTemplate(dir, map[string]string{"Topic": "tabs"})
+	if err == nil || !strings.Contains(err.Error(), "missing template variables in initial_prompt: Audience") {
+		t.Errorf("expected missing variable error, got %v", err)
+	}
+
+	missing := newConfig()
+	missing.Template = "nope.yaml"
+	if err := missing.ApplyTemplate(dir, nil); err == nil {
+		t.Error("expected an error for a missing template file")
+	}
+}
+
+func TestApplyTemplateWithoutTemplateOrVars(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Orchestrator.InitialPrompt = "Explain Go's {{.}} syntax"
+	if err := cfg.ApplyTemplate(".", nil); err != nil {
+		t.Fatalf("ApplyTemplate() unexpected error: %v", err)
+	}
+	if cfg.Orchestrator.InitialPrompt != "Explain Go's {{.}} syntax" {
+		t.Errorf("expected prompt to be left untouched, got %q", cfg.Orchestrator.InitialPrompt)
+	}
+}
+
+func TestApplyTemplateWithPromptKeepsUserTextLiteral(t *testing.T) {
+	dir := t.TempDir()
+	templateYAML := `initial_prompt: "Debate {{.Topic}}."
+agent_prompts:
+  pro: "Argue for {{.Topic}}."
+`
+	if err := os.WriteFile(filepath.Join(dir, "debate.yaml"), []byte(templateYAML), 0600); err != nil {
+		t.Fatalf("failed to write template: %v", err)
+	}
+
+	cfg := NewDefaultConfig()
+	cfg.Template = "debate.yaml"
+	cfg.Agents = []agent.AgentConfig{{ID: "pro", Type: "claude", Name: "Pro"}}
+	prompt := "Explain Go's {{.Field}} and {{ range }} syntax"
+	if err := cfg.ApplyTemplateWithPrompt(dir, map[string]string{"Topic": "tabs"}, prompt); err != nil {
+		t.Fatalf("ApplyTemplateWithPrompt() unexpected error: %v", err)
+	}
+	if cfg.Orchestrator.InitialPrompt != prompt {
+		t.Errorf("expected the user's prompt verbatim, got %q", cfg.Orchestrator.InitialPrompt)
+	}
+	if cfg.Agents[0].Prompt != "Argue for tabs." {
+		t.Errorf("expected the template's agent prompt to still be rendered, got %q", cfg.Agents[0].Prompt)
+	}
+}
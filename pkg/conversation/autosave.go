@@ -0,0 +1,69 @@
+package conversation
+
+import (
+	"context"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// Autosave periodically snapshots a running conversation to a single state file,
+// overwriting it each time, so a crash loses at most one interval of messages.
+type Autosave struct {
+	path     string
+	interval time.Duration
+	snapshot func() *State
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// StartAutosave saves snapshot() to path every interval until ctx is done or Stop
+// is called. Failed saves are logged and retried at the next tick.
+func StartAutosave(ctx context.Context, path string, interval time.Duration, snapshot func() *State) *Autosave {
+	ctx, cancel := context.WithCancel(ctx)
+	a := &Autosave{
+		path:     path,
+		interval: interval,
+		snapshot: snapshot,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+
+	log.WithFields(map[string]interface{}{
+		"path":     path,
+		"interval": interval.String(),
+	}).Info("conversation autosave started")
+
+	go a.run(ctx)
+	return a
+}
+
+// Path returns the file the conversation is autosaved to.
+func (a *Autosave) Path() string {
+	return a.path
+}
+
+// Stop ends autosaving and waits for a save in progress to finish.
+func (a *Autosave) Stop() {
+	a.cancel()
+	<-a.done
+}
+
+func (a *Autosave) run(ctx context.Context) {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			state := a.snapshot()
+			if err := state.Save(a.path); err != nil {
+				log.WithError(err).WithField("path", a.path).Warn("conversation autosave failed")
+			}
+		}
+	}
+}
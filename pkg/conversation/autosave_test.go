@@ -0,0 +1,95 @@
+package conversation
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// TestAutosave tests that snapshots are written at the interval and stop with Stop
+func TestAutosave(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "rolling.json")
+	cfg := config.NewDefaultConfig()
+	startedAt := time.Now()
+
+	var turns atomic.Int32
+	snapshot := func() *State {
+		n := int(turns.Add(1))
+		messages := make([]agent.Message, n)
+		for i := range messages {
+			messages[i] = agent.Message{AgentID: "a", AgentName: "Alice", Content: "hi", Role: "agent"}
+		}
+		return NewState(messages, cfg, startedAt)
+	}
+
+	autosave := StartAutosave(context.Background(), statePath, 20*time.Millisecond, snapshot)
+	if autosave.Path() != statePath {
+		t.Errorf("Expected path %s, got %s", statePath, autosave.Path())
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for turns.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	autosave.Stop()
+
+	saves := turns.Load()
+	if saves < 2 {
+		t.Fatalf("Expected at least 2 snapshots, got %d", saves)
+	}
+
+	// The last snapshot must be a complete, loadable state
+	state, err := LoadState(statePath)
+	if err != nil {
+		t.Fatalf("Autosaved state is not valid: %v", err)
+	}
+	if len(state.Messages) != int(saves) {
+		t.Errorf("Expected the latest snapshot with %d messages, got %d", saves, len(state.Messages))
+	}
+
+	// No temp files are left beside the state file
+	entries, err := os.ReadDir(filepath.Dir(statePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only the state file in the directory, got %d entries", len(entries))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if turns.Load() != saves {
+		t.Error("Expected no snapshots after Stop")
+	}
+}
+
+// TestAutosave_ContextCancelled tests that cancelling the context ends autosaving
+func TestAutosave_ContextCancelled(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "rolling.json")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	autosave := StartAutosave(ctx, statePath, time.Hour, func() *State {
+		return NewState(nil, config.NewDefaultConfig(), time.Now())
+	})
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		autosave.Stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return after the context was cancelled")
+	}
+
+	if _, err := os.Stat(statePath); !os.IsNotExist(err) {
+		t.Error("Expected no snapshot before the first interval")
+	}
+}
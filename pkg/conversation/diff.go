@@ -0,0 +1,105 @@
+package conversation
+
+import (
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// DiffOp identifies how a message appears in a transcript diff.
+type DiffOp int
+
+const (
+	// DiffEqual marks a message present in both transcripts
+	DiffEqual DiffOp = iota
+	// DiffRemoved marks a message only present in the first transcript
+	DiffRemoved
+	// DiffAdded marks a message only present in the second transcript
+	DiffAdded
+)
+
+// DiffLine is a single aligned message in a transcript diff.
+type DiffLine struct {
+	Op      DiffOp
+	Message agent.Message
+}
+
+// TranscriptDiff is the alignment of two conversation transcripts.
+type TranscriptDiff struct {
+	// CommonPrefix is the number of leading messages shared by both transcripts;
+	// it is also the index of the first divergent message
+	CommonPrefix int
+	// Lines holds every message of both transcripts in aligned order
+	Lines []DiffLine
+}
+
+// Identical reports whether the two transcripts contain the same messages.
+func (d TranscriptDiff) Identical() bool {
+	for _, line := range d.Lines {
+		if line.Op != DiffEqual {
+			return false
+		}
+	}
+	return true
+}
+
+// DiffMessages aligns two transcripts using their longest common subsequence.
+// Messages match when they have the same speaker, role and content; timestamps
+// and metrics are ignored since they differ between otherwise identical runs.
+func DiffMessages(a, b []agent.Message) TranscriptDiff {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && sameMessage(a[prefix], b[prefix]) {
+		prefix++
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:], after the shared prefix
+	restA, restB := a[prefix:], b[prefix:]
+	lcs := make([][]int, len(restA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(restB)+1)
+	}
+	for i := len(restA) - 1; i >= 0; i-- {
+		for j := len(restB) - 1; j >= 0; j-- {
+			if sameMessage(restA[i], restB[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := TranscriptDiff{
+		CommonPrefix: prefix,
+		Lines:        make([]DiffLine, 0, len(a)+len(b)-prefix),
+	}
+	for _, msg := range a[:prefix] {
+		diff.Lines = append(diff.Lines, DiffLine{Op: DiffEqual, Message: msg})
+	}
+
+	i, j := 0, 0
+	for i < len(restA) && j < len(restB) {
+		switch {
+		case sameMessage(restA[i], restB[j]):
+			diff.Lines = append(diff.Lines, DiffLine{Op: DiffEqual, Message: restA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff.Lines = append(diff.Lines, DiffLine{Op: DiffRemoved, Message: restA[i]})
+			i++
+		default:
+			diff.Lines = append(diff.Lines, DiffLine{Op: DiffAdded, Message: restB[j]})
+			j++
+		}
+	}
+	for ; i < len(restA); i++ {
+		diff.Lines = append(diff.Lines, DiffLine{Op: DiffRemoved, Message: restA[i]})
+	}
+	for ; j < len(restB); j++ {
+		diff.Lines = append(diff.Lines, DiffLine{Op: DiffAdded, Message: restB[j]})
+	}
+
+	return diff
+}
+
+// sameMessage reports whether two messages match for diffing purposes
+func sameMessage(a, b agent.Message) bool {
+	return a.AgentName == b.AgentName && a.Role == b.Role && a.Content == b.Content
+}
@@ -0,0 +1,86 @@
+package conversation
+
+import (
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func diffMsg(name, content string) agent.Message {
+	return agent.Message{AgentName: name, Role: "agent", Content: content}
+}
+
+// diffOps flattens a diff into op markers for compact comparison
+func diffOps(d TranscriptDiff) string {
+	ops := make([]byte, len(d.Lines))
+	for i, line := range d.Lines {
+		switch line.Op {
+		case DiffEqual:
+			ops[i] = '='
+		case DiffRemoved:
+			ops[i] = '-'
+		case DiffAdded:
+			ops[i] = '+'
+		}
+	}
+	return string(ops)
+}
+
+func TestDiffMessagesIdentical(t *testing.T) {
+	a := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi"), diffMsg("Bob", "hello")}
+	b := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi"), diffMsg("Bob", "hello")}
+	// Timestamps differ between runs but shouldn't affect the diff
+	b[1].Timestamp = 12345
+
+	d := DiffMessages(a, b)
+	if !d.Identical() {
+		t.Errorf("expected identical transcripts, got %s", diffOps(d))
+	}
+	if d.CommonPrefix != 3 {
+		t.Errorf("expected common prefix of 3, got %d", d.CommonPrefix)
+	}
+}
+
+func TestDiffMessagesDivergent(t *testing.T) {
+	a := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi"), diffMsg("Bob", "yes"), diffMsg("Alice", "bye")}
+	b := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi"), diffMsg("Bob", "no"), diffMsg("Alice", "bye")}
+
+	d := DiffMessages(a, b)
+	if d.Identical() {
+		t.Fatal("expected transcripts to differ")
+	}
+	if d.CommonPrefix != 2 {
+		t.Errorf("expected divergence at message 2, got %d", d.CommonPrefix)
+	}
+	if got := diffOps(d); got != "==-+=" {
+		t.Errorf("unexpected alignment %s", got)
+	}
+	if d.Lines[2].Message.Content != "yes" || d.Lines[3].Message.Content != "no" {
+		t.Errorf("expected removed 'yes' then added 'no', got %+v", d.Lines[2:4])
+	}
+}
+
+func TestDiffMessagesDifferentLengths(t *testing.T) {
+	short := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi")}
+	long := []agent.Message{diffMsg("HOST", "topic"), diffMsg("Alice", "hi"), diffMsg("Bob", "more"), diffMsg("Alice", "even more")}
+
+	if got := diffOps(DiffMessages(short, long)); got != "==++" {
+		t.Errorf("expected trailing additions, got %s", got)
+	}
+	if got := diffOps(DiffMessages(long, short)); got != "==--" {
+		t.Errorf("expected trailing removals, got %s", got)
+	}
+	if got := diffOps(DiffMessages(nil, short)); got != "++" {
+		t.Errorf("expected all additions against an empty transcript, got %s", got)
+	}
+
+	// An inserted message in the middle realigns the rest
+	inserted := []agent.Message{diffMsg("HOST", "topic"), diffMsg("User", "wait"), diffMsg("Alice", "hi")}
+	d := DiffMessages(short, inserted)
+	if got := diffOps(d); got != "=+=" {
+		t.Errorf("expected insertion to realign, got %s", got)
+	}
+	if d.CommonPrefix != 1 {
+		t.Errorf("expected common prefix of 1, got %d", d.CommonPrefix)
+	}
+}
@@ -73,7 +73,7 @@ func NewState(messages []agent.Message, cfg *config.Config, startedAt time.Time)
 	}
 }
 
-// Save writes the conversation state to a file.
+// Save writes the conversation state to a file, replacing it atomically.
 // The file is created with 0600 permissions (read/write for owner only).
 func (s *State) Save(path string) error {
 	// Ensure directory exists
@@ -90,8 +90,9 @@ func (s *State) Save(path string) error {
 		return fmt.Errorf("failed to marshal state: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	// Write to a temp file and rename it over path, so a crash mid-write never
+	// leaves a truncated state file behind
+	if err := writeFileAtomic(path, data); err != nil {
 		log.WithError(err).WithField("path", path).Error("failed to write state file")
 		return fmt.Errorf("failed to write state file: %w", err)
 	}
@@ -106,6 +107,31 @@ func (s *State) Save(path string) error {
 	return nil
 }
 
+// writeFileAtomic writes data to a temp file beside path (created with 0600
+// permissions) and renames it into place.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
 // LoadState loads a conversation state from a file.
 func LoadState(path string) (*State, error) {
 	log.WithField("path", path).Debug("loading conversation state")
@@ -146,6 +172,15 @@ func GetDefaultStateDir() (string, error) {
 	return filepath.Join(homeDir, ".agentpipe", "states"), nil
 }
 
+// DefaultStatePath returns a timestamped state file path in the default state directory.
+func DefaultStatePath() (string, error) {
+	stateDir, err := GetDefaultStateDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get state directory: %w", err)
+	}
+	return filepath.Join(stateDir, GenerateStateFileName()), nil
+}
+
 // GenerateStateFileName generates a filename for a conversation state.
 // Format: conversation-YYYYMMDD-HHMMSS.json
 func GenerateStateFileName() string {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -111,6 +112,36 @@ func TestState_Save_CreatesDirectory(t *testing.T) {
 }
 
 // TestLoadState tests loading state from file
+// TestState_SaveOmitsResolvedAPIKeys tests that keys resolved from api_key_ref never reach the state file
+func TestState_SaveOmitsResolvedAPIKeys(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "router", Type: "openrouter", Name: "Router", Model: "openai/gpt-4o", APIKeyRef: "openrouter"},
+	}
+	if err := cfg.ResolveSecrets(map[string]string{"openrouter": "sk-resolved-secret"}); err != nil {
+		t.Fatalf("ResolveSecrets failed: %v", err)
+	}
+
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	if err := NewState(nil, cfg, time.Now()).Save(statePath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("failed to read state file: %v", err)
+	}
+	if strings.Contains(string(data), "sk-resolved-secret") {
+		t.Errorf("state file must not contain the resolved api key:\n%s", data)
+	}
+	if !strings.Contains(string(data), `"APIKeyRef": "openrouter"`) {
+		t.Errorf("expected the key reference to be kept, got:\n%s", data)
+	}
+	if cfg.Agents[0].EffectiveAPIKey() != "sk-resolved-secret" {
+		t.Error("expected the resolved key to stay available in memory")
+	}
+}
+
 func TestLoadState(t *testing.T) {
 	tmpDir := t.TempDir()
 	statePath := filepath.Join(tmpDir, "test-state.json")
@@ -33,6 +33,9 @@ type ExportOptions struct {
 	IncludeMetrics bool
 	// IncludeTimestamps includes message timestamps in export
 	IncludeTimestamps bool
+	// MetricFootnotes adds a footnote to each agent message in Markdown exports with its
+	// tokens, cost, duration and finish reason, in place of the inline metrics line
+	MetricFootnotes bool
 	// Title is an optional title for the exported conversation
 	Title string
 }
@@ -40,12 +43,14 @@ type ExportOptions struct {
 // Exporter handles conversation exports to different formats.
 type Exporter struct {
 	options ExportOptions
+	now     func() time.Time // clock for the export timestamp
 }
 
 // NewExporter creates a new Exporter with the given options.
 func NewExporter(options ExportOptions) *Exporter {
 	return &Exporter{
 		options: options,
+		now:     time.Now,
 	}
 }
 
@@ -72,7 +77,7 @@ func (e *Exporter) exportJSON(messages []agent.Message, writer io.Writer) error
 		Summary    *ExportSummary  `json:"summary,omitempty"`
 	}{
 		Title:      e.options.Title,
-		ExportedAt: time.Now().Format(time.RFC3339),
+		ExportedAt: e.now().Format(time.RFC3339),
 		Messages:   messages,
 	}
 
@@ -98,7 +103,7 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 
 	// Export metadata
 	sb.WriteString("*Exported: ")
-	sb.WriteString(time.Now().Format("2006-01-02 15:04:05"))
+	sb.WriteString(e.now().Format("2006-01-02 15:04:05"))
 	sb.WriteString("*\n\n")
 
 	// Summary
@@ -115,6 +120,7 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 	// Messages
 	sb.WriteString("## Conversation\n\n")
 
+	var footnotes []string
 	for _, msg := range messages {
 		// Agent/System badge
 		if msg.Role == "system" {
@@ -134,10 +140,14 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 
 		// Content
 		sb.WriteString(msg.Content)
+		if e.options.MetricFootnotes && msg.Metrics != nil {
+			footnotes = append(footnotes, metricsFootnote(msg.Metrics))
+			sb.WriteString(fmt.Sprintf("[^%d]", len(footnotes)))
+		}
 		sb.WriteString("\n\n")
 
 		// Metrics
-		if e.options.IncludeMetrics && msg.Metrics != nil {
+		if e.options.IncludeMetrics && !e.options.MetricFootnotes && msg.Metrics != nil {
 			sb.WriteString("*")
 			sb.WriteString(fmt.Sprintf("Duration: %v | ", msg.Metrics.Duration))
 			sb.WriteString(fmt.Sprintf("Tokens: %d | ", msg.Metrics.TotalTokens))
@@ -148,10 +158,33 @@ func (e *Exporter) exportMarkdown(messages []agent.Message, writer io.Writer) er
 		sb.WriteString("---\n\n")
 	}
 
+	for i, note := range footnotes {
+		sb.WriteString(fmt.Sprintf("[^%d]: %s\n", i+1, note))
+	}
+
 	_, err := writer.Write([]byte(sb.String()))
 	return err
 }
 
+// metricsFootnote formats a message's metrics as a Markdown footnote body
+func metricsFootnote(m *agent.ResponseMetrics) string {
+	parts := []string{
+		fmt.Sprintf("Tokens: %d (%d in, %d out)", m.TotalTokens, m.InputTokens, m.OutputTokens),
+		fmt.Sprintf("Cost: $%.4f", m.Cost),
+		fmt.Sprintf("Duration: %v", m.Duration),
+	}
+	if m.Model != "" {
+		parts = append(parts, "Model: "+m.Model)
+	}
+	if m.FinishReason != "" {
+		parts = append(parts, "Finish reason: "+m.FinishReason)
+	}
+	if m.BillingTag != "" {
+		parts = append(parts, "Billing tag: "+m.BillingTag)
+	}
+	return strings.Join(parts, " · ")
+}
+
 // exportHTML exports messages as HTML.
 func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error {
 	var sb strings.Builder
@@ -180,7 +213,7 @@ func (e *Exporter) exportHTML(messages []agent.Message, writer io.Writer) error
 	sb.WriteString("  <div class=\"container\">\n")
 	sb.WriteString("    <header>\n")
 	sb.WriteString(fmt.Sprintf("      <h1>%s</h1>\n", html.EscapeString(title)))
-	sb.WriteString(fmt.Sprintf("      <p class=\"export-date\">Exported: %s</p>\n", time.Now().Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("      <p class=\"export-date\">Exported: %s</p>\n", e.now().Format("2006-01-02 15:04:05")))
 	sb.WriteString("    </header>\n\n")
 
 	// Summary
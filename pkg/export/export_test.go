@@ -3,6 +3,9 @@ package export
 import (
 	"bytes"
 	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -10,6 +13,8 @@ import (
 	"github.com/shawkym/agentpipe/pkg/agent"
 )
 
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
 func TestExportJSON(t *testing.T) {
 	messages := createTestMessages()
 
@@ -431,3 +436,73 @@ func createTestMessages() []agent.Message {
 		},
 	}
 }
+
+func TestExportBillingTag(t *testing.T) {
+	messages := createTestMessages()
+	messages[1].Metrics.BillingTag = "acme"
+
+	var buf bytes.Buffer
+	if err := NewExporter(ExportOptions{Format: FormatJSON}).Export(messages, &buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"BillingTag": "acme"`) {
+		t.Errorf("expected the billing tag in the JSON export, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	options := ExportOptions{Format: FormatMarkdown, IncludeMetrics: true, MetricFootnotes: true}
+	if err := NewExporter(options).Export(messages, &buf); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Billing tag: acme") {
+		t.Errorf("expected the billing tag in the metric footnotes, got:\n%s", buf.String())
+	}
+}
+
+func TestExportMarkdownGolden(t *testing.T) {
+	messages := createTestMessages()
+	messages[2].Metrics.FinishReason = "length"
+
+	tests := []struct {
+		name    string
+		options ExportOptions
+		golden  string
+	}{
+		{
+			name:    "without metrics",
+			options: ExportOptions{Format: FormatMarkdown, Title: "Golden"},
+			golden:  "markdown.golden",
+		},
+		{
+			name:    "with metric footnotes",
+			options: ExportOptions{Format: FormatMarkdown, Title: "Golden", IncludeMetrics: true, MetricFootnotes: true},
+			golden:  "markdown_with_metrics.golden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := NewExporter(tt.options)
+			exporter.now = func() time.Time { return time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC) }
+
+			var buf bytes.Buffer
+			if err := exporter.Export(messages, &buf); err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *updateGolden {
+				if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("output does not match %s (run with -update to refresh):\n%s", path, buf.String())
+			}
+		})
+	}
+}
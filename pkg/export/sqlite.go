@@ -0,0 +1,225 @@
+package export
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	// Pure-Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+
+	"github.com/shawkym/agentpipe/pkg/conversation"
+)
+
+// FormatSQLite exports a saved conversation state into a SQLite database.
+// Unlike the other formats it needs a file path rather than a writer, see ExportSQLite.
+const FormatSQLite Format = "sqlite"
+
+// sqliteSchema is a small normalized schema. Each export adds one conversation,
+// so many conversations can be collected in one database and queried together.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS conversations (
+	id             INTEGER PRIMARY KEY AUTOINCREMENT,
+	source         TEXT,
+	mode           TEXT,
+	initial_prompt TEXT,
+	started_at     TEXT,
+	saved_at       TEXT,
+	total_messages INTEGER NOT NULL DEFAULT 0,
+	total_turns    INTEGER NOT NULL DEFAULT 0,
+	duration_ms    INTEGER NOT NULL DEFAULT 0,
+	summary_short  TEXT,
+	summary_text   TEXT
+);
+
+CREATE TABLE IF NOT EXISTS participants (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+	agent_id        TEXT NOT NULL,
+	name            TEXT NOT NULL,
+	type            TEXT,
+	model           TEXT,
+	UNIQUE (conversation_id, agent_id)
+);
+
+CREATE TABLE IF NOT EXISTS messages (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+	participant_id  INTEGER REFERENCES participants(id),
+	sequence        INTEGER NOT NULL,
+	agent_id        TEXT,
+	agent_name      TEXT,
+	role            TEXT NOT NULL,
+	content         TEXT NOT NULL,
+	timestamp       INTEGER,
+	model           TEXT,
+	input_tokens    INTEGER,
+	output_tokens   INTEGER,
+	total_tokens    INTEGER,
+	cost            REAL,
+	duration_ms     INTEGER,
+	billing_tag     TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation ON messages (conversation_id, sequence);
+CREATE INDEX IF NOT EXISTS idx_messages_billing_tag ON messages (billing_tag);
+CREATE INDEX IF NOT EXISTS idx_participants_conversation ON participants (conversation_id);
+`
+
+// CreateSQLiteSchema creates the conversation tables if they don't exist yet.
+func CreateSQLiteSchema(db *sql.DB) error {
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("failed to create sqlite schema: %w", err)
+	}
+	return nil
+}
+
+// InsertConversation stores a conversation state with its participants and messages
+// in a single transaction and returns the new conversation's ID. source records where
+// the state came from (e.g. the state file path).
+func InsertConversation(db *sql.DB, state *conversation.State, source string) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var mode, initialPrompt string
+	if state.Config != nil {
+		mode = state.Config.Orchestrator.Mode
+		initialPrompt = state.Config.Orchestrator.InitialPrompt
+	}
+
+	res, err := tx.Exec(`INSERT INTO conversations
+		(source, mode, initial_prompt, started_at, saved_at, total_messages, total_turns, duration_ms, summary_short, summary_text)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		source, mode, initialPrompt,
+		formatTime(state.Metadata.StartedAt), formatTime(state.SavedAt),
+		len(state.Messages), state.Metadata.TotalTurns, state.Metadata.TotalDuration,
+		nullString(state.Metadata.ShortText), nullString(state.Metadata.Text))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert conversation: %w", err)
+	}
+	conversationID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read conversation id: %w", err)
+	}
+
+	participantIDs, err := insertParticipants(tx, conversationID, state)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO messages
+		(conversation_id, participant_id, sequence, agent_id, agent_name, role, content, timestamp,
+		 model, input_tokens, output_tokens, total_tokens, cost, duration_ms, billing_tag)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare message insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, msg := range state.Messages {
+		var participantID interface{}
+		if id, ok := participantIDs[msg.AgentID]; ok {
+			participantID = id
+		}
+
+		var model, inputTokens, outputTokens, totalTokens, cost, durationMs, billingTag interface{}
+		if m := msg.Metrics; m != nil {
+			model = nullString(m.Model)
+			inputTokens, outputTokens, totalTokens = m.InputTokens, m.OutputTokens, m.TotalTokens
+			cost, durationMs = m.Cost, m.Duration.Milliseconds()
+			billingTag = nullString(m.BillingTag)
+		}
+
+		if _, err := stmt.Exec(conversationID, participantID, i+1, msg.AgentID, msg.AgentName, msg.Role, msg.Content,
+			msg.Timestamp, model, inputTokens, outputTokens, totalTokens, cost, durationMs, billingTag); err != nil {
+			return 0, fmt.Errorf("failed to insert message %d: %w", i+1, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit conversation: %w", err)
+	}
+	return conversationID, nil
+}
+
+// insertParticipants stores the configured agents, plus any agent that appears only in
+// the messages, and returns their row IDs keyed by agent ID.
+func insertParticipants(tx *sql.Tx, conversationID int64, state *conversation.State) (map[string]int64, error) {
+	ids := make(map[string]int64)
+	insert := func(agentID, name, agentType, model string) error {
+		if agentID == "" {
+			return nil
+		}
+		if _, ok := ids[agentID]; ok {
+			return nil
+		}
+		res, err := tx.Exec(`INSERT INTO participants (conversation_id, agent_id, name, type, model) VALUES (?, ?, ?, ?, ?)`,
+			conversationID, agentID, name, nullString(agentType), nullString(model))
+		if err != nil {
+			return fmt.Errorf("failed to insert participant %s: %w", agentID, err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to read participant id: %w", err)
+		}
+		ids[agentID] = id
+		return nil
+	}
+
+	if state.Config != nil {
+		for _, a := range state.Config.Agents {
+			if err := insert(a.ID, a.Name, a.Type, a.Model); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, msg := range state.Messages {
+		if msg.Role != "agent" {
+			continue
+		}
+		if err := insert(msg.AgentID, msg.AgentName, msg.AgentType, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// ExportSQLite appends a conversation state to the SQLite database at path,
+// creating the database and schema if needed. It returns the new conversation's ID.
+func ExportSQLite(path string, state *conversation.State, source string) (int64, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	defer db.Close()
+	// A single connection keeps PRAGMAs and transactions on the same database handle
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		return 0, fmt.Errorf("failed to enable foreign keys: %w", err)
+	}
+	if err := CreateSQLiteSchema(db); err != nil {
+		return 0, err
+	}
+	return InsertConversation(db, state, source)
+}
+
+// formatTime renders a timestamp for storage, leaving zero times empty
+func formatTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// nullString stores empty strings as NULL
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
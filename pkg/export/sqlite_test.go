@@ -0,0 +1,160 @@
+package export
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/conversation"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open in-memory database: %v", err)
+	}
+	// Each connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	if err := CreateSQLiteSchema(db); err != nil {
+		t.Fatalf("CreateSQLiteSchema failed: %v", err)
+	}
+	return db
+}
+
+func createTestState() *conversation.State {
+	cfg := config.NewDefaultConfig()
+	cfg.Orchestrator.InitialPrompt = "Discuss testing"
+	cfg.Agents = []agent.AgentConfig{
+		{ID: "agent-1", Type: "claude", Name: "Agent1", Model: "test-model"},
+		{ID: "agent-3", Type: "gemini", Name: "Agent3"},
+	}
+
+	state := conversation.NewState(createTestMessages(), cfg, time.Now().Add(-time.Minute))
+	state.Metadata.ShortText = "A short summary"
+	return state
+}
+
+func countRows(t *testing.T, db *sql.DB, query string, args ...interface{}) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(query, args...).Scan(&n); err != nil {
+		t.Fatalf("query %q failed: %v", query, err)
+	}
+	return n
+}
+
+func TestCreateSQLiteSchemaIsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	if err := CreateSQLiteSchema(db); err != nil {
+		t.Fatalf("second CreateSQLiteSchema failed: %v", err)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('conversations', 'participants', 'messages')`); n != 3 {
+		t.Errorf("expected 3 tables, got %d", n)
+	}
+}
+
+func TestInsertConversation(t *testing.T) {
+	db := openTestDB(t)
+	state := createTestState()
+
+	id, err := InsertConversation(db, state, "state.json")
+	if err != nil {
+		t.Fatalf("InsertConversation failed: %v", err)
+	}
+
+	if n := countRows(t, db, `SELECT COUNT(*) FROM conversations`); n != 1 {
+		t.Errorf("expected 1 conversation, got %d", n)
+	}
+	// Configured agents plus agent-2, which only appears in the messages
+	if n := countRows(t, db, `SELECT COUNT(*) FROM participants WHERE conversation_id = ?`, id); n != 3 {
+		t.Errorf("expected 3 participants, got %d", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM messages WHERE conversation_id = ?`, id); n != len(state.Messages) {
+		t.Errorf("expected %d messages, got %d", len(state.Messages), n)
+	}
+
+	// Agent messages link to their participant; system messages have none
+	if n := countRows(t, db, `SELECT COUNT(*) FROM messages m JOIN participants p ON p.id = m.participant_id WHERE p.agent_id = 'agent-2'`); n != 1 {
+		t.Errorf("expected 1 message joined to agent-2, got %d", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM messages WHERE participant_id IS NULL`); n != 1 {
+		t.Errorf("expected 1 message without participant, got %d", n)
+	}
+
+	var prompt, summary string
+	var tokens int
+	if err := db.QueryRow(`SELECT initial_prompt, summary_short FROM conversations WHERE id = ?`, id).Scan(&prompt, &summary); err != nil {
+		t.Fatalf("failed to read conversation: %v", err)
+	}
+	if prompt != "Discuss testing" || summary != "A short summary" {
+		t.Errorf("unexpected conversation row: prompt=%q summary=%q", prompt, summary)
+	}
+	if err := db.QueryRow(`SELECT total_tokens FROM messages WHERE agent_id = 'agent-2'`).Scan(&tokens); err != nil {
+		t.Fatalf("failed to read message metrics: %v", err)
+	}
+	if tokens != 200 {
+		t.Errorf("expected 200 total tokens, got %d", tokens)
+	}
+
+	// A second export adds a separate conversation with its own participants
+	if _, err := InsertConversation(db, state, "other.json"); err != nil {
+		t.Fatalf("second InsertConversation failed: %v", err)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM participants`); n != 6 {
+		t.Errorf("expected 6 participants across conversations, got %d", n)
+	}
+}
+
+func TestInsertConversationBillingTag(t *testing.T) {
+	db := openTestDB(t)
+	state := createTestState()
+	for i := range state.Messages {
+		if state.Messages[i].Metrics != nil {
+			state.Messages[i].Metrics.BillingTag = "acme"
+		}
+	}
+
+	if _, err := InsertConversation(db, state, "state.json"); err != nil {
+		t.Fatalf("InsertConversation failed: %v", err)
+	}
+
+	if n := countRows(t, db, `SELECT COUNT(*) FROM messages WHERE billing_tag = 'acme'`); n != 2 {
+		t.Errorf("expected both agent messages tagged, got %d", n)
+	}
+	if n := countRows(t, db, `SELECT COUNT(*) FROM messages WHERE billing_tag IS NULL`); n != 1 {
+		t.Errorf("expected the system message to be untagged, got %d", n)
+	}
+
+	var cost float64
+	if err := db.QueryRow(`SELECT SUM(cost) FROM messages WHERE billing_tag = 'acme'`).Scan(&cost); err != nil {
+		t.Fatalf("failed to aggregate cost by tag: %v", err)
+	}
+	if cost < 0.00299 || cost > 0.00301 {
+		t.Errorf("expected $0.003 attributed to acme, got %v", cost)
+	}
+}
+
+func TestExportSQLiteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conversations.sqlite")
+
+	for i := 0; i < 2; i++ {
+		if _, err := ExportSQLite(path, createTestState(), "state.json"); err != nil {
+			t.Fatalf("ExportSQLite failed: %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to reopen database: %v", err)
+	}
+	defer db.Close()
+	if n := countRows(t, db, `SELECT COUNT(*) FROM conversations`); n != 2 {
+		t.Errorf("expected 2 conversations, got %d", n)
+	}
+}
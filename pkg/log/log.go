@@ -3,8 +3,10 @@
 package log
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -227,6 +229,11 @@ func InitLogger(w io.Writer, level zerolog.Level, pretty bool) {
 	log.Logger = global.zlog
 }
 
+// GetLevel returns the minimum level of the global logger.
+func GetLevel() zerolog.Level {
+	return global.zlog.GetLevel()
+}
+
 // ParseLevel converts a string level to zerolog.Level.
 func ParseLevel(level string) zerolog.Level {
 	switch level {
@@ -248,3 +255,20 @@ func ParseLevel(level string) zerolog.Level {
 		return zerolog.InfoLevel
 	}
 }
+
+// ParseLevelStrict converts a user-supplied level name (debug, info, warn or error,
+// case-insensitive) to zerolog.Level. Unlike ParseLevel it rejects unknown names.
+func ParseLevelStrict(level string) (zerolog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn", "warning":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.InfoLevel, fmt.Errorf("invalid log level %q (must be debug, info, warn or error)", level)
+	}
+}
@@ -225,6 +225,40 @@ func TestParseLevel(t *testing.T) {
 	}
 }
 
+// TestParseLevelStrict tests strict level parsing for user input
+func TestParseLevelStrict(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected zerolog.Level
+	}{
+		{"debug", zerolog.DebugLevel},
+		{"info", zerolog.InfoLevel},
+		{"warn", zerolog.WarnLevel},
+		{"warning", zerolog.WarnLevel},
+		{"error", zerolog.ErrorLevel},
+		{"DEBUG", zerolog.DebugLevel},
+		{" Error ", zerolog.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			level, err := ParseLevelStrict(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if level != tt.expected {
+				t.Errorf("Expected level %v, got %v", tt.expected, level)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"verbose", "", "trace"} {
+		if _, err := ParseLevelStrict(invalid); err == nil {
+			t.Errorf("Expected error for level %q", invalid)
+		}
+	}
+}
+
 // TestInitLogger tests logger initialization
 func TestInitLogger(t *testing.T) {
 	buf := &bytes.Buffer{}
@@ -2,17 +2,20 @@ package logger
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/shawkym/agentpipe/internal/bridge"
 	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/utils"
 )
 
 type ChatLogger struct {
@@ -22,8 +25,18 @@ type ChatLogger struct {
 	agentColors map[string]lipgloss.Style
 	colorIndex  int
 	termWidth   int
+	renderWidth int
 	showMetrics bool
 	jsonEmitter *bridge.StdoutEmitter // For JSON mode output
+
+	// Per-agent logs live in a directory named after the combined transcript
+	agentLogDir   string
+	perAgentLogs  bool
+	agentLogFiles map[string]*os.File
+
+	// mu guards file writes so Flush and Close can run from a signal handler
+	mu     sync.Mutex
+	closed bool
 }
 
 var colors = []lipgloss.Color{
@@ -105,12 +118,14 @@ func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetri
 	}
 
 	logger := &ChatLogger{
-		logFile:     logFile,
-		logFormat:   logFormat,
-		console:     console,
-		agentColors: make(map[string]lipgloss.Style),
-		termWidth:   termWidth,
-		showMetrics: showMetrics,
+		logFile:       logFile,
+		logFormat:     logFormat,
+		console:       console,
+		agentColors:   make(map[string]lipgloss.Style),
+		termWidth:     termWidth,
+		showMetrics:   showMetrics,
+		agentLogDir:   strings.TrimSuffix(logPath, ".log"),
+		agentLogFiles: make(map[string]*os.File),
 	}
 
 	// Write header to log file
@@ -125,6 +140,18 @@ func NewChatLogger(logDir string, logFormat string, console io.Writer, showMetri
 	return logger, nil
 }
 
+// SetPerAgentLogs enables writing each agent's messages to its own <agent>.log file,
+// in addition to the combined transcript. It has no effect without a log directory.
+func (l *ChatLogger) SetPerAgentLogs(enabled bool) {
+	l.perAgentLogs = enabled
+}
+
+// SetRenderWidth wraps agent messages on the console to width columns instead of the
+// terminal width, e.g. when output is piped to a file (0 = use the terminal width)
+func (l *ChatLogger) SetRenderWidth(width int) {
+	l.renderWidth = width
+}
+
 // SetJSONEmitter sets the JSON emitter for JSON-only output mode
 func (l *ChatLogger) SetJSONEmitter(emitter *bridge.StdoutEmitter) {
 	l.jsonEmitter = emitter
@@ -177,6 +204,7 @@ func (l *ChatLogger) LogMessage(msg agent.Message) {
 
 	// Write to file
 	l.writeFileLog(msg, timestamp)
+	l.writeAgentFileLog(msg, timestamp)
 
 	// Write to console with colors
 	l.writeConsoleLog(msg, timestamp)
@@ -190,6 +218,7 @@ func (l *ChatLogger) emitJSONLog(msg agent.Message) {
 			DurationSeconds: msg.Metrics.Duration.Seconds(),
 			TotalTokens:     msg.Metrics.TotalTokens,
 			Cost:            msg.Metrics.Cost,
+			BillingTag:      msg.Metrics.BillingTag,
 		}
 	}
 
@@ -211,15 +240,82 @@ func (l *ChatLogger) writeFileLog(msg agent.Message, timestamp string) {
 		return
 	}
 
+	if entry, ok := l.formatFileEntry(msg, timestamp); ok {
+		l.writeToFile(entry)
+	}
+}
+
+// formatFileEntry renders a message in the configured log file format
+func (l *ChatLogger) formatFileEntry(msg agent.Message, timestamp string) (string, bool) {
 	if l.logFormat == "json" {
 		data, err := json.Marshal(msg)
-		if err == nil {
-			l.writeToFile(string(data) + "\n")
+		if err != nil {
+			return "", false
 		}
-	} else {
-		l.writeToFile(fmt.Sprintf("[%s] %s (%s): %s\n\n",
-			timestamp, msg.AgentName, msg.Role, msg.Content))
+		return string(data) + "\n", true
+	}
+	return fmt.Sprintf("[%s] %s (%s): %s\n\n",
+		timestamp, msg.AgentName, msg.Role, msg.Content), true
+}
+
+// writeAgentFileLog writes an agent's own message to its per-agent log file.
+// Host, system and user messages only go to the combined transcript.
+func (l *ChatLogger) writeAgentFileLog(msg agent.Message, timestamp string) {
+	if !l.perAgentLogs || l.logFile == nil || (msg.Role != "agent" && msg.Role != "tool") {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+
+	file, err := l.agentLogFile(msg.AgentName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening agent log file: %v\n", err)
+		return
+	}
+
+	if entry, ok := l.formatFileEntry(msg, timestamp); ok {
+		if _, err := file.WriteString(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to agent log file: %v\n", err)
+		}
+	}
+}
+
+// agentLogFile returns the log file for an agent, creating it on first use
+func (l *ChatLogger) agentLogFile(agentName string) (*os.File, error) {
+	if file, ok := l.agentLogFiles[agentName]; ok {
+		return file, nil
+	}
+
+	if err := os.MkdirAll(l.agentLogDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create agent log directory: %w", err)
+	}
+
+	file, err := os.Create(filepath.Join(l.agentLogDir, agentLogFileName(agentName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent log file: %w", err)
+	}
+
+	l.agentLogFiles[agentName] = file
+	return file, nil
+}
+
+// agentLogFileName turns an agent name into a safe <agent>.log file name
+func agentLogFileName(agentName string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(agentName))
+	if name == "" || name == "." || name == ".." {
+		name = "agent"
 	}
+	return name + ".log"
 }
 
 // writeConsoleLog writes a formatted message to the console
@@ -241,7 +337,9 @@ func (l *ChatLogger) writeConsoleLog(msg agent.Message, timestamp string) {
 	isHost := msg.Role == "system" && (msg.AgentID == "host" || msg.AgentName == "HOST")
 	isSystemMsg := msg.Role == "system" && !isHost
 
-	if isSystemMsg {
+	if msg.Role == "tool" {
+		l.writeToolMessage(&output, msg)
+	} else if isSystemMsg {
 		l.writeSystemMessage(&output, msg)
 	} else {
 		l.writeAgentMessage(&output, msg, isHost)
@@ -257,6 +355,12 @@ func (l *ChatLogger) writeSystemMessage(output *strings.Builder, msg agent.Messa
 	output.WriteString(systemStyle.Render(msg.Content))
 }
 
+// writeToolMessage formats and writes an agent's intermediate tool step
+func (l *ChatLogger) writeToolMessage(output *strings.Builder, msg agent.Message) {
+	output.WriteString(systemBadgeStyle.Render(" " + msg.AgentName + " tool "))
+	output.WriteString(systemStyle.Render(msg.Content))
+}
+
 // writeAgentMessage formats and writes an agent message
 func (l *ChatLogger) writeAgentMessage(output *strings.Builder, msg agent.Message, isHost bool) {
 	var badgeStyle, contentStyle lipgloss.Style
@@ -272,6 +376,9 @@ func (l *ChatLogger) writeAgentMessage(output *strings.Builder, msg agent.Messag
 		if msg.AgentType != "" {
 			displayName = fmt.Sprintf("%s (%s)", msg.AgentName, msg.AgentType)
 		}
+		if msg.Team != "" {
+			displayName += " → team " + msg.Team
+		}
 		output.WriteString(badgeStyle.Render(" " + displayName + " "))
 	}
 
@@ -315,6 +422,13 @@ func (l *ChatLogger) writeMetrics(output *strings.Builder, metrics *agent.Respon
 		metrics.Duration.Seconds(),
 		metrics.TotalTokens,
 		metrics.Cost)
+	if metrics.BillingTag != "" {
+		metricsStr = fmt.Sprintf("(%.2fs, %d tokens, $%.6f, %s)",
+			metrics.Duration.Seconds(),
+			metrics.TotalTokens,
+			metrics.Cost,
+			metrics.BillingTag)
+	}
 
 	output.WriteString(" ")
 	output.WriteString(metricsStyle.Render(metricsStr))
@@ -365,73 +479,96 @@ func (l *ChatLogger) LogSystem(message string) {
 	l.LogMessage(msg)
 }
 
+// wrapText wraps console text to the render width, or to the terminal width when
+// none is set, and indents every line
 func (l *ChatLogger) wrapText(text string, indent int) string {
-	if l.termWidth <= 0 {
+	var maxWidth int
+	switch {
+	case l.renderWidth > 0:
+		maxWidth = max(l.renderWidth-indent, 1)
+	case l.termWidth > 0:
+		maxWidth = l.termWidth - indent - 2 // Leave some margin
+		if maxWidth <= 20 {
+			maxWidth = 20 // Minimum width
+		}
+	default:
 		return text
 	}
 
-	maxWidth := l.termWidth - indent - 2 // Leave some margin
-	if maxWidth <= 20 {
-		maxWidth = 20 // Minimum width
-	}
-
-	lines := strings.Split(text, "\n")
-	var wrapped []string
+	lines := strings.Split(utils.WrapText(text, maxWidth), "\n")
 	indentStr := strings.Repeat(" ", indent)
+	for i, line := range lines {
+		lines[i] = indentStr + line
+	}
+	return strings.Join(lines, "\n")
+}
 
-	for _, line := range lines {
-		if len(line) <= maxWidth {
-			wrapped = append(wrapped, indentStr+line)
-			continue
-		}
+func (l *ChatLogger) writeToFile(content string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writeToFileLocked(content)
+}
 
-		// Wrap long lines at word boundaries
-		words := strings.Fields(line)
-		current := indentStr
-
-		for _, word := range words {
-			if len(current)+len(word)+1 > l.termWidth {
-				if len(current) > indent {
-					wrapped = append(wrapped, current)
-					current = indentStr + word
-				} else {
-					// Word is too long, break it
-					wrapped = append(wrapped, indentStr+word[:maxWidth])
-					current = indentStr + word[maxWidth:]
-				}
-			} else {
-				if len(current) > indent {
-					current += " "
-				}
-				current += word
-			}
+func (l *ChatLogger) writeToFileLocked(content string) {
+	if l.logFile != nil && !l.closed {
+		if _, err := l.logFile.WriteString(content); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing to log file: %v\n", err)
 		}
-
-		if len(current) > indent {
-			wrapped = append(wrapped, current)
+		if err := l.logFile.Sync(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error syncing log file: %v\n", err)
 		}
 	}
+}
 
-	return strings.Join(wrapped, "\n")
+// Flush syncs the chat log and any per-agent log files to disk.
+func (l *ChatLogger) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.flushLocked()
 }
 
-func (l *ChatLogger) writeToFile(content string) {
+func (l *ChatLogger) flushLocked() error {
+	if l.closed {
+		return nil
+	}
+
+	var errs []error
 	if l.logFile != nil {
-		if _, err := l.logFile.WriteString(content); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing to log file: %v\n", err)
-		}
 		if err := l.logFile.Sync(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error syncing log file: %v\n", err)
+			errs = append(errs, fmt.Errorf("failed to sync log file: %w", err))
+		}
+	}
+	for name, file := range l.agentLogFiles {
+		if err := file.Sync(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to sync log file for %s: %w", name, err))
 		}
 	}
+	return errors.Join(errs...)
 }
 
+// Close writes the end marker, flushes and closes all log files.
+// It is safe to call more than once, including from a signal handler.
 func (l *ChatLogger) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+
+	l.writeToFileLocked("\n=== Chat Ended ===\n")
+	l.writeToFileLocked("Ended: " + time.Now().Format("2006-01-02 15:04:05") + "\n")
+	if err := l.flushLocked(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error flushing log files: %v\n", err)
+	}
+
 	if l.logFile != nil {
-		l.writeToFile("\n=== Chat Ended ===\n")
-		l.writeToFile("Ended: " + time.Now().Format("2006-01-02 15:04:05") + "\n")
 		l.logFile.Close()
 	}
+	for name, file := range l.agentLogFiles {
+		file.Close()
+		delete(l.agentLogFiles, name)
+	}
+	l.closed = true
 }
 
 // Helper function to get terminal size
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 )
@@ -202,6 +203,39 @@ func TestLogMessageToConsole(t *testing.T) {
 	}
 }
 
+func TestLogMessageRenderWidth(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewChatLogger("", "text", &buf, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger.SetRenderWidth(30)
+
+	logger.LogMessage(agent.Message{
+		AgentID:   "test-agent",
+		AgentName: "TestAgent",
+		Content:   "A console message that is much too long for a thirty column render width",
+		Timestamp: time.Now().Unix(),
+		Role:      "agent",
+	})
+
+	var contentLines int
+	for _, line := range strings.Split(buf.String(), "\n") {
+		plain := ansi.Strip(line)
+		if !strings.HasPrefix(plain, "  ") {
+			continue
+		}
+		contentLines++
+		if width := ansi.StringWidth(plain); width > 30 {
+			t.Errorf("line %q is %d columns wide, want at most 30", plain, width)
+		}
+	}
+	if contentLines < 3 {
+		t.Errorf("expected the message wrapped over several lines, got %q", buf.String())
+	}
+}
+
 func TestLogMessageWithMetrics(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -239,6 +273,28 @@ func TestLogMessageWithMetrics(t *testing.T) {
 	}
 }
 
+func TestLogMessageWithBillingTag(t *testing.T) {
+	var buf bytes.Buffer
+
+	logger, err := NewChatLogger("", "text", &buf, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.LogMessage(agent.Message{
+		AgentID:   "test-agent",
+		AgentName: "TestAgent",
+		Content:   "Tagged message",
+		Timestamp: time.Now().Unix(),
+		Role:      "agent",
+		Metrics:   &agent.ResponseMetrics{TotalTokens: 30, Cost: 0.001, BillingTag: "acme"},
+	})
+
+	if !strings.Contains(buf.String(), "$0.001000, acme)") {
+		t.Errorf("expected metrics to show the billing tag, got %q", buf.String())
+	}
+}
+
 func TestLogMessageSystemRole(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -469,6 +525,160 @@ func TestClose(t *testing.T) {
 	}
 }
 
+func TestMessagesLoggedBeforeCloseArePersisted(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewChatLogger(tempDir, "text", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger.SetPerAgentLogs(true)
+
+	now := time.Now().Unix()
+	logger.LogMessage(agent.Message{AgentID: "a1", AgentName: "Alice", Role: "agent", Content: "last words", Timestamp: now})
+	logger.LogError("Alice", errors.New("final failure"))
+	logger.Close()
+
+	// Closing again, or logging after close, must be harmless
+	logger.Close()
+	logger.LogMessage(agent.Message{AgentID: "a1", AgentName: "Alice", Role: "agent", Content: "too late", Timestamp: now})
+	if err := logger.Flush(); err != nil {
+		t.Errorf("expected Flush after Close to be a no-op, got %v", err)
+	}
+
+	content, err := os.ReadFile(logger.agentLogDir + ".log")
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	for _, want := range []string{"last words", "final failure", "Chat Ended"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected log file to contain %q, got %q", want, content)
+		}
+	}
+	if strings.Contains(string(content), "too late") {
+		t.Error("expected messages after Close to be dropped")
+	}
+
+	agentContent, err := os.ReadFile(filepath.Join(logger.agentLogDir, "Alice.log"))
+	if err != nil {
+		t.Fatalf("failed to read agent log file: %v", err)
+	}
+	if !strings.Contains(string(agentContent), "last words") {
+		t.Errorf("expected agent log to contain the final message, got %q", agentContent)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewChatLogger(tempDir, "text", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer logger.Close()
+	logger.SetPerAgentLogs(true)
+
+	logger.LogMessage(agent.Message{AgentID: "b1", AgentName: "Bob", Role: "agent", Content: "flushed", Timestamp: time.Now().Unix()})
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(logger.agentLogDir, "Bob.log"))
+	if err != nil {
+		t.Fatalf("failed to read agent log file: %v", err)
+	}
+	if !strings.Contains(string(content), "flushed") {
+		t.Errorf("expected flushed message in agent log before Close, got %q", content)
+	}
+
+	// A logger without a log directory has nothing to flush
+	consoleOnly, _ := NewChatLogger("", "text", nil, false)
+	if err := consoleOnly.Flush(); err != nil {
+		t.Errorf("expected Flush without log files to succeed, got %v", err)
+	}
+}
+
+func TestPerAgentLogs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewChatLogger(tempDir, "text", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger.SetPerAgentLogs(true)
+
+	now := time.Now().Unix()
+	logger.LogMessage(agent.Message{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Topic", Timestamp: now})
+	logger.LogMessage(agent.Message{AgentID: "a1", AgentName: "Alice", Role: "agent", Content: "alice says hi", Timestamp: now})
+	logger.LogMessage(agent.Message{AgentID: "b1", AgentName: "Bob", Role: "agent", Content: "bob says hi", Timestamp: now})
+	logger.LogMessage(agent.Message{AgentID: "a1", AgentName: "Alice", Role: "agent", Content: "alice again", Timestamp: now})
+	logger.Close()
+
+	if len(logger.agentLogFiles) != 0 {
+		t.Error("expected Close to release per-agent log files")
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(logger.agentLogDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+
+	entries, err := os.ReadDir(logger.agentLogDir)
+	if err != nil {
+		t.Fatalf("failed to read agent log dir: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 agent log files, got %d", len(entries))
+	}
+
+	alice := read("Alice.log")
+	if !strings.Contains(alice, "alice says hi") || !strings.Contains(alice, "alice again") {
+		t.Errorf("expected Alice's log to contain her messages, got %q", alice)
+	}
+	if strings.Contains(alice, "bob") || strings.Contains(alice, "Topic") {
+		t.Errorf("expected Alice's log to contain only her messages, got %q", alice)
+	}
+
+	bob := read("Bob.log")
+	if !strings.Contains(bob, "bob says hi") || strings.Contains(bob, "alice") {
+		t.Errorf("expected Bob's log to contain only his messages, got %q", bob)
+	}
+}
+
+func TestPerAgentLogsDisabledByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+
+	logger, err := NewChatLogger(tempDir, "text", nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	logger.LogMessage(agent.Message{AgentName: "Alice", Role: "agent", Content: "hi", Timestamp: time.Now().Unix()})
+	logger.Close()
+
+	if _, err := os.Stat(logger.agentLogDir); !os.IsNotExist(err) {
+		t.Errorf("expected no agent log directory, got err=%v", err)
+	}
+}
+
+func TestAgentLogFileName(t *testing.T) {
+	tests := map[string]string{
+		"Alice":    "Alice.log",
+		"team/bot": "team_bot.log",
+		"  ":       "agent.log",
+		"..":       "agent.log",
+		"a:b*c?":   "a_b_c_.log",
+	}
+	for in, want := range tests {
+		if got := agentLogFileName(in); got != want {
+			t.Errorf("agentLogFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
 func TestColorCycling(t *testing.T) {
 	logger := &ChatLogger{
 		agentColors: make(map[string]lipgloss.Style),
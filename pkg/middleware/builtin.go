@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 	"github.com/shawkym/agentpipe/pkg/log"
@@ -168,6 +169,52 @@ func SanitizationMiddleware(removeSpecialChars bool) Middleware {
 	})
 }
 
+// StripMarkupMiddleware creates middleware that removes markdown and HTML formatting
+// for plain-text consumers (SMS, logs). Code block contents are preserved.
+// It is opt-in and not part of the default chain.
+func StripMarkupMiddleware() Middleware {
+	return NewTransformMiddleware("strip-markup", func(ctx *MessageContext, msg *agent.Message) (*agent.Message, error) {
+		msg.Content = StripMarkup(msg.Content)
+		return msg, nil
+	})
+}
+
+// StripSelfLabelMiddleware creates middleware that removes a redundant leading
+// "<AgentName>:" speaker label from a message, since the orchestrator already
+// attributes it. The label is matched case-insensitively against the message's own
+// agent name. It is opt-in and not part of the default chain.
+func StripSelfLabelMiddleware() Middleware {
+	return NewTransformMiddleware("strip-self-label", func(ctx *MessageContext, msg *agent.Message) (*agent.Message, error) {
+		msg.Content = StripSelfLabel(msg.Content, msg.AgentName)
+		return msg, nil
+	})
+}
+
+// StripSelfLabel removes a leading "<name>:" label (case-insensitive, optional spaces
+// before the colon) from content. Content that is only the label is returned unchanged.
+func StripSelfLabel(content, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return content
+	}
+
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+	if len(trimmed) < len(name) || !strings.EqualFold(trimmed[:len(name)], name) {
+		return content
+	}
+
+	rest := strings.TrimLeft(trimmed[len(name):], " \t")
+	if !strings.HasPrefix(rest, ":") {
+		return content
+	}
+
+	stripped := strings.TrimLeft(rest[1:], " \t\r\n")
+	if stripped == "" {
+		return content
+	}
+	return stripped
+}
+
 // RoleValidationMiddleware creates middleware that validates message roles.
 // It ensures messages have valid roles from the allowed list.
 func RoleValidationMiddleware(allowedRoles []string) Middleware {
@@ -196,6 +243,50 @@ func EmptyContentValidationMiddleware() Middleware {
 	})
 }
 
+// ContentRules configures ContentValidationMiddleware. Zero values disable a rule.
+type ContentRules struct {
+	// MinLength is the minimum content length in characters, ignoring surrounding whitespace
+	MinLength int
+	// MaxLength is the maximum content length in characters, ignoring surrounding whitespace
+	MaxLength int
+	// Required lists substrings that must all appear in the content (case-insensitive)
+	Required []string
+	// Forbidden lists substrings that must not appear in the content (case-insensitive)
+	Forbidden []string
+}
+
+// ContentValidationMiddleware creates middleware that rejects empty messages and
+// messages breaking any of the given rules. A rejected message fails the agent's turn.
+func ContentValidationMiddleware(rules ContentRules) Middleware {
+	return NewValidationMiddleware("content-validation", func(ctx *MessageContext, msg *agent.Message) error {
+		content := strings.TrimSpace(msg.Content)
+		if content == "" {
+			return fmt.Errorf("message content cannot be empty")
+		}
+
+		length := utf8.RuneCountInString(content)
+		if rules.MinLength > 0 && length < rules.MinLength {
+			return fmt.Errorf("message content too short: %d characters, minimum is %d", length, rules.MinLength)
+		}
+		if rules.MaxLength > 0 && length > rules.MaxLength {
+			return fmt.Errorf("message content too long: %d characters, maximum is %d", length, rules.MaxLength)
+		}
+
+		lower := strings.ToLower(content)
+		for _, s := range rules.Required {
+			if !strings.Contains(lower, strings.ToLower(s)) {
+				return fmt.Errorf("message content is missing required text %q", s)
+			}
+		}
+		for _, s := range rules.Forbidden {
+			if s != "" && strings.Contains(lower, strings.ToLower(s)) {
+				return fmt.Errorf("message content contains forbidden text %q", s)
+			}
+		}
+		return nil
+	})
+}
+
 // ContextEnrichmentMiddleware creates middleware that enriches the message context.
 // It adds additional metadata fields to the context.
 func ContextEnrichmentMiddleware(enricher func(*MessageContext, *agent.Message)) Middleware {
@@ -264,6 +264,52 @@ func TestSanitizationMiddleware(t *testing.T) {
 }
 
 // TestRoleValidationMiddleware tests role validation
+func TestStripSelfLabel(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		agent    string
+		expected string
+	}{
+		{"redundant prefix", "Claude: I agree with that.", "Claude", "I agree with that."},
+		{"lowercase label", "claude: I agree.", "Claude", "I agree."},
+		{"uppercase label", "CLAUDE : I agree.", "Claude", "I agree."},
+		{"leading whitespace and newline", "\n  Claude:\nI agree.", "Claude", "I agree."},
+		{"multi-word name", "Code Reviewer: Looks good.", "Code Reviewer", "Looks good."},
+		{"no prefix", "I agree with Claude: it works.", "Claude", "I agree with Claude: it works."},
+		{"other agent's label", "Gemini: I agree.", "Claude", "Gemini: I agree."},
+		{"name without colon", "Claude thinks so.", "Claude", "Claude thinks so."},
+		{"longer word sharing prefix", "Claudette: hello", "Claude", "Claudette: hello"},
+		{"label only", "Claude:", "Claude", "Claude:"},
+		{"empty agent name", ": hello", "", ": hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripSelfLabel(tt.content, tt.agent); got != tt.expected {
+				t.Errorf("StripSelfLabel(%q, %q) = %q, want %q", tt.content, tt.agent, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripSelfLabelMiddleware(t *testing.T) {
+	chain := NewChain(StripSelfLabelMiddleware())
+	ctx := &MessageContext{
+		Ctx:      context.Background(),
+		AgentID:  "claude-1",
+		Metadata: make(map[string]interface{}),
+	}
+
+	result, err := chain.Process(ctx, &agent.Message{AgentName: "Claude", Content: "Claude: Hello there"})
+	if err != nil {
+		t.Fatalf("StripSelfLabelMiddleware failed: %v", err)
+	}
+	if result.Content != "Hello there" {
+		t.Errorf("expected label to be stripped, got %q", result.Content)
+	}
+}
+
 func TestRoleValidationMiddleware(t *testing.T) {
 	allowedRoles := []string{"user", "assistant", "system"}
 	m := RoleValidationMiddleware(allowedRoles)
@@ -328,6 +374,52 @@ func TestEmptyContentValidationMiddleware(t *testing.T) {
 	}
 }
 
+// TestContentValidationMiddleware tests each configurable content rule
+func TestContentValidationMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   ContentRules
+		content string
+		wantErr string
+	}{
+		{name: "no rules accepts content", content: "anything"},
+		{name: "no rules rejects empty", content: "  \n", wantErr: "cannot be empty"},
+		{name: "min length met", rules: ContentRules{MinLength: 5}, content: "  hello  "},
+		{name: "min length counts characters", rules: ContentRules{MinLength: 4}, content: "héé", wantErr: "too short: 3 characters"},
+		{name: "max length met", rules: ContentRules{MaxLength: 5}, content: "hello"},
+		{name: "max length exceeded", rules: ContentRules{MaxLength: 5}, content: "hello world", wantErr: "too long: 11 characters"},
+		{name: "required present", rules: ContentRules{Required: []string{"verdict", "reason"}}, content: "VERDICT: yes. Reason: tests pass"},
+		{name: "required missing", rules: ContentRules{Required: []string{"verdict", "reason"}}, content: "Verdict: yes", wantErr: `missing required text "reason"`},
+		{name: "forbidden absent", rules: ContentRules{Forbidden: []string{"as an ai"}}, content: "Here is my answer"},
+		{name: "forbidden present", rules: ContentRules{Forbidden: []string{"as an ai"}}, content: "As an AI, I cannot", wantErr: `forbidden text "as an ai"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := NewChain(ContentValidationMiddleware(tt.rules))
+			ctx := &MessageContext{
+				Ctx:      context.Background(),
+				AgentID:  "test",
+				Metadata: make(map[string]interface{}),
+			}
+
+			result, err := chain.Process(ctx, &agent.Message{Content: tt.content})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if result == nil || result.Content != tt.content {
+					t.Errorf("Expected message to pass through unchanged, got %+v", result)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 // TestContextEnrichmentMiddleware tests context enrichment
 func TestContextEnrichmentMiddleware(t *testing.T) {
 	enricher := func(ctx *MessageContext, msg *agent.Message) {
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markupHeadingPattern  = regexp.MustCompile(`^\s{0,3}#{1,6}\s+`)
+	markupQuotePattern    = regexp.MustCompile(`^\s*>\s?`)
+	markupRulePattern     = regexp.MustCompile(`^(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markupImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markupLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	markupBoldPattern     = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	markupItalicPattern   = regexp.MustCompile(`(^|[^\w*])[*_](\S(?:[^*_]*?\S)?)[*_]($|[^\w*])`)
+	markupStrikePattern   = regexp.MustCompile(`~~(.+?)~~`)
+	markupHTMLBreak       = regexp.MustCompile(`(?i)<br\s*/?>`)
+	markupHTMLTagPattern  = regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)
+	markupMultipleSpaces  = regexp.MustCompile(`[ \t]+`)
+	markupFenceLinePrefix = []string{"```", "~~~"}
+)
+
+// StripMarkup removes markdown and HTML formatting so content reads cleanly in
+// plain-text sinks such as SMS or log files. The contents of code blocks and
+// inline code spans are kept verbatim; only the fences and backticks are removed.
+func StripMarkup(content string) string {
+	var out []string
+	inFence := false
+	fence := ""
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if marker, ok := fenceMarker(trimmed); ok && (!inFence || marker == fence) {
+			inFence = !inFence
+			fence = marker
+			continue
+		}
+		if inFence {
+			out = append(out, line)
+			continue
+		}
+
+		if markupRulePattern.MatchString(trimmed) {
+			continue
+		}
+
+		out = append(out, stripMarkupLine(line))
+	}
+
+	// Collapse the blank lines left behind by removed fences, rules and tags
+	var result []string
+	blank := false
+	for _, line := range out {
+		if strings.TrimSpace(line) == "" {
+			if !blank && len(result) > 0 {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		result = append(result, line)
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// fenceMarker reports whether a line opens or closes a fenced code block
+func fenceMarker(line string) (string, bool) {
+	for _, prefix := range markupFenceLinePrefix {
+		if strings.HasPrefix(line, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// stripMarkupLine removes inline formatting from a single line outside code blocks
+func stripMarkupLine(line string) string {
+	line = markupHeadingPattern.ReplaceAllString(line, "")
+	line = markupQuotePattern.ReplaceAllString(line, "")
+	line = markupHTMLBreak.ReplaceAllString(line, " ")
+
+	// Odd segments are inline code spans, which are kept as-is
+	segments := strings.Split(line, "`")
+	if len(segments)%2 == 0 {
+		// Unbalanced backtick: treat the trailing one as literal text
+		segments[len(segments)-2] += "`" + segments[len(segments)-1]
+		segments = segments[:len(segments)-1]
+	}
+	for i := 0; i < len(segments); i += 2 {
+		segments[i] = stripInlineMarkup(segments[i])
+	}
+
+	line = strings.Join(segments, "")
+	return strings.TrimSpace(markupMultipleSpaces.ReplaceAllString(line, " "))
+}
+
+// stripInlineMarkup removes emphasis, links and HTML from text outside code spans
+func stripInlineMarkup(text string) string {
+	text = markupImagePattern.ReplaceAllString(text, "$1")
+	text = markupLinkPattern.ReplaceAllString(text, "$1 ($2)")
+	text = markupBoldPattern.ReplaceAllString(text, "$2")
+	text = markupStrikePattern.ReplaceAllString(text, "$1")
+	text = markupItalicPattern.ReplaceAllString(text, "$1$2$3")
+	text = markupHTMLTagPattern.ReplaceAllString(text, "")
+	return html.UnescapeString(text)
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestStripMarkup(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "headings",
+			input:    "# Title\n## Section\nBody text",
+			expected: "Title\nSection\nBody text",
+		},
+		{
+			name:     "links and images",
+			input:    "See [the docs](https://example.com/docs) and ![diagram](img.png).",
+			expected: "See the docs (https://example.com/docs) and diagram.",
+		},
+		{
+			name:     "emphasis",
+			input:    "This is **bold**, *italic*, __strong__, _em_ and ~~gone~~.",
+			expected: "This is bold, italic, strong, em and gone.",
+		},
+		{
+			name:     "snake_case is untouched",
+			input:    "Set max_turns and retry_initial_delay",
+			expected: "Set max_turns and retry_initial_delay",
+		},
+		{
+			name:     "html tags and entities",
+			input:    "<p>Hello <b>world</b><br/>next &amp; last</p>",
+			expected: "Hello world next & last",
+		},
+		{
+			name:     "blockquotes and rules",
+			input:    "> quoted line\n\n---\n\nafter",
+			expected: "quoted line\n\nafter",
+		},
+		{
+			name:     "code fence contents preserved",
+			input:    "Run this:\n\n```go\nfunc main() {\n    fmt.Println(\"**not bold**\")\n}\n```\n\nDone.",
+			expected: "Run this:\n\nfunc main() {\n    fmt.Println(\"**not bold**\")\n}\n\nDone.",
+		},
+		{
+			name:     "inline code preserved",
+			input:    "Use `a*b*c` or `<div>` in **text**",
+			expected: "Use a*b*c or <div> in text",
+		},
+		{
+			name:     "list bullets kept",
+			input:    "- first item\n- second **item**",
+			expected: "- first item\n- second item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripMarkup(tt.input); got != tt.expected {
+				t.Errorf("StripMarkup() =\n%q\nwant\n%q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripMarkupMiddleware(t *testing.T) {
+	chain := NewChain(StripMarkupMiddleware())
+	ctx := &MessageContext{
+		Ctx:      context.Background(),
+		AgentID:  "test",
+		Metadata: make(map[string]interface{}),
+	}
+
+	result, err := chain.Process(ctx, &agent.Message{Content: "## Plan\n\n1. **Ship** it"})
+	if err != nil {
+		t.Fatalf("StripMarkupMiddleware failed: %v", err)
+	}
+	if want := "Plan\n\n1. Ship it"; result.Content != want {
+		t.Errorf("expected %q, got %q", want, result.Content)
+	}
+}
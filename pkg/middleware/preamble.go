@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// DefaultPreamblePhrases are the openers StripPreambleMiddleware looks for when no
+// phrases are configured
+var DefaultPreamblePhrases = []string{
+	"As an AI language model",
+	"As a large language model",
+	"As an AI assistant",
+	"As an AI",
+	"Sure",
+	"Certainly",
+	"Of course",
+	"Absolutely",
+	"Great question",
+}
+
+// StripPreamble removes the first sentence of content when it opens with one of the
+// phrases (case-insensitive, as whole words), such as "As an AI language model, I
+// don't have opinions." or "Sure!". Only that one leading sentence is removed, and
+// content is returned unchanged when nothing would be left after it.
+func StripPreamble(content string, phrases []string) string {
+	trimmed := strings.TrimLeft(content, " \t\r\n")
+	if !startsWithPhrase(trimmed, phrases) {
+		return content
+	}
+
+	end := sentenceEnd(trimmed)
+	if end < 0 {
+		return content
+	}
+	rest := strings.TrimLeft(trimmed[end:], " \t\r\n")
+	if rest == "" {
+		return content
+	}
+	return rest
+}
+
+// startsWithPhrase reports whether text begins with one of the phrases followed by a
+// word boundary, so "Sure" doesn't match "Surely"
+func startsWithPhrase(text string, phrases []string) bool {
+	for _, phrase := range phrases {
+		phrase = strings.TrimSpace(phrase)
+		if phrase == "" || len(text) < len(phrase) || !strings.EqualFold(text[:len(phrase)], phrase) {
+			continue
+		}
+		next, _ := utf8.DecodeRuneInString(text[len(phrase):])
+		if len(text) == len(phrase) || !(unicode.IsLetter(next) || unicode.IsDigit(next)) {
+			return true
+		}
+	}
+	return false
+}
+
+// sentenceEnd returns the index just past the first sentence of text: its first
+// ".", "!" or "?" followed by whitespace, or its first line break. It returns -1 when
+// text is a single sentence.
+func sentenceEnd(text string) int {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			return i
+		case '.', '!', '?':
+			if i+1 < len(text) && (text[i+1] == ' ' || text[i+1] == '\t' || text[i+1] == '\r' || text[i+1] == '\n') {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// StripPreambleMiddleware creates middleware that removes a boilerplate opening
+// sentence ("As an AI language model, ...", "Sure! ...") from agent responses, for
+// cleaner transcripts. Empty phrases use DefaultPreamblePhrases. It is opt-in and not
+// part of the default chain.
+func StripPreambleMiddleware(phrases []string) Middleware {
+	if len(phrases) == 0 {
+		phrases = DefaultPreamblePhrases
+	}
+	return NewTransformMiddleware("strip-preamble", func(ctx *MessageContext, msg *agent.Message) (*agent.Message, error) {
+		if msg.Role == "agent" {
+			msg.Content = StripPreamble(msg.Content, phrases)
+		}
+		return msg, nil
+	})
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestStripPreamble(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "AI disclaimer",
+			content: "As an AI language model, I don't have personal opinions. Tabs keep diffs smaller.",
+			want:    "Tabs keep diffs smaller.",
+		},
+		{
+			name:    "interjection",
+			content: "Sure! Here's a plan:\n1. Measure\n2. Fix",
+			want:    "Here's a plan:\n1. Measure\n2. Fix",
+		},
+		{
+			name:    "case-insensitive with leading whitespace",
+			content: "\n  CERTAINLY. The answer is 42.",
+			want:    "The answer is 42.",
+		},
+		{
+			name:    "line break ends the preamble",
+			content: "Of course\n\nThe migration needs two steps.",
+			want:    "The migration needs two steps.",
+		},
+		{
+			name:    "no preamble",
+			content: "Tabs keep diffs smaller. Spaces look consistent.",
+			want:    "Tabs keep diffs smaller. Spaces look consistent.",
+		},
+		{
+			name:    "phrase inside a word",
+			content: "Surely not. We should measure first.",
+			want:    "Surely not. We should measure first.",
+		},
+		{
+			name:    "phrase later in the response",
+			content: "I agree. Sure, as an AI I would say so. Next point.",
+			want:    "I agree. Sure, as an AI I would say so. Next point.",
+		},
+		{
+			name:    "only one sentence",
+			content: "Sure, tabs are better.",
+			want:    "Sure, tabs are better.",
+		},
+		{
+			name:    "only one leading sentence is stripped",
+			content: "Sure! Certainly! Tabs.",
+			want:    "Certainly! Tabs.",
+		},
+		{
+			name:    "decimal point is not a sentence end",
+			content: "Absolutely, version 2.5 is out. Upgrade today.",
+			want:    "Upgrade today.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripPreamble(tt.content, DefaultPreamblePhrases); got != tt.want {
+				t.Errorf("StripPreamble(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripPreambleCustomPhrases(t *testing.T) {
+	phrases := []string{"Great point"}
+	if got := StripPreamble("Great point, Bob. Let's vote.", phrases); got != "Let's vote." {
+		t.Errorf("expected the custom phrase to be stripped, got %q", got)
+	}
+	if got := StripPreamble("Sure! Let's vote.", phrases); got != "Sure! Let's vote." {
+		t.Errorf("expected defaults not to apply when phrases are configured, got %q", got)
+	}
+}
+
+func TestStripPreambleMiddleware(t *testing.T) {
+	ctx := &MessageContext{Ctx: context.Background(), Metadata: make(map[string]interface{})}
+	chain := NewChain(StripPreambleMiddleware(nil))
+
+	msg := &agent.Message{Role: "agent", Content: "As an AI, I can't vote. But option B is cheaper."}
+	result, err := chain.Process(ctx, msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Content != "But option B is cheaper." {
+		t.Errorf("expected the preamble to be stripped, got %q", result.Content)
+	}
+
+	user := &agent.Message{Role: "user", Content: "Sure! Go ahead."}
+	result, err = chain.Process(ctx, user)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Content != "Sure! Go ahead." {
+		t.Errorf("expected user messages to be left alone, got %q", result.Content)
+	}
+}
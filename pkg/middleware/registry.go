@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory creates a middleware instance for use in a configured chain.
+type Factory func() Middleware
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"logging":          LoggingMiddleware,
+		"metrics":          MetricsMiddleware,
+		"sanitization":     func() Middleware { return SanitizationMiddleware(false) },
+		"strip-markup":     StripMarkupMiddleware,
+		"strip-self-label": StripSelfLabelMiddleware,
+		"empty-content":    EmptyContentValidationMiddleware,
+		"error-recovery":   ErrorRecoveryMiddleware,
+	}
+)
+
+// Register makes a middleware available by name for config-driven chains.
+// Registering an existing name replaces its factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Names returns the registered middleware names in sorted order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// New creates the middleware registered under name.
+func New(name string) (Middleware, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown middleware %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(), nil
+}
+
+// FromNames creates middleware for each name, in order.
+// It fails on the first unknown name so misconfigured chains are caught before a conversation starts.
+func FromNames(names []string) ([]Middleware, error) {
+	chain := make([]Middleware, 0, len(names))
+	for _, name := range names {
+		m, err := New(name)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, m)
+	}
+	return chain, nil
+}
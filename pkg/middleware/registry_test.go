@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestRegistryNew(t *testing.T) {
+	for _, name := range []string{"logging", "metrics", "sanitization", "strip-markup", "strip-self-label", "empty-content", "error-recovery"} {
+		m, err := New(name)
+		if err != nil {
+			t.Errorf("New(%q) failed: %v", name, err)
+			continue
+		}
+		if m.Name() != name {
+			t.Errorf("New(%q) returned middleware named %q", name, m.Name())
+		}
+	}
+
+	_, err := New("no-such-middleware")
+	if err == nil {
+		t.Fatal("expected error for unknown middleware")
+	}
+	if !strings.Contains(err.Error(), `"no-such-middleware"`) || !strings.Contains(err.Error(), "strip-markup") {
+		t.Errorf("expected error to name the unknown middleware and list available ones, got %v", err)
+	}
+}
+
+func TestRegisterCustomMiddleware(t *testing.T) {
+	Register("test-shout", func() Middleware {
+		return NewTransformMiddleware("test-shout", func(ctx *MessageContext, msg *agent.Message) (*agent.Message, error) {
+			msg.Content = strings.ToUpper(msg.Content)
+			return msg, nil
+		})
+	})
+
+	found := false
+	for _, name := range Names() {
+		if name == "test-shout" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected registered middleware in Names(), got %v", Names())
+	}
+
+	if _, err := New("test-shout"); err != nil {
+		t.Errorf("expected registered middleware to be constructible: %v", err)
+	}
+}
+
+func TestFromNames(t *testing.T) {
+	chain, err := FromNames([]string{"strip-markup", "sanitization"})
+	if err != nil {
+		t.Fatalf("FromNames failed: %v", err)
+	}
+	if len(chain) != 2 || chain[0].Name() != "strip-markup" || chain[1].Name() != "sanitization" {
+		t.Fatalf("expected chain in config order, got %v", chain)
+	}
+
+	ctx := &MessageContext{
+		Ctx:      context.Background(),
+		AgentID:  "test",
+		Metadata: make(map[string]interface{}),
+	}
+	result, err := NewChain(chain...).Process(ctx, &agent.Message{Content: "  **Hello**   world  "})
+	if err != nil {
+		t.Fatalf("chain processing failed: %v", err)
+	}
+	if result.Content != "Hello world" {
+		t.Errorf("expected %q, got %q", "Hello world", result.Content)
+	}
+
+	if _, err := FromNames([]string{"logging", "bogus"}); err == nil || !strings.Contains(err.Error(), "bogus") {
+		t.Errorf("expected error for unknown middleware in list, got %v", err)
+	}
+
+	if chain, err := FromNames(nil); err != nil || len(chain) != 0 {
+		t.Errorf("expected empty chain for no names, got %v, %v", chain, err)
+	}
+}
@@ -0,0 +1,267 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// SchemaError reports an agent response that doesn't match its JSON schema.
+type SchemaError struct {
+	// Path locates the offending value, e.g. "$.items[2].name"
+	Path string
+	// Reason describes the mismatch
+	Reason string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("response does not match schema: %s: %s", e.Path, e.Reason)
+}
+
+// ValidateResponse checks that content is a JSON document matching schema. A surrounding
+// markdown code fence is ignored, since models often wrap JSON in one. Mismatches are
+// returned as a *SchemaError.
+//
+// The supported subset of JSON Schema is: type (a name or a list of names), enum,
+// properties, required, additionalProperties, items, minItems, maxItems, minLength,
+// maxLength, minimum and maximum.
+func ValidateResponse(content string, schema map[string]interface{}) error {
+	var value interface{}
+	if err := json.Unmarshal([]byte(unfenceJSON(content)), &value); err != nil {
+		return &SchemaError{Path: "$", Reason: "not valid JSON: " + err.Error()}
+	}
+	return validateValue("$", value, schema)
+}
+
+// unfenceJSON strips a markdown code fence (``` or ```json) around content
+func unfenceJSON(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") || !strings.HasSuffix(content, "```") || len(content) < 6 {
+		return content
+	}
+	body := strings.TrimSuffix(content[3:], "```")
+	if newline := strings.IndexByte(body, '\n'); newline >= 0 {
+		// Drop the info string, e.g. "json"
+		body = body[newline+1:]
+	}
+	return strings.TrimSpace(body)
+}
+
+func validateValue(path string, value interface{}, schema map[string]interface{}) error {
+	if types, ok := schemaTypes(schema["type"]); ok && !matchesAnyType(value, types) {
+		return &SchemaError{Path: path, Reason: fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), jsonType(value))}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		return &SchemaError{Path: path, Reason: fmt.Sprintf("value %s is not one of the allowed values", compactJSON(value))}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateObject(path, v, schema)
+	case []interface{}:
+		return validateArray(path, v, schema)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if limit, ok := schemaNumber(schema["minLength"]); ok && float64(length) < limit {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("string shorter than %v characters", limit)}
+		}
+		if limit, ok := schemaNumber(schema["maxLength"]); ok && float64(length) > limit {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("string longer than %v characters", limit)}
+		}
+	case float64:
+		if limit, ok := schemaNumber(schema["minimum"]); ok && v < limit {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("%v is less than the minimum %v", v, limit)}
+		}
+		if limit, ok := schemaNumber(schema["maximum"]); ok && v > limit {
+			return &SchemaError{Path: path, Reason: fmt.Sprintf("%v is greater than the maximum %v", v, limit)}
+		}
+	}
+	return nil
+}
+
+func validateObject(path string, obj map[string]interface{}, schema map[string]interface{}) error {
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, present := obj[name]; !present {
+				return &SchemaError{Path: path, Reason: fmt.Sprintf("missing required property %q", name)}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		childPath := path + "." + key
+		if propSchema, ok := properties[key].(map[string]interface{}); ok {
+			if err := validateValue(childPath, obj[key], propSchema); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, declared := properties[key]; declared {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return &SchemaError{Path: path, Reason: fmt.Sprintf("unexpected property %q", key)}
+			}
+		case map[string]interface{}:
+			if err := validateValue(childPath, obj[key], additional); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateArray(path string, arr []interface{}, schema map[string]interface{}) error {
+	if limit, ok := schemaNumber(schema["minItems"]); ok && float64(len(arr)) < limit {
+		return &SchemaError{Path: path, Reason: fmt.Sprintf("expected at least %v items, got %d", limit, len(arr))}
+	}
+	if limit, ok := schemaNumber(schema["maxItems"]); ok && float64(len(arr)) > limit {
+		return &SchemaError{Path: path, Reason: fmt.Sprintf("expected at most %v items, got %d", limit, len(arr))}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range arr {
+			if err := validateValue(fmt.Sprintf("%s[%d]", path, i), item, items); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// schemaTypes returns the type names allowed by a schema's "type" keyword
+func schemaTypes(raw interface{}) ([]string, bool) {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}, true
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types, len(types) > 0
+	}
+	return nil, false
+}
+
+func matchesAnyType(value interface{}, types []string) bool {
+	actual := jsonType(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON Schema type of a decoded JSON value
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaNumber reads a numeric keyword, which YAML may decode as an int or a float
+func schemaNumber(raw interface{}) (float64, bool) {
+	switch n := raw.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// containsValue reports whether value equals one of the enum values, comparing
+// their JSON encodings so YAML integers match decoded JSON numbers
+func containsValue(enum []interface{}, value interface{}) bool {
+	encoded := compactJSON(value)
+	for _, allowed := range enum {
+		if compactJSON(allowed) == encoded {
+			return true
+		}
+	}
+	return false
+}
+
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// SchemaReminder returns the instruction sent to an agent whose response broke its
+// schema, so the retried response can correct it.
+func SchemaReminder(schema map[string]interface{}, err error) string {
+	data, marshalErr := json.Marshal(schema)
+	if marshalErr != nil {
+		data = []byte(fmt.Sprint(schema))
+	}
+	return fmt.Sprintf("Your previous response was rejected (%v). Your response must match this JSON schema; "+
+		"reply with only the JSON document:\n%s", err, data)
+}
+
+// SchemaValidationMiddleware creates middleware that checks agent responses against
+// their agent's JSON schema, keyed by agent ID. Agents without a schema pass through.
+// A non-conforming response is rejected when reject is set, failing the agent's turn;
+// otherwise it is kept and flagged with a "schema_error" metadata entry.
+func SchemaValidationMiddleware(schemas map[string]map[string]interface{}, reject bool) Middleware {
+	return NewMiddlewareFunc("schema-validation", func(ctx *MessageContext, msg *agent.Message, next ProcessFunc) (*agent.Message, error) {
+		schema, ok := schemas[msg.AgentID]
+		if !ok || msg.Role != "agent" {
+			return next(ctx, msg)
+		}
+
+		if err := ValidateResponse(msg.Content, schema); err != nil {
+			if reject {
+				return nil, err
+			}
+			log.WithFields(map[string]interface{}{
+				"agent_name": msg.AgentName,
+				"turn":       ctx.TurnNumber,
+			}).WithError(err).Warn("agent response does not match its schema")
+			if msg.Metadata == nil {
+				msg.Metadata = make(map[string]interface{})
+			}
+			msg.Metadata["schema_error"] = err.Error()
+		}
+		return next(ctx, msg)
+	})
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// verdictSchema is decoded the way YAML decodes a response_schema
+var verdictSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"verdict", "confidence"},
+	"properties": map[string]interface{}{
+		"verdict":    map[string]interface{}{"type": "string", "enum": []interface{}{"yes", "no"}},
+		"confidence": map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+		"reasons": map[string]interface{}{
+			"type":     "array",
+			"minItems": 1,
+			"items":    map[string]interface{}{"type": "string", "minLength": 3},
+		},
+	},
+	"additionalProperties": false,
+}
+
+func TestValidateResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"valid", `{"verdict": "yes", "confidence": 0.9}`, ""},
+		{"valid with array", `{"verdict": "no", "confidence": 1, "reasons": ["too slow"]}`, ""},
+		{"code fence", "```json\n{\"verdict\": \"yes\", \"confidence\": 0.5}\n```", ""},
+		{"not json", "I think yes.", "$: not valid JSON"},
+		{"wrong root type", `["yes"]`, "$: expected object, got array"},
+		{"missing required", `{"verdict": "yes"}`, `missing required property "confidence"`},
+		{"enum", `{"verdict": "maybe", "confidence": 0.5}`, `$.verdict: value "maybe" is not one of the allowed values`},
+		{"maximum", `{"verdict": "yes", "confidence": 2}`, "$.confidence: 2 is greater than the maximum 1"},
+		{"extra property", `{"verdict": "yes", "confidence": 0.5, "note": "x"}`, `unexpected property "note"`},
+		{"min items", `{"verdict": "yes", "confidence": 0.5, "reasons": []}`, "$.reasons: expected at least 1 items"},
+		{"item type", `{"verdict": "yes", "confidence": 0.5, "reasons": ["good", 4]}`, "$.reasons[1]: expected string, got integer"},
+		{"item min length", `{"verdict": "yes", "confidence": 0.5, "reasons": ["ok"]}`, "$.reasons[0]: string shorter than 3 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResponse(tt.content, verdictSchema)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected a valid response, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.wantErr)
+			}
+			var schemaErr *SchemaError
+			if !errors.As(err, &schemaErr) {
+				t.Errorf("expected a *SchemaError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateResponseTypeList(t *testing.T) {
+	schema := map[string]interface{}{"type": []interface{}{"string", "null"}}
+	if err := ValidateResponse(`null`, schema); err != nil {
+		t.Errorf("expected null to match, got %v", err)
+	}
+	if err := ValidateResponse(`3`, schema); err == nil {
+		t.Error("expected an integer not to match a string-or-null schema")
+	}
+}
+
+func TestSchemaValidationMiddleware(t *testing.T) {
+	schemas := map[string]map[string]interface{}{"judge": verdictSchema}
+	ctx := &MessageContext{Ctx: context.Background(), AgentID: "judge", Metadata: make(map[string]interface{})}
+
+	t.Run("flags a mismatch", func(t *testing.T) {
+		msg := &agent.Message{AgentID: "judge", Role: "agent", Content: "yes"}
+		result, err := NewChain(SchemaValidationMiddleware(schemas, false)).Process(ctx, msg)
+		if err != nil {
+			t.Fatalf("expected the response to be kept, got %v", err)
+		}
+		if _, ok := result.Metadata["schema_error"].(string); !ok {
+			t.Errorf("expected a schema_error flag, got %v", result.Metadata)
+		}
+	})
+
+	t.Run("rejects a mismatch", func(t *testing.T) {
+		msg := &agent.Message{AgentID: "judge", Role: "agent", Content: "yes"}
+		if _, err := NewChain(SchemaValidationMiddleware(schemas, true)).Process(ctx, msg); err == nil {
+			t.Error("expected the response to be rejected")
+		}
+	})
+
+	t.Run("passes a match", func(t *testing.T) {
+		msg := &agent.Message{AgentID: "judge", Role: "agent", Content: `{"verdict": "no", "confidence": 0.1}`}
+		result, err := NewChain(SchemaValidationMiddleware(schemas, true)).Process(ctx, msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Metadata != nil {
+			t.Errorf("expected no flag on a valid response, got %v", result.Metadata)
+		}
+	})
+
+	t.Run("ignores agents without a schema", func(t *testing.T) {
+		msg := &agent.Message{AgentID: "chatter", Role: "agent", Content: "free text"}
+		if _, err := NewChain(SchemaValidationMiddleware(schemas, true)).Process(ctx, msg); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestSchemaReminder(t *testing.T) {
+	reminder := SchemaReminder(verdictSchema, &SchemaError{Path: "$", Reason: "not valid JSON"})
+	if !strings.Contains(reminder, "must match this JSON schema") {
+		t.Errorf("expected the reminder to ask for the schema, got %q", reminder)
+	}
+	if !strings.Contains(reminder, `"required":["verdict","confidence"]`) {
+		t.Errorf("expected the reminder to include the schema, got %q", reminder)
+	}
+	if !strings.Contains(reminder, "not valid JSON") {
+		t.Errorf("expected the reminder to include the problem, got %q", reminder)
+	}
+}
@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/middleware"
+)
+
+// ConfigFrom builds the orchestrator configuration for a loaded config file, so the
+// CLI and both TUIs run conversations with the same settings. Defaults for fields left
+// unset are applied by NewOrchestrator.
+func ConfigFrom(cfg *config.Config) OrchestratorConfig {
+	return OrchestratorConfig{
+		Mode:                   ConversationMode(cfg.Orchestrator.Mode),
+		TurnTimeout:            cfg.Orchestrator.TurnTimeout,
+		AgentTimeouts:          cfg.AgentTimeouts(),
+		AgentMaxTurns:          cfg.AgentMaxTurns(),
+		AgentTeams:             cfg.AgentTeams(),
+		MaxTurns:               cfg.Orchestrator.MaxTurns,
+		WarmupTurns:            cfg.Orchestrator.WarmupTurns,
+		ResponseDelay:          cfg.Orchestrator.ResponseDelay,
+		InitialPrompt:          cfg.Orchestrator.InitialPrompt,
+		StyleGuide:             cfg.StyleGuide,
+		Scenario:               cfg.Scenario.Message(),
+		KickoffPrompt:          cfg.Orchestrator.KickoffPrompt,
+		RoleReminderInterval:   cfg.Orchestrator.RoleReminderInterval,
+		DevilsAdvocateInterval: cfg.Orchestrator.DevilsAdvocateInterval,
+		ClosingRound:           cfg.Orchestrator.ClosingRound,
+		PromptSuffix:           cfg.Orchestrator.PromptSuffix,
+		SuppressAnnouncements:  cfg.Orchestrator.SuppressAnnouncements,
+		Explain:                cfg.Orchestrator.Explain,
+		Prewarm:                cfg.Orchestrator.Prewarm,
+		StreamResponses:        cfg.Orchestrator.StreamResponses,
+		StartupDelay:           cfg.Orchestrator.StartupDelay,
+		Referee:                cfg.Orchestrator.Referee,
+		Voting:                 cfg.Orchestrator.Voting,
+		EndConditions:          cfg.Orchestrator.EndConditions,
+		BillingTag:             cfg.Orchestrator.BillingTag,
+		MaxTotalRetries:        cfg.Orchestrator.MaxTotalRetries,
+		RetriesDisabled:        cfg.Orchestrator.RetriesDisabled,
+		RetryableErrors:        cfg.Orchestrator.RetryableErrors,
+		CollapseCheckEnabled:   cfg.Orchestrator.CollapseCheckEnabled,
+		CollapseThreshold:      cfg.Orchestrator.CollapseThreshold,
+		MaxHistoryMessages:     cfg.Orchestrator.MaxHistoryMessages,
+		TrimStrategy:           TrimStrategy(cfg.Orchestrator.TrimStrategy),
+		SelectionStrategy:      SelectionStrategy(cfg.Orchestrator.SelectionStrategy),
+		SelectionWeights:       cfg.Orchestrator.SelectionWeights,
+		MentionRouting:         cfg.Orchestrator.MentionRouting,
+		DriftCheckEnabled:      cfg.Orchestrator.DriftCheckEnabled,
+		DriftCheckInterval:     cfg.Orchestrator.DriftCheckInterval,
+		DriftThreshold:         cfg.Orchestrator.DriftThreshold,
+		Summary:                cfg.Orchestrator.Summary,
+		MaxConsecutiveFailures: cfg.Orchestrator.MaxConsecutiveFailures,
+		MaxEmptyPasses:         cfg.Orchestrator.MaxEmptyPasses,
+		ResponseSchemas:        cfg.AgentResponseSchemas(),
+		RejectSchemaMismatch:   cfg.Orchestrator.RejectSchemaMismatch,
+		RenderWidth:            cfg.Orchestrator.RenderWidth,
+	}
+}
+
+// MiddlewareFrom builds the response middleware a config file asks for, in the order the
+// CLI and both TUIs add it: the named chain, then preamble stripping so the content rules
+// see the response without its boilerplate opener, then content validation.
+func MiddlewareFrom(cfg *config.Config) ([]middleware.Middleware, error) {
+	chain, err := middleware.FromNames(cfg.Middleware)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.StripPreamble.Enabled {
+		chain = append(chain, middleware.StripPreambleMiddleware(cfg.StripPreamble.Phrases))
+	}
+
+	if cv := cfg.ContentValidation; cv.Enabled() {
+		chain = append(chain, middleware.ContentValidationMiddleware(middleware.ContentRules{
+			MinLength: cv.MinLength,
+			MaxLength: cv.MaxLength,
+			Required:  cv.Required,
+			Forbidden: cv.Forbidden,
+		}))
+	}
+	return chain, nil
+}
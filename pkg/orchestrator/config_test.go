@@ -0,0 +1,76 @@
+package orchestrator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+func TestConfigFrom(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Orchestrator.Mode = "reactive"
+	cfg.Orchestrator.PromptSuffix = "Be brief."
+	cfg.Orchestrator.RetryableErrors = []string{"timeout"}
+	cfg.Orchestrator.MaxConsecutiveFailures = 3
+	cfg.Orchestrator.MaxEmptyPasses = 2
+	cfg.Orchestrator.MaxHistoryMessages = 10
+	cfg.Orchestrator.TrimStrategy = "importance"
+	cfg.Orchestrator.MentionRouting = true
+	cfg.Orchestrator.SelectionStrategy = "weighted"
+	cfg.Orchestrator.SelectionWeights = map[string]float64{"a": 2}
+	cfg.Orchestrator.DriftCheckEnabled = true
+	cfg.Orchestrator.DriftCheckInterval = 4
+	cfg.Orchestrator.DriftThreshold = 0.6
+	cfg.Orchestrator.StreamResponses = true
+	cfg.Orchestrator.RenderWidth = 80
+
+	got := ConfigFrom(cfg)
+	if got.Mode != ModeReactive || got.PromptSuffix != "Be brief." || got.RenderWidth != 80 || !got.StreamResponses {
+		t.Errorf("unexpected basic settings: %+v", got)
+	}
+	if len(got.RetryableErrors) != 1 || got.MaxConsecutiveFailures != 3 || got.MaxEmptyPasses != 2 {
+		t.Errorf("expected the failure handling settings to be copied, got %+v", got)
+	}
+	if got.MaxHistoryMessages != 10 || got.TrimStrategy != TrimImportance {
+		t.Errorf("expected the history settings to be copied, got %+v", got)
+	}
+	if !got.MentionRouting || got.SelectionStrategy != SelectWeighted || got.SelectionWeights["a"] != 2 {
+		t.Errorf("expected the speaker selection settings to be copied, got %+v", got)
+	}
+	if !got.DriftCheckEnabled || got.DriftCheckInterval != 4 || got.DriftThreshold != 0.6 {
+		t.Errorf("expected the drift settings to be copied, got %+v", got)
+	}
+	if got.Summary != cfg.Orchestrator.Summary {
+		t.Errorf("expected the summary settings to be copied, got %+v", got.Summary)
+	}
+}
+
+func TestMiddlewareFrom(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	chain, err := MiddlewareFrom(cfg)
+	if err != nil || len(chain) != 0 {
+		t.Fatalf("expected no middleware for a default config, got %d (err %v)", len(chain), err)
+	}
+
+	cfg.Middleware = []string{"strip-markup"}
+	cfg.StripPreamble.Enabled = true
+	cfg.ContentValidation.MinLength = 5
+	chain, err = MiddlewareFrom(cfg)
+	if err != nil {
+		t.Fatalf("MiddlewareFrom() unexpected error: %v", err)
+	}
+	var names []string
+	for _, m := range chain {
+		names = append(names, m.Name())
+	}
+	want := []string{"strip-markup", "strip-preamble", "content-validation"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("expected middleware %v, got %v", want, names)
+	}
+
+	cfg.Middleware = []string{"no-such-middleware"}
+	if _, err := MiddlewareFrom(cfg); err == nil {
+		t.Error("expected an unknown middleware name to fail")
+	}
+}
@@ -0,0 +1,50 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// devilsAdvocatePrefix marks devil's advocate directives in the transcript
+const devilsAdvocatePrefix = "[Devil's advocate]"
+
+// injectDevilsAdvocate posts a HOST directive addressed to a, the agent about to speak,
+// asking it to challenge the prevailing view whenever DevilsAdvocateInterval turns have
+// passed. The directive is public and carries the "devils_advocate" metadata key, with
+// the addressed agent under "target_agent_id". It is skipped during warmup and the
+// closing round, and posted at most once per turn, so a failed turn isn't re-directed.
+func (o *Orchestrator) injectDevilsAdvocate(a agent.Agent) {
+	if o.config.DevilsAdvocateInterval <= 0 {
+		return
+	}
+
+	o.mu.Lock()
+	turn := o.currentTurnNumber
+	due := turn > 0 && turn%o.config.DevilsAdvocateInterval == 0 && o.advocateTurn != turn &&
+		!o.inWarmup && !o.inClosing
+	if due {
+		o.advocateTurn = turn
+	}
+	o.mu.Unlock()
+	if !due {
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent_id":   a.GetID(),
+		"agent_name": a.GetName(),
+		"turn":       turn,
+	}).Debug("injecting devil's advocate directive")
+
+	o.InjectMessage(agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		AgentType: "system",
+		Role:      "system",
+		Content: fmt.Sprintf("%s %s, play devil's advocate this turn: challenge the view the conversation is "+
+			"settling on, point out its weaknesses and make the strongest case for an alternative.", devilsAdvocatePrefix, a.GetName()),
+		Metadata: map[string]interface{}{"devils_advocate": true, "target_agent_id": a.GetID()},
+	})
+}
@@ -0,0 +1,97 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func devilsAdvocateAgents() []*scriptedAgent {
+	var agents []*scriptedAgent
+	for _, name := range []string{"Alpha", "Beta", "Gamma"} {
+		agents = append(agents, &scriptedAgent{
+			MockAgent: MockAgent{id: strings.ToLower(name), name: name, agentType: "mock", available: true},
+			responses: []string{name + " agrees."},
+		})
+	}
+	return agents
+}
+
+func TestDevilsAdvocateCadence(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                   ModeRoundRobin,
+		MaxTurns:               2,
+		TurnTimeout:            time.Second,
+		ResponseDelay:          time.Millisecond,
+		DevilsAdvocateInterval: 2,
+	}, nil)
+	agents := devilsAdvocateAgents()
+	for _, a := range agents {
+		orch.AddAgent(a)
+	}
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Six responses with an interval of 2: directives precede the 3rd and 5th, which
+	// round-robin gives to Gamma and Beta
+	var targets []string
+	turns := 0
+	messages := orch.GetMessages()
+	for i, msg := range messages {
+		if msg.Role == "agent" {
+			turns++
+			continue
+		}
+		if msg.Metadata["devils_advocate"] != true {
+			continue
+		}
+		if turns%2 != 0 {
+			t.Errorf("expected directives only after every 2 turns, got one after %d", turns)
+		}
+		if !strings.HasPrefix(msg.Content, devilsAdvocatePrefix) || msg.AgentName != "HOST" {
+			t.Errorf("expected a marked HOST directive, got %s: %q", msg.AgentName, msg.Content)
+		}
+		target, _ := msg.Metadata["target_agent_id"].(string)
+		if i+1 >= len(messages) || messages[i+1].AgentID != target {
+			t.Errorf("expected the directive to be answered by its target %q", target)
+		}
+		targets = append(targets, target)
+	}
+	if strings.Join(targets, ",") != "gamma,beta" {
+		t.Errorf("expected directives for gamma then beta, got %v", targets)
+	}
+
+	// The addressed speaker sees its directive as the last message of its request
+	gamma := agents[2]
+	request := gamma.requests[0]
+	if last := request[len(request)-1]; last.Metadata["devils_advocate"] != true || !strings.Contains(last.Content, "Gamma") {
+		t.Errorf("expected Gamma's request to end with its directive, got %q", last.Content)
+	}
+	if request := agents[0].requests[0]; request[len(request)-1].Metadata["devils_advocate"] == true {
+		t.Error("expected the opening turn not to be challenged")
+	}
+}
+
+func TestDevilsAdvocateDisabled(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	for _, a := range devilsAdvocateAgents() {
+		orch.AddAgent(a)
+	}
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, msg := range orch.GetMessages() {
+		if msg.Metadata["devils_advocate"] == true {
+			t.Fatalf("expected no directives when the interval is 0, got %q", msg.Content)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+const (
+	// defaultDriftCheckInterval is how many agent turns pass between drift checks
+	defaultDriftCheckInterval = 5
+	// defaultDriftThreshold is the drift score at which a refocus directive is injected
+	defaultDriftThreshold = 0.8
+)
+
+// DriftClassifier scores how far recent messages have drifted from the conversation topic.
+// Implementations should be cheap; they run every DriftCheckInterval turns.
+type DriftClassifier interface {
+	// DriftScore returns a value between 0 (on topic) and 1 (completely off topic)
+	DriftScore(ctx context.Context, topic string, recent []agent.Message) (float64, error)
+}
+
+// KeywordDriftClassifier is the default DriftClassifier.
+// It measures the share of topic keywords missing from the recent messages, so no model call is needed.
+type KeywordDriftClassifier struct{}
+
+// DriftScore returns the fraction of topic keywords that do not appear in the recent messages
+func (KeywordDriftClassifier) DriftScore(ctx context.Context, topic string, recent []agent.Message) (float64, error) {
+	topicWords := keywords(topic)
+	if len(topicWords) == 0 || len(recent) == 0 {
+		return 0, nil
+	}
+
+	var text strings.Builder
+	for _, msg := range recent {
+		text.WriteString(msg.Content)
+		text.WriteString(" ")
+	}
+	recentWords := keywords(text.String())
+
+	missing := 0
+	for word := range topicWords {
+		if !recentWords[word] {
+			missing++
+		}
+	}
+
+	return float64(missing) / float64(len(topicWords)), nil
+}
+
+// keywords extracts lowercase words of four or more letters, which skips most stop words
+func keywords(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make(map[string]bool, len(words))
+	for _, w := range words {
+		if len([]rune(w)) >= 4 {
+			result[w] = true
+		}
+	}
+	return result
+}
+
+// SetDriftClassifier replaces the classifier used for topic drift checks.
+// Pass nil to restore the default keyword-based classifier.
+// This method is thread-safe.
+func (o *Orchestrator) SetDriftClassifier(classifier DriftClassifier) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.driftClassifier = classifier
+}
+
+// checkTopicDrift runs the drift classifier every DriftCheckInterval agent turns and
+// injects a refocus directive when the score reaches DriftThreshold.
+func (o *Orchestrator) checkTopicDrift(ctx context.Context, agentTurns int) {
+	if !o.config.DriftCheckEnabled || o.config.InitialPrompt == "" {
+		return
+	}
+	if agentTurns == 0 || agentTurns%o.config.DriftCheckInterval != 0 {
+		return
+	}
+
+	o.mu.RLock()
+	classifier := o.driftClassifier
+	o.mu.RUnlock()
+	if classifier == nil {
+		classifier = KeywordDriftClassifier{}
+	}
+
+	// Only consider the agent messages produced since the last check
+	var recent []agent.Message
+	messages := o.getMessages()
+	for i := len(messages) - 1; i >= 0 && len(recent) < o.config.DriftCheckInterval; i-- {
+		if messages[i].Role == "agent" {
+			recent = append([]agent.Message{messages[i]}, recent...)
+		}
+	}
+
+	score, err := classifier.DriftScore(ctx, o.config.InitialPrompt, recent)
+	if err != nil {
+		log.WithError(err).Warn("topic drift check failed")
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"drift_score": score,
+		"threshold":   o.config.DriftThreshold,
+		"turn":        agentTurns,
+	}).Debug("topic drift checked")
+
+	if score < o.config.DriftThreshold {
+		return
+	}
+
+	o.InjectMessage(agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		AgentType: "system",
+		Role:      "system",
+		Content:   "The conversation seems to be drifting. Please refocus on the original topic: " + o.config.InitialPrompt,
+		Metadata:  map[string]interface{}{"drift_refocus": true, "drift_score": score},
+	})
+}
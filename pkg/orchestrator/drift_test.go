@@ -0,0 +1,131 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// mockDriftClassifier returns a fixed score and records how often it was called
+type mockDriftClassifier struct {
+	score  float64
+	err    error
+	calls  int
+	recent int
+}
+
+func (m *mockDriftClassifier) DriftScore(ctx context.Context, topic string, recent []agent.Message) (float64, error) {
+	m.calls++
+	m.recent = len(recent)
+	return m.score, m.err
+}
+
+func countRefocusDirectives(messages []agent.Message) int {
+	count := 0
+	for _, msg := range messages {
+		if refocus, ok := msg.Metadata["drift_refocus"].(bool); ok && refocus {
+			count++
+		}
+	}
+	return count
+}
+
+func TestCheckTopicDrift(t *testing.T) {
+	tests := []struct {
+		name            string
+		enabled         bool
+		classifier      *mockDriftClassifier
+		agentTurns      int
+		wantCalls       int
+		wantDirectives  int
+		wantRecentCount int
+	}{
+		{
+			name:       "disabled",
+			enabled:    false,
+			classifier: &mockDriftClassifier{score: 1},
+			agentTurns: 3,
+		},
+		{
+			name:       "not on interval",
+			enabled:    true,
+			classifier: &mockDriftClassifier{score: 1},
+			agentTurns: 2,
+		},
+		{
+			name:            "drift below threshold",
+			enabled:         true,
+			classifier:      &mockDriftClassifier{score: 0.3},
+			agentTurns:      3,
+			wantCalls:       1,
+			wantRecentCount: 3,
+		},
+		{
+			name:            "drift above threshold injects directive",
+			enabled:         true,
+			classifier:      &mockDriftClassifier{score: 0.9},
+			agentTurns:      3,
+			wantCalls:       1,
+			wantDirectives:  1,
+			wantRecentCount: 3,
+		},
+		{
+			name:            "classifier error is ignored",
+			enabled:         true,
+			classifier:      &mockDriftClassifier{score: 1, err: errors.New("classifier down")},
+			agentTurns:      6,
+			wantCalls:       1,
+			wantRecentCount: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:               ModeRoundRobin,
+				InitialPrompt:      "Discuss renewable energy storage",
+				DriftCheckEnabled:  tt.enabled,
+				DriftCheckInterval: 3,
+				DriftThreshold:     0.5,
+			}, io.Discard)
+			orch.SetDriftClassifier(tt.classifier)
+
+			for i := 0; i < 4; i++ {
+				orch.messages = append(orch.messages, agent.Message{Role: "agent", Content: "football scores"})
+			}
+
+			orch.checkTopicDrift(context.Background(), tt.agentTurns)
+
+			if tt.classifier.calls != tt.wantCalls {
+				t.Errorf("expected %d classifier calls, got %d", tt.wantCalls, tt.classifier.calls)
+			}
+			if tt.classifier.recent != tt.wantRecentCount {
+				t.Errorf("expected %d recent messages, got %d", tt.wantRecentCount, tt.classifier.recent)
+			}
+			if got := countRefocusDirectives(orch.GetMessages()); got != tt.wantDirectives {
+				t.Errorf("expected %d refocus directives, got %d", tt.wantDirectives, got)
+			}
+		})
+	}
+}
+
+func TestKeywordDriftClassifier(t *testing.T) {
+	topic := "Discuss renewable energy storage"
+	classifier := KeywordDriftClassifier{}
+
+	onTopic := []agent.Message{{Content: "Battery storage makes renewable energy practical."}}
+	offTopic := []agent.Message{{Content: "Who won the football match yesterday?"}}
+
+	onScore, _ := classifier.DriftScore(context.Background(), topic, onTopic)
+	offScore, _ := classifier.DriftScore(context.Background(), topic, offTopic)
+
+	if onScore >= offScore {
+		t.Errorf("expected on-topic score (%v) to be lower than off-topic score (%v)", onScore, offScore)
+	}
+	if offScore != 1 {
+		t.Errorf("expected fully drifted score 1, got %v", offScore)
+	}
+}
@@ -0,0 +1,148 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// EndCondition names the condition that ended a conversation.
+type EndCondition string
+
+const (
+	// EndStopPhrase means an agent response contained one of the stop phrases
+	EndStopPhrase EndCondition = "stop_phrase"
+	// EndConsensus means every agent's latest response contained the consensus phrase
+	EndConsensus EndCondition = "consensus"
+	// EndTokenBudget means agent responses used up the token budget
+	EndTokenBudget EndCondition = "token_budget"
+	// EndIdleTimeout means no agent responded successfully within the idle timeout
+	EndIdleTimeout EndCondition = "idle_timeout"
+	// EndMaxTurns means the conversation reached MaxTurns
+	EndMaxTurns EndCondition = "max_turns"
+	// EndQuotasExhausted means the agents used up their turn quotas
+	EndQuotasExhausted EndCondition = "quotas_exhausted"
+	// EndNoResponses means free-form passes kept ending without a successful response
+	EndNoResponses EndCondition = "no_responses"
+)
+
+// shouldEnd checks every end condition before a turn and returns the first one met,
+// with the message announcing it, or "" if the conversation goes on. When several are
+// met at once, conditions raised by the conversation itself (a stop phrase, then
+// consensus) take precedence over limits (token budget, idle timeout, max turns,
+// turn quotas). lastSpeaker is the agent that can't take the next turn, if any.
+func (o *Orchestrator) shouldEnd(turns int, lastSpeaker string) (EndCondition, string) {
+	ec := o.config.EndConditions
+
+	if phrase, agentName := o.stopPhraseSpoken(); phrase != "" {
+		return EndStopPhrase, fmt.Sprintf("%s said %q. Conversation ended.", agentName, phrase)
+	}
+	if o.consensusReached() {
+		return EndConsensus, "All agents reached consensus. Conversation ended."
+	}
+
+	o.mu.RLock()
+	tokens := o.cumulativeTokens
+	idle := time.Since(o.lastResponseAt)
+	o.mu.RUnlock()
+
+	if ec.TokenBudget > 0 && tokens >= ec.TokenBudget {
+		return EndTokenBudget, fmt.Sprintf("Token budget of %d used (%d tokens). Conversation ended.", ec.TokenBudget, tokens)
+	}
+	if ec.IdleTimeout > 0 && idle >= ec.IdleTimeout {
+		return EndIdleTimeout, fmt.Sprintf("No response for %s. Conversation ended.", ec.IdleTimeout)
+	}
+	if o.maxTurnsReached(turns) {
+		return EndMaxTurns, "Maximum turns reached. Conversation ended."
+	}
+	if endMsg, ok := o.quotasExhausted(lastSpeaker); ok {
+		return EndQuotasExhausted, endMsg
+	}
+	return "", ""
+}
+
+// endConversation records why the conversation ended and announces it. Reaching
+// MaxTurns first gives the agents their closing round, if enabled.
+func (o *Orchestrator) endConversation(ctx context.Context, condition EndCondition, endMsg string) {
+	if condition == EndMaxTurns {
+		o.runClosingRound(ctx)
+	}
+
+	o.mu.Lock()
+	o.endReason = condition
+	o.mu.Unlock()
+
+	log.WithField("condition", string(condition)).Info("ending conversation: end condition met")
+	if o.logger != nil {
+		o.logger.LogSystem(endMsg)
+	}
+	if o.writer != nil {
+		fmt.Fprintln(o.writer, "\n[System] "+endMsg)
+	}
+}
+
+// GetEndReason returns the condition that ended the conversation, or "" while it is
+// running or when it was interrupted or failed. This method is thread-safe.
+func (o *Orchestrator) GetEndReason() EndCondition {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.endReason
+}
+
+// stopPhraseSpoken returns the stop phrase in the latest agent response and the agent
+// that said it, or "" if it contains none
+func (o *Orchestrator) stopPhraseSpoken() (phrase, agentName string) {
+	phrases := o.config.EndConditions.StopPhrases
+	if len(phrases) == 0 {
+		return "", ""
+	}
+
+	messages := o.getMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != "agent" {
+			continue
+		}
+		content := strings.ToLower(messages[i].Content)
+		for _, p := range phrases {
+			if p != "" && strings.Contains(content, strings.ToLower(p)) {
+				return p, messages[i].AgentName
+			}
+		}
+		return "", ""
+	}
+	return "", ""
+}
+
+// consensusReached reports whether every agent has responded and its latest response
+// contains the consensus phrase
+func (o *Orchestrator) consensusReached() bool {
+	phrase := strings.ToLower(o.config.EndConditions.ConsensusPhrase)
+	if phrase == "" {
+		return false
+	}
+
+	o.mu.RLock()
+	agents := append([]agent.Agent(nil), o.agents...)
+	o.mu.RUnlock()
+	if len(agents) == 0 {
+		return false
+	}
+
+	latest := make(map[string]string, len(agents))
+	for _, msg := range o.getMessages() {
+		if msg.Role == "agent" {
+			latest[msg.AgentID] = msg.Content
+		}
+	}
+	for _, a := range agents {
+		content, ok := latest[a.GetID()]
+		if !ok || !strings.Contains(strings.ToLower(content), phrase) {
+			return false
+		}
+	}
+	return true
+}
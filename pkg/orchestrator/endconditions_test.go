@@ -0,0 +1,279 @@
+package orchestrator
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// newEndConditionsOrchestrator returns an orchestrator with two agents whose
+// conversation has just started
+func newEndConditionsOrchestrator(maxTurns int, ec config.EndConditionsConfig) *Orchestrator {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      maxTurns,
+		ResponseDelay: time.Millisecond,
+		EndConditions: ec,
+	}, nil)
+	orch.AddAgent(&MockAgent{id: "a", name: "Alice", agentType: "mock", available: true})
+	orch.AddAgent(&MockAgent{id: "b", name: "Bob", agentType: "mock", available: true})
+	orch.lastResponseAt = time.Now()
+	return orch
+}
+
+func agentMessage(id, name, content string) agent.Message {
+	return agent.Message{AgentID: id, AgentName: name, Content: content, Role: "agent"}
+}
+
+func TestShouldEndConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxTurns int
+		ec       config.EndConditionsConfig
+		setup    func(o *Orchestrator)
+		turns    int
+		want     EndCondition
+		wantMsg  string
+	}{
+		{
+			name: "nothing configured",
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages, agentMessage("a", "Alice", "I agree, DONE."))
+				o.cumulativeTokens = 1 << 20
+				o.lastResponseAt = time.Now().Add(-time.Hour)
+			},
+			turns: 100,
+			want:  "",
+		},
+		{
+			name:     "max turns",
+			maxTurns: 3,
+			turns:    3,
+			want:     EndMaxTurns,
+			wantMsg:  "Maximum turns reached",
+		},
+		{
+			name:     "max turns not yet reached",
+			maxTurns: 3,
+			turns:    2,
+			want:     "",
+		},
+		{
+			name: "token budget",
+			ec:   config.EndConditionsConfig{TokenBudget: 500},
+			setup: func(o *Orchestrator) {
+				o.cumulativeTokens = 500
+			},
+			want:    EndTokenBudget,
+			wantMsg: "Token budget of 500 used",
+		},
+		{
+			name: "token budget not yet used",
+			ec:   config.EndConditionsConfig{TokenBudget: 500},
+			setup: func(o *Orchestrator) {
+				o.cumulativeTokens = 499
+			},
+			want: "",
+		},
+		{
+			name: "stop phrase in the latest response",
+			ec:   config.EndConditionsConfig{StopPhrases: []string{"[DONE]", "we are finished"}},
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages, agentMessage("a", "Alice", "Looks good. We are FINISHED here."))
+			},
+			want:    EndStopPhrase,
+			wantMsg: `Alice said "we are finished"`,
+		},
+		{
+			name: "stop phrase only in an earlier response",
+			ec:   config.EndConditionsConfig{StopPhrases: []string{"[DONE]"}},
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages,
+					agentMessage("a", "Alice", "[DONE]"),
+					agentMessage("b", "Bob", "Not so fast."))
+			},
+			want: "",
+		},
+		{
+			name: "idle timeout",
+			ec:   config.EndConditionsConfig{IdleTimeout: time.Minute},
+			setup: func(o *Orchestrator) {
+				o.lastResponseAt = time.Now().Add(-2 * time.Minute)
+			},
+			want:    EndIdleTimeout,
+			wantMsg: "No response for 1m0s",
+		},
+		{
+			name: "idle timeout not yet reached",
+			ec:   config.EndConditionsConfig{IdleTimeout: time.Minute},
+			want: "",
+		},
+		{
+			name: "consensus",
+			ec:   config.EndConditionsConfig{ConsensusPhrase: "I agree"},
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages,
+					agentMessage("a", "Alice", "Tabs it is."),
+					agentMessage("b", "Bob", "I agree with tabs."),
+					agentMessage("a", "Alice", "Then I agree too."))
+			},
+			want:    EndConsensus,
+			wantMsg: "consensus",
+		},
+		{
+			name: "consensus needs every agent's latest response",
+			ec:   config.EndConditionsConfig{ConsensusPhrase: "I agree"},
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages,
+					agentMessage("a", "Alice", "I agree."),
+					agentMessage("b", "Bob", "I agree."),
+					agentMessage("a", "Alice", "On second thought, no."))
+			},
+			want: "",
+		},
+		{
+			name: "consensus needs every agent to have responded",
+			ec:   config.EndConditionsConfig{ConsensusPhrase: "I agree"},
+			setup: func(o *Orchestrator) {
+				o.messages = append(o.messages, agentMessage("a", "Alice", "I agree."))
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch := newEndConditionsOrchestrator(tt.maxTurns, tt.ec)
+			if tt.setup != nil {
+				tt.setup(orch)
+			}
+			condition, endMsg := orch.shouldEnd(tt.turns, "")
+			if condition != tt.want {
+				t.Fatalf("expected condition %q, got %q (%s)", tt.want, condition, endMsg)
+			}
+			if !strings.Contains(endMsg, tt.wantMsg) {
+				t.Errorf("expected message containing %q, got %q", tt.wantMsg, endMsg)
+			}
+			if condition != "" && !strings.Contains(endMsg, "Conversation ended.") {
+				t.Errorf("expected the end message to say the conversation ended, got %q", endMsg)
+			}
+		})
+	}
+}
+
+func TestShouldEndPrecedence(t *testing.T) {
+	// Every condition is met at once
+	all := config.EndConditionsConfig{
+		TokenBudget:     10,
+		StopPhrases:     []string{"[DONE]"},
+		IdleTimeout:     time.Minute,
+		ConsensusPhrase: "agree",
+	}
+	setup := func(o *Orchestrator) {
+		o.messages = append(o.messages,
+			agentMessage("a", "Alice", "I agree. [DONE]"),
+			agentMessage("b", "Bob", "I agree. [DONE]"))
+		o.cumulativeTokens = 100
+		o.lastResponseAt = time.Now().Add(-time.Hour)
+	}
+
+	// Disabling the winner each time reveals the next in line
+	order := []EndCondition{EndStopPhrase, EndConsensus, EndTokenBudget, EndIdleTimeout, EndMaxTurns}
+	ec := all
+	for _, want := range order {
+		orch := newEndConditionsOrchestrator(1, ec)
+		setup(orch)
+		if got, _ := orch.shouldEnd(1, ""); got != want {
+			t.Fatalf("expected %q to take precedence, got %q", want, got)
+		}
+		switch want {
+		case EndStopPhrase:
+			ec.StopPhrases = nil
+		case EndConsensus:
+			ec.ConsensusPhrase = ""
+		case EndTokenBudget:
+			ec.TokenBudget = 0
+		case EndIdleTimeout:
+			ec.IdleTimeout = 0
+		}
+	}
+}
+
+func TestStopPhraseEndsConversation(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      10,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		EndConditions: config.EndConditionsConfig{StopPhrases: []string{"[DONE]"}},
+	}, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	talker := &MockAgent{id: "a", name: "Talker", agentType: "mock", available: true, sendMessageResp: "Still thinking."}
+	closer := &MockAgent{id: "b", name: "Closer", agentType: "mock", available: true, sendMessageResp: "That settles it. [DONE]"}
+	orch.AddAgent(talker)
+	orch.AddAgent(closer)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if talker.callCount != 1 || closer.callCount != 1 {
+		t.Errorf("expected the conversation to end after the stop phrase, got %d and %d calls", talker.callCount, closer.callCount)
+	}
+	if got := orch.GetEndReason(); got != EndStopPhrase {
+		t.Errorf("expected end reason %q, got %q", EndStopPhrase, got)
+	}
+	if emitter.completedStatus != "completed" || emitter.completedEndReason != string(EndStopPhrase) {
+		t.Errorf("expected a completed event naming the stop phrase, got %q / %q", emitter.completedStatus, emitter.completedEndReason)
+	}
+}
+
+func TestIdleTimeoutEndsConversation(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:            ModeRoundRobin,
+		MaxTurns:        10,
+		TurnTimeout:     time.Second,
+		ResponseDelay:   time.Millisecond,
+		RetriesDisabled: true,
+		EndConditions:   config.EndConditionsConfig{IdleTimeout: 50 * time.Millisecond},
+	}, io.Discard)
+	stuck := &MockAgent{id: "a", name: "Stuck", agentType: "mock", available: true, sendMessageErr: context.DeadlineExceeded, sendDelay: 30 * time.Millisecond}
+	orch.AddAgent(stuck)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := orch.GetEndReason(); got != EndIdleTimeout {
+		t.Errorf("expected end reason %q, got %q", EndIdleTimeout, got)
+	}
+	if stuck.callCount >= 10 {
+		t.Errorf("expected the idle timeout to end the conversation early, got %d calls", stuck.callCount)
+	}
+}
+
+func TestEndReasonMaxTurns(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, io.Discard)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "hi"})
+
+	if got := orch.GetEndReason(); got != "" {
+		t.Errorf("expected no end reason before the conversation, got %q", got)
+	}
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := orch.GetEndReason(); got != EndMaxTurns {
+		t.Errorf("expected end reason %q, got %q", EndMaxTurns, got)
+	}
+}
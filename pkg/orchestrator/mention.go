@@ -0,0 +1,90 @@
+package orchestrator
+
+import (
+	"strings"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// parseMention returns the agent a message is addressed to, or nil.
+// A message addresses an agent with "@Name" anywhere in the text, or by opening
+// the message or one of its lines with "Name,". Names are matched case-insensitively
+// against the given agents; when several are addressed the earliest wins, and when
+// names overlap at the same position the longest wins.
+func parseMention(content string, agents []agent.Agent) agent.Agent {
+	lower := strings.ToLower(content)
+
+	var selected agent.Agent
+	selectedAt, selectedLen := -1, 0
+	for _, a := range agents {
+		name := strings.ToLower(strings.TrimSpace(a.GetName()))
+		at := mentionIndex(lower, name)
+		if at < 0 {
+			continue
+		}
+		if selectedAt < 0 || at < selectedAt || (at == selectedAt && len(name) > selectedLen) {
+			selected, selectedAt, selectedLen = a, at, len(name)
+		}
+	}
+	return selected
+}
+
+// mentionIndex returns where the earliest "@name" or line-leading "name," occurs in s, or -1
+func mentionIndex(s, name string) int {
+	if name == "" {
+		return -1
+	}
+
+	best := -1
+	if at := indexWord(s, "@"+name); at >= 0 {
+		best = at
+	}
+
+	lineStart := 0
+	for lineStart <= len(s) {
+		line := s[lineStart:]
+		if end := strings.IndexByte(line, '\n'); end >= 0 {
+			line = line[:end]
+		}
+		trimmed := strings.TrimLeft(line, " \t")
+		at := lineStart + len(line) - len(trimmed)
+		if strings.HasPrefix(trimmed, name+",") {
+			if best < 0 || at < best {
+				best = at
+			}
+			break
+		}
+		if best >= 0 && at > best {
+			break
+		}
+		lineStart += len(line) + 1
+	}
+
+	return best
+}
+
+// mentionTarget returns the agent addressed by the latest message when MentionRouting
+// is enabled, along with that message's index. The author of the message is never
+// returned, and a message is routed at most once: pass the index returned by the
+// previous routing as routed.
+func (o *Orchestrator) mentionTarget(routed int) (agent.Agent, int) {
+	if !o.config.MentionRouting {
+		return nil, -1
+	}
+
+	messages := o.getMessages()
+	last := len(messages) - 1
+	if last < 0 || last == routed {
+		return nil, -1
+	}
+	msg := messages[last]
+
+	o.mu.RLock()
+	addressable := candidates(o.agents, msg.AgentID)
+	o.mu.RUnlock()
+
+	if target := parseMention(msg.Content, addressable); target != nil {
+		return target, last
+	}
+	return nil, -1
+}
@@ -0,0 +1,115 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestParseMention(t *testing.T) {
+	agents := []agent.Agent{
+		&MockAgent{id: "agent-1", name: "Alice"},
+		&MockAgent{id: "agent-2", name: "Bob"},
+		&MockAgent{id: "agent-3", name: "Bob Jr"},
+		&MockAgent{id: "agent-4", name: "Technical Expert"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"at mention", "I agree. @alice, can you check?", "agent-1"},
+		{"leading name and comma", "Bob, what do you think?", "agent-2"},
+		{"name on a later line", "Good point.\n  Alice, over to you.", "agent-1"},
+		{"multi-word name", "Let's ask @Technical Expert about this", "agent-4"},
+		{"earliest mention wins", "@Bob and @Alice should weigh in", "agent-2"},
+		{"longest overlapping name wins", "@Bob Jr please answer", "agent-3"},
+		{"bare name is not a mention", "I think Alice is right", ""},
+		{"name mid-line with comma is not a mention", "As Bob, said earlier", ""},
+		{"email address is not a mention", "mail me at team@bob.dev", ""},
+		{"partial name is not a mention", "@Bobby, hello", ""},
+		{"unknown name", "@Dave, thoughts?", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selectedID(parseMention(tt.content, agents)); got != tt.want {
+				t.Errorf("parseMention(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+// runMentionConversation runs a short conversation where Alice always addresses Carol
+// and returns the order in which agents spoke
+func runMentionConversation(t *testing.T, mode ConversationMode, mentionRouting bool) []string {
+	t.Helper()
+	cfg := OrchestratorConfig{
+		Mode:              mode,
+		MaxTurns:          4,
+		TurnTimeout:       time.Second,
+		ResponseDelay:     time.Millisecond,
+		SelectionStrategy: SelectRoundRobin,
+		MentionRouting:    mentionRouting,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Alice", available: true, sendMessageResp: "@Carol, your turn"})
+	orch.AddAgent(&MockAgent{id: "agent-2", name: "Bob", available: true, sendMessageResp: "ok"})
+	orch.AddAgent(&MockAgent{id: "agent-3", name: "Carol", available: true, sendMessageResp: "thanks"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var order []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			order = append(order, msg.AgentName)
+		}
+	}
+	return order
+}
+
+func TestMentionRoutingPrecedence(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    ConversationMode
+		routing bool
+		want    []string
+	}{
+		{"reactive without routing uses selector", ModeReactive, false, []string{"Alice", "Bob", "Carol", "Alice"}},
+		{"reactive routes to addressed agent", ModeReactive, true, []string{"Alice", "Carol", "Alice", "Carol"}},
+		{"free-form routes to addressed agent", ModeFreeForm, true, []string{"Alice", "Carol", "Alice", "Carol"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runMentionConversation(t, tt.mode, tt.routing); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected speaking order %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMentionTargetRoutesEachMessageOnce(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{MentionRouting: true}, nil)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Alice"})
+	orch.AddAgent(&MockAgent{id: "agent-2", name: "Bob"})
+	orch.InjectMessage(agent.Message{AgentID: "user", AgentName: "User", Content: "Bob, start us off"})
+
+	target, at := orch.mentionTarget(-1)
+	if selectedID(target) != "agent-2" {
+		t.Fatalf("expected Bob to be addressed, got %q", selectedID(target))
+	}
+	if again, _ := orch.mentionTarget(at); again != nil {
+		t.Errorf("expected a message to be routed only once, got %q", again.GetID())
+	}
+}
@@ -8,13 +8,13 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"math/rand"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/shawkym/agentpipe/internal/bridge"
 	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/client"
 	"github.com/shawkym/agentpipe/pkg/config"
 	"github.com/shawkym/agentpipe/pkg/log"
 	"github.com/shawkym/agentpipe/pkg/logger"
@@ -42,12 +42,60 @@ type OrchestratorConfig struct {
 	Mode ConversationMode
 	// TurnTimeout is the maximum time an agent has to respond
 	TurnTimeout time.Duration
+	// AgentTimeouts overrides TurnTimeout for individual agents, keyed by agent ID
+	AgentTimeouts map[string]time.Duration
+	// AgentMaxTurns caps how many times individual agents may respond, keyed by agent ID.
+	// An agent that reaches its quota is skipped for the rest of the conversation.
+	AgentMaxTurns map[string]int
+	// AgentTeams assigns agents to teams, keyed by agent ID. Teammates share a private
+	// scratchpad: responses starting with "[team]" are only seen by the same team.
+	AgentTeams map[string]string
 	// MaxTurns is the maximum number of conversation turns (0 = unlimited)
 	MaxTurns int
+	// WarmupTurns is the number of initial turns that don't count toward MaxTurns.
+	// Messages produced during warmup are tagged with the "warmup" metadata key.
+	WarmupTurns int
 	// ResponseDelay is the pause between agent responses
 	ResponseDelay time.Duration
+	// StartupDelay is a one-time pause after the initial prompt is posted and before the
+	// first agent turn, giving observers (TUI, web) time to attach and read the prompt
+	StartupDelay time.Duration
 	// InitialPrompt is an optional starting prompt for the conversation
 	InitialPrompt string
+	// StyleGuide is an optional tone and style directive for all agents, posted once as a
+	// pinned HOST message after InitialPrompt, which stays the task agents respond to
+	StyleGuide string
+	// Scenario is an optional shared role-play setting, posted once as a pinned HOST
+	// message after InitialPrompt and StyleGuide
+	Scenario string
+	// KickoffPrompt is a one-time directive for the agent that opens the conversation
+	// (e.g. "Open the discussion by stating your position"). It is sent with agent turns
+	// only until the first response succeeds, and is never stored in the history.
+	KickoffPrompt string
+	// RoleReminderInterval re-sends each agent its own prompt as a private reminder once
+	// this many turns have passed since its last one, so long conversations don't erode
+	// personas. Like KickoffPrompt, the reminder is never stored in the history. 0 disables.
+	RoleReminderInterval int
+	// DevilsAdvocateInterval posts a directive every this many turns asking the next
+	// speaker to challenge the prevailing view, to head off premature consensus. The
+	// directive is a HOST message with the "devils_advocate" metadata key. 0 disables.
+	DevilsAdvocateInterval int
+	// ClosingRound gives every agent one final turn to summarize its position once MaxTurns
+	// is reached. Closing messages carry the "closing" metadata key and don't count as turns.
+	ClosingRound bool
+	// PromptSuffix is appended to every agent's per-turn instruction
+	// (e.g. "Respond in under 100 words."), reinforcing it each turn
+	PromptSuffix string
+	// SuppressAnnouncements skips the "X has joined" system message AddAgent normally posts
+	SuppressAnnouncements bool
+	// Explain makes agents that support it capture their prompts and log the first one in full
+	Explain bool
+	// Prewarm opens each agent's connection before the first turn (see agent.Prewarmer)
+	Prewarm bool
+	// StreamResponses streams replies from agents that support it (see agent.Streamer)
+	// to the stream writer as they are generated. Other agents fall
+	// back to buffered responses, which reach the stream writer once complete.
+	StreamResponses bool
 	// MaxRetries is the maximum number of retry attempts for failed agent responses (0 = no retries)
 	MaxRetries int
 	// RetryInitialDelay is the initial delay before the first retry
@@ -56,8 +104,68 @@ type OrchestratorConfig struct {
 	RetryMaxDelay time.Duration
 	// RetryMultiplier is the multiplier for exponential backoff (typically 2.0)
 	RetryMultiplier float64
+	// RetriesDisabled gives every agent response a single attempt. It overrides MaxRetries
+	// and skips the retry defaults, so no other retry field needs to be set.
+	RetriesDisabled bool
+	// RetryableErrors limits retries to errors matching these types ("timeout", "rate_limit", "5xx")
+	// or message substrings. Empty means every error is retried.
+	RetryableErrors []string
+	// MaxTotalRetries caps retry attempts across the whole conversation, so a flaky provider
+	// can't retry on every turn. Once the budget is spent, failed responses aren't retried.
+	// 0 means unlimited.
+	MaxTotalRetries int
+	// DriftCheckEnabled periodically checks whether the conversation has drifted from InitialPrompt
+	DriftCheckEnabled bool
+	// DriftCheckInterval is the number of agent turns between drift checks (default: 5)
+	DriftCheckInterval int
+	// DriftThreshold is the drift score (0-1) at which a refocus directive is injected (default: 0.8)
+	DriftThreshold float64
+	// CollapseCheckEnabled warns mid-run when agents keep giving near-identical responses
+	CollapseCheckEnabled bool
+	// CollapseThreshold is the mean response similarity (0-1) reported as mode collapse (default: 0.8)
+	CollapseThreshold float64
 	// Summary defines conversation summary generation settings
 	Summary config.SummaryConfig
+	// Referee enables scoring of each agent response by a referee agent
+	Referee config.RefereeConfig
+	// Voting enables an end-of-conversation vote for the most convincing participant
+	Voting config.VotingConfig
+	// EndConditions end the conversation as soon as any of them, or MaxTurns, is met
+	EndConditions config.EndConditionsConfig
+	// BillingTag is recorded on every agent response's metrics, and in its message.created
+	// event, so costs can be attributed to a project across runs
+	BillingTag string
+	// RenderWidth wraps agent responses written to the plain writer to this many
+	// columns, for console output that isn't a TUI (0 = no wrap)
+	RenderWidth int
+	// MaxConsecutiveFailures aborts the conversation once this many agent responses
+	// fail in a row, across all agents (0 = disabled)
+	MaxConsecutiveFailures int
+	// MaxEmptyPasses ends a free-form conversation after this many passes in a row in which
+	// no agent responded successfully (default: 3)
+	MaxEmptyPasses int
+	// ResponseSchemas holds the JSON schemas of agents that must answer with structured
+	// output, keyed by agent ID. An off-schema response is retried with a reminder of the
+	// schema; if it is still off-schema when retries run out it is flagged, or rejected
+	// when RejectSchemaMismatch is set.
+	ResponseSchemas map[string]map[string]interface{}
+	// RejectSchemaMismatch fails the turn instead of flagging an off-schema response
+	RejectSchemaMismatch bool
+	// MaxHistoryMessages limits how many messages are sent to an agent each turn (0 = unlimited)
+	MaxHistoryMessages int
+	// TrimStrategy decides which messages are dropped once MaxHistoryMessages is exceeded (default: TrimOldest)
+	TrimStrategy TrimStrategy
+	// SelectionStrategy picks the built-in selector for reactive mode (default: SelectRandom).
+	// SetAgentSelector overrides it with a custom AgentSelector.
+	SelectionStrategy SelectionStrategy
+	// SelectionWeights maps agent IDs to relative weights for SelectWeighted (default weight: 1)
+	SelectionWeights map[string]float64
+	// MentionRouting gives the next reactive or free-form turn to an agent addressed in the
+	// latest message as "@Name" or "Name, ...", overriding normal selection
+	MentionRouting bool
+	// HookTimeout is the deadline of the context each message hook receives (default: 10s).
+	// Hooks run synchronously, so hooks doing I/O must honor their context.
+	HookTimeout time.Duration
 }
 
 // Orchestrator coordinates multi-agent conversations.
@@ -76,19 +184,58 @@ type Orchestrator struct {
 	metrics           *metrics.Metrics        // Prometheus metrics for monitoring
 	bridgeEmitter     bridge.BridgeEmitter    // optional streaming bridge for real-time updates
 	conversationStart time.Time               // conversation start time for duration tracking
+	conversationEnd   time.Time               // when the conversation's turns ended, zero while running
+	lastResponseAt    time.Time               // when an agent last responded successfully, for the idle timeout
+	endReason         EndCondition            // the end condition that ended the conversation
 	commandInfo       *bridge.CommandInfo     // information about the command that started this conversation
 	summary           *bridge.SummaryMetadata // conversation summary (populated after completion if enabled)
-	messageHooks      []MessageHook           // optional hooks for message events
+	messageHooks      []ContextMessageHook    // optional hooks for message events
+	hookCtx           context.Context         // conversation context handed to message hooks
+	inWarmup          bool                    // true while the warmup turns are running
+	driftClassifier   DriftClassifier         // scores topic drift (nil = keyword classifier)
+	collapseWarned    map[string]bool         // agent pairs already warned about mode collapse
+	consecutiveFails  int                     // agent responses failed in a row, reset on success
+	totalRetries      int                     // retry attempts made across the conversation
+	cumulativeTokens  int                     // running token total across agent responses
+	cumulativeCost    float64                 // running cost total across agent responses
+	selector          AgentSelector           // picks the next speaker in reactive mode
+	kickedOff         bool                    // an agent has responded, so KickoffPrompt no longer applies
+	roleReminders     map[string]int          // turn number of each agent's last role reminder
+	advocateTurn      int                     // turn number of the last devil's advocate directive
+	inClosing         bool                    // true while the closing round is running
+	focusAgentID      string                  // agent given the floor by FocusAgent
+	focusTurns        int                     // turns left for focusAgentID
+	referee           agent.Agent             // scores agent responses when Referee is enabled (resolved lazily)
+	voteResult        *bridge.VoteResult      // end-of-conversation vote (populated after completion if enabled)
+	agentResponses    map[string]int          // successful responses per agent ID, for AgentMaxTurns
+	pendingPrompts    map[string]string       // prompts set by SetAgentPrompt, applied before the agent's next turn
+	skipSummary       bool                    // set by SkipSummary for a conversation that is being discarded
+	streamWriter      io.Writer               // receives response text as it arrives when StreamResponses is enabled
 }
 
+// ErrTooManyConsecutiveFailures is returned when MaxConsecutiveFailures is reached.
+var ErrTooManyConsecutiveFailures = errors.New("too many consecutive failures")
+
 // MessageHook is invoked whenever a message is appended to the conversation history.
 type MessageHook func(msg agent.Message)
 
+// ContextMessageHook is a MessageHook that receives a context.
+// The context is cancelled when the conversation is cancelled or the hook exceeds
+// HookTimeout; hooks doing network I/O should honor it.
+type ContextMessageHook func(ctx context.Context, msg agent.Message)
+
+// defaultSummaryTimeout bounds summary generation when the config sets no timeout
+const defaultSummaryTimeout = 30 * time.Second
+
+// defaultMaxEmptyPasses is how many free-form passes without a response end the conversation
+const defaultMaxEmptyPasses = 3
+
 // NewOrchestrator creates a new Orchestrator with the given configuration.
 // Default values are applied if TurnTimeout (30s) or ResponseDelay (1s) are zero.
 // Retry defaults: MaxRetries=3, InitialDelay=1s, MaxDelay=30s, Multiplier=2.0.
-// To disable retries, explicitly set all retry fields (at minimum RetryInitialDelay)
-// The writer receives formatted conversation output for display (e.g., TUI).
+// To disable retries, set RetriesDisabled.
+// The writer receives formatted conversation output for display (e.g., TUI). If
+// writes to it keep failing, output is discarded after a single logged warning.
 func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator {
 	if config.TurnTimeout == 0 {
 		config.TurnTimeout = 30 * time.Second
@@ -96,10 +243,15 @@ func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator
 	if config.ResponseDelay == 0 {
 		config.ResponseDelay = 1 * time.Second
 	}
+	if config.HookTimeout == 0 {
+		config.HookTimeout = 10 * time.Second
+	}
 
 	// Only apply retry defaults if retry config appears unset
 	// Check if RetryInitialDelay is 0 - if so, assume retry config is not set
-	if config.RetryInitialDelay == 0 && config.MaxRetries == 0 && config.RetryMaxDelay == 0 && config.RetryMultiplier == 0 {
+	if config.RetriesDisabled {
+		config.MaxRetries = 0
+	} else if config.RetryInitialDelay == 0 && config.MaxRetries == 0 && config.RetryMaxDelay == 0 && config.RetryMultiplier == 0 {
 		// Apply all retry defaults
 		config.MaxRetries = 3
 		config.RetryInitialDelay = 1 * time.Second
@@ -119,15 +271,60 @@ func NewOrchestrator(config OrchestratorConfig, writer io.Writer) *Orchestrator
 		// Don't override MaxRetries if user set other retry fields
 	}
 
+	if config.DriftCheckInterval <= 0 {
+		config.DriftCheckInterval = defaultDriftCheckInterval
+	}
+	if config.DriftThreshold <= 0 {
+		config.DriftThreshold = defaultDriftThreshold
+	}
+	if config.CollapseThreshold <= 0 {
+		config.CollapseThreshold = defaultCollapseThreshold
+	}
+	if config.MaxEmptyPasses <= 0 {
+		config.MaxEmptyPasses = defaultMaxEmptyPasses
+	}
+
+	selector, err := NewAgentSelector(config.SelectionStrategy, config.SelectionWeights)
+	if err != nil {
+		log.WithField("strategy", config.SelectionStrategy).WithError(err).Warn("falling back to random agent selection")
+		selector = RandomSelector{}
+	}
+
+	chain := middleware.NewChain()
+	if len(config.ResponseSchemas) > 0 {
+		chain.Add(middleware.SchemaValidationMiddleware(config.ResponseSchemas, config.RejectSchemaMismatch))
+	}
+
 	return &Orchestrator{
 		config:            config,
 		agents:            make([]agent.Agent, 0),
 		messages:          make([]agent.Message, 0),
 		rateLimiters:      make(map[string]*ratelimit.Limiter),
-		middlewareChain:   middleware.NewChain(),
-		writer:            writer,
+		collapseWarned:    make(map[string]bool),
+		roleReminders:     make(map[string]int),
+		agentResponses:    make(map[string]int),
+		pendingPrompts:    make(map[string]string),
+		middlewareChain:   chain,
+		writer:            newGuardedWriter(writer),
 		currentTurnNumber: 0,
+		selector:          selector,
+	}
+}
+
+// SetAgentSelector replaces the selector that picks the next speaker in reactive mode.
+// Pass nil to restore the selector configured by SelectionStrategy.
+// This method is thread-safe.
+func (o *Orchestrator) SetAgentSelector(selector AgentSelector) {
+	if selector == nil {
+		var err error
+		if selector, err = NewAgentSelector(o.config.SelectionStrategy, o.config.SelectionWeights); err != nil {
+			selector = RandomSelector{}
+		}
 	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.selector = selector
 }
 
 // SetLogger sets the chat logger for the orchestrator.
@@ -163,6 +360,24 @@ func (o *Orchestrator) SetBridgeEmitter(emitter bridge.BridgeEmitter) {
 	o.bridgeEmitter = emitter
 }
 
+// SetStreamWriter sets the writer that receives agent response text as it is generated
+// when StreamResponses is enabled. Passing nil stops streamed output.
+// This method is thread-safe.
+func (o *Orchestrator) SetStreamWriter(w io.Writer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.streamWriter = w
+}
+
+// SkipSummary stops the conversation from generating its summary when it ends,
+// e.g. because it is being stopped to be thrown away and restarted.
+// This method is thread-safe.
+func (o *Orchestrator) SkipSummary() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.skipSummary = true
+}
+
 // SetCommandInfo sets the command information for this conversation.
 // This captures the agentpipe command that was executed.
 // This method is thread-safe.
@@ -173,8 +388,20 @@ func (o *Orchestrator) SetCommandInfo(info *bridge.CommandInfo) {
 }
 
 // AddMessageHook registers a hook to receive message events.
-// Hooks are invoked synchronously; keep them lightweight.
+// Hooks are invoked in order, synchronously, and can't be cancelled; keep them
+// lightweight and use AddContextMessageHook for anything that blocks.
 func (o *Orchestrator) AddMessageHook(hook MessageHook) {
+	if hook == nil {
+		return
+	}
+	o.AddContextMessageHook(func(_ context.Context, msg agent.Message) {
+		hook(msg)
+	})
+}
+
+// AddContextMessageHook registers a context-aware hook to receive message events.
+// Use this for hooks that do network I/O so they can stop on shutdown.
+func (o *Orchestrator) AddContextMessageHook(hook ContextMessageHook) {
 	if hook == nil {
 		return
 	}
@@ -183,6 +410,33 @@ func (o *Orchestrator) AddMessageHook(hook MessageHook) {
 	o.messageHooks = append(o.messageHooks, hook)
 }
 
+// runHooks delivers msg to each hook in turn. Each hook gets a context that is
+// cancelled after HookTimeout or once the conversation context is cancelled.
+func (o *Orchestrator) runHooks(hooks []ContextMessageHook, msg agent.Message) {
+	if len(hooks) == 0 {
+		return
+	}
+
+	o.mu.RLock()
+	parent := o.hookCtx
+	o.mu.RUnlock()
+	if parent == nil {
+		parent = context.Background()
+	}
+
+	for i, hook := range hooks {
+		ctx, cancel := context.WithTimeout(parent, o.config.HookTimeout)
+		hook(ctx, msg)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			log.WithFields(map[string]interface{}{
+				"hook":       i,
+				"agent_name": msg.AgentName,
+			}).Warn("message hook ran into its deadline")
+		}
+		cancel()
+	}
+}
+
 // InjectMessage appends an external message (e.g., user input) into the conversation.
 // This is safe to call concurrently while the orchestrator is running.
 func (o *Orchestrator) InjectMessage(msg agent.Message) {
@@ -195,7 +449,7 @@ func (o *Orchestrator) InjectMessage(msg agent.Message) {
 
 	o.mu.Lock()
 	o.messages = append(o.messages, msg)
-	hooks := append([]MessageHook(nil), o.messageHooks...)
+	hooks := append([]ContextMessageHook(nil), o.messageHooks...)
 	o.mu.Unlock()
 
 	if o.logger != nil {
@@ -205,9 +459,7 @@ func (o *Orchestrator) InjectMessage(msg agent.Message) {
 		fmt.Fprintf(o.writer, "\n[%s] %s\n", msg.AgentName, msg.Content)
 	}
 
-	for _, hook := range hooks {
-		hook(msg)
-	}
+	o.runHooks(hooks, msg)
 }
 
 // emitConversationCompleted emits the conversation.completed event if bridge is enabled.
@@ -217,6 +469,7 @@ func (o *Orchestrator) emitConversationCompleted(status string, summary *bridge.
 	bridgeEmitter := o.bridgeEmitter
 	messageCount := len(o.messages)
 	startTime := o.conversationStart
+	endReason := o.endReason
 	o.mu.RUnlock()
 
 	if bridgeEmitter == nil {
@@ -243,6 +496,7 @@ func (o *Orchestrator) emitConversationCompleted(status string, summary *bridge.
 
 	bridgeEmitter.EmitConversationCompleted(
 		status,
+		string(endReason),
 		messageCount,
 		o.currentTurnNumber,
 		totalTokens,
@@ -328,11 +582,22 @@ func parseDualSummary(response string) (shortText, fullText string, err error) {
 	return shortText, fullText, nil
 }
 
+// summaryTimeout returns the configured summary timeout or the default
+func (o *Orchestrator) summaryTimeout() time.Duration {
+	if o.config.Summary.Timeout > 0 {
+		return o.config.Summary.Timeout
+	}
+	return defaultSummaryTimeout
+}
+
 // generateSummary generates a summary of the conversation using the configured summary agent.
 // Returns nil if summary is disabled or if generation fails.
 func (o *Orchestrator) generateSummary(ctx context.Context) *bridge.SummaryMetadata {
 	// Check if summary is enabled
-	if !o.config.Summary.Enabled {
+	o.mu.RLock()
+	skip := o.skipSummary
+	o.mu.RUnlock()
+	if !o.config.Summary.Enabled || skip {
 		return nil
 	}
 
@@ -345,8 +610,8 @@ func (o *Orchestrator) generateSummary(ctx context.Context) *bridge.SummaryMetad
 	// Build conversation text for summary
 	var conversationText strings.Builder
 	for _, msg := range messages {
-		// Skip system messages
-		if msg.Role == "system" {
+		// Skip system messages and tool steps
+		if msg.Role == "system" || msg.Role == "tool" {
 			continue
 		}
 		conversationText.WriteString(fmt.Sprintf("%s: %s\n\n", msg.AgentName, msg.Content))
@@ -371,27 +636,50 @@ Do not include meta-commentary about the conversation structure (e.g., "This is
 Conversation:
 %s`, conversationText.String())
 
-	// Create a temporary agent for summary generation
-	summaryAgent, err := agent.CreateAgent(agent.AgentConfig{
-		ID:   "summary-agent",
-		Type: o.config.Summary.Agent,
-		Name: "Summary",
-	})
+	o.mu.RLock()
+	bridgeEmitter := o.bridgeEmitter
+	o.mu.RUnlock()
 
-	if err != nil || summaryAgent == nil {
-		log.WithField("agent_type", o.config.Summary.Agent).WithError(err).Warn("failed to create summary agent")
+	// Bracket generation with summary.started/summary.completed so consumers can show progress
+	if bridgeEmitter != nil {
+		bridgeEmitter.EmitSummaryStarted(o.config.Summary.Agent)
+	}
+	summaryMetadata, err := o.summarize(ctx, summaryPrompt, conversationText.String())
+	if err != nil {
+		if bridgeEmitter != nil {
+			bridgeEmitter.EmitSummaryCompleted(err.Error())
+		}
 		return nil
 	}
 
-	// Initialize the summary agent
-	err = summaryAgent.Initialize(agent.AgentConfig{
-		ID:   "summary-agent",
-		Type: o.config.Summary.Agent,
-		Name: "Summary",
-	})
+	// Store summary in orchestrator for later access
+	o.mu.Lock()
+	o.summary = summaryMetadata
+	o.mu.Unlock()
+
+	log.WithFields(map[string]interface{}{
+		"agent_type":   summaryMetadata.AgentType,
+		"model":        summaryMetadata.Model,
+		"total_tokens": summaryMetadata.TotalTokens,
+		"cost":         summaryMetadata.Cost,
+		"duration_ms":  summaryMetadata.DurationMs,
+	}).Info("conversation summary generated")
+
+	if bridgeEmitter != nil {
+		bridgeEmitter.EmitSummaryGenerated(summaryMetadata)
+		bridgeEmitter.EmitSummaryCompleted("")
+	}
+
+	return summaryMetadata
+}
+
+// summarize asks the summary agent for the dual summary of conversationText and
+// measures the call. Failures are logged and returned.
+func (o *Orchestrator) summarize(ctx context.Context, summaryPrompt, conversationText string) (*bridge.SummaryMetadata, error) {
+	summaryAgent, err := o.resolveSummaryAgent()
 	if err != nil {
-		log.WithError(err).Warn("failed to initialize summary agent")
-		return nil
+		log.WithField("agent_type", o.config.Summary.Agent).WithError(err).Warn("failed to create summary agent")
+		return nil, fmt.Errorf("failed to create summary agent: %w", err)
 	}
 
 	// Create summary messages
@@ -406,11 +694,11 @@ Conversation:
 	}
 
 	// Generate summary with a timeout
-	summaryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	summaryCtx, cancel := context.WithTimeout(ctx, o.summaryTimeout())
 	defer cancel()
 
 	// Calculate input tokens from conversation text
-	inputTokens := utils.EstimateTokens(conversationText.String())
+	inputTokens := utils.EstimateTokens(conversationText)
 
 	startTime := time.Now()
 	response, err := summaryAgent.SendMessage(summaryCtx, summaryMessages)
@@ -418,7 +706,7 @@ Conversation:
 
 	if err != nil {
 		log.WithError(err).Warn("failed to generate conversation summary")
-		return nil
+		return nil, err
 	}
 
 	// Parse dual summary from response
@@ -446,7 +734,7 @@ Conversation:
 	model := summaryAgent.GetModel()
 	cost := utils.EstimateCost(model, inputTokens, outputTokens)
 
-	summaryMetadata := &bridge.SummaryMetadata{
+	return &bridge.SummaryMetadata{
 		ShortText:    shortSummary,
 		Text:         fullSummary,
 		AgentType:    o.config.Summary.Agent,
@@ -456,14 +744,78 @@ Conversation:
 		TotalTokens:  totalTokens,
 		Cost:         cost,
 		DurationMs:   duration.Milliseconds(),
+	}, nil
+}
+
+// resolveSummaryAgent returns the agent used for summary generation.
+// With Summary.ReuseAgent set, a conversation agent whose type, name or ID matches
+// Summary.Agent is reused; otherwise a fresh agent of that type is created.
+func (o *Orchestrator) resolveSummaryAgent() (agent.Agent, error) {
+	return o.resolveHelperAgent(o.config.Summary.Agent, "summary-agent", "Summary", o.config.Summary.ReuseAgent)
+}
+
+// resolveHelperAgent returns the agent behind a helper role such as the summary or the
+// referee. With reuse set, a conversation agent whose type, name or ID matches agentType
+// is returned; otherwise a fresh agent of that type is created with the given ID and name.
+func (o *Orchestrator) resolveHelperAgent(agentType, id, name string, reuse bool) (agent.Agent, error) {
+	if reuse {
+		o.mu.RLock()
+		for _, a := range o.agents {
+			if a.GetType() == agentType || a.GetName() == agentType || a.GetID() == agentType {
+				o.mu.RUnlock()
+				return a, nil
+			}
+		}
+		o.mu.RUnlock()
+		log.WithFields(map[string]interface{}{
+			"agent_type": agentType,
+			"role":       name,
+		}).Debug("no conversation agent to reuse, creating one")
 	}
 
-	// Store summary in orchestrator for later access
-	o.mu.Lock()
-	o.summary = summaryMetadata
-	o.mu.Unlock()
+	helperConfig := agent.AgentConfig{
+		ID:   id,
+		Type: agentType,
+		Name: name,
+	}
 
-	return summaryMetadata
+	helper, err := agent.CreateAgent(helperConfig)
+	if err != nil {
+		return nil, err
+	}
+	if helper == nil {
+		return nil, fmt.Errorf("no agent created for type %s", agentType)
+	}
+
+	if err := helper.Initialize(helperConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize %s agent: %w", strings.ToLower(name), err)
+	}
+
+	return helper, nil
+}
+
+// checkSummaryAgent warns up front when summaries are enabled but the summary
+// agent can't be created or its CLI isn't installed, instead of the summary
+// silently going missing at the end of the conversation.
+func (o *Orchestrator) checkSummaryAgent() {
+	if !o.config.Summary.Enabled {
+		return
+	}
+
+	summaryAgent, err := o.resolveSummaryAgent()
+	if err == nil && !summaryAgent.IsAvailable() {
+		err = fmt.Errorf("agent %s is not available", summaryAgent.GetName())
+	}
+	if err == nil {
+		return
+	}
+
+	log.WithField("agent_type", o.config.Summary.Agent).WithError(err).Warn("summary agent unavailable, summary will be skipped")
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "[Warning] Summary agent %q is unavailable (%v); the conversation summary will be skipped. "+
+			"Set orchestrator.summary.agent to an installed agent, enable summary.reuse_agent, or use --no-summary.\n",
+			o.config.Summary.Agent, err)
+	}
 }
 
 // AddMiddleware adds a middleware to the orchestrator's processing chain.
@@ -488,7 +840,9 @@ func (o *Orchestrator) SetupDefaultMiddleware() {
 }
 
 // AddAgent registers an agent with the orchestrator.
-// The agent's announcement is added to the conversation history and logged.
+// Unless SuppressAnnouncements is set, the agent's announcement is added to the
+// conversation history and logged. An agent whose announcement is already in the
+// history (e.g. one restored with LoadMessages) is not announced again.
 // A rate limiter is created for the agent based on its configuration.
 // This method is thread-safe.
 func (o *Orchestrator) AddAgent(a agent.Agent) {
@@ -496,6 +850,10 @@ func (o *Orchestrator) AddAgent(a agent.Agent) {
 	defer o.mu.Unlock()
 	o.agents = append(o.agents, a)
 
+	if explainer, ok := a.(agent.PromptExplainer); ok && o.config.Explain {
+		explainer.SetExplain(true)
+	}
+
 	// Create rate limiter for this agent
 	rateLimit := a.GetRateLimit()
 	rateLimitBurst := a.GetRateLimitBurst()
@@ -509,6 +867,14 @@ func (o *Orchestrator) AddAgent(a agent.Agent) {
 		"burst":      rateLimitBurst,
 	}).Info("agent added to orchestrator")
 
+	if o.config.SuppressAnnouncements {
+		return
+	}
+	if o.hasAnnouncement(a.GetID(), a.Announce()) {
+		log.WithField("agent_id", a.GetID()).Debug("agent already announced in loaded history, skipping announcement")
+		return
+	}
+
 	announcement := agent.Message{
 		AgentID:   a.GetID(),
 		AgentName: a.GetName(),
@@ -529,6 +895,29 @@ func (o *Orchestrator) AddAgent(a agent.Agent) {
 	}
 }
 
+// hasAnnouncement reports whether the history already holds the join announcement
+// of the agent with the given ID. Other system messages carrying the agent's ID don't
+// count. The caller must hold o.mu.
+func (o *Orchestrator) hasAnnouncement(agentID, announcement string) bool {
+	for _, msg := range o.messages {
+		if msg.Role == "system" && msg.AgentID == agentID && msg.Content == announcement {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadMessages replaces the conversation history, e.g. with the messages of a saved
+// conversation being resumed. Call it before adding agents so that agents already
+// announced in the loaded history are not announced again.
+// The messages are not logged or written again, since they were recorded in the
+// original session.
+func (o *Orchestrator) LoadMessages(messages []agent.Message) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append([]agent.Message(nil), messages...)
+}
+
 // Start begins the multi-agent conversation using the configured orchestration mode.
 // It returns an error if no agents are registered or if the orchestration mode is invalid.
 // The conversation continues until MaxTurns is reached, the context is canceled, or an error occurs.
@@ -553,13 +942,34 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 	}).Info("starting conversation")
 
 	// Record conversation start time for duration tracking
+	o.mu.Lock()
 	o.conversationStart = time.Now()
+	o.conversationEnd = time.Time{}
+	o.mu.Unlock()
+
+	// Message hooks observe the conversation context so they can stop on shutdown
+	o.mu.Lock()
+	o.hookCtx = ctx
+	o.mu.Unlock()
+
+	o.resetAgents()
+	o.checkSummaryAgent()
+	o.prepareReferee()
+
+	if o.config.Prewarm {
+		o.prewarmAgents(ctx)
+	}
 
 	// Track return error to determine status
 	var runErr error
 
 	// Emit conversation.completed and close bridge when function returns
 	defer func() {
+		// The vote and summary below don't count towards the conversation's duration
+		o.mu.Lock()
+		o.conversationEnd = time.Now()
+		o.mu.Unlock()
+
 		// Determine status based on context cancellation or error
 		status := "completed"
 
@@ -574,9 +984,16 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 			}
 		}
 
+		// Interrupted conversations are not voted on
+		if status == "completed" {
+			o.runVote()
+		}
+
 		// Generate summary if enabled
-		// Use background context since original ctx may be canceled
-		summary := o.generateSummary(context.Background())
+		// Use a fresh, bounded context since original ctx may be canceled
+		summaryCtx, cancelSummary := context.WithTimeout(context.Background(), o.summaryTimeout())
+		summary := o.generateSummary(summaryCtx)
+		cancelSummary()
 
 		o.emitConversationCompleted(status, summary)
 
@@ -598,14 +1015,19 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 		// Build agent participants list
 		participants := make([]bridge.AgentParticipant, 0, len(o.agents))
 		for _, a := range o.agents {
-			participants = append(participants, bridge.AgentParticipant{
+			participant := bridge.AgentParticipant{
 				AgentID:    a.GetID(),
 				AgentType:  a.GetType(),
 				Model:      a.GetModel(),
 				Name:       a.GetName(),
 				Prompt:     a.GetPrompt(),
 				CLIVersion: a.GetCLIVersion(),
-			})
+			}
+			if appearance, ok := a.(agent.Appearance); ok {
+				participant.Avatar = appearance.GetAvatar()
+				participant.Color = appearance.GetColor()
+			}
+			participants = append(participants, participant)
 		}
 
 		bridgeEmitter.EmitConversationStarted(
@@ -617,33 +1039,29 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 		)
 	}
 
+	// The style guide and scenario are pinned so they survive history trimming and reach every agent.
+	// CLI adapters take the first host message as the task, so both follow the initial prompt.
 	if o.config.InitialPrompt != "" {
-		initialMsg := agent.Message{
-			AgentID:   "host",
-			AgentName: "HOST",
-			Content:   o.config.InitialPrompt,
-			Timestamp: time.Now().Unix(),
-			Role:      "system",
-		}
-		o.mu.Lock()
-		o.messages = append(o.messages, initialMsg)
-		hooks := append([]MessageHook(nil), o.messageHooks...)
-		o.mu.Unlock()
-
-		// Log using the logger if available
-		if o.logger != nil {
-			o.logger.LogMessage(initialMsg)
-		}
-		// Always write to writer if available (for TUI)
-		if o.writer != nil {
-			fmt.Fprintf(o.writer, "\n[HOST] %s\n", initialMsg.Content)
-		}
+		o.postHostMessage(o.config.InitialPrompt, false)
+	}
+	if o.config.StyleGuide != "" {
+		o.postHostMessage(styleGuidePrefix+o.config.StyleGuide, true)
+	}
+	if o.config.Scenario != "" {
+		o.postHostMessage(o.config.Scenario, true)
+	}
 
-		for _, hook := range hooks {
-			hook(initialMsg)
-		}
+	if err := o.waitStartupDelay(ctx); err != nil {
+		runErr = err
+		return runErr
 	}
 
+	// The idle timeout runs from the first turn
+	o.mu.Lock()
+	o.lastResponseAt = time.Now()
+	o.endReason = ""
+	o.mu.Unlock()
+
 	switch o.config.Mode {
 	case ModeRoundRobin:
 		runErr = o.runRoundRobin(ctx)
@@ -663,6 +1081,175 @@ func (o *Orchestrator) Start(ctx context.Context) error {
 	}
 }
 
+// waitStartupDelay pauses for StartupDelay before the first agent turn, returning
+// early with the context's error if the conversation is cancelled meanwhile.
+func (o *Orchestrator) waitStartupDelay(ctx context.Context) error {
+	if o.config.StartupDelay <= 0 {
+		return nil
+	}
+
+	log.WithField("delay", o.config.StartupDelay.String()).Info("waiting before the first agent turn")
+	select {
+	case <-time.After(o.config.StartupDelay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// prewarmTimeout bounds each agent's prewarm so an unreachable endpoint can't delay the conversation
+const prewarmTimeout = 10 * time.Second
+
+// prewarmAgents opens every agent's connection concurrently before the first turn and
+// logs how long each took. Failures are logged and otherwise ignored: the first real
+// turn simply pays the connection cost instead.
+func (o *Orchestrator) prewarmAgents(ctx context.Context) {
+	o.mu.RLock()
+	agents := append([]agent.Agent(nil), o.agents...)
+	o.mu.RUnlock()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for _, a := range agents {
+		prewarmer, ok := a.(agent.Prewarmer)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(a agent.Agent, prewarmer agent.Prewarmer) {
+			defer wg.Done()
+
+			prewarmCtx, cancel := context.WithTimeout(ctx, prewarmTimeout)
+			defer cancel()
+
+			agentStart := time.Now()
+			err := prewarmer.Prewarm(prewarmCtx)
+			fields := map[string]interface{}{
+				"agent_id":    a.GetID(),
+				"agent_name":  a.GetName(),
+				"duration_ms": time.Since(agentStart).Milliseconds(),
+			}
+			if err != nil {
+				log.WithFields(fields).WithError(err).Warn("agent prewarm failed")
+				return
+			}
+			log.WithFields(fields).Debug("agent prewarmed")
+		}(a, prewarmer)
+	}
+	wg.Wait()
+
+	log.WithFields(map[string]interface{}{
+		"agents":      len(agents),
+		"duration_ms": time.Since(start).Milliseconds(),
+	}).Info("agents prewarmed")
+}
+
+// resetAgents clears per-conversation state held by agents implementing agent.Resetter.
+// Agent instances may be reused from an earlier conversation (e.g. a TUI restart), and
+// must not carry its state, such as a server-side thread, into this one.
+func (o *Orchestrator) resetAgents() {
+	o.mu.RLock()
+	agents := append([]agent.Agent(nil), o.agents...)
+	o.mu.RUnlock()
+
+	for _, a := range agents {
+		if r, ok := a.(agent.Resetter); ok {
+			r.Reset()
+		}
+	}
+}
+
+// kickoffMessage returns the KickoffPrompt directive while no agent has responded yet,
+// or nil. The directive is only added to the opening agent's request, never to the history.
+func (o *Orchestrator) kickoffMessage() *agent.Message {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	if o.config.KickoffPrompt == "" || o.kickedOff {
+		return nil
+	}
+	return privateDirective(o.config.KickoffPrompt)
+}
+
+// privateDirective returns a HOST message that is only added to one agent's request and
+// never stored in the history. It is marked with "directive" metadata so adapters that
+// track what they already sent (e.g. Amp's threads) send it every time it appears.
+func privateDirective(content string) *agent.Message {
+	return &agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   content,
+		Timestamp: time.Now().Unix(),
+		Role:      "system",
+		Metadata:  map[string]interface{}{"directive": true},
+	}
+}
+
+// checkResponseSchema validates a response against the agent's response schema, if any
+func (o *Orchestrator) checkResponseSchema(a agent.Agent, response string) error {
+	schema, ok := o.config.ResponseSchemas[a.GetID()]
+	if !ok {
+		return nil
+	}
+	return middleware.ValidateResponse(response, schema)
+}
+
+// schemaReminderMessage returns a private reminder of the agent's response schema,
+// added to the request when its previous attempt broke the schema. Like the kickoff
+// directive, it is never stored in the history.
+func (o *Orchestrator) schemaReminderMessage(a agent.Agent, schemaErr error) agent.Message {
+	return *privateDirective(middleware.SchemaReminder(o.config.ResponseSchemas[a.GetID()], schemaErr))
+}
+
+// roleReminderPrefix introduces an agent's own prompt when it is re-sent as a reminder
+const roleReminderPrefix = "Reminder of your role in this conversation (stay consistent with it): "
+
+// roleReminderMessage returns a private reminder of the agent's own prompt once
+// RoleReminderInterval turns have passed since its last reminder, or nil. Like the
+// kickoff directive, it is only added to the agent's request, never to the history.
+func (o *Orchestrator) roleReminderMessage(a agent.Agent) *agent.Message {
+	if o.config.RoleReminderInterval <= 0 || a.GetPrompt() == "" {
+		return nil
+	}
+
+	o.mu.RLock()
+	due := o.currentTurnNumber-o.roleReminders[a.GetID()] >= o.config.RoleReminderInterval
+	o.mu.RUnlock()
+	if !due {
+		return nil
+	}
+	return privateDirective(roleReminderPrefix + a.GetPrompt())
+}
+
+// styleGuidePrefix introduces the style guide so agents read it as tone guidance, not a task
+const styleGuidePrefix = "Style guide for all participants: "
+
+// postHostMessage adds a HOST system message to the conversation
+func (o *Orchestrator) postHostMessage(content string, pinned bool) {
+	msg := agent.Message{
+		AgentID:   "host",
+		AgentName: "HOST",
+		Content:   content,
+		Timestamp: time.Now().Unix(),
+		Role:      "system",
+		Pinned:    pinned,
+	}
+	o.mu.Lock()
+	o.messages = append(o.messages, msg)
+	hooks := append([]ContextMessageHook(nil), o.messageHooks...)
+	o.mu.Unlock()
+
+	// Log using the logger if available
+	if o.logger != nil {
+		o.logger.LogMessage(msg)
+	}
+	// Always write to writer if available (for TUI)
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[HOST] %s\n", msg.Content)
+	}
+
+	o.runHooks(hooks, msg)
+}
+
 func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
 	turns := 0
 	agentIndex := 0
@@ -674,32 +1261,47 @@ func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
 		default:
 		}
 
-		if o.config.MaxTurns > 0 && turns >= o.config.MaxTurns {
-			endMsg := "Maximum turns reached. Conversation ended."
-			if o.logger != nil {
-				o.logger.LogSystem(endMsg)
-			}
-			if o.writer != nil {
-				fmt.Fprintln(o.writer, "\n[System] "+endMsg)
-			}
+		if condition, endMsg := o.shouldEnd(turns, ""); condition != "" {
+			o.endConversation(ctx, condition, endMsg)
 			break
 		}
 
-		currentAgent := o.agents[agentIndex]
+		// A focused agent takes extra turns without advancing the rotation
+		currentAgent := o.takeFocusTurn()
+		focused := currentAgent != nil && !o.quotaReached(currentAgent)
+		if !focused {
+			currentAgent = o.agents[agentIndex]
+			// Agents that used their turn quota are passed over in the rotation
+			if o.quotaReached(currentAgent) {
+				agentIndex = (agentIndex + 1) % len(o.agents)
+				if agentIndex == 0 {
+					turns++
+				}
+				continue
+			}
+		}
+		o.setWarmup(turns)
 
-		if err := o.getAgentResponse(ctx, currentAgent); err != nil {
+		respErr := o.getAgentResponse(ctx, currentAgent)
+		if err := o.trackConsecutiveFailures(ctx, respErr); err != nil {
+			return err
+		}
+		if respErr != nil {
 			if o.logger != nil {
-				o.logger.LogError(currentAgent.GetName(), err)
+				o.logger.LogError(currentAgent.GetName(), respErr)
 				o.logger.LogSystem("Continuing conversation with remaining agents...")
 			}
 			if o.writer != nil {
-				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", currentAgent.GetName(), err)
+				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", currentAgent.GetName(), respErr)
 				fmt.Fprintf(o.writer, "[Info] Continuing conversation with remaining agents...\n")
 			}
 		}
 
 		time.Sleep(o.config.ResponseDelay)
 
+		if focused {
+			continue
+		}
 		agentIndex = (agentIndex + 1) % len(o.agents)
 		if agentIndex == 0 {
 			turns++
@@ -712,6 +1314,7 @@ func (o *Orchestrator) runRoundRobin(ctx context.Context) error {
 func (o *Orchestrator) runReactive(ctx context.Context) error {
 	turns := 0
 	lastSpeaker := ""
+	routed := -1
 
 	for {
 		select {
@@ -720,26 +1323,40 @@ func (o *Orchestrator) runReactive(ctx context.Context) error {
 		default:
 		}
 
-		if o.config.MaxTurns > 0 && turns >= o.config.MaxTurns {
-			endMsg := "Maximum turns reached. Conversation ended."
-			if o.logger != nil {
-				o.logger.LogSystem(endMsg)
-			}
-			if o.writer != nil {
-				fmt.Fprintln(o.writer, "\n[System] "+endMsg)
-			}
+		if condition, endMsg := o.shouldEnd(turns, lastSpeaker); condition != "" {
+			o.endConversation(ctx, condition, endMsg)
 			break
 		}
 
-		nextAgent := o.selectNextAgent(lastSpeaker)
+		// A focused agent, then an agent addressed by name in the latest message,
+		// take precedence over the selector, unless they have used their turn quota
+		nextAgent := o.takeFocusTurn()
+		if nextAgent != nil && o.quotaReached(nextAgent) {
+			nextAgent = nil
+		}
+		if nextAgent == nil {
+			var routedAt int
+			nextAgent, routedAt = o.mentionTarget(routed)
+			if nextAgent != nil {
+				routed = routedAt
+			}
+			if nextAgent == nil || o.quotaReached(nextAgent) {
+				nextAgent = o.selectNextAgent(lastSpeaker)
+			}
+		}
 		if nextAgent == nil {
 			time.Sleep(o.config.ResponseDelay)
 			continue
 		}
 
-		if err := o.getAgentResponse(ctx, nextAgent); err != nil {
+		o.setWarmup(turns)
+		respErr := o.getAgentResponse(ctx, nextAgent)
+		if err := o.trackConsecutiveFailures(ctx, respErr); err != nil {
+			return err
+		}
+		if respErr != nil {
 			if o.writer != nil {
-				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", nextAgent.GetName(), err)
+				fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", nextAgent.GetName(), respErr)
 			}
 		} else {
 			lastSpeaker = nextAgent.GetID()
@@ -754,6 +1371,9 @@ func (o *Orchestrator) runReactive(ctx context.Context) error {
 
 func (o *Orchestrator) runFreeForm(ctx context.Context) error {
 	turns := 0
+	relaxCooldowns := false
+	emptyPasses := 0
+	routed := -1
 
 	for {
 		select {
@@ -762,34 +1382,180 @@ func (o *Orchestrator) runFreeForm(ctx context.Context) error {
 		default:
 		}
 
-		if o.config.MaxTurns > 0 && turns >= o.config.MaxTurns {
-			endMsg := "Maximum turns reached. Conversation ended."
-			if o.logger != nil {
-				o.logger.LogSystem(endMsg)
-			}
-			if o.writer != nil {
-				fmt.Fprintln(o.writer, "\n[System] "+endMsg)
-			}
+		if condition, endMsg := o.shouldEnd(turns, ""); condition != "" {
+			o.endConversation(ctx, condition, endMsg)
 			break
 		}
 
-		for _, a := range o.agents {
-			if shouldRespond(o.getMessages(), a) {
-				if err := o.getAgentResponse(ctx, a); err != nil {
+		// If every agent sat out the previous pass because of cooldowns,
+		// relax them for this pass so the conversation can't stall
+		respondedThisPass := false
+		succeededThisPass := false
+		passAgents := o.agents
+
+		// A focused agent, or else an agent addressed by name in the latest message,
+		// answers next on its own
+		addressed := o.takeFocusTurn()
+		if addressed == nil {
+			var routedAt int
+			addressed, routedAt = o.mentionTarget(routed)
+			if addressed != nil {
+				routed = routedAt
+			}
+		}
+		if addressed != nil && o.quotaReached(addressed) {
+			addressed = nil
+		}
+		if addressed != nil {
+			passAgents = []agent.Agent{addressed}
+		}
+
+		for _, a := range passAgents {
+			if o.quotaReached(a) {
+				continue
+			}
+			cooldown := 0
+			if c, ok := a.(agent.ResponseCooldown); ok && !relaxCooldowns {
+				cooldown = c.GetMinTurnsBetweenResponses()
+			}
+			if addressed != nil || shouldRespond(o.getMessages(), a, cooldown) {
+				respondedThisPass = true
+				o.setWarmup(turns)
+				respErr := o.getAgentResponse(ctx, a)
+				if err := o.trackConsecutiveFailures(ctx, respErr); err != nil {
+					return err
+				}
+				if respErr != nil {
 					if o.writer != nil {
-						fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", a.GetName(), err)
+						fmt.Fprintf(o.writer, "\n[Error] Agent %s failed: %v\n", a.GetName(), respErr)
 					}
 				} else {
 					turns++
+					succeededThisPass = true
 				}
 				time.Sleep(o.config.ResponseDelay)
+
+				// End the pass early so the next one can route to an agent just addressed
+				if next, _ := o.mentionTarget(routed); next != nil {
+					break
+				}
 			}
 		}
+		relaxCooldowns = !respondedThisPass
+
+		// Passes where every agent declined or failed don't advance MaxTurns,
+		// so stop once they repeat instead of spinning indefinitely
+		if succeededThisPass {
+			emptyPasses = 0
+			continue
+		}
+		emptyPasses++
+		if emptyPasses >= o.config.MaxEmptyPasses {
+			log.WithField("empty_passes", emptyPasses).Warn("ending free-form conversation: no participants responding")
+			o.endConversation(ctx, EndNoResponses,
+				fmt.Sprintf("No participants responding after %d passes. Conversation ended.", emptyPasses))
+			break
+		}
+		if !respondedThisPass {
+			time.Sleep(o.config.ResponseDelay)
+		}
 	}
 
 	return nil
 }
 
+// trackConsecutiveFailures counts agent response failures in a row and returns
+// ErrTooManyConsecutiveFailures once MaxConsecutiveFailures is reached.
+// Any success resets the count; failures caused by cancellation are not counted.
+func (o *Orchestrator) trackConsecutiveFailures(ctx context.Context, respErr error) error {
+	if respErr == nil {
+		o.consecutiveFails = 0
+		return nil
+	}
+	if ctx.Err() != nil || o.config.MaxConsecutiveFailures <= 0 {
+		return nil
+	}
+
+	o.consecutiveFails++
+	if o.consecutiveFails < o.config.MaxConsecutiveFailures {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %d agent responses failed in a row (last error: %v)",
+		ErrTooManyConsecutiveFailures, o.consecutiveFails, respErr)
+	log.WithField("max_consecutive_failures", o.config.MaxConsecutiveFailures).WithError(respErr).Error("aborting conversation after too many consecutive failures")
+	if o.logger != nil {
+		o.logger.LogSystem("Aborting conversation: " + err.Error())
+	}
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[Error] Aborting conversation: %v\n", err)
+	}
+	return err
+}
+
+// turnTimeout returns the response timeout for an agent, preferring its override.
+func (o *Orchestrator) turnTimeout(a agent.Agent) time.Duration {
+	if timeout, ok := o.config.AgentTimeouts[a.GetID()]; ok && timeout > 0 {
+		return timeout
+	}
+	return o.config.TurnTimeout
+}
+
+// maxTurnsReached reports whether the conversation has used up its turn budget.
+// Warmup turns are added on top of MaxTurns so they don't count against it.
+func (o *Orchestrator) maxTurnsReached(turns int) bool {
+	if o.config.MaxTurns <= 0 {
+		return false
+	}
+	return turns >= o.config.MaxTurns+o.config.WarmupTurns
+}
+
+// closingDirective asks each agent for its closing statement
+const closingDirective = "Closing round: the conversation is ending. Each participant gets one final turn. " +
+	"Give a brief closing statement summarizing your position."
+
+// runClosingRound gives every agent one final turn, in registration order, when
+// ClosingRound is enabled. Failed closing statements are reported and skipped.
+func (o *Orchestrator) runClosingRound(ctx context.Context) {
+	if !o.config.ClosingRound || ctx.Err() != nil {
+		return
+	}
+
+	o.mu.Lock()
+	o.inWarmup = false
+	o.inClosing = true
+	agents := append([]agent.Agent(nil), o.agents...)
+	o.mu.Unlock()
+	defer func() {
+		o.mu.Lock()
+		o.inClosing = false
+		o.mu.Unlock()
+	}()
+
+	log.WithField("agents", len(agents)).Info("starting closing round")
+	o.postHostMessage(closingDirective, false)
+
+	for _, a := range agents {
+		if ctx.Err() != nil {
+			return
+		}
+		if o.quotaReached(a) {
+			continue
+		}
+		if err := o.getAgentResponse(ctx, a); err != nil && o.writer != nil {
+			fmt.Fprintf(o.writer, "\n[Error] Agent %s failed to give a closing statement: %v\n", a.GetName(), err)
+		}
+		time.Sleep(o.config.ResponseDelay)
+	}
+}
+
+// setWarmup records whether the given turn falls within the warmup period.
+func (o *Orchestrator) setWarmup(turns int) {
+	o.mu.Lock()
+	o.inWarmup = turns < o.config.WarmupTurns
+	o.mu.Unlock()
+}
+
 func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) error {
 	// Apply rate limiting before attempting to get response
 	o.mu.RLock()
@@ -811,7 +1577,31 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		}
 	}
 
-	messages := o.getMessages()
+	o.applyPendingPrompt(a)
+	o.injectDevilsAdvocate(a)
+
+	// The prompt suffix only applies to conversation turns. It is cleared again after
+	// the turn so summary, referee and vote requests to a reused agent go out as written.
+	if setter, ok := a.(agent.PromptSuffixSetter); ok && o.config.PromptSuffix != "" {
+		setter.SetPromptSuffix(o.config.PromptSuffix)
+		defer setter.SetPromptSuffix("")
+	}
+
+	// Tool steps are kept for the record but never sent back to agents, and other
+	// teams' scratchpads stay private
+	visible := visibleTo(withoutToolSteps(o.getMessages()), o.teamOf(a.GetID()))
+	messages := trimHistory(visible, o.config.MaxHistoryMessages, o.config.TrimStrategy)
+	kickoff := o.kickoffMessage()
+	if kickoff != nil {
+		messages = append(messages, *kickoff)
+	}
+	if briefing := o.teamBriefingMessage(a); briefing != nil {
+		messages = append(messages, *briefing)
+	}
+	reminder := o.roleReminderMessage(a)
+	if reminder != nil {
+		messages = append(messages, *reminder)
+	}
 
 	// Calculate input tokens from conversation history (once, outside retry loop)
 	var inputBuilder strings.Builder
@@ -833,6 +1623,11 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 	var response string
 	var startTime time.Time
 
+	endTurn := o.observeTurn(a)
+	defer endTurn()
+
+	attemptMessages := messages
+
 	for attempt := 0; attempt <= o.config.MaxRetries; attempt++ {
 		// Apply exponential backoff delay before retry (skip on first attempt)
 		if attempt > 0 {
@@ -842,6 +1637,12 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			}
 
 			delay := o.calculateBackoffDelay(attempt)
+			// Never retry sooner than the provider asked us to
+			if limiter != nil {
+				if cooldown := limiter.CooldownRemaining(); cooldown > delay {
+					delay = cooldown
+				}
+			}
 			log.WithFields(map[string]interface{}{
 				"agent_name":  a.GetName(),
 				"attempt":     attempt,
@@ -859,13 +1660,21 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			}
 		}
 
-		timeoutCtx, cancel := context.WithTimeout(ctx, o.config.TurnTimeout)
+		timeoutCtx, cancel := context.WithTimeout(ctx, o.turnTimeout(a))
 		startTime = time.Now()
 
 		// Attempt to get response
-		response, lastErr = a.SendMessage(timeoutCtx, messages)
+		response, lastErr = o.requestResponse(timeoutCtx, a, attemptMessages)
 		cancel()
 
+		// An off-schema response is retried with a reminder of the schema
+		if lastErr == nil {
+			if schemaErr := o.checkResponseSchema(a, response); schemaErr != nil {
+				lastErr = schemaErr
+				attemptMessages = append(messages[:len(messages):len(messages)], o.schemaReminderMessage(a, schemaErr))
+			}
+		}
+
 		if lastErr == nil {
 			// Success! Break out of retry loop
 			log.WithFields(map[string]interface{}{
@@ -876,6 +1685,19 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			break
 		}
 
+		if o.metrics != nil && client.IsRateLimitError(lastErr) {
+			o.metrics.RecordRateLimitHit(a.GetName())
+		}
+
+		// Pause the agent's limiter so later attempts and turns honor the provider's Retry-After
+		if delay := retryAfter(lastErr); delay > 0 && limiter != nil {
+			limiter.Pause(delay)
+			log.WithFields(map[string]interface{}{
+				"agent_name":  a.GetName(),
+				"retry_after": delay.String(),
+			}).Warn("provider requested backoff, pausing agent rate limiter")
+		}
+
 		// Log retry attempt
 		if o.logger != nil {
 			o.logger.LogError(a.GetName(), fmt.Errorf("attempt %d/%d failed: %w", attempt+1, o.config.MaxRetries+1, lastErr))
@@ -890,6 +1712,36 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			"attempt":     attempt + 1,
 			"max_retries": o.config.MaxRetries + 1,
 		}).WithError(lastErr).Warn("agent request attempt failed")
+
+		if !o.isRetryable(lastErr) {
+			log.WithFields(map[string]interface{}{
+				"agent_name": a.GetName(),
+				"error_type": classifyError(lastErr),
+			}).Warn("error is not retryable, giving up")
+			break
+		}
+
+		if attempt < o.config.MaxRetries && !o.takeRetry() {
+			log.WithFields(map[string]interface{}{
+				"agent_name":        a.GetName(),
+				"max_total_retries": o.config.MaxTotalRetries,
+			}).Warn("conversation retry budget exhausted, giving up")
+			if o.writer != nil {
+				fmt.Fprintf(o.writer, "[Error] Retry budget of %d exhausted, not retrying %s\n",
+					o.config.MaxTotalRetries, a.GetName())
+			}
+			break
+		}
+	}
+
+	// End the turn before any of its events are emitted
+	endTurn()
+
+	// A response still off-schema when retries run out is kept; the schema
+	// middleware flags or rejects it
+	var schemaErr *middleware.SchemaError
+	if errors.As(lastErr, &schemaErr) {
+		lastErr = nil
 	}
 
 	// If all retries failed, return the last error
@@ -900,12 +1752,7 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		}).WithError(lastErr).Error("all agent request attempts failed")
 
 		// Determine error type
-		errorType := "unknown"
-		if strings.Contains(lastErr.Error(), "timeout") || strings.Contains(lastErr.Error(), "deadline") {
-			errorType = "timeout"
-		} else if strings.Contains(lastErr.Error(), "rate limit") {
-			errorType = "rate_limit"
-		}
+		errorType := classifyError(lastErr)
 
 		// Record error metric
 		if o.metrics != nil {
@@ -919,6 +1766,17 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		return lastErr
 	}
 
+	if kickoff != nil {
+		o.mu.Lock()
+		o.kickedOff = true
+		o.mu.Unlock()
+	}
+	if reminder != nil {
+		o.mu.Lock()
+		o.roleReminders[a.GetID()] = o.currentTurnNumber
+		o.mu.Unlock()
+	}
+
 	// Calculate metrics
 	duration := time.Since(startTime)
 	outputTokens := utils.EstimateTokens(response)
@@ -967,8 +1825,32 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 			TotalTokens:  totalTokens,
 			Model:        model,
 			Cost:         cost,
+			BillingTag:   o.config.BillingTag,
 		},
 	}
+	if reporter, ok := a.(agent.FinishReasonReporter); ok {
+		msg.Metrics.FinishReason = reporter.LastFinishReason()
+	}
+	if reporter, ok := a.(agent.ReasoningReporter); ok {
+		msg.Reasoning = reporter.LastReasoning()
+	}
+	if team := o.teamOf(a.GetID()); team != "" {
+		if note, ok := splitTeamNote(response); ok {
+			msg.Content = note
+			msg.Team = team
+		}
+	}
+
+	o.mu.RLock()
+	warmup := o.inWarmup
+	closing := o.inClosing
+	o.mu.RUnlock()
+	if warmup {
+		msg.Metadata = map[string]interface{}{"warmup": true}
+	}
+	if closing {
+		msg.Metadata = map[string]interface{}{"closing": true}
+	}
 
 	// Process message through middleware chain
 	o.mu.RLock()
@@ -1000,27 +1882,44 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 		}
 	}
 
+	if reporter, ok := a.(agent.ToolStepReporter); ok {
+		o.recordToolSteps(reporter.TakeToolSteps())
+	}
+
+	o.scoreResponse(ctx, &msg)
+
 	o.mu.Lock()
+	if msg.Metrics != nil {
+		o.cumulativeTokens += msg.Metrics.TotalTokens
+		o.cumulativeCost += msg.Metrics.Cost
+		msg.Metrics.CumulativeTokens = o.cumulativeTokens
+		msg.Metrics.CumulativeCost = o.cumulativeCost
+	}
 	o.messages = append(o.messages, msg)
+	o.agentResponses[a.GetID()]++
+	o.lastResponseAt = time.Now()
 	currentTurn := o.currentTurnNumber
 	o.currentTurnNumber++
 	bridgeEmitter := o.bridgeEmitter
-	hooks := append([]MessageHook(nil), o.messageHooks...)
+	hooks := append([]ContextMessageHook(nil), o.messageHooks...)
 	o.mu.Unlock()
 
-	// Emit message.created event if bridge is enabled
+	// Emit message.created event if bridge is enabled. Team notes are marked with
+	// their team so viewers can tell them apart from the public channel.
 	if bridgeEmitter != nil {
 		bridgeEmitter.EmitMessageCreated(
 			a.GetID(),
 			a.GetType(),
 			a.GetName(),
-			response,
+			msg.Content,
 			model,
 			currentTurn,
 			totalTokens,
 			inputTokens,
 			outputTokens,
 			cost,
+			o.config.BillingTag,
+			msg.Team,
 			duration,
 		)
 	}
@@ -1029,28 +1928,172 @@ func (o *Orchestrator) getAgentResponse(ctx context.Context, a agent.Agent) erro
 	if o.logger != nil {
 		o.logger.LogMessage(msg)
 	}
-	// Always write to writer if available (for TUI)
-	if o.writer != nil {
+	// Always write to writer if available (for TUI). Team notes stay private: like
+	// hooks (e.g. the Matrix room), the writer only gets the public channel.
+	if writing(o.writer) && msg.Team == "" {
 		// Include metrics in a special format if available
+		var line string
 		if msg.Metrics != nil {
-			fmt.Fprintf(o.writer, "\n[%s|%dms|%dt|%.4f] %s\n",
+			line = fmt.Sprintf("[%s|%dms|%dt|%.4f] %s",
 				a.GetName(),
 				msg.Metrics.Duration.Milliseconds(),
 				msg.Metrics.TotalTokens,
 				msg.Metrics.Cost,
-				response)
+				msg.Content)
 		} else {
-			fmt.Fprintf(o.writer, "\n[%s] %s\n", a.GetName(), response)
+			line = fmt.Sprintf("[%s] %s", a.GetName(), msg.Content)
 		}
+		fmt.Fprintf(o.writer, "\n%s\n", utils.WrapText(line, o.config.RenderWidth))
 	}
 
-	for _, hook := range hooks {
-		hook(msg)
+	if msg.Team == "" {
+		o.runHooks(hooks, msg)
+	}
+
+	if updater, ok := a.(agent.MemoryUpdater); ok {
+		if err := updater.UpdateMemory(ctx, msg.Content); err != nil {
+			log.WithField("agent_name", a.GetName()).WithError(err).Warn("failed to update agent memory")
+		}
 	}
 
+	o.checkTopicDrift(ctx, currentTurn+1)
+	o.checkModeCollapse()
+
 	return nil
 }
 
+// takeRetry claims one attempt from the conversation-wide retry budget, reporting
+// false once MaxTotalRetries attempts have been made.
+func (o *Orchestrator) takeRetry() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.config.MaxTotalRetries > 0 && o.totalRetries >= o.config.MaxTotalRetries {
+		return false
+	}
+	o.totalRetries++
+	return true
+}
+
+// observeTurn tells a bridge emitter implementing bridge.TurnObserver that the agent's
+// turn has started and returns the func that ends it. Only the first call to that func has any effect.
+func (o *Orchestrator) observeTurn(a agent.Agent) func() {
+	o.mu.RLock()
+	emitter := o.bridgeEmitter
+	o.mu.RUnlock()
+
+	observer, ok := emitter.(bridge.TurnObserver)
+	if !ok {
+		return func() {}
+	}
+	observer.AgentTurnStarted(a.GetID(), a.GetName())
+	var once sync.Once
+	return func() { once.Do(func() { observer.AgentTurnEnded(a.GetID()) }) }
+}
+
+// recordToolSteps stores an agent's intermediate tool steps ahead of its response.
+// Tool steps are not turns: they don't advance the turn number or emit bridge events.
+func (o *Orchestrator) recordToolSteps(steps []agent.Message) {
+	if len(steps) == 0 {
+		return
+	}
+
+	for i := range steps {
+		steps[i].Role = "tool"
+		if steps[i].Timestamp == 0 {
+			steps[i].Timestamp = time.Now().Unix()
+		}
+	}
+
+	o.mu.Lock()
+	o.messages = append(o.messages, steps...)
+	hooks := append([]ContextMessageHook(nil), o.messageHooks...)
+	o.mu.Unlock()
+
+	for _, step := range steps {
+		if o.logger != nil {
+			o.logger.LogMessage(step)
+		}
+		if writing(o.writer) {
+			fmt.Fprintf(o.writer, "\n[%s|tool] %s\n", step.AgentName, step.Content)
+		}
+		o.runHooks(hooks, step)
+	}
+}
+
+// withoutToolSteps returns the messages that are not tool steps
+func withoutToolSteps(messages []agent.Message) []agent.Message {
+	filtered := messages[:0]
+	for _, msg := range messages {
+		if msg.Role != "tool" {
+			filtered = append(filtered, msg)
+		}
+	}
+	return filtered
+}
+
+// classifyError maps an agent error to a coarse type used for metrics, events and retry decisions.
+// Returns "auth", "timeout", "rate_limit", "5xx" or "unknown".
+func classifyError(err error) string {
+	if agent.IsAuthError(err) {
+		return "auth"
+	}
+
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == 401 || apiErr.StatusCode == 403) {
+		return "auth"
+	}
+	if client.IsRateLimitError(err) {
+		return "rate_limit"
+	}
+	var schemaErr *middleware.SchemaError
+	if errors.As(err, &schemaErr) {
+		return "schema"
+	}
+	if apiErr != nil && apiErr.StatusCode >= 500 {
+		return "5xx"
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline") {
+		return "timeout"
+	}
+	return "unknown"
+}
+
+// retryAfter returns the provider's Retry-After hint carried by an agent error, if any.
+func retryAfter(err error) time.Duration {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.RetryAfter
+	}
+	return 0
+}
+
+// isRetryable reports whether an error should be retried under the RetryableErrors allowlist.
+// Entries match either the classified error type or a substring of the error message.
+// Authentication failures are never retried since they need the user to log in.
+func (o *Orchestrator) isRetryable(err error) bool {
+	switch classifyError(err) {
+	case "auth":
+		return false
+	case "schema":
+		// The agent answered; it only needs reminding of the schema
+		return true
+	}
+	if len(o.config.RetryableErrors) == 0 {
+		return true
+	}
+
+	errorType := classifyError(err)
+	msg := err.Error()
+	for _, retryable := range o.config.RetryableErrors {
+		if retryable == errorType || strings.Contains(msg, retryable) {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateBackoffDelay computes the delay for the given retry attempt using exponential backoff.
 // The delay grows exponentially: InitialDelay * (Multiplier ^ attempt), capped at MaxDelay.
 func (o *Orchestrator) calculateBackoffDelay(attempt int) time.Duration {
@@ -1074,50 +2117,193 @@ func (o *Orchestrator) getMessages() []agent.Message {
 	return messages
 }
 
+// selectNextAgent asks the configured AgentSelector for the next reactive speaker
 func (o *Orchestrator) selectNextAgent(lastSpeaker string) agent.Agent {
-	// Count available agents (excluding last speaker)
-	availableCount := 0
-	for _, a := range o.agents {
-		if a.GetID() != lastSpeaker {
-			availableCount++
+	o.mu.RLock()
+	selector := o.selector
+	o.mu.RUnlock()
+
+	return selector.Select(o.agentsWithTurnsLeft(), o.getMessages(), lastSpeaker)
+}
+
+// shouldRespond decides whether an agent takes a free-form turn. An agent never
+// responds to itself, and with a cooldown it also sits out until at least that
+// many non-system messages have followed its last response.
+func shouldRespond(messages []agent.Message, a agent.Agent, cooldown int) bool {
+	if len(messages) == 0 {
+		return true
+	}
+
+	lastMessage := messages[len(messages)-1]
+	if lastMessage.AgentID == a.GetID() {
+		return false
+	}
+
+	since := 0
+	for i := len(messages) - 1; i >= 0 && since < cooldown; i-- {
+		msg := messages[i]
+		if msg.AgentID == a.GetID() && msg.Role == "agent" {
+			return false
+		}
+		if msg.Role != "system" && msg.Role != "tool" {
+			since++
 		}
 	}
+	return true
+}
 
-	if availableCount == 0 {
-		return nil
+// GetMessages returns a copy of all messages in the conversation history.
+// The returned slice is a copy and can be safely modified without affecting the orchestrator's state.
+// This method is thread-safe.
+func (o *Orchestrator) GetMessages() []agent.Message {
+	return o.getMessages()
+}
+
+// ErrMessageNotFound is returned when a message index is outside the conversation history.
+var ErrMessageNotFound = errors.New("message not found")
+
+// PinMessage pins the message at index in the conversation history (as returned by
+// GetMessages) so it is always included in the context sent to agents.
+// This method is thread-safe.
+func (o *Orchestrator) PinMessage(index int) error {
+	return o.setPinned(index, true)
+}
+
+// UnpinMessage removes the pin from the message at index, making it subject to trimming again.
+// This method is thread-safe.
+func (o *Orchestrator) UnpinMessage(index int) error {
+	return o.setPinned(index, false)
+}
+
+func (o *Orchestrator) setPinned(index int, pinned bool) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if index < 0 || index >= len(o.messages) {
+		return fmt.Errorf("%w: index %d (history has %d messages)", ErrMessageNotFound, index, len(o.messages))
 	}
+	o.messages[index].Pinned = pinned
+	return nil
+}
 
-	// Select a random index among available agents
-	targetIndex := rand.Intn(availableCount)
+// ErrAgentNotFound is returned when no registered agent has the requested ID.
+var ErrAgentNotFound = errors.New("agent not found")
+
+// SetAgentPrompt replaces the system prompt of the agent with the given ID, e.g. to
+// switch it to a different persona mid-conversation. The new prompt is handed to the
+// agent (see agent.PromptUpdater) just before its next turn, so it never changes under
+// a turn in progress. It returns ErrAgentNotFound for unknown IDs.
+// This method is thread-safe.
+func (o *Orchestrator) SetAgentPrompt(agentID, newPrompt string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
 
-	// Find the agent at that index
-	currentIndex := 0
 	for _, a := range o.agents {
-		if a.GetID() != lastSpeaker {
-			if currentIndex == targetIndex {
-				return a
-			}
-			currentIndex++
+		if a.GetID() != agentID {
+			continue
 		}
+		o.pendingPrompts[agentID] = newPrompt
+		log.WithFields(map[string]interface{}{
+			"agent_id":   agentID,
+			"agent_name": a.GetName(),
+			"prompt_len": len(newPrompt),
+		}).Info("agent prompt update queued for its next turn")
+		return nil
 	}
+	return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+}
 
-	return nil
+// applyPendingPrompt hands a the prompt queued for it by SetAgentPrompt, if any.
+// It runs on the conversation goroutine between turns, the only time agents are touched.
+func (o *Orchestrator) applyPendingPrompt(a agent.Agent) {
+	o.mu.Lock()
+	prompt, ok := o.pendingPrompts[a.GetID()]
+	delete(o.pendingPrompts, a.GetID())
+	o.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	updater, ok := a.(agent.PromptUpdater)
+	if !ok {
+		log.WithField("agent_name", a.GetName()).Warn("agent does not support prompt updates, keeping its prompt")
+		return
+	}
+	updater.UpdatePrompt(prompt)
+	log.WithFields(map[string]interface{}{
+		"agent_id":   a.GetID(),
+		"agent_name": a.GetName(),
+		"prompt_len": len(prompt),
+	}).Info("agent prompt updated")
 }
 
-func shouldRespond(messages []agent.Message, a agent.Agent) bool {
-	if len(messages) == 0 {
-		return true
+// FocusAgent gives the agent with the given ID the next turns turns exclusively (e.g. to
+// let an expert elaborate), after which normal speaker selection resumes. In round-robin
+// mode focused turns are extra and do not advance the rotation. A turns value of 0 or
+// less clears any active focus. It returns ErrAgentNotFound for unknown IDs.
+// This method is thread-safe.
+func (o *Orchestrator) FocusAgent(agentID string, turns int) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if turns <= 0 {
+		o.focusAgentID = ""
+		o.focusTurns = 0
+		return nil
 	}
+	for _, a := range o.agents {
+		if a.GetID() == agentID {
+			o.focusAgentID = agentID
+			o.focusTurns = turns
+			log.WithFields(map[string]interface{}{
+				"agent_id":   agentID,
+				"agent_name": a.GetName(),
+				"turns":      turns,
+			}).Info("agent focused")
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+}
 
-	lastMessage := messages[len(messages)-1]
-	return lastMessage.AgentID != a.GetID()
+// takeFocusTurn returns the focused agent and uses up one of its turns, or nil when
+// no focus is active
+func (o *Orchestrator) takeFocusTurn() agent.Agent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.focusTurns <= 0 {
+		return nil
+	}
+	for _, a := range o.agents {
+		if a.GetID() == o.focusAgentID {
+			o.focusTurns--
+			if o.focusTurns == 0 {
+				o.focusAgentID = ""
+			}
+			return a
+		}
+	}
+	// The focused agent is gone; drop the focus
+	o.focusAgentID = ""
+	o.focusTurns = 0
+	return nil
 }
 
-// GetMessages returns a copy of all messages in the conversation history.
-// The returned slice is a copy and can be safely modified without affecting the orchestrator's state.
+// LimiterStats is a snapshot of an agent's rate limiter.
+type LimiterStats = ratelimit.Stats
+
+// GetRateLimiterStats returns a snapshot of every agent's rate limiter, keyed by agent ID.
 // This method is thread-safe.
-func (o *Orchestrator) GetMessages() []agent.Message {
-	return o.getMessages()
+func (o *Orchestrator) GetRateLimiterStats() map[string]LimiterStats {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	stats := make(map[string]LimiterStats, len(o.rateLimiters))
+	for id, limiter := range o.rateLimiters {
+		stats[id] = limiter.GetStats()
+	}
+	return stats
 }
 
 // GetSummary returns the conversation summary if one was generated.
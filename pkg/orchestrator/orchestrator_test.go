@@ -5,17 +5,26 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/shawkym/agentpipe/internal/bridge"
 	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/client"
 	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/metrics"
+	"github.com/shawkym/agentpipe/pkg/middleware"
 )
 
 // MockAgent is a test double for agent.Agent
@@ -35,18 +44,21 @@ type MockAgent struct {
 	// For retry testing: fail first N attempts
 	failFirstN int
 	failCount  int
+	// Free-form cooldown in messages
+	cooldown int
 }
 
-func (m *MockAgent) GetID() string          { return m.id }
-func (m *MockAgent) GetName() string        { return m.name }
-func (m *MockAgent) GetType() string        { return m.agentType }
-func (m *MockAgent) GetModel() string       { return m.model }
-func (m *MockAgent) GetRateLimit() float64  { return m.rateLimit }
-func (m *MockAgent) GetRateLimitBurst() int { return m.rateLimitBurst }
-func (m *MockAgent) IsAvailable() bool      { return m.available }
-func (m *MockAgent) Announce() string       { return m.name + " has joined" }
-func (m *MockAgent) GetCLIVersion() string  { return "1.0.0" }
-func (m *MockAgent) GetPrompt() string      { return "You are a helpful assistant" }
+func (m *MockAgent) GetID() string                    { return m.id }
+func (m *MockAgent) GetName() string                  { return m.name }
+func (m *MockAgent) GetType() string                  { return m.agentType }
+func (m *MockAgent) GetModel() string                 { return m.model }
+func (m *MockAgent) GetRateLimit() float64            { return m.rateLimit }
+func (m *MockAgent) GetRateLimitBurst() int           { return m.rateLimitBurst }
+func (m *MockAgent) IsAvailable() bool                { return m.available }
+func (m *MockAgent) Announce() string                 { return m.name + " has joined" }
+func (m *MockAgent) GetCLIVersion() string            { return "1.0.0" }
+func (m *MockAgent) GetPrompt() string                { return "You are a helpful assistant" }
+func (m *MockAgent) GetMinTurnsBetweenResponses() int { return m.cooldown }
 func (m *MockAgent) Initialize(config agent.AgentConfig) error {
 	m.id = config.ID
 	m.name = config.Name
@@ -92,9 +104,19 @@ func (m *MockAgent) StreamMessage(ctx context.Context, messages []agent.Message,
 type MockBridgeEmitter struct {
 	conversationStartedCalled   bool
 	conversationCompletedCalled bool
+	completedEndReason          string
 	completedStatus             string
 	messageCreatedCount         int
+	billingTags                 []string // billing tag of each message.created event
+	contents                    []string // content of each message.created event
+	teams                       []string // team of each message.created event
 	errorCalled                 bool
+	participants                []bridge.AgentParticipant
+	summaryGenerated            *bridge.SummaryMetadata
+	voteCompleted               *bridge.VoteResult
+	completedTotalTokens        int
+	summaryError                string
+	events                      []bridge.EventType // summary and completion events, in order
 }
 
 func (m *MockBridgeEmitter) GetConversationID() string {
@@ -103,21 +125,46 @@ func (m *MockBridgeEmitter) GetConversationID() string {
 
 func (m *MockBridgeEmitter) EmitConversationStarted(mode string, initialPrompt string, maxTurns int, agents []bridge.AgentParticipant, commandInfo *bridge.CommandInfo) {
 	m.conversationStartedCalled = true
+	m.participants = agents
 }
 
-func (m *MockBridgeEmitter) EmitMessageCreated(agentID, agentType, agentName, content, model string, turnNumber, tokensUsed, inputTokens, outputTokens int, cost float64, duration time.Duration) {
+func (m *MockBridgeEmitter) EmitMessageCreated(agentID, agentType, agentName, content, model string, turnNumber, tokensUsed, inputTokens, outputTokens int, cost float64, billingTag, team string, duration time.Duration) {
 	m.messageCreatedCount++
+	m.billingTags = append(m.billingTags, billingTag)
+	m.contents = append(m.contents, content)
+	m.teams = append(m.teams, team)
 }
 
-func (m *MockBridgeEmitter) EmitConversationCompleted(status string, totalMessages, totalTurns, totalTokens int, totalCost float64, duration time.Duration, summary *bridge.SummaryMetadata) {
+func (m *MockBridgeEmitter) EmitConversationCompleted(status, endReason string, totalMessages, totalTurns, totalTokens int, totalCost float64, duration time.Duration, summary *bridge.SummaryMetadata) {
 	m.conversationCompletedCalled = true
+	m.events = append(m.events, bridge.EventConversationCompleted)
 	m.completedStatus = status
+	m.completedEndReason = endReason
+	m.completedTotalTokens = totalTokens
 }
 
 func (m *MockBridgeEmitter) EmitConversationError(errorMessage, errorType, agentType string) {
 	m.errorCalled = true
 }
 
+func (m *MockBridgeEmitter) EmitSummaryStarted(agentType string) {
+	m.events = append(m.events, bridge.EventSummaryStarted)
+}
+
+func (m *MockBridgeEmitter) EmitSummaryGenerated(summary *bridge.SummaryMetadata) {
+	m.events = append(m.events, bridge.EventSummaryGenerated)
+	m.summaryGenerated = summary
+}
+
+func (m *MockBridgeEmitter) EmitSummaryCompleted(errorMessage string) {
+	m.events = append(m.events, bridge.EventSummaryCompleted)
+	m.summaryError = errorMessage
+}
+
+func (m *MockBridgeEmitter) EmitVoteCompleted(result *bridge.VoteResult) {
+	m.voteCompleted = result
+}
+
 func (m *MockBridgeEmitter) Close() error {
 	return nil
 }
@@ -244,6 +291,65 @@ func TestRoundRobinMode(t *testing.T) {
 	}
 }
 
+func TestWarmupTurnsDoNotCountTowardMaxTurns(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      3,
+		WarmupTurns:   2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(config, &buf)
+
+	agent1 := &MockAgent{
+		id:              "agent-1",
+		name:            "Agent1",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "Response from Agent1",
+	}
+	agent2 := &MockAgent{
+		id:              "agent-2",
+		name:            "Agent2",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "Response from Agent2",
+	}
+
+	orch.AddAgent(agent1)
+	orch.AddAgent(agent2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// (2 warmup rounds + 3 counted rounds) * 2 agents = 10 agent messages
+	var agentMessages, warmupMessages int
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" {
+			continue
+		}
+		agentMessages++
+		if warmup, ok := msg.Metadata["warmup"].(bool); ok && warmup {
+			if agentMessages > 4 {
+				t.Errorf("message %d should not be marked as warmup", agentMessages)
+			}
+			warmupMessages++
+		}
+	}
+
+	if agentMessages != 10 {
+		t.Errorf("expected 10 agent messages, got %d", agentMessages)
+	}
+	if warmupMessages != 4 {
+		t.Errorf("expected 4 warmup messages, got %d", warmupMessages)
+	}
+}
+
 func TestReactiveMode(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:          ModeReactive,
@@ -630,6 +736,257 @@ func TestRetryExhaustion(t *testing.T) {
 	}
 }
 
+func TestMaxTotalRetries(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          3,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        2,
+		MaxTotalRetries:   3,
+		RetryInitialDelay: time.Millisecond,
+		RetryMaxDelay:     time.Millisecond,
+		RetryMultiplier:   1.0,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(config, &buf)
+
+	failingAgent := &MockAgent{
+		id:             "flaky-agent",
+		name:           "FlakyAgent",
+		agentType:      "mock",
+		available:      true,
+		sendMessageErr: errors.New("provider unavailable"),
+	}
+	orch.AddAgent(failingAgent)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	// Turn 1 uses 2 retries, turn 2 the last one, and turn 3 fails fast
+	if failingAgent.callCount != 3+2+1 {
+		t.Errorf("expected 6 attempts, got %d", failingAgent.callCount)
+	}
+	if orch.totalRetries != 3 {
+		t.Errorf("expected the retry budget of 3 to be spent, got %d", orch.totalRetries)
+	}
+	if !strings.Contains(buf.String(), "Retry budget of 3 exhausted") {
+		t.Errorf("expected a budget exhaustion notice, got:\n%s", buf.String())
+	}
+}
+
+func TestMaxTotalRetriesUnlimited(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          3,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        2,
+		RetryInitialDelay: time.Millisecond,
+		RetryMaxDelay:     time.Millisecond,
+		RetryMultiplier:   1.0,
+	}
+	orch := NewOrchestrator(config, io.Discard)
+
+	failingAgent := &MockAgent{
+		id:             "flaky-agent",
+		name:           "FlakyAgent",
+		agentType:      "mock",
+		available:      true,
+		sendMessageErr: errors.New("provider unavailable"),
+	}
+	orch.AddAgent(failingAgent)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	// Without a budget every turn gets its full MaxRetries
+	if failingAgent.callCount != 3*3 {
+		t.Errorf("expected 9 attempts, got %d", failingAgent.callCount)
+	}
+}
+
+// firstCallAgent records when it was first asked to respond
+type firstCallAgent struct {
+	MockAgent
+	firstCall time.Time
+}
+
+func (f *firstCallAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	if f.firstCall.IsZero() {
+		f.firstCall = time.Now()
+	}
+	return f.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestStartupDelay(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Hello",
+		StartupDelay:  delay,
+	}, io.Discard)
+
+	a := &firstCallAgent{MockAgent: MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Hi"}}
+	orch.AddAgent(a)
+
+	start := time.Now()
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if a.firstCall.IsZero() {
+		t.Fatal("expected the agent to be called")
+	}
+	if waited := a.firstCall.Sub(start); waited < delay {
+		t.Errorf("first agent call came %v after start, want at least %v", waited, delay)
+	}
+}
+
+func TestStartupDelayCancelled(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		StartupDelay:  time.Hour,
+	}, io.Discard)
+
+	a := &firstCallAgent{MockAgent: MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Hi"}}
+	orch.AddAgent(a)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := orch.Start(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context error, got %v", err)
+	}
+	if !a.firstCall.IsZero() {
+		t.Error("expected no agent call after cancellation during the startup delay")
+	}
+}
+
+func TestRetryableErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		retryable     []string
+		err           error
+		expectedCalls int
+	}{
+		{
+			name:          "empty allowlist retries everything",
+			retryable:     nil,
+			err:           errors.New("HTTP 401: invalid api key"),
+			expectedCalls: 3,
+		},
+		{
+			name:          "timeout is retryable",
+			retryable:     []string{"timeout", "rate_limit"},
+			err:           errors.New("request timeout"),
+			expectedCalls: 3,
+		},
+		{
+			name:          "5xx api error is retryable",
+			retryable:     []string{"5xx"},
+			err:           fmt.Errorf("request failed: %w", &client.APIError{StatusCode: 503, Message: "unavailable"}),
+			expectedCalls: 3,
+		},
+		{
+			name:          "substring match is retryable",
+			retryable:     []string{"connection reset"},
+			err:           errors.New("read: connection reset by peer"),
+			expectedCalls: 3,
+		},
+		{
+			name:          "auth failure fails fast",
+			retryable:     []string{"timeout", "rate_limit", "5xx"},
+			err:           fmt.Errorf("request failed: %w", &client.APIError{StatusCode: 401, Message: "invalid api key"}),
+			expectedCalls: 1,
+		},
+		{
+			name:          "CLI auth error is never retried",
+			retryable:     nil,
+			err:           &agent.AuthError{AgentType: "claude"},
+			expectedCalls: 1,
+		},
+		{
+			name:          "bad request fails fast",
+			retryable:     []string{"timeout"},
+			err:           errors.New("HTTP 400: bad request"),
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := OrchestratorConfig{
+				Mode:              ModeRoundRobin,
+				MaxTurns:          1,
+				TurnTimeout:       5 * time.Second,
+				ResponseDelay:     1 * time.Millisecond,
+				MaxRetries:        2,
+				RetryInitialDelay: 1 * time.Millisecond,
+				RetryMaxDelay:     5 * time.Millisecond,
+				RetryMultiplier:   2.0,
+				RetryableErrors:   tt.retryable,
+			}
+			orch := NewOrchestrator(config, io.Discard)
+
+			failingAgent := &MockAgent{
+				id:             "failing-agent",
+				name:           "FailingAgent",
+				agentType:      "mock",
+				available:      true,
+				sendMessageErr: tt.err,
+			}
+			orch.AddAgent(failingAgent)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			if err := orch.Start(ctx); err != nil {
+				t.Fatalf("unexpected orchestrator error: %v", err)
+			}
+
+			if failingAgent.callCount != tt.expectedCalls {
+				t.Errorf("expected %d attempts, got %d", tt.expectedCalls, failingAgent.callCount)
+			}
+		})
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{context.DeadlineExceeded, "timeout"},
+		{errors.New("turn timeout"), "timeout"},
+		{errors.New("rate limit exceeded"), "rate_limit"},
+		{&client.APIError{StatusCode: 429}, "rate_limit"},
+		{errors.New("Error 429: RESOURCE_EXHAUSTED"), "rate_limit"},
+		{errors.New("claude failed: usage limit reached"), "rate_limit"},
+		{errors.New("429 Too Many Requests: request timeout"), "rate_limit"},
+		{&client.APIError{StatusCode: 502}, "5xx"},
+		{&client.APIError{StatusCode: 400, Message: "bad request"}, "unknown"},
+		{&client.APIError{StatusCode: 401, Message: "invalid api key"}, "auth"},
+		{fmt.Errorf("turn failed: %w", &agent.AuthError{AgentType: "gemini"}), "auth"},
+		{errors.New("something else"), "unknown"},
+	}
+
+	for _, tt := range tests {
+		if got := classifyError(tt.err); got != tt.want {
+			t.Errorf("classifyError(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
 func TestCalculateBackoffDelay(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode:              ModeRoundRobin,
@@ -739,6 +1096,128 @@ func TestRetryDefaults(t *testing.T) {
 	}
 }
 
+func TestRetriesDisabled(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:            ModeRoundRobin,
+		MaxTurns:        1,
+		TurnTimeout:     time.Second,
+		ResponseDelay:   time.Millisecond,
+		MaxRetries:      5, // overridden by RetriesDisabled
+		RetriesDisabled: true,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(config, &buf)
+
+	if orch.config.MaxRetries != 0 {
+		t.Errorf("expected MaxRetries=0 with retries disabled, got %d", orch.config.MaxRetries)
+	}
+
+	failing := &MockAgent{
+		id:             "failing-agent",
+		name:           "FailingAgent",
+		agentType:      "mock",
+		available:      true,
+		sendMessageErr: errors.New("provider unavailable"),
+	}
+	orch.AddAgent(failing)
+
+	start := time.Now()
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if failing.callCount != 1 {
+		t.Errorf("expected a single attempt with retries disabled, got %d", failing.callCount)
+	}
+	if strings.Contains(buf.String(), "[Retry]") {
+		t.Errorf("expected no retry output, got %q", buf.String())
+	}
+	// Without retries there's no backoff delay (the default initial delay is 1s)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected no backoff delay, conversation took %v", elapsed)
+	}
+}
+
+func TestBillingTagAttached(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		BillingTag:    "acme",
+	}
+	orch := NewOrchestrator(config, nil)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "one"})
+	orch.AddAgent(&MockAgent{id: "b", name: "B", agentType: "mock", available: true, sendMessageResp: "two"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	var tagged int
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" {
+			continue
+		}
+		if msg.Metrics == nil || msg.Metrics.BillingTag != "acme" {
+			t.Errorf("expected %s's response tagged acme, got %+v", msg.AgentName, msg.Metrics)
+			continue
+		}
+		tagged++
+	}
+	if tagged != 2 {
+		t.Errorf("expected 2 tagged responses, got %d", tagged)
+	}
+	if len(emitter.billingTags) != 2 || emitter.billingTags[0] != "acme" || emitter.billingTags[1] != "acme" {
+		t.Errorf("expected every message.created event tagged acme, got %v", emitter.billingTags)
+	}
+}
+
+func TestRenderWidthWrapsResponses(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:                  ModeRoundRobin,
+		MaxTurns:              1,
+		TurnTimeout:           time.Second,
+		ResponseDelay:         time.Millisecond,
+		SuppressAnnouncements: true,
+		RenderWidth:           24,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(config, &buf)
+	orch.AddAgent(&MockAgent{
+		id:              "talker",
+		name:            "Talker",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "A response long enough that it has to be wrapped over several lines",
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	output := buf.String()
+	start := strings.Index(output, "[Talker")
+	if start < 0 {
+		t.Fatalf("expected the response in the output, got %q", output)
+	}
+	block := strings.SplitN(output[start:], "\n\n", 2)[0]
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+	if len(lines) < 3 {
+		t.Errorf("expected the response wrapped over several lines, got %q", block)
+	}
+	for _, line := range lines {
+		if len(line) > 24 {
+			t.Errorf("line %q is longer than the render width", line)
+		}
+	}
+	if got := strings.Join(strings.Fields(block), " "); !strings.HasSuffix(got, "] A response long enough that it has to be wrapped over several lines") {
+		t.Errorf("expected wrapping to keep the response intact, got %q", got)
+	}
+}
+
 func TestRateLimitingCreation(t *testing.T) {
 	config := OrchestratorConfig{
 		Mode: ModeRoundRobin,
@@ -1180,3 +1659,1825 @@ func TestGetSummary(t *testing.T) {
 		t.Errorf("summary mismatch: expected %q, got %q", testSummary.Text, retrievedSummary.Text)
 	}
 }
+
+func TestShouldRespondCooldown(t *testing.T) {
+	a := &MockAgent{id: "agent-a"}
+	msg := func(id, role string) agent.Message {
+		return agent.Message{AgentID: id, Role: role}
+	}
+
+	tests := []struct {
+		name     string
+		messages []agent.Message
+		cooldown int
+		want     bool
+	}{
+		{name: "empty conversation", messages: nil, cooldown: 2, want: true},
+		{name: "never responds to itself", messages: []agent.Message{msg("agent-a", "agent")}, cooldown: 0, want: false},
+		{
+			name:     "within cooldown",
+			messages: []agent.Message{msg("agent-a", "agent"), msg("agent-b", "agent")},
+			cooldown: 2,
+			want:     false,
+		},
+		{
+			name:     "cooldown elapsed",
+			messages: []agent.Message{msg("agent-a", "agent"), msg("agent-b", "agent"), msg("agent-c", "agent")},
+			cooldown: 2,
+			want:     true,
+		},
+		{
+			name:     "system messages don't count toward cooldown",
+			messages: []agent.Message{msg("agent-a", "agent"), msg("agent-b", "agent"), msg("host", "system")},
+			cooldown: 2,
+			want:     false,
+		},
+		{
+			name:     "no cooldown allows reply after one message",
+			messages: []agent.Message{msg("agent-a", "agent"), msg("agent-b", "agent")},
+			cooldown: 0,
+			want:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRespond(tt.messages, a, tt.cooldown); got != tt.want {
+				t.Errorf("shouldRespond() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreeFormCooldownSkipsEagerAgent(t *testing.T) {
+	config := OrchestratorConfig{
+		Mode:          ModeFreeForm,
+		MaxTurns:      9,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(config, &buf)
+
+	eager := &MockAgent{id: "agent-a", name: "Eager", agentType: "mock", available: true, sendMessageResp: "A", cooldown: 3}
+	orch.AddAgent(eager)
+	orch.AddAgent(&MockAgent{id: "agent-b", name: "B", agentType: "mock", available: true, sendMessageResp: "B"})
+	orch.AddAgent(&MockAgent{id: "agent-c", name: "C", agentType: "mock", available: true, sendMessageResp: "C"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lastEager := -1
+	position := 0
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" {
+			continue
+		}
+		if msg.AgentID == eager.id {
+			if lastEager >= 0 && position-lastEager-1 < eager.cooldown {
+				t.Errorf("eager agent responded after only %d other messages", position-lastEager-1)
+			}
+			lastEager = position
+		}
+		position++
+	}
+
+	// Free-form only checks MaxTurns between passes, so the last pass may overshoot
+	if position < 9 {
+		t.Errorf("expected at least 9 agent messages, got %d", position)
+	}
+	if eager.callCount == 0 || eager.callCount*(eager.cooldown+1) > position+eager.cooldown {
+		t.Errorf("expected the eager agent to be throttled, got %d of %d responses", eager.callCount, position)
+	}
+}
+
+func TestSummaryReusesConversationAgent(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock", ReuseAgent: true},
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+
+	mock := &MockAgent{
+		id:              "agent-1",
+		name:            "Agent1",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "SHORT: Brief.\nFULL: Detailed summary.",
+	}
+	orch.AddAgent(mock)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := orch.GetSummary()
+	if summary == nil {
+		t.Fatal("expected a summary from the reused agent")
+	}
+	if summary.ShortText != "Brief." {
+		t.Errorf("unexpected short summary: %q", summary.ShortText)
+	}
+	// One conversation turn plus one summary request on the same instance
+	if mock.callCount != 2 {
+		t.Errorf("expected the conversation agent to handle the summary, got %d calls", mock.callCount)
+	}
+	if strings.Contains(buf.String(), "[Warning] Summary agent") {
+		t.Errorf("did not expect an availability warning, got: %s", buf.String())
+	}
+}
+
+func TestSkipSummary(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock", ReuseAgent: true},
+	}
+	orch := NewOrchestrator(cfg, nil)
+	mock := &MockAgent{
+		id:              "agent-1",
+		name:            "Agent1",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "SHORT: Brief.\nFULL: Detailed summary.",
+	}
+	orch.AddAgent(mock)
+	orch.SkipSummary()
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if orch.GetSummary() != nil {
+		t.Error("expected no summary for a conversation that is being discarded")
+	}
+	if mock.callCount != 1 {
+		t.Errorf("expected only the conversation turn, got %d calls", mock.callCount)
+	}
+}
+
+func TestSummaryGeneratedEvent(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock", ReuseAgent: true},
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	orch.AddAgent(&MockAgent{
+		id:              "agent-1",
+		name:            "Agent1",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "SHORT: Brief.\nFULL: Detailed summary.",
+		sendDelay:       5 * time.Millisecond,
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := emitter.summaryGenerated
+	if summary == nil {
+		t.Fatal("expected a summary.generated event")
+	}
+	if summary != orch.GetSummary() {
+		t.Error("expected the event to carry the stored summary")
+	}
+	if summary.TotalTokens <= 0 || summary.InputTokens+summary.OutputTokens != summary.TotalTokens {
+		t.Errorf("expected summary token metrics, got %+v", summary)
+	}
+	if summary.DurationMs <= 0 {
+		t.Errorf("expected summary duration to be recorded, got %dms", summary.DurationMs)
+	}
+
+	// Conversation totals include the summary, which is also reported on its own
+	var conversationTokens int
+	for _, msg := range orch.GetMessages() {
+		if msg.Metrics != nil {
+			conversationTokens += msg.Metrics.TotalTokens
+		}
+	}
+	if emitter.completedTotalTokens != conversationTokens+summary.TotalTokens {
+		t.Errorf("expected completed total %d, got %d", conversationTokens+summary.TotalTokens, emitter.completedTotalTokens)
+	}
+}
+
+func TestSummaryGeneratedEventNotEmittedWhenDisabled(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "hi"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if emitter.summaryGenerated != nil {
+		t.Error("did not expect a summary.generated event with summaries disabled")
+	}
+}
+
+func TestSummaryStartedAndCompletedEvents(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock", ReuseAgent: true},
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "SHORT: Brief.\nFULL: Detailed summary."})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bridge.EventType{bridge.EventSummaryStarted, bridge.EventSummaryGenerated, bridge.EventSummaryCompleted, bridge.EventConversationCompleted}
+	if !reflect.DeepEqual(emitter.events, want) {
+		t.Errorf("expected events %v, got %v", want, emitter.events)
+	}
+	if emitter.summaryError != "" {
+		t.Errorf("did not expect a summary error, got %q", emitter.summaryError)
+	}
+}
+
+func TestSummaryCompletedEventOnFailure(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "no-such-summary-agent"},
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "hi"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []bridge.EventType{bridge.EventSummaryStarted, bridge.EventSummaryCompleted, bridge.EventConversationCompleted}
+	if !reflect.DeepEqual(emitter.events, want) {
+		t.Errorf("expected events %v, got %v", want, emitter.events)
+	}
+	if emitter.summaryError == "" {
+		t.Error("expected summary.completed to carry the failure")
+	}
+}
+
+func TestSummaryEventsNotEmittedWhenDisabled(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "hi"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []bridge.EventType{bridge.EventConversationCompleted}; !reflect.DeepEqual(emitter.events, want) {
+		t.Errorf("expected no summary events, got %v", emitter.events)
+	}
+}
+
+func TestSummaryAgentUnavailableWarning(t *testing.T) {
+	agent.RegisterFactory("mock-unavailable-summary", func() agent.Agent {
+		return &MockAgent{available: false}
+	})
+
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock-unavailable-summary"},
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "Hi"})
+
+	orch.checkSummaryAgent()
+
+	output := buf.String()
+	if !strings.Contains(output, `[Warning] Summary agent "mock-unavailable-summary" is unavailable`) {
+		t.Errorf("expected unavailability warning, got: %s", output)
+	}
+
+	// Unknown agent types are reported the same way
+	buf.Reset()
+	orch.config.Summary.Agent = "does-not-exist"
+	orch.checkSummaryAgent()
+	if !strings.Contains(buf.String(), "unknown agent type") {
+		t.Errorf("expected unknown type warning, got: %s", buf.String())
+	}
+
+	// No warning when summaries are disabled
+	buf.Reset()
+	orch.config.Summary.Enabled = false
+	orch.checkSummaryAgent()
+	if buf.Len() != 0 {
+		t.Errorf("expected no output with summaries disabled, got: %s", buf.String())
+	}
+}
+
+// toolStepAgent is a MockAgent that reports a tool step with every response
+type toolStepAgent struct {
+	*MockAgent
+	pending []agent.Message
+}
+
+func (t *toolStepAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	for _, msg := range messages {
+		if msg.Role == "tool" {
+			return "", errors.New("tool steps must not be sent to agents")
+		}
+	}
+	t.pending = []agent.Message{{AgentID: t.id, AgentName: t.name, Content: "Bash ls", Metadata: map[string]interface{}{"tool_step": "tool_use"}}}
+	return t.MockAgent.SendMessage(ctx, messages)
+}
+
+func (t *toolStepAgent) TakeToolSteps() []agent.Message {
+	steps := t.pending
+	t.pending = nil
+	return steps
+}
+
+func TestToolStepsAreStoredButNotCounted(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          2,
+		TurnTimeout:       5 * time.Second,
+		ResponseDelay:     1 * time.Millisecond,
+		RetryInitialDelay: 1 * time.Millisecond,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+
+	tool := &toolStepAgent{MockAgent: &MockAgent{id: "amp-1", name: "Amp", agentType: "amp", available: true, sendMessageResp: "Done"}}
+	orch.AddAgent(tool)
+	orch.AddAgent(&MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "Ok"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var agentMessages, toolMessages int
+	messages := orch.GetMessages()
+	for i, msg := range messages {
+		switch msg.Role {
+		case "agent":
+			agentMessages++
+		case "tool":
+			toolMessages++
+			if i+1 >= len(messages) || messages[i+1].AgentID != "amp-1" || messages[i+1].Role != "agent" {
+				t.Errorf("expected tool step %d to precede Amp's response", i)
+			}
+		}
+	}
+
+	// 2 rounds * 2 agents; tool steps don't consume turns
+	if agentMessages != 4 {
+		t.Errorf("expected 4 agent messages, got %d", agentMessages)
+	}
+	if toolMessages != 2 {
+		t.Errorf("expected 2 tool steps, got %d", toolMessages)
+	}
+	if !strings.Contains(buf.String(), "[Amp|tool] Bash ls") {
+		t.Errorf("expected tool step in writer output, got: %s", buf.String())
+	}
+}
+
+func TestAgentTimeoutOverride(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		AgentTimeouts:     map[string]time.Duration{"slow": 20 * time.Millisecond},
+		ResponseDelay:     1 * time.Millisecond,
+		RetryInitialDelay: 1 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+
+	slow := &MockAgent{id: "slow", name: "Slow", agentType: "mock", available: true, sendMessageResp: "late", sendDelay: 200 * time.Millisecond}
+	fast := &MockAgent{id: "fast", name: "Fast", agentType: "mock", available: true, sendMessageResp: "ok", sendDelay: 50 * time.Millisecond}
+
+	if got := orch.turnTimeout(fast); got != 5*time.Second {
+		t.Errorf("expected default timeout for agent without override, got %v", got)
+	}
+
+	if err := orch.getAgentResponse(context.Background(), slow); err == nil {
+		t.Error("expected the per-agent timeout to cut off the slow agent")
+	}
+	if err := orch.getAgentResponse(context.Background(), fast); err != nil {
+		t.Errorf("expected agent without override to use the turn timeout, got %v", err)
+	}
+}
+
+func TestSlowHookDoesNotBlockShutdown(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      100,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 10 * time.Millisecond,
+		HookTimeout:   time.Hour,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "Response"})
+
+	// A hook blocked on slow I/O that only returns once its context is cancelled
+	orch.AddContextMessageHook(func(ctx context.Context, msg agent.Message) {
+		<-ctx.Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- orch.Start(ctx) }()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Start did not return after cancellation while a hook was blocked")
+	}
+}
+
+func TestHookTimeoutBoundsSlowHook(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+		HookTimeout:   20 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "Response"})
+
+	var mu sync.Mutex
+	var simpleCalls int
+	var hookErrs []error
+	orch.AddMessageHook(func(msg agent.Message) {
+		mu.Lock()
+		simpleCalls++
+		mu.Unlock()
+	})
+	orch.AddContextMessageHook(func(ctx context.Context, msg agent.Message) {
+		<-ctx.Done()
+		mu.Lock()
+		hookErrs = append(hookErrs, ctx.Err())
+		mu.Unlock()
+	})
+
+	start := time.Now()
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected hook timeout to bound the conversation, took %v", elapsed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if simpleCalls != 2 {
+		t.Errorf("expected simple hook to see 2 messages, got %d", simpleCalls)
+	}
+	for _, err := range hookErrs {
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected hook context to hit its deadline, got %v", err)
+		}
+	}
+}
+
+func TestSummaryTimeout(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{}, io.Discard)
+	if got := orch.summaryTimeout(); got != defaultSummaryTimeout {
+		t.Errorf("expected default summary timeout, got %v", got)
+	}
+
+	orch = NewOrchestrator(OrchestratorConfig{Summary: config.SummaryConfig{Timeout: 5 * time.Second}}, io.Discard)
+	if got := orch.summaryTimeout(); got != 5*time.Second {
+		t.Errorf("expected configured summary timeout, got %v", got)
+	}
+}
+
+func TestMaxConsecutiveFailuresTripsBreaker(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive} {
+		t.Run(string(mode), func(t *testing.T) {
+			cfg := OrchestratorConfig{
+				Mode:                   mode,
+				MaxTurns:               100,
+				TurnTimeout:            time.Second,
+				ResponseDelay:          time.Millisecond,
+				RetryInitialDelay:      time.Millisecond, // Explicit retry config with MaxRetries 0
+				MaxConsecutiveFailures: 4,
+			}
+			var buf bytes.Buffer
+			orch := NewOrchestrator(cfg, &buf)
+
+			a1 := &MockAgent{id: "a1", name: "A1", agentType: "mock", available: true, sendMessageErr: errors.New("provider down")}
+			a2 := &MockAgent{id: "a2", name: "A2", agentType: "mock", available: true, sendMessageErr: errors.New("provider down")}
+			orch.AddAgent(a1)
+			orch.AddAgent(a2)
+
+			err := orch.Start(context.Background())
+			if !errors.Is(err, ErrTooManyConsecutiveFailures) {
+				t.Fatalf("expected ErrTooManyConsecutiveFailures, got %v", err)
+			}
+			if calls := a1.callCount + a2.callCount; calls != 4 {
+				t.Errorf("expected breaker to trip after 4 failed responses, got %d calls", calls)
+			}
+			if !strings.Contains(buf.String(), "too many consecutive failures") {
+				t.Errorf("expected abort message in output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestMaxConsecutiveFailuresResetsOnSuccess(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:                   ModeRoundRobin,
+		MaxTurns:               3,
+		TurnTimeout:            time.Second,
+		ResponseDelay:          time.Millisecond,
+		RetryInitialDelay:      time.Millisecond,
+		MaxConsecutiveFailures: 2,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+
+	// Failures alternate with successes, so the count never reaches 2
+	orch.AddAgent(&MockAgent{id: "bad", name: "Bad", agentType: "mock", available: true, sendMessageErr: errors.New("provider down")})
+	orch.AddAgent(&MockAgent{id: "good", name: "Good", agentType: "mock", available: true, sendMessageResp: "ok"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("expected conversation to finish, got %v", err)
+	}
+}
+
+// suffixAgent records the prompt suffix set by the orchestrator at each request
+type suffixAgent struct {
+	MockAgent
+	suffix string
+	seen   []string
+}
+
+func (s *suffixAgent) SetPromptSuffix(suffix string) { s.suffix = suffix }
+
+func (s *suffixAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	s.seen = append(s.seen, s.suffix)
+	return s.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestPromptSuffixIsPassedToAgents(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		PromptSuffix:  "Be brief.",
+	}, io.Discard)
+
+	a := &suffixAgent{MockAgent: MockAgent{id: "a1", name: "A1", agentType: "mock", available: true}}
+	orch.AddAgent(a)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(a.seen) != 1 || a.seen[0] != "Be brief." {
+		t.Errorf("expected the prompt suffix to be set for the agent's turn, got %q", a.seen)
+	}
+	if a.suffix != "" {
+		t.Errorf("expected the prompt suffix to be cleared after the turn, got %q", a.suffix)
+	}
+}
+
+func TestPromptSuffixSkipsHelperRequests(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		PromptSuffix:  "End every reply with a question.",
+		Summary:       config.SummaryConfig{Enabled: true, Agent: "mock", ReuseAgent: true},
+	}, io.Discard)
+
+	a := &suffixAgent{MockAgent: MockAgent{id: "a1", name: "A1", agentType: "mock", available: true,
+		sendMessageResp: "SHORT: Brief.\nFULL: Detailed summary."}}
+	orch.AddAgent(a)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// One conversation turn, then the summary request to the same instance
+	if len(a.seen) != 2 || a.seen[1] != "" {
+		t.Errorf("expected the summary request to go out without the suffix, got %q", a.seen)
+	}
+}
+
+func TestRetryAfterPausesAgentLimiter(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       5 * time.Second,
+		MaxRetries:        1,
+		RetryInitialDelay: 1 * time.Millisecond,
+		RetryMaxDelay:     5 * time.Millisecond,
+		RetryMultiplier:   2.0,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+
+	limited := &MockAgent{
+		id:             "limited",
+		name:           "Limited",
+		agentType:      "mock",
+		available:      true,
+		sendMessageErr: fmt.Errorf("api agent request failed: %w", &client.APIError{StatusCode: 429, Message: "slow down", RetryAfter: 150 * time.Millisecond}),
+	}
+	orch.AddAgent(limited)
+
+	// The retry must wait for the provider's Retry-After rather than the 1ms backoff
+	start := time.Now()
+	if err := orch.getAgentResponse(context.Background(), limited); err == nil {
+		t.Fatal("expected rate limited agent to fail")
+	}
+	if elapsed := time.Since(start); elapsed < 120*time.Millisecond {
+		t.Errorf("expected retry to honor Retry-After, took %v", elapsed)
+	}
+	if limited.callCount != 2 {
+		t.Errorf("expected 2 attempts, got %d", limited.callCount)
+	}
+
+	// The limiter stays paused so the agent's next turn also backs off
+	if cooldown := orch.rateLimiters[limited.GetID()].CooldownRemaining(); cooldown < 100*time.Millisecond {
+		t.Errorf("expected agent limiter to be paused after a 429, cooldown %v", cooldown)
+	}
+
+	limited.sendMessageErr = nil
+	limited.sendMessageResp = "ok"
+	start = time.Now()
+	if err := orch.getAgentResponse(context.Background(), limited); err != nil {
+		t.Fatalf("unexpected error after backoff: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected next turn to wait for the limiter pause, took %v", elapsed)
+	}
+}
+
+// appearanceAgent is a MockAgent with configured display metadata
+type appearanceAgent struct {
+	MockAgent
+	avatar string
+	color  string
+}
+
+func (a *appearanceAgent) GetAvatar() string { return a.avatar }
+func (a *appearanceAgent) GetColor() string  { return a.color }
+
+func TestParticipantsIncludeAppearance(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	orch.AddAgent(&appearanceAgent{
+		MockAgent: MockAgent{id: "a1", name: "Alice", agentType: "mock", available: true, sendMessageResp: "hi"},
+		avatar:    "🦊",
+		color:     "#ff87d7",
+	})
+	orch.AddAgent(&MockAgent{id: "b1", name: "Bob", agentType: "mock", available: true, sendMessageResp: "hello"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(emitter.participants) != 2 {
+		t.Fatalf("expected 2 participants, got %d", len(emitter.participants))
+	}
+	if p := emitter.participants[0]; p.Avatar != "🦊" || p.Color != "#ff87d7" {
+		t.Errorf("expected Alice's avatar and color in the participant payload, got %+v", p)
+	}
+	if p := emitter.participants[1]; p.Avatar != "" || p.Color != "" {
+		t.Errorf("expected no appearance for an agent without one, got %+v", p)
+	}
+}
+
+func TestFreeFormEndsWhenNoAgentsRespond(t *testing.T) {
+	tests := []struct {
+		name   string
+		agents []*MockAgent
+	}{
+		{
+			// A lone agent always declines to follow its own message
+			name:   "all agents decline",
+			agents: []*MockAgent{{id: "agent-1", name: "Solo", agentType: "mock", available: true, sendMessageResp: "hi"}},
+		},
+		{
+			name: "all agents fail",
+			agents: []*MockAgent{
+				{id: "agent-1", name: "A", agentType: "mock", available: true, sendMessageErr: errors.New("boom")},
+				{id: "agent-2", name: "B", agentType: "mock", available: true, sendMessageErr: errors.New("boom")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := OrchestratorConfig{
+				Mode:              ModeFreeForm,
+				MaxTurns:          0, // unlimited, so only empty-pass detection can end the run
+				TurnTimeout:       time.Second,
+				ResponseDelay:     time.Millisecond,
+				InitialPrompt:     "topic",
+				MaxEmptyPasses:    2,
+				MaxRetries:        0,
+				RetryInitialDelay: time.Millisecond,
+			}
+			var buf bytes.Buffer
+			orch := NewOrchestrator(cfg, &buf)
+			for _, a := range tt.agents {
+				orch.AddAgent(a)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			if err := orch.Start(ctx); err != nil {
+				t.Fatalf("expected the conversation to end cleanly, got %v", err)
+			}
+			if !strings.Contains(buf.String(), "No participants responding after 2 passes") {
+				t.Errorf("expected a no participants message, got:\n%s", buf.String())
+			}
+		})
+	}
+}
+
+func TestCumulativeMetrics(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      3,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Discuss pricing",
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "A", agentType: "mock", model: "gpt-5", available: true, sendMessageResp: "first answer"})
+	orch.AddAgent(&MockAgent{id: "agent-2", name: "B", agentType: "mock", model: "claude-sonnet-4-5-20250929", available: true, sendMessageResp: "second answer"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var totalTokens, prevTokens, count int
+	var totalCost, prevCost float64
+	for _, msg := range orch.GetMessages() {
+		if msg.Metrics == nil {
+			continue
+		}
+		count++
+		totalTokens += msg.Metrics.TotalTokens
+		totalCost += msg.Metrics.Cost
+
+		if msg.Metrics.CumulativeTokens <= prevTokens {
+			t.Errorf("message %d: cumulative tokens %d did not increase from %d", count, msg.Metrics.CumulativeTokens, prevTokens)
+		}
+		if msg.Metrics.CumulativeCost <= prevCost {
+			t.Errorf("message %d: cumulative cost %f did not increase from %f", count, msg.Metrics.CumulativeCost, prevCost)
+		}
+		if msg.Metrics.CumulativeTokens != totalTokens {
+			t.Errorf("message %d: cumulative tokens %d, want running total %d", count, msg.Metrics.CumulativeTokens, totalTokens)
+		}
+		prevTokens, prevCost = msg.Metrics.CumulativeTokens, msg.Metrics.CumulativeCost
+	}
+
+	if count != 6 {
+		t.Fatalf("expected 6 messages with metrics, got %d", count)
+	}
+	if prevTokens != totalTokens {
+		t.Errorf("final cumulative tokens %d, want %d", prevTokens, totalTokens)
+	}
+	if math.Abs(prevCost-totalCost) > 1e-12 {
+		t.Errorf("final cumulative cost %f, want %f", prevCost, totalCost)
+	}
+}
+
+func TestStyleGuide(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:               ModeRoundRobin,
+		MaxTurns:           2,
+		TurnTimeout:        time.Second,
+		ResponseDelay:      time.Millisecond,
+		InitialPrompt:      "Discuss caching",
+		StyleGuide:         "Disagree when you disagree; no flattery.",
+		MaxHistoryMessages: 2,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	first := &contextRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "First", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	late := &contextRecordingAgent{MockAgent: MockAgent{id: "agent-2", name: "Late", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	orch.AddAgent(first)
+	orch.AddAgent(late)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Posted once, as a system message, after the initial prompt so that stays the task
+	var guideAt, promptAt, guides int
+	for i, msg := range orch.GetMessages() {
+		if strings.Contains(msg.Content, cfg.StyleGuide) {
+			guides++
+			guideAt = i
+			if msg.Role != "system" || !msg.Pinned {
+				t.Errorf("expected a pinned system message, got role %q pinned %v", msg.Role, msg.Pinned)
+			}
+		}
+		if msg.Content == cfg.InitialPrompt {
+			promptAt = i
+		}
+	}
+	if guides != 1 {
+		t.Fatalf("expected the style guide to be posted once, got %d", guides)
+	}
+	if guideAt <= promptAt {
+		t.Errorf("expected the style guide (index %d) after the initial prompt (index %d)", guideAt, promptAt)
+	}
+
+	// Agents taking later turns still see it once history is trimmed
+	for _, a := range []*contextRecordingAgent{first, late} {
+		if !strings.Contains(strings.Join(contents(a.last), "\n"), cfg.StyleGuide) {
+			t.Errorf("expected %s's last turn to include the style guide, got %q", a.name, contents(a.last))
+		}
+	}
+}
+
+func TestScenario(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "The keeper hears a knock at the door.",
+		StyleGuide:    "Stay in first person.",
+		Scenario:      "Scenario for all participants.\nLocation: A lighthouse",
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	keeper := &contextRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "Keeper", agentType: "mock", available: true, sendMessageResp: "Who's there?"}}
+	orch.AddAgent(keeper)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Posted once, as a pinned system message, after the initial prompt and the style guide
+	guideAt, scenarioAt, promptAt, scenarios := -1, -1, -1, 0
+	for i, msg := range orch.GetMessages() {
+		switch {
+		case strings.Contains(msg.Content, cfg.StyleGuide):
+			guideAt = i
+		case msg.Content == cfg.Scenario:
+			scenarios++
+			scenarioAt = i
+			if msg.Role != "system" || !msg.Pinned {
+				t.Errorf("expected a pinned system message, got role %q pinned %v", msg.Role, msg.Pinned)
+			}
+		case msg.Content == cfg.InitialPrompt:
+			promptAt = i
+		}
+	}
+	if scenarios != 1 {
+		t.Fatalf("expected the scenario to be posted once, got %d", scenarios)
+	}
+	if !(promptAt < guideAt && guideAt < scenarioAt) {
+		t.Errorf("expected initial prompt < style guide < scenario, got indexes %d, %d, %d", promptAt, guideAt, scenarioAt)
+	}
+
+	if !strings.Contains(strings.Join(contents(keeper.last), "\n"), cfg.Scenario) {
+		t.Errorf("expected the agent to receive the scenario, got %q", contents(keeper.last))
+	}
+}
+
+func TestSuppressAnnouncements(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm} {
+		t.Run(string(mode), func(t *testing.T) {
+			cfg := OrchestratorConfig{
+				Mode:                  mode,
+				MaxTurns:              2,
+				TurnTimeout:           time.Second,
+				ResponseDelay:         time.Millisecond,
+				SuppressAnnouncements: true,
+			}
+			var buf bytes.Buffer
+			orch := NewOrchestrator(cfg, &buf)
+			orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "one"})
+			orch.AddAgent(&MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "two"})
+
+			if len(orch.GetMessages()) != 0 {
+				t.Fatalf("expected no announcement messages, got %+v", orch.GetMessages())
+			}
+			if len(orch.rateLimiters) != 2 {
+				t.Errorf("expected rate limiters for both agents, got %d", len(orch.rateLimiters))
+			}
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			agentMessages := 0
+			for _, msg := range orch.GetMessages() {
+				if msg.Role == "system" {
+					t.Errorf("expected no system messages, got %q", msg.Content)
+				}
+				if msg.Role == "agent" {
+					agentMessages++
+				}
+			}
+			if agentMessages == 0 {
+				t.Error("expected agents to respond without announcements")
+			}
+			if strings.Contains(buf.String(), "has joined") {
+				t.Errorf("expected no announcements in output, got %q", buf.String())
+			}
+		})
+	}
+}
+
+func TestAddAgentSkipsAnnouncementInLoadedHistory(t *testing.T) {
+	var buf bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, &buf)
+
+	agent1 := &MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true}
+	agent2 := &MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true}
+	orch.LoadMessages([]agent.Message{
+		{AgentID: "agent-1", AgentName: "Agent1", AgentType: "mock", Content: agent1.Announce(), Role: "system"},
+		{AgentID: "agent-1", AgentName: "Agent1", AgentType: "mock", Content: "Earlier reply", Role: "agent"},
+	})
+
+	orch.AddAgent(agent1)
+	orch.AddAgent(agent2)
+
+	messages := orch.GetMessages()
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 messages after resuming, got %d: %+v", len(messages), messages)
+	}
+	announced := make(map[string]int)
+	for _, msg := range messages {
+		if msg.Role == "system" {
+			announced[msg.AgentID]++
+		}
+	}
+	if announced["agent-1"] != 1 {
+		t.Errorf("expected agent-1 to be announced once, got %d", announced["agent-1"])
+	}
+	if announced["agent-2"] != 1 {
+		t.Errorf("expected agent-2 to be announced once, got %d", announced["agent-2"])
+	}
+	if strings.Contains(buf.String(), "Agent1") {
+		t.Errorf("expected no output for the already announced agent, got %q", buf.String())
+	}
+}
+
+func TestAddAgentAnnouncesDespiteOtherSystemMessages(t *testing.T) {
+	var buf bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, &buf)
+
+	agent1 := &MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true}
+	orch.LoadMessages([]agent.Message{
+		{AgentID: "agent-1", AgentName: "Agent1", AgentType: "mock", Content: "Agent1 timed out", Role: "system"},
+	})
+
+	orch.AddAgent(agent1)
+
+	messages := orch.GetMessages()
+	if len(messages) != 2 || messages[1].Content != agent1.Announce() {
+		t.Fatalf("expected the announcement after the unrelated system message, got %+v", messages)
+	}
+	if !strings.Contains(buf.String(), agent1.Announce()) {
+		t.Errorf("expected the announcement to be written, got %q", buf.String())
+	}
+}
+
+// promptAgent is a MockAgent whose system prompt can be updated and whose replies echo it
+type promptAgent struct {
+	*MockAgent
+	prompt string
+}
+
+func (p *promptAgent) GetPrompt() string { return p.prompt }
+
+func (p *promptAgent) UpdatePrompt(prompt string) { p.prompt = prompt }
+
+func (p *promptAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	time.Sleep(p.sendDelay)
+	return "prompt: " + p.prompt, nil
+}
+
+func TestSetAgentPrompt(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	persona := &promptAgent{
+		MockAgent: &MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true},
+		prompt:    "You are a supporter.",
+	}
+	plain := &MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true}
+	orch.AddAgent(persona)
+	orch.AddAgent(plain)
+
+	if err := orch.SetAgentPrompt("agent-1", "Now act as a critic."); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if persona.GetPrompt() != "You are a supporter." {
+		t.Errorf("expected the prompt to wait for the agent's next turn, got %q", persona.GetPrompt())
+	}
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if persona.GetPrompt() != "Now act as a critic." {
+		t.Errorf("expected the agent prompt to be updated, got %q", persona.GetPrompt())
+	}
+	found := false
+	for _, msg := range orch.GetMessages() {
+		if msg.AgentID == "agent-1" && msg.Role == "agent" {
+			found = true
+			if msg.Content != "prompt: Now act as a critic." {
+				t.Errorf("expected the next turn to use the new prompt, got %q", msg.Content)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected agent-1 to respond")
+	}
+
+	if err := orch.SetAgentPrompt("missing", "anything"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("expected ErrAgentNotFound, got %v", err)
+	}
+}
+
+func TestSetAgentPromptDuringConversation(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      20,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	persona := &promptAgent{
+		MockAgent: &MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendDelay: 2 * time.Millisecond},
+		prompt:    "You are a supporter.",
+	}
+	orch.AddAgent(persona)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			if err := orch.SetAgentPrompt("agent-1", fmt.Sprintf("Persona %d", i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-done
+}
+
+func TestProviderRateLimitRecordedInMetrics(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        1,
+		RetryInitialDelay: time.Millisecond,
+	}, nil)
+	m := metrics.NewMetrics(prometheus.NewRegistry())
+	orch.SetMetrics(m)
+	orch.AddAgent(&MockAgent{
+		id:             "limited",
+		name:           "Limited",
+		agentType:      "mock",
+		available:      true,
+		sendMessageErr: errors.New("Error 429: RESOURCE_EXHAUSTED"),
+	})
+
+	_ = orch.Start(context.Background())
+
+	if got := testutil.ToFloat64(m.RateLimitHits.WithLabelValues("Limited")); got != 2 {
+		t.Errorf("expected 2 rate limit hits (one per attempt), got %v", got)
+	}
+	if got := testutil.ToFloat64(m.AgentErrors.WithLabelValues("Limited", "mock", "rate_limit")); got != 1 {
+		t.Errorf("expected the final error to be classified as rate_limit, got %v", got)
+	}
+}
+
+// callRecordingAgent records the messages it was sent on every turn
+type callRecordingAgent struct {
+	MockAgent
+	calls [][]agent.Message
+}
+
+func (c *callRecordingAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	c.calls = append(c.calls, append([]agent.Message(nil), messages...))
+	return c.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestKickoffPrompt(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Discuss caching",
+		KickoffPrompt: "Open the discussion by stating your position.",
+	}
+	orch := NewOrchestrator(cfg, nil)
+	first := &callRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "First", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	second := &callRecordingAgent{MockAgent: MockAgent{id: "agent-2", name: "Second", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	orch.AddAgent(first)
+	orch.AddAgent(second)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hasKickoff := func(messages []agent.Message) bool {
+		for _, msg := range messages {
+			if msg.Content == cfg.KickoffPrompt {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(first.calls) < 2 || len(second.calls) == 0 {
+		t.Fatalf("expected both agents to take turns, got %d and %d", len(first.calls), len(second.calls))
+	}
+	opening := first.calls[0]
+	if last := opening[len(opening)-1]; last.Content != cfg.KickoffPrompt || last.Role != "system" {
+		t.Errorf("expected the opening turn to end with the kickoff directive, got %+v", last)
+	}
+	for i, call := range first.calls[1:] {
+		if hasKickoff(call) {
+			t.Errorf("expected no kickoff on the first agent's turn %d", i+2)
+		}
+	}
+	for i, call := range second.calls {
+		if hasKickoff(call) {
+			t.Errorf("expected no kickoff on the second agent's turn %d", i+1)
+		}
+	}
+	if hasKickoff(orch.GetMessages()) {
+		t.Error("expected the kickoff directive to stay out of the history")
+	}
+}
+
+// promptedAgent is a callRecordingAgent with its own system prompt
+type promptedAgent struct {
+	callRecordingAgent
+	prompt string
+}
+
+func (p *promptedAgent) GetPrompt() string { return p.prompt }
+
+func TestRoleReminderInterval(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:                 ModeRoundRobin,
+		MaxTurns:             4,
+		TurnTimeout:          time.Second,
+		ResponseDelay:        time.Millisecond,
+		InitialPrompt:        "Plan the heist",
+		RoleReminderInterval: 4,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	pirate := &promptedAgent{
+		callRecordingAgent: callRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "Pirate", agentType: "mock", available: true, sendMessageResp: "arr"}},
+		prompt:             "You are a pirate.",
+	}
+	robot := &promptedAgent{
+		callRecordingAgent: callRecordingAgent{MockAgent: MockAgent{id: "agent-2", name: "Robot", agentType: "mock", available: true, sendMessageResp: "beep"}},
+		prompt:             "You are a robot.",
+	}
+	orch.AddAgent(pirate)
+	orch.AddAgent(robot)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The agents alternate, so each one's calls are 2 turns apart; with an interval of 4
+	// turns, only their third calls (turns 4 and 5) fall due
+	for _, a := range []*promptedAgent{pirate, robot} {
+		if len(a.calls) != 4 {
+			t.Fatalf("expected %s to take 4 turns, got %d", a.name, len(a.calls))
+		}
+		for i, call := range a.calls {
+			var reminders []string
+			for _, msg := range call {
+				if strings.HasPrefix(msg.Content, roleReminderPrefix) {
+					reminders = append(reminders, msg.Content)
+				}
+			}
+			if i != 2 {
+				if len(reminders) != 0 {
+					t.Errorf("%s call %d: expected no reminder, got %q", a.name, i, reminders)
+				}
+				continue
+			}
+			if len(reminders) != 1 || reminders[0] != roleReminderPrefix+a.prompt {
+				t.Errorf("%s call %d: expected a reminder of its own prompt, got %q", a.name, i, reminders)
+			}
+			if last := call[len(call)-1]; last.Role != "system" || last.AgentName != "HOST" {
+				t.Errorf("%s call %d: expected the reminder last, as a HOST system message, got %+v", a.name, i, last)
+			}
+		}
+	}
+
+	// Reminders are private: never stored or displayed
+	for _, msg := range orch.GetMessages() {
+		if strings.HasPrefix(msg.Content, roleReminderPrefix) {
+			t.Errorf("expected no reminder in the history, got %+v", msg)
+		}
+	}
+	if strings.Contains(buf.String(), roleReminderPrefix) {
+		t.Error("expected no reminder in the output")
+	}
+}
+
+func TestKickoffPromptRetriedUntilAnAgentResponds(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:              ModeRoundRobin,
+		MaxTurns:          1,
+		TurnTimeout:       time.Second,
+		ResponseDelay:     time.Millisecond,
+		MaxRetries:        0,
+		RetryInitialDelay: time.Millisecond,
+		KickoffPrompt:     "Open the discussion.",
+	}
+	orch := NewOrchestrator(cfg, nil)
+	failing := &callRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "Failing", agentType: "mock", available: true, sendMessageErr: errors.New("boom")}}
+	opener := &callRecordingAgent{MockAgent: MockAgent{id: "agent-2", name: "Opener", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	orch.AddAgent(failing)
+	orch.AddAgent(opener)
+
+	_ = orch.Start(context.Background())
+
+	if len(opener.calls) == 0 {
+		t.Fatal("expected the second agent to take a turn")
+	}
+	call := opener.calls[0]
+	if call[len(call)-1].Content != cfg.KickoffPrompt {
+		t.Errorf("expected the kickoff to pass to the first agent that responds, got %q", contents(call))
+	}
+}
+
+func TestClosingRound(t *testing.T) {
+	for _, mode := range []ConversationMode{ModeRoundRobin, ModeReactive, ModeFreeForm} {
+		t.Run(string(mode), func(t *testing.T) {
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:          mode,
+				MaxTurns:      2,
+				TurnTimeout:   time.Second,
+				ResponseDelay: time.Millisecond,
+				ClosingRound:  true,
+			}, nil)
+			agents := []*callRecordingAgent{
+				{MockAgent: MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "one"}},
+				{MockAgent: MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "two"}},
+				{MockAgent: MockAgent{id: "agent-3", name: "Agent3", agentType: "mock", available: true, sendMessageResp: "three"}},
+			}
+			for _, a := range agents {
+				orch.AddAgent(a)
+			}
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			messages := orch.GetMessages()
+			directiveAt := -1
+			for i, msg := range messages {
+				if msg.Content == closingDirective {
+					directiveAt = i
+				}
+			}
+			if directiveAt < 0 {
+				t.Fatal("expected the closing directive to be posted")
+			}
+
+			closing := make(map[string]int)
+			for i, msg := range messages {
+				isClosing := msg.Metadata["closing"] == true
+				if isClosing && i < directiveAt {
+					t.Errorf("expected closing messages only after the directive, got %q at %d", msg.Content, i)
+				}
+				if i > directiveAt && msg.Role == "agent" && !isClosing {
+					t.Errorf("expected every message after the directive to be a closing statement, got %+v", msg)
+				}
+				if isClosing {
+					closing[msg.AgentID]++
+				}
+			}
+			for _, a := range agents {
+				if closing[a.id] != 1 {
+					t.Errorf("expected %s to speak once in the closing round, got %d", a.id, closing[a.id])
+				}
+			}
+		})
+	}
+}
+
+func TestClosingRoundDisabledByDefault(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "one"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, msg := range orch.GetMessages() {
+		if msg.Content == closingDirective || msg.Metadata["closing"] == true {
+			t.Errorf("expected no closing round by default, got %+v", msg)
+		}
+	}
+}
+
+func TestFocusAgent(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     ConversationMode
+		maxTurns int
+		focus    string
+		turns    int
+		want     []string
+	}{
+		// Focused turns are extra in round-robin, so the full rotation still runs
+		{name: "round-robin", mode: ModeRoundRobin, maxTurns: 1, focus: "agent-2", turns: 2,
+			want: []string{"agent-2", "agent-2", "agent-1", "agent-2", "agent-3"}},
+		{name: "reactive", mode: ModeReactive, maxTurns: 5, focus: "agent-3", turns: 3,
+			want: []string{"agent-3", "agent-3", "agent-3", "agent-1", "agent-2"}},
+		// The focused agent speaks even right after itself; afterwards it waits its turn again
+		{name: "free-form", mode: ModeFreeForm, maxTurns: 3, focus: "agent-1", turns: 2,
+			want: []string{"agent-1", "agent-1", "agent-2", "agent-3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:          tt.mode,
+				MaxTurns:      tt.maxTurns,
+				TurnTimeout:   time.Second,
+				ResponseDelay: time.Millisecond,
+			}, nil)
+			orch.SetAgentSelector(RoundRobinSelector{})
+			for i := 1; i <= 3; i++ {
+				orch.AddAgent(&MockAgent{
+					id:              fmt.Sprintf("agent-%d", i),
+					name:            fmt.Sprintf("Agent%d", i),
+					agentType:       "mock",
+					available:       true,
+					sendMessageResp: "ok",
+				})
+			}
+
+			if err := orch.FocusAgent(tt.focus, tt.turns); err != nil {
+				t.Fatalf("FocusAgent() error = %v", err)
+			}
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var speakers []string
+			for _, msg := range orch.GetMessages() {
+				if msg.Role == "agent" {
+					speakers = append(speakers, msg.AgentID)
+				}
+			}
+			if !reflect.DeepEqual(speakers, tt.want) {
+				t.Errorf("expected speakers %v, got %v", tt.want, speakers)
+			}
+		})
+	}
+}
+
+func TestFocusAgentUnknownAndClear(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeReactive}, nil)
+	orch.AddAgent(&MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true})
+
+	if err := orch.FocusAgent("nobody", 2); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("expected ErrAgentNotFound, got %v", err)
+	}
+
+	if err := orch.FocusAgent("agent-1", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orch.FocusAgent("agent-1", 0); err != nil {
+		t.Fatalf("unexpected error clearing focus: %v", err)
+	}
+	if a := orch.takeFocusTurn(); a != nil {
+		t.Errorf("expected focus to be cleared, got %s", a.GetID())
+	}
+}
+
+func TestGetRateLimiterStats(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, nil)
+	orch.AddAgent(&MockAgent{id: "limited", name: "Limited", agentType: "mock", available: true, rateLimit: 0.5, rateLimitBurst: 2})
+	orch.AddAgent(&MockAgent{id: "free", name: "Free", agentType: "mock", available: true})
+
+	stats := orch.GetRateLimiterStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected stats for 2 agents, got %d", len(stats))
+	}
+
+	limited := stats["limited"]
+	if limited.Disabled || limited.Rate != 0.5 || limited.Burst != 2 {
+		t.Errorf("unexpected stats for limited agent: %+v", limited)
+	}
+	if limited.Limited() {
+		t.Errorf("expected a fresh limiter to have tokens available, got %+v", limited)
+	}
+	if !stats["free"].Disabled {
+		t.Errorf("expected rate limiting to be disabled for free agent, got %+v", stats["free"])
+	}
+
+	// Use up the burst so the next request would wait
+	orch.rateLimiters["limited"].Allow()
+	orch.rateLimiters["limited"].Allow()
+	if !orch.GetRateLimiterStats()["limited"].Limited() {
+		t.Error("expected limited agent to be waiting after using its burst")
+	}
+}
+
+// prewarmAgent is a MockAgent that records Prewarm calls
+type prewarmAgent struct {
+	MockAgent
+	mu          sync.Mutex
+	prewarmed   int
+	prewarmErr  error
+	sentBefore  bool // SendMessage was called before Prewarm
+	sendStarted bool
+}
+
+func (p *prewarmAgent) Prewarm(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prewarmed++
+	p.sentBefore = p.sendStarted
+	return p.prewarmErr
+}
+
+func (p *prewarmAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	p.mu.Lock()
+	p.sendStarted = true
+	p.mu.Unlock()
+	return p.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestPrewarm(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		t.Run(fmt.Sprintf("enabled=%v", enabled), func(t *testing.T) {
+			orch := NewOrchestrator(OrchestratorConfig{
+				Mode:          ModeRoundRobin,
+				MaxTurns:      1,
+				TurnTimeout:   time.Second,
+				ResponseDelay: time.Millisecond,
+				Prewarm:       enabled,
+			}, nil)
+			ok := &prewarmAgent{MockAgent: MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "one"}}
+			failing := &prewarmAgent{
+				MockAgent:  MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "two"},
+				prewarmErr: errors.New("dial tcp: connection refused"),
+			}
+			orch.AddAgent(ok)
+			orch.AddAgent(failing)
+
+			if err := orch.Start(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want := 0
+			if enabled {
+				want = 1
+			}
+			for _, a := range []*prewarmAgent{ok, failing} {
+				if a.prewarmed != want {
+					t.Errorf("expected %s to be prewarmed %d times, got %d", a.id, want, a.prewarmed)
+				}
+				if a.sentBefore {
+					t.Errorf("expected %s to be prewarmed before its first turn", a.id)
+				}
+			}
+
+			// A failed prewarm doesn't keep the agent out of the conversation
+			if got := contents(orch.GetMessages()); !strings.Contains(strings.Join(got, "|"), "two") {
+				t.Errorf("expected both agents to respond, got %v", got)
+			}
+		})
+	}
+}
+
+// turnObservingEmitter is a MockBridgeEmitter that also implements bridge.TurnObserver,
+// recording turn boundaries and message events in order
+type turnObservingEmitter struct {
+	MockBridgeEmitter
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *turnObservingEmitter) record(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+func (e *turnObservingEmitter) AgentTurnStarted(agentID, agentName string) {
+	e.record("started:" + agentID)
+}
+
+func (e *turnObservingEmitter) AgentTurnEnded(agentID string) {
+	e.record("ended:" + agentID)
+}
+
+func (e *turnObservingEmitter) EmitMessageCreated(agentID, agentType, agentName, content, model string, turnNumber, tokensUsed, inputTokens, outputTokens int, cost float64, billingTag, team string, duration time.Duration) {
+	e.record("message:" + agentID)
+}
+
+func TestTurnObserverBracketsSlowAgentCall(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: 1 * time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	emitter := &turnObservingEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	orch.AddAgent(&MockAgent{
+		id:              "agent-1",
+		name:            "Agent1",
+		agentType:       "mock",
+		available:       true,
+		sendMessageResp: "slow answer",
+		sendDelay:       20 * time.Millisecond,
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The turn ends before its message event, so heartbeats never trail the response
+	want := []string{"started:agent-1", "ended:agent-1", "message:agent-1"}
+	if !reflect.DeepEqual(emitter.events, want) {
+		t.Errorf("expected events %v, got %v", want, emitter.events)
+	}
+}
+
+// finishReasonAgent is a MockAgent that reports a provider finish reason
+type finishReasonAgent struct {
+	MockAgent
+	reason string
+}
+
+func (f *finishReasonAgent) LastFinishReason() string {
+	return f.reason
+}
+
+func TestFinishReasonRecordedInMetrics(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	orch.AddAgent(&finishReasonAgent{
+		MockAgent: MockAgent{id: "agent-1", name: "Truncated", agentType: "mock", available: true, sendMessageResp: "cut off mid"},
+		reason:    "length",
+	})
+	orch.AddAgent(&MockAgent{id: "agent-2", name: "Plain", agentType: "mock", available: true, sendMessageResp: "done"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reasons := make(map[string]string)
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" && msg.Metrics != nil {
+			reasons[msg.AgentName] = msg.Metrics.FinishReason
+		}
+	}
+	want := map[string]string{"Truncated": "length", "Plain": ""}
+	if !reflect.DeepEqual(reasons, want) {
+		t.Errorf("expected finish reasons %v, got %v", want, reasons)
+	}
+}
+
+// reasoningAgent is a MockAgent whose model returns its reasoning apart from the answer
+type reasoningAgent struct {
+	MockAgent
+	reasoning string
+}
+
+func (r *reasoningAgent) LastReasoning() string {
+	return r.reasoning
+}
+
+func TestReasoningStoredApartFromContent(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      3,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}
+	orch := NewOrchestrator(cfg, io.Discard)
+	thinker := &reasoningAgent{
+		MockAgent: MockAgent{id: "agent-1", name: "Thinker", agentType: "mock", available: true, sendMessageResp: "Tabs."},
+		reasoning: "Tabs are configurable per reader.",
+	}
+	listener := &scriptedAgent{
+		MockAgent: MockAgent{id: "agent-2", name: "Listener", agentType: "mock", available: true},
+		responses: []string{"Spaces."},
+	}
+	orch.AddAgent(thinker)
+	orch.AddAgent(listener)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, msg := range orch.GetMessages() {
+		if msg.Role != "agent" {
+			continue
+		}
+		switch msg.AgentName {
+		case "Thinker":
+			if msg.Content != "Tabs." || msg.Reasoning != "Tabs are configurable per reader." {
+				t.Errorf("expected the answer as content and the reasoning apart, got %q / %q", msg.Content, msg.Reasoning)
+			}
+		case "Listener":
+			if msg.Reasoning != "" {
+				t.Errorf("expected no reasoning from a plain agent, got %q", msg.Reasoning)
+			}
+		}
+	}
+
+	// Other agents see the answer, not the reasoning
+	for _, request := range listener.requests {
+		for _, msg := range request {
+			if strings.Contains(msg.Content, "configurable per reader") {
+				t.Errorf("expected the reasoning to stay out of agent requests, got %q", msg.Content)
+			}
+		}
+	}
+}
+
+// resettingAgent is a MockAgent that records resets and the calls made since the last one
+type resettingAgent struct {
+	MockAgent
+	resets          int
+	callsSinceReset int
+}
+
+func (r *resettingAgent) Reset() {
+	r.resets++
+	r.callsSinceReset = 0
+}
+
+func (r *resettingAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	r.callsSinceReset++
+	return r.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestStartResetsReusedAgents(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}
+	reused := &resettingAgent{MockAgent: MockAgent{id: "agent-1", name: "Reused", agentType: "mock", available: true, sendMessageResp: "ok"}}
+
+	// The same agent instance takes part in two conversations in a row
+	for run := 1; run <= 2; run++ {
+		orch := NewOrchestrator(cfg, io.Discard)
+		orch.AddAgent(reused)
+		if err := orch.Start(context.Background()); err != nil {
+			t.Fatalf("run %d: unexpected error: %v", run, err)
+		}
+		if reused.resets != run {
+			t.Errorf("run %d: expected %d resets, got %d", run, run, reused.resets)
+		}
+		if reused.callsSinceReset != 2 {
+			t.Errorf("run %d: expected the reset before the conversation's 2 turns, got %d calls since", run, reused.callsSinceReset)
+		}
+	}
+}
+
+// memoryAgent records the responses the orchestrator passes to UpdateMemory
+type memoryAgent struct {
+	MockAgent
+	updates []string
+}
+
+func (m *memoryAgent) UpdateMemory(ctx context.Context, response string) error {
+	m.updates = append(m.updates, response)
+	return nil
+}
+
+func TestMemoryUpdaterReceivesResponses(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	remembering := &memoryAgent{MockAgent: MockAgent{id: "m", name: "Memo", agentType: "mock", available: true, sendMessageResp: "Noted."}}
+	plain := &MockAgent{id: "p", name: "Plain", agentType: "mock", available: true, sendMessageResp: "Fine."}
+	orch.AddAgent(remembering)
+	orch.AddAgent(plain)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(remembering.updates) != 2 || remembering.updates[0] != "Noted." {
+		t.Errorf("expected UpdateMemory after each of the agent's turns, got %q", remembering.updates)
+	}
+	if plain.callCount != 2 {
+		t.Errorf("expected agents without memory to take part as usual, got %d turns", plain.callCount)
+	}
+}
+
+func TestOutputsUseProcessedContent(t *testing.T) {
+	var output bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                  ModeRoundRobin,
+		MaxTurns:              1,
+		TurnTimeout:           time.Second,
+		ResponseDelay:         time.Millisecond,
+		SuppressAnnouncements: true,
+	}, &output)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	orch.AddMiddleware(middleware.StripPreambleMiddleware(nil))
+	orch.AddAgent(&MockAgent{id: "a", name: "Alice", agentType: "mock", available: true,
+		sendMessageResp: "As an AI language model, I think tests matter. Write them first."})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(output.String(), "As an AI language model") || !strings.Contains(output.String(), "] Write them first.") {
+		t.Errorf("expected the writer to show the processed response, got %q", output.String())
+	}
+	if len(emitter.contents) != 1 || emitter.contents[0] != "Write them first." {
+		t.Errorf("expected the bridge to get the processed response, got %q", emitter.contents)
+	}
+}
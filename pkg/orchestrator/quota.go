@@ -0,0 +1,54 @@
+package orchestrator
+
+import (
+	"fmt"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// quotaReached reports whether a has responded as many times as its AgentMaxTurns quota allows
+func (o *Orchestrator) quotaReached(a agent.Agent) bool {
+	limit := o.config.AgentMaxTurns[a.GetID()]
+	if limit <= 0 {
+		return false
+	}
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.agentResponses[a.GetID()] >= limit
+}
+
+// agentsWithTurnsLeft returns the agents that haven't reached their turn quota, in order
+func (o *Orchestrator) agentsWithTurnsLeft() []agent.Agent {
+	o.mu.RLock()
+	agents := append([]agent.Agent(nil), o.agents...)
+	o.mu.RUnlock()
+
+	available := make([]agent.Agent, 0, len(agents))
+	for _, a := range agents {
+		if !o.quotaReached(a) {
+			available = append(available, a)
+		}
+	}
+	return available
+}
+
+// quotasExhausted reports whether the conversation must end because agents have used
+// their turn quotas: either none has turns left, or the only one left is lastSpeaker,
+// who can't follow itself. It returns the message announcing the end when it does.
+func (o *Orchestrator) quotasExhausted(lastSpeaker string) (string, bool) {
+	if len(o.config.AgentMaxTurns) == 0 {
+		return "", false
+	}
+
+	available := o.agentsWithTurnsLeft()
+	if len(available) > 1 || (len(available) == 1 && available[0].GetID() != lastSpeaker) {
+		return "", false
+	}
+
+	log.WithField("agents_left", len(available)).Debug("agent turn quotas used")
+	if len(available) == 1 {
+		return fmt.Sprintf("Only %s has turns left. Conversation ended.", available[0].GetName()), true
+	}
+	return "All agents have used their turn quotas. Conversation ended.", true
+}
@@ -0,0 +1,129 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func quotaConfig(mode ConversationMode, maxTurns int, quotas map[string]int) OrchestratorConfig {
+	return OrchestratorConfig{
+		Mode:          mode,
+		MaxTurns:      maxTurns,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		AgentMaxTurns: quotas,
+	}
+}
+
+func TestAgentMaxTurnsRoundRobin(t *testing.T) {
+	orch := NewOrchestrator(quotaConfig(ModeRoundRobin, 4, map[string]int{"guest": 1}), nil)
+	host := &MockAgent{id: "host-agent", name: "Host", agentType: "mock", available: true, sendMessageResp: "Welcome."}
+	guest := &MockAgent{id: "guest", name: "Guest", agentType: "mock", available: true, sendMessageResp: "Thanks."}
+	orch.AddAgent(host)
+	orch.AddAgent(guest)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if guest.callCount != 1 {
+		t.Errorf("expected the guest to respond once, got %d", guest.callCount)
+	}
+	if host.callCount != 4 {
+		t.Errorf("expected the rotation to keep going without the guest, got %d host turns", host.callCount)
+	}
+}
+
+func TestAgentMaxTurnsReactive(t *testing.T) {
+	orch := NewOrchestrator(quotaConfig(ModeReactive, 8, map[string]int{"guest": 2}), nil)
+	orch.SetAgentSelector(RoundRobinSelector{})
+	alice := &MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "A"}
+	bob := &MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "B"}
+	guest := &MockAgent{id: "guest", name: "Guest", agentType: "mock", available: true, sendMessageResp: "G"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	orch.AddAgent(guest)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if guest.callCount != 2 {
+		t.Errorf("expected the guest to stop being selected after 2 responses, got %d", guest.callCount)
+	}
+	if total := alice.callCount + bob.callCount + guest.callCount; total != 8 {
+		t.Errorf("expected 8 responses in total, got %d", total)
+	}
+}
+
+func TestAgentMaxTurnsFreeForm(t *testing.T) {
+	orch := NewOrchestrator(quotaConfig(ModeFreeForm, 6, map[string]int{"guest": 1}), nil)
+	alice := &MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "A"}
+	bob := &MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "B"}
+	guest := &MockAgent{id: "guest", name: "Guest", agentType: "mock", available: true, sendMessageResp: "G"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	orch.AddAgent(guest)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if guest.callCount != 1 {
+		t.Errorf("expected the guest to respond once, got %d", guest.callCount)
+	}
+	// Free-form checks MaxTurns between passes, so the others each get at least two more
+	if alice.callCount < 3 || bob.callCount < 3 {
+		t.Errorf("expected the other agents to keep responding, got alice=%d bob=%d", alice.callCount, bob.callCount)
+	}
+}
+
+func TestAgentMaxTurnsAllExhausted(t *testing.T) {
+	var buf bytes.Buffer
+	// Without MaxTurns, the quotas alone must end the conversation
+	orch := NewOrchestrator(quotaConfig(ModeRoundRobin, 0, map[string]int{"a": 2, "b": 1}), &buf)
+	alice := &MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "A"}
+	bob := &MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "B"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if alice.callCount != 2 || bob.callCount != 1 {
+		t.Errorf("expected alice=2 bob=1 responses, got alice=%d bob=%d", alice.callCount, bob.callCount)
+	}
+	if !strings.Contains(buf.String(), "All agents have used their turn quotas") {
+		t.Errorf("expected an end notice, got:\n%s", buf.String())
+	}
+}
+
+func TestAgentMaxTurnsReactiveLastAgentLeft(t *testing.T) {
+	var buf bytes.Buffer
+	orch := NewOrchestrator(quotaConfig(ModeReactive, 0, map[string]int{"b": 1}), &buf)
+	orch.SetAgentSelector(RoundRobinSelector{})
+	alice := &MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "A"}
+	bob := &MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "B"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	// Alice can't follow herself once Bob is out of turns
+	if bob.callCount != 1 || alice.callCount > 2 {
+		t.Errorf("unexpected responses: alice=%d bob=%d", alice.callCount, bob.callCount)
+	}
+	if !strings.Contains(buf.String(), "Only Alice has turns left") {
+		t.Errorf("expected an end notice, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,229 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+const (
+	// RefereeScoreKey is the message metadata key holding the referee's score (float64, 0-10)
+	RefereeScoreKey = "referee_score"
+	// maxRefereeScore is the top of the referee's scoring scale
+	maxRefereeScore = 10
+	// refereeContextMessages is how many earlier messages the referee sees for context
+	refereeContextMessages = 4
+	// defaultRefereeTimeout bounds each scoring call when the config sets no timeout
+	defaultRefereeTimeout = 30 * time.Second
+)
+
+var (
+	// labeledScorePattern matches "Score: 7", "SCORE = 7.5", "score of **8**"
+	labeledScorePattern = regexp.MustCompile(`(?i)\bscore(?:\s+of)?[\s:=*#\-]*(\d+(?:\.\d+)?)`)
+	// outOfTenPattern matches "7/10", "7 / 10", "7 out of 10"
+	outOfTenPattern = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:/|out\s+of)\s*10\b`)
+	// numberPattern matches any plain number, the last resort
+	numberPattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+)
+
+// AgentScore is an agent's average referee score over its scored responses.
+type AgentScore struct {
+	AgentName string
+	// Average is the mean score, between 0 and 10
+	Average float64
+	// Count is the number of scored responses
+	Count int
+}
+
+// ParseRefereeScore extracts a 0-10 score from a referee's reply. It prefers a labeled
+// score ("Score: 7"), then a fraction of ten ("7/10", "7 out of 10"), then the first
+// number in the reply, so markdown emphasis and surrounding commentary are tolerated.
+// Scores outside 0-10 are rejected rather than clamped.
+func ParseRefereeScore(text string) (float64, error) {
+	var raw string
+	if m := labeledScorePattern.FindStringSubmatch(text); m != nil {
+		raw = m[1]
+	} else if m := outOfTenPattern.FindStringSubmatch(text); m != nil {
+		raw = m[1]
+	} else if m := numberPattern.FindString(text); m != "" {
+		raw = m
+	} else {
+		return 0, fmt.Errorf("no score found in referee reply")
+	}
+
+	score, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid referee score %q: %w", raw, err)
+	}
+	if score < 0 || score > maxRefereeScore {
+		return 0, fmt.Errorf("referee score %v is outside 0-%d", score, maxRefereeScore)
+	}
+	return score, nil
+}
+
+// AverageRefereeScores returns each agent's mean referee score, in order of first
+// appearance. Messages without a score in their metadata are ignored.
+func AverageRefereeScores(messages []agent.Message) []AgentScore {
+	var order []string
+	totals := make(map[string]*AgentScore)
+	for _, msg := range messages {
+		score, ok := msg.Metadata[RefereeScoreKey].(float64)
+		if !ok {
+			continue
+		}
+		s, seen := totals[msg.AgentID]
+		if !seen {
+			s = &AgentScore{AgentName: msg.AgentName}
+			totals[msg.AgentID] = s
+			order = append(order, msg.AgentID)
+		}
+		s.Average += score
+		s.Count++
+	}
+
+	scores := make([]AgentScore, 0, len(order))
+	for _, id := range order {
+		s := totals[id]
+		s.Average /= float64(s.Count)
+		scores = append(scores, *s)
+	}
+	return scores
+}
+
+// GetRefereeScores returns each agent's average referee score so far.
+// This method is thread-safe.
+func (o *Orchestrator) GetRefereeScores() []AgentScore {
+	return AverageRefereeScores(o.getMessages())
+}
+
+// SetRefereeAgent sets the agent used to score responses when Referee is enabled,
+// instead of resolving one from Referee.Agent.
+// This method is thread-safe.
+func (o *Orchestrator) SetRefereeAgent(a agent.Agent) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.referee = a
+}
+
+// refereeTimeout returns how long a single scoring call may take
+func (o *Orchestrator) refereeTimeout() time.Duration {
+	if o.config.Referee.Timeout > 0 {
+		return o.config.Referee.Timeout
+	}
+	return defaultRefereeTimeout
+}
+
+// prepareReferee resolves the referee agent at the start of the conversation, warning
+// up front and disabling scoring when it can't be created or isn't installed.
+func (o *Orchestrator) prepareReferee() {
+	if !o.config.Referee.Enabled {
+		return
+	}
+
+	o.mu.RLock()
+	referee := o.referee
+	o.mu.RUnlock()
+	if referee != nil {
+		return
+	}
+
+	referee, err := o.resolveRefereeAgent()
+	if err == nil && !referee.IsAvailable() {
+		err = fmt.Errorf("agent %s is not available", referee.GetName())
+	}
+	if err != nil {
+		log.WithField("agent_type", o.config.Referee.Agent).WithError(err).Warn("referee agent unavailable, responses will not be scored")
+		if o.writer != nil {
+			fmt.Fprintf(o.writer, "[Warning] Referee agent %q is unavailable (%v); responses will not be scored.\n",
+				o.config.Referee.Agent, err)
+		}
+		return
+	}
+	o.SetRefereeAgent(referee)
+}
+
+// resolveRefereeAgent returns the conversation agent to reuse as referee, or creates one.
+func (o *Orchestrator) resolveRefereeAgent() (agent.Agent, error) {
+	return o.resolveHelperAgent(o.config.Referee.Agent, "referee-agent", "Referee", o.config.Referee.ReuseAgent)
+}
+
+// scoreResponse asks the referee to score msg and records the score in its metadata.
+// Scoring failures are logged and leave the message unscored; warmup turns are skipped.
+func (o *Orchestrator) scoreResponse(ctx context.Context, msg *agent.Message) {
+	if !o.config.Referee.Enabled || msg.Metadata["warmup"] == true {
+		return
+	}
+
+	o.mu.RLock()
+	referee := o.referee
+	o.mu.RUnlock()
+	if referee == nil {
+		return
+	}
+
+	scoreCtx, cancel := context.WithTimeout(ctx, o.refereeTimeout())
+	defer cancel()
+
+	reply, err := referee.SendMessage(scoreCtx, []agent.Message{{
+		AgentID:   "system",
+		AgentName: "SYSTEM",
+		Content:   o.refereePrompt(*msg),
+		Timestamp: time.Now().Unix(),
+		Role:      "user",
+	}})
+	if err != nil {
+		log.WithField("agent_name", msg.AgentName).WithError(err).Warn("referee failed to score response")
+		return
+	}
+
+	score, err := ParseRefereeScore(reply)
+	if err != nil {
+		log.WithField("agent_name", msg.AgentName).WithError(err).Warn("failed to parse referee score")
+		return
+	}
+
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]interface{})
+	}
+	msg.Metadata[RefereeScoreKey] = score
+
+	log.WithFields(map[string]interface{}{
+		"agent_name": msg.AgentName,
+		"score":      score,
+	}).Debug("response scored by referee")
+}
+
+// refereePrompt builds the structured scoring request for msg, including the topic and
+// the last few messages so the referee can judge relevance.
+func (o *Orchestrator) refereePrompt(msg agent.Message) string {
+	var recent []agent.Message
+	messages := o.getMessages()
+	for i := len(messages) - 1; i >= 0 && len(recent) < refereeContextMessages; i-- {
+		if messages[i].Role == "agent" || messages[i].Role == "user" {
+			recent = append([]agent.Message{messages[i]}, recent...)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("You are a referee evaluating one response in a multi-agent conversation. ")
+	b.WriteString("Rate the response's quality and relevance to the topic and the preceding discussion ")
+	b.WriteString("on a scale from 0 (useless or off-topic) to 10 (excellent).\n\n")
+	b.WriteString("Reply with exactly one line in this format and nothing else:\nSCORE: <number from 0 to 10>\n\n")
+	if o.config.InitialPrompt != "" {
+		fmt.Fprintf(&b, "Topic:\n%s\n\n", o.config.InitialPrompt)
+	}
+	if len(recent) > 0 {
+		b.WriteString("Preceding messages:\n")
+		for _, m := range recent {
+			fmt.Fprintf(&b, "%s: %s\n\n", m.AgentName, m.Content)
+		}
+	}
+	fmt.Fprintf(&b, "Response to score (from %s):\n%s", msg.AgentName, msg.Content)
+	return b.String()
+}
@@ -0,0 +1,191 @@
+package orchestrator
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// scriptedReferee replies with each of replies in turn and records the prompts it was sent
+type scriptedReferee struct {
+	MockAgent
+	replies []string
+	prompts []string
+}
+
+func (r *scriptedReferee) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	r.prompts = append(r.prompts, messages[len(messages)-1].Content)
+	reply := r.replies[0]
+	if len(r.replies) > 1 {
+		r.replies = r.replies[1:]
+	}
+	return reply, nil
+}
+
+func TestParseRefereeScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    float64
+		wantErr bool
+	}{
+		{name: "labeled", text: "SCORE: 7", want: 7},
+		{name: "labeled lowercase with decimal", text: "score: 7.5", want: 7.5},
+		{name: "labeled markdown", text: "**Score:** **8**", want: 8},
+		{name: "labeled fraction", text: "Score: 6/10 - solid but repetitive", want: 6},
+		{name: "score of", text: "I would give this a score of 9.", want: 9},
+		{name: "fraction", text: "Overall 7/10.", want: 7},
+		{name: "out of ten", text: "This earns a 4 out of 10 because it ignores the question.", want: 4},
+		{name: "bare number", text: "  10\n", want: 10},
+		{name: "emphasized number", text: "**3**", want: 3},
+		{name: "zero", text: "SCORE: 0", want: 0},
+		{name: "out of range", text: "SCORE: 85", wantErr: true},
+		{name: "no number", text: "I cannot rate this response.", wantErr: true},
+		{name: "empty", text: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRefereeScore(tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRefereeScore(%q) = %v, want error", tt.text, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRefereeScore(%q) unexpected error: %v", tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRefereeScore(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAverageRefereeScores(t *testing.T) {
+	scored := func(id, name string, score float64) agent.Message {
+		return agent.Message{AgentID: id, AgentName: name, Role: "agent", Metadata: map[string]interface{}{RefereeScoreKey: score}}
+	}
+	messages := []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system"},
+		scored("b", "Bob", 6),
+		scored("a", "Alice", 8),
+		{AgentID: "a", AgentName: "Alice", Role: "agent"},
+		scored("b", "Bob", 9),
+		scored("a", "Alice", 7),
+	}
+
+	got := AverageRefereeScores(messages)
+	want := []AgentScore{
+		{AgentName: "Bob", Average: 7.5, Count: 2},
+		{AgentName: "Alice", Average: 7.5, Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d agents, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("score %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if scores := AverageRefereeScores(nil); len(scores) != 0 {
+		t.Errorf("expected no scores without messages, got %+v", scores)
+	}
+}
+
+func TestRefereeScoresResponses(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      2,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Discuss tabs versus spaces",
+		Referee:       config.RefereeConfig{Enabled: true, Agent: "judge"},
+	}, io.Discard)
+
+	alice := &MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Tabs."}
+	bob := &MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Spaces."}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+
+	referee := &scriptedReferee{
+		MockAgent: MockAgent{id: "judge", name: "Judge", agentType: "mock", available: true},
+		replies:   []string{"SCORE: 8", "Score: 4/10", "no idea", "**6**"},
+	}
+	orch.SetRefereeAgent(referee)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if len(referee.prompts) != 4 {
+		t.Fatalf("expected the referee to score 4 responses, got %d", len(referee.prompts))
+	}
+	first := referee.prompts[0]
+	if !strings.Contains(first, "SCORE:") || !strings.Contains(first, "Discuss tabs versus spaces") || !strings.Contains(first, "Tabs.") {
+		t.Errorf("unexpected referee prompt:\n%s", first)
+	}
+
+	// Alice's second response got an unparseable reply and stays unscored
+	got := orch.GetRefereeScores()
+	want := []AgentScore{
+		{AgentName: "Alice", Average: 8, Count: 1},
+		{AgentName: "Bob", Average: 5, Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d agents, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("score %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRefereeDisabledByDefault(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, io.Discard)
+	orch.AddAgent(&MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Hi."})
+
+	referee := &scriptedReferee{replies: []string{"SCORE: 8"}}
+	orch.SetRefereeAgent(referee)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+	if len(referee.prompts) != 0 {
+		t.Errorf("expected no scoring when the referee is disabled, got %d calls", len(referee.prompts))
+	}
+}
+
+func TestResolveRefereeAgentReusesConversationAgent(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Referee: config.RefereeConfig{Enabled: true, Agent: "Alice", ReuseAgent: true},
+	}, nil)
+	alice := &MockAgent{id: "alice", name: "Alice", agentType: "mock", available: true}
+	orch.AddAgent(alice)
+
+	referee, err := orch.resolveRefereeAgent()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if referee != alice {
+		t.Errorf("expected the conversation agent named Alice to be reused, got %s", referee.GetName())
+	}
+
+	orch.config.Referee.Agent = "no-such-agent"
+	if _, err := orch.resolveRefereeAgent(); err == nil {
+		t.Error("expected an unknown referee type to fail")
+	}
+}
@@ -0,0 +1,139 @@
+package orchestrator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// scriptedAgent returns its responses in order, repeating the last, and records each request
+type scriptedAgent struct {
+	MockAgent
+	responses []string
+	requests  [][]agent.Message
+}
+
+func (s *scriptedAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	s.requests = append(s.requests, messages)
+	i := len(s.requests) - 1
+	if i >= len(s.responses) {
+		i = len(s.responses) - 1
+	}
+	return s.responses[i], nil
+}
+
+var answerSchema = map[string]interface{}{
+	"type":     "object",
+	"required": []interface{}{"answer"},
+	"properties": map[string]interface{}{
+		"answer": map[string]interface{}{"type": "string"},
+	},
+}
+
+func schemaConfig(maxRetries int, reject bool) OrchestratorConfig {
+	return OrchestratorConfig{
+		Mode:                 ModeRoundRobin,
+		MaxTurns:             1,
+		TurnTimeout:          5 * time.Second,
+		ResponseDelay:        time.Millisecond,
+		MaxRetries:           maxRetries,
+		RetryInitialDelay:    time.Millisecond,
+		RetryMaxDelay:        time.Millisecond,
+		RetryMultiplier:      1,
+		ResponseSchemas:      map[string]map[string]interface{}{"s": answerSchema},
+		RejectSchemaMismatch: reject,
+	}
+}
+
+func newScriptedAgent(responses ...string) *scriptedAgent {
+	return &scriptedAgent{
+		MockAgent: MockAgent{id: "s", name: "Structured", agentType: "mock", available: true},
+		responses: responses,
+	}
+}
+
+func lastAgentMessage(orch *Orchestrator) *agent.Message {
+	messages := orch.GetMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "agent" {
+			return &messages[i]
+		}
+	}
+	return nil
+}
+
+func TestSchemaMismatchRetriedWithReminder(t *testing.T) {
+	orch := NewOrchestrator(schemaConfig(2, false), nil)
+	a := newScriptedAgent("The answer is 42.", `{"answer": "42"}`)
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if len(a.requests) != 2 {
+		t.Fatalf("expected one retry after the off-schema response, got %d requests", len(a.requests))
+	}
+	for _, msg := range a.requests[0] {
+		if strings.Contains(msg.Content, "must match this JSON schema") {
+			t.Error("expected no schema reminder on the first attempt")
+		}
+	}
+	retry := a.requests[1]
+	reminder := retry[len(retry)-1]
+	if reminder.Role != "system" || !strings.Contains(reminder.Content, "must match this JSON schema") {
+		t.Errorf("expected the retry to end with a schema reminder, got %+v", reminder)
+	}
+	if len(retry) != len(a.requests[0])+1 {
+		t.Errorf("expected exactly one reminder added to the retry, got %d messages vs %d", len(retry), len(a.requests[0]))
+	}
+
+	msg := lastAgentMessage(orch)
+	if msg == nil || msg.Content != `{"answer": "42"}` {
+		t.Fatalf("expected the conforming response to be recorded, got %+v", msg)
+	}
+	if msg.Metadata != nil {
+		t.Errorf("expected no schema flag on a conforming response, got %v", msg.Metadata)
+	}
+	for _, m := range orch.GetMessages() {
+		if strings.Contains(m.Content, "must match this JSON schema") {
+			t.Error("expected the schema reminder to stay out of the history")
+		}
+	}
+}
+
+func TestSchemaMismatchFlaggedAfterRetries(t *testing.T) {
+	orch := NewOrchestrator(schemaConfig(1, false), nil)
+	a := newScriptedAgent("still prose")
+	orch.AddAgent(a)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if len(a.requests) != 2 {
+		t.Errorf("expected 2 attempts, got %d", len(a.requests))
+	}
+	msg := lastAgentMessage(orch)
+	if msg == nil || msg.Content != "still prose" {
+		t.Fatalf("expected the off-schema response to be kept, got %+v", msg)
+	}
+	if _, ok := msg.Metadata["schema_error"]; !ok {
+		t.Errorf("expected the response to be flagged, got %v", msg.Metadata)
+	}
+}
+
+func TestSchemaMismatchRejected(t *testing.T) {
+	orch := NewOrchestrator(schemaConfig(1, true), nil)
+	a := newScriptedAgent("still prose")
+	orch.AddAgent(a)
+
+	_ = orch.Start(context.Background())
+
+	if msg := lastAgentMessage(orch); msg != nil {
+		t.Errorf("expected the off-schema response to be rejected, got %+v", msg)
+	}
+}
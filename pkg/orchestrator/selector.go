@@ -0,0 +1,239 @@
+package orchestrator
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// AgentSelector picks the agent that speaks next in reactive mode.
+// history is the conversation so far and lastSpeaker is the ID of the agent that
+// spoke last ("" before any agent has spoken). Returning nil skips the turn.
+type AgentSelector interface {
+	Select(agents []agent.Agent, history []agent.Message, lastSpeaker string) agent.Agent
+}
+
+// SelectionStrategy names a built-in AgentSelector.
+type SelectionStrategy string
+
+const (
+	// SelectRandom picks a random agent other than the last speaker (the default)
+	SelectRandom SelectionStrategy = "random"
+	// SelectRoundRobin picks the agent after the last speaker in registration order
+	SelectRoundRobin SelectionStrategy = "round-robin"
+	// SelectWeighted picks a random agent other than the last speaker, biased by per-agent weights
+	SelectWeighted SelectionStrategy = "weighted"
+	// SelectLeastRecent picks the agent that has gone longest without speaking
+	SelectLeastRecent SelectionStrategy = "least-recent"
+	// SelectMention picks the agent named in the last message, falling back to random
+	SelectMention SelectionStrategy = "mention"
+)
+
+// NewAgentSelector returns the built-in selector for strategy.
+// weights maps agent IDs to relative weights and is only used by SelectWeighted.
+// An empty strategy selects SelectRandom.
+func NewAgentSelector(strategy SelectionStrategy, weights map[string]float64) (AgentSelector, error) {
+	switch strategy {
+	case "", SelectRandom:
+		return RandomSelector{}, nil
+	case SelectRoundRobin:
+		return RoundRobinSelector{}, nil
+	case SelectWeighted:
+		return WeightedSelector{Weights: weights}, nil
+	case SelectLeastRecent:
+		return LeastRecentSelector{}, nil
+	case SelectMention:
+		return MentionSelector{}, nil
+	default:
+		return nil, fmt.Errorf("unknown selection strategy %q", strategy)
+	}
+}
+
+// candidates returns the agents other than the last speaker
+func candidates(agents []agent.Agent, lastSpeaker string) []agent.Agent {
+	result := make([]agent.Agent, 0, len(agents))
+	for _, a := range agents {
+		if a.GetID() != lastSpeaker {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// RandomSelector picks uniformly among the agents other than the last speaker.
+type RandomSelector struct{}
+
+// Select implements AgentSelector.
+func (RandomSelector) Select(agents []agent.Agent, _ []agent.Message, lastSpeaker string) agent.Agent {
+	available := candidates(agents, lastSpeaker)
+	if len(available) == 0 {
+		return nil
+	}
+	return available[rand.Intn(len(available))]
+}
+
+// RoundRobinSelector picks the agent registered after the last speaker, wrapping around.
+type RoundRobinSelector struct{}
+
+// Select implements AgentSelector.
+func (RoundRobinSelector) Select(agents []agent.Agent, _ []agent.Message, lastSpeaker string) agent.Agent {
+	if len(agents) == 0 {
+		return nil
+	}
+	for i, a := range agents {
+		if a.GetID() == lastSpeaker {
+			next := agents[(i+1)%len(agents)]
+			if next.GetID() == lastSpeaker {
+				return nil
+			}
+			return next
+		}
+	}
+	return agents[0]
+}
+
+// WeightedSelector picks randomly among the agents other than the last speaker,
+// in proportion to Weights (keyed by agent ID). Agents without a weight count as 1;
+// agents with a weight of zero or less are never picked.
+type WeightedSelector struct {
+	Weights map[string]float64
+}
+
+// Select implements AgentSelector.
+func (s WeightedSelector) Select(agents []agent.Agent, _ []agent.Message, lastSpeaker string) agent.Agent {
+	available := candidates(agents, lastSpeaker)
+
+	total := 0.0
+	for _, a := range available {
+		total += s.weight(a)
+	}
+	if total <= 0 {
+		return nil
+	}
+
+	target := rand.Float64() * total
+	for _, a := range available {
+		w := s.weight(a)
+		if w <= 0 {
+			continue
+		}
+		if target < w {
+			return a
+		}
+		target -= w
+	}
+
+	// Floating point rounding can leave target just past the last weight
+	for i := len(available) - 1; i >= 0; i-- {
+		if s.weight(available[i]) > 0 {
+			return available[i]
+		}
+	}
+	return nil
+}
+
+func (s WeightedSelector) weight(a agent.Agent) float64 {
+	if w, ok := s.Weights[a.GetID()]; ok {
+		return w
+	}
+	return 1
+}
+
+// LeastRecentSelector picks the agent that has gone longest without speaking.
+// Agents that have never spoken come first, in registration order.
+type LeastRecentSelector struct{}
+
+// Select implements AgentSelector.
+func (LeastRecentSelector) Select(agents []agent.Agent, history []agent.Message, lastSpeaker string) agent.Agent {
+	lastSpoke := make(map[string]int)
+	for i, msg := range history {
+		if msg.Role == "agent" {
+			lastSpoke[msg.AgentID] = i
+		}
+	}
+
+	var selected agent.Agent
+	selectedAt := 0
+	for _, a := range candidates(agents, lastSpeaker) {
+		at, spoke := lastSpoke[a.GetID()]
+		if !spoke {
+			return a
+		}
+		if selected == nil || at < selectedAt {
+			selected, selectedAt = a, at
+		}
+	}
+	return selected
+}
+
+// MentionSelector picks the agent whose name appears in the last message, choosing
+// the earliest mention when several agents are named. The author of the message is
+// never picked. Without a mention it defers to Fallback (RandomSelector when nil).
+// Unlike OrchestratorConfig.MentionRouting, any whole-word use of a name counts.
+type MentionSelector struct {
+	Fallback AgentSelector
+}
+
+// Select implements AgentSelector.
+func (s MentionSelector) Select(agents []agent.Agent, history []agent.Message, lastSpeaker string) agent.Agent {
+	if len(history) > 0 {
+		last := history[len(history)-1]
+		if a := mentionedAgent(candidates(agents, last.AgentID), last.Content); a != nil && a.GetID() != lastSpeaker {
+			return a
+		}
+	}
+
+	fallback := s.Fallback
+	if fallback == nil {
+		fallback = RandomSelector{}
+	}
+	return fallback.Select(agents, history, lastSpeaker)
+}
+
+// mentionedAgent returns the agent whose name appears earliest in content as a whole word
+func mentionedAgent(agents []agent.Agent, content string) agent.Agent {
+	lower := strings.ToLower(content)
+
+	var selected agent.Agent
+	selectedAt := -1
+	for _, a := range agents {
+		at := indexWord(lower, strings.ToLower(a.GetName()))
+		if at >= 0 && (selectedAt < 0 || at < selectedAt) {
+			selected, selectedAt = a, at
+		}
+	}
+	return selected
+}
+
+// indexWord returns the index of the first occurrence of word in s that is not part
+// of a longer word, or -1
+func indexWord(s, word string) int {
+	if word == "" {
+		return -1
+	}
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], word)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(word)
+		if !isWordByteAt(s, start-1) && !isWordByteAt(s, end) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+// isWordByteAt reports whether s[i] belongs to a word: an ASCII letter, digit or
+// underscore, or part of a multi-byte character. Out of range is false.
+func isWordByteAt(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	b := s[i]
+	return b == '_' || b >= 0x80 ||
+		('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
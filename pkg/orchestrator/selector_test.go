@@ -0,0 +1,179 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func selectorTestAgents() []agent.Agent {
+	return []agent.Agent{
+		&MockAgent{id: "agent-1", name: "Alice"},
+		&MockAgent{id: "agent-2", name: "Bob"},
+		&MockAgent{id: "agent-3", name: "Carol"},
+	}
+}
+
+func selectedID(a agent.Agent) string {
+	if a == nil {
+		return ""
+	}
+	return a.GetID()
+}
+
+func TestMentionSelector(t *testing.T) {
+	agents := selectorTestAgents()
+	said := func(id, name, content string) []agent.Message {
+		return []agent.Message{{AgentID: id, AgentName: name, Role: "agent", Content: content}}
+	}
+
+	tests := []struct {
+		name        string
+		history     []agent.Message
+		lastSpeaker string
+		want        string
+	}{
+		{"single mention", said("agent-1", "Alice", "What do you think, Carol?"), "agent-1", "agent-3"},
+		{"earliest mention wins", said("agent-1", "Alice", "Bob, then carol, should answer"), "agent-1", "agent-2"},
+		{"author mentioning self is ignored", said("agent-2", "Bob", "I'm Bob. Carol?"), "agent-2", "agent-3"},
+		{"partial words do not count", said("agent-1", "Alice", "Bobby and Caroline agree"), "agent-1", "fallback"},
+		{"user message can address anyone", said("user", "User", "@Alice please summarize"), "agent-2", "agent-1"},
+		{"no history", nil, "", "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fallback := &recordingSelector{}
+			got := MentionSelector{Fallback: fallback}.Select(agents, tt.history, tt.lastSpeaker)
+			if tt.want == "fallback" {
+				if !fallback.called {
+					t.Errorf("expected fallback selector, got %q", selectedID(got))
+				}
+				return
+			}
+			if selectedID(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, selectedID(got))
+			}
+		})
+	}
+}
+
+func TestLeastRecentSelector(t *testing.T) {
+	agents := selectorTestAgents()
+	spoke := func(ids ...string) []agent.Message {
+		var msgs []agent.Message
+		for _, id := range ids {
+			msgs = append(msgs, agent.Message{AgentID: id, Role: "agent"})
+		}
+		return msgs
+	}
+
+	tests := []struct {
+		name        string
+		history     []agent.Message
+		lastSpeaker string
+		want        string
+	}{
+		{"nobody has spoken", nil, "", "agent-1"},
+		{"silent agent first", spoke("agent-1", "agent-2"), "agent-2", "agent-3"},
+		{"longest silence", spoke("agent-3", "agent-1", "agent-2"), "agent-2", "agent-3"},
+		{"repeat speaker ignored", spoke("agent-1", "agent-2", "agent-3", "agent-2", "agent-1"), "agent-1", "agent-3"},
+		{"last speaker excluded", spoke("agent-2", "agent-3"), "agent-1", "agent-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LeastRecentSelector{}.Select(agents, tt.history, tt.lastSpeaker)
+			if selectedID(got) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, selectedID(got))
+			}
+		})
+	}
+
+	if got := (LeastRecentSelector{}).Select(agents[:1], nil, "agent-1"); got != nil {
+		t.Errorf("expected nil when only the last speaker is available, got %q", got.GetID())
+	}
+}
+
+func TestRoundRobinAndWeightedSelectors(t *testing.T) {
+	agents := selectorTestAgents()
+
+	for last, want := range map[string]string{"": "agent-1", "agent-1": "agent-2", "agent-3": "agent-1"} {
+		if got := (RoundRobinSelector{}).Select(agents, nil, last); selectedID(got) != want {
+			t.Errorf("round-robin after %q: expected %q, got %q", last, want, selectedID(got))
+		}
+	}
+
+	weighted := WeightedSelector{Weights: map[string]float64{"agent-2": 0, "agent-3": 0}}
+	for i := 0; i < 20; i++ {
+		if got := weighted.Select(agents, nil, ""); selectedID(got) != "agent-1" {
+			t.Fatalf("expected only agent-1 to have weight, got %q", selectedID(got))
+		}
+	}
+	if got := weighted.Select(agents, nil, "agent-1"); got != nil {
+		t.Errorf("expected nil when every candidate has zero weight, got %q", got.GetID())
+	}
+}
+
+func TestNewAgentSelector(t *testing.T) {
+	for _, strategy := range []SelectionStrategy{"", SelectRandom, SelectRoundRobin, SelectWeighted, SelectLeastRecent, SelectMention} {
+		if _, err := NewAgentSelector(strategy, nil); err != nil {
+			t.Errorf("strategy %q: unexpected error: %v", strategy, err)
+		}
+	}
+	if _, err := NewAgentSelector("loudest", nil); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
+
+func TestReactiveModeUsesAgentSelector(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:              ModeReactive,
+		MaxTurns:          4,
+		TurnTimeout:       time.Second,
+		ResponseDelay:     time.Millisecond,
+		SelectionStrategy: SelectRoundRobin,
+	}
+	var buf bytes.Buffer
+	orch := NewOrchestrator(cfg, &buf)
+	for _, a := range selectorTestAgents() {
+		a.(*MockAgent).available = true
+		a.(*MockAgent).sendMessageResp = "ok"
+		orch.AddAgent(a)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := orch.Start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var order []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			order = append(order, msg.AgentID)
+		}
+	}
+	want := []string{"agent-1", "agent-2", "agent-3", "agent-1"}
+	if len(order) != len(want) {
+		t.Fatalf("expected %d agent messages, got %v", len(want), order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected round-robin order %v, got %v", want, order)
+		}
+	}
+}
+
+// recordingSelector records that it was asked and returns nil
+type recordingSelector struct {
+	called bool
+}
+
+func (s *recordingSelector) Select([]agent.Agent, []agent.Message, string) agent.Agent {
+	s.called = true
+	return nil
+}
@@ -0,0 +1,144 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+const (
+	// collapseWindow is how many of each agent's most recent responses are compared
+	collapseWindow = 3
+	// minCollapseRounds is how many rounds two agents must match before it counts as collapse
+	minCollapseRounds = 2
+	// defaultCollapseThreshold is the mean similarity at which two agents are reported
+	defaultCollapseThreshold = 0.8
+)
+
+// SimilarPair reports two agents whose recent responses are near-identical.
+type SimilarPair struct {
+	// AgentA and AgentB are the agent names, in order of first appearance
+	AgentA string
+	AgentB string
+	// Similarity is the mean ResponseSimilarity over the compared rounds
+	Similarity float64
+	// Rounds is the number of response pairs compared
+	Rounds int
+}
+
+// ResponseSimilarity returns the Jaccard similarity (0-1) of the word sets of two
+// responses, ignoring case and punctuation. Two empty responses are identical.
+func ResponseSimilarity(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 && len(wordsB) == 0 {
+		return 1
+	}
+
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+// wordSet returns the distinct lowercase words in text
+func wordSet(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+// DetectModeCollapse compares every pair of agents over their last window responses,
+// pairing each agent's most recent response with the other's most recent, and so on.
+// Pairs compared over at least two rounds whose mean similarity reaches threshold are
+// returned, in order of first appearance. Non-agent messages are ignored.
+func DetectModeCollapse(messages []agent.Message, window int, threshold float64) []SimilarPair {
+	if window <= 0 {
+		window = collapseWindow
+	}
+
+	var order []string
+	names := make(map[string]string)
+	responses := make(map[string][]string)
+	for _, msg := range messages {
+		if msg.Role != "agent" {
+			continue
+		}
+		if _, ok := names[msg.AgentID]; !ok {
+			order = append(order, msg.AgentID)
+			names[msg.AgentID] = msg.AgentName
+		}
+		responses[msg.AgentID] = append(responses[msg.AgentID], msg.Content)
+	}
+
+	var pairs []SimilarPair
+	for i := 0; i < len(order); i++ {
+		for j := i + 1; j < len(order); j++ {
+			a, b := responses[order[i]], responses[order[j]]
+			rounds := min(len(a), len(b), window)
+			if rounds < minCollapseRounds {
+				continue
+			}
+
+			total := 0.0
+			for k := 1; k <= rounds; k++ {
+				total += ResponseSimilarity(a[len(a)-k], b[len(b)-k])
+			}
+			if mean := total / float64(rounds); mean >= threshold {
+				pairs = append(pairs, SimilarPair{
+					AgentA:     names[order[i]],
+					AgentB:     names[order[j]],
+					Similarity: mean,
+					Rounds:     rounds,
+				})
+			}
+		}
+	}
+	return pairs
+}
+
+// GetModeCollapse reports agent pairs whose recent responses are near-identical,
+// using CollapseThreshold. This method is thread-safe.
+func (o *Orchestrator) GetModeCollapse() []SimilarPair {
+	return DetectModeCollapse(o.getMessages(), collapseWindow, o.config.CollapseThreshold)
+}
+
+// checkModeCollapse warns once per agent pair, mid-run, when CollapseCheckEnabled is set
+// and the pair's recent responses have become near-identical.
+func (o *Orchestrator) checkModeCollapse() {
+	if !o.config.CollapseCheckEnabled {
+		return
+	}
+
+	for _, pair := range o.GetModeCollapse() {
+		key := pair.AgentA + "\x00" + pair.AgentB
+		o.mu.Lock()
+		warned := o.collapseWarned[key]
+		o.collapseWarned[key] = true
+		o.mu.Unlock()
+		if warned {
+			continue
+		}
+
+		log.WithFields(map[string]interface{}{
+			"agent_a":    pair.AgentA,
+			"agent_b":    pair.AgentB,
+			"similarity": pair.Similarity,
+			"rounds":     pair.Rounds,
+		}).Warn("agents are producing near-identical responses")
+		if o.writer != nil {
+			fmt.Fprintf(o.writer, "\n[Info] %s and %s are giving near-identical responses (%.0f%% similar over %d rounds)\n",
+				pair.AgentA, pair.AgentB, pair.Similarity*100, pair.Rounds)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestResponseSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{name: "identical", a: "Caching cuts latency.", b: "caching CUTS latency", want: 1},
+		{name: "disjoint", a: "use a queue", b: "prefer polling", want: 0},
+		{name: "partial overlap", a: "red green blue", b: "red green yellow", want: 0.5},
+		{name: "repeated words count once", a: "yes yes yes no", b: "yes no", want: 1},
+		{name: "both empty", a: "", b: "  ", want: 1},
+		{name: "one empty", a: "hello", b: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResponseSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ResponseSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func collapseMessages(rounds [][3]string) []agent.Message {
+	var messages []agent.Message
+	messages = append(messages, agent.Message{AgentID: "host", AgentName: "HOST", Role: "system", Content: "Discuss caching"})
+	for _, round := range rounds {
+		for i, content := range round {
+			id := []string{"a", "b", "c"}[i]
+			messages = append(messages, agent.Message{AgentID: id, AgentName: strings.ToUpper(id), Role: "agent", Content: content})
+		}
+	}
+	return messages
+}
+
+func TestDetectModeCollapse(t *testing.T) {
+	same := "I agree caching is the right call here"
+	messages := collapseMessages([][3]string{
+		{"Early divergent opening from A", "B opens quite differently", "C starts elsewhere"},
+		{same, same, "C argues for a message queue instead"},
+		{same + " too", same + " too", "C still prefers queues over caches"},
+		{same, same, "C suggests benchmarking both options"},
+	})
+
+	pairs := DetectModeCollapse(messages, 3, 0.8)
+	if len(pairs) != 1 {
+		t.Fatalf("expected exactly one collapsed pair, got %+v", pairs)
+	}
+	if pairs[0].AgentA != "A" || pairs[0].AgentB != "B" || pairs[0].Rounds != 3 || pairs[0].Similarity != 1 {
+		t.Errorf("unexpected pair %+v", pairs[0])
+	}
+
+	// The divergent first round falls inside a wider window and pulls the mean down
+	if pairs := DetectModeCollapse(messages, 4, 0.8); len(pairs) != 0 {
+		t.Errorf("expected no collapse over a 4-round window, got %+v", pairs)
+	}
+	// A single matching round is not consistent enough to count
+	if pairs := DetectModeCollapse(collapseMessages([][3]string{{same, same, "other"}}), 3, 0.8); len(pairs) != 0 {
+		t.Errorf("expected one round not to count as collapse, got %+v", pairs)
+	}
+}
+
+func TestCollapseCheckWarnsOncePerPair(t *testing.T) {
+	var out bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                 ModeRoundRobin,
+		MaxTurns:             4,
+		TurnTimeout:          time.Second,
+		ResponseDelay:        time.Millisecond,
+		CollapseCheckEnabled: true,
+	}, &out)
+	orch.AddAgent(&MockAgent{id: "a", name: "Echo1", agentType: "mock", available: true, sendMessageResp: "the same answer"})
+	orch.AddAgent(&MockAgent{id: "b", name: "Echo2", agentType: "mock", available: true, sendMessageResp: "The same answer!"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if n := strings.Count(out.String(), "Echo1 and Echo2 are giving near-identical responses"); n != 1 {
+		t.Errorf("expected one mid-run warning, got %d in:\n%s", n, out.String())
+	}
+	if pairs := orch.GetModeCollapse(); len(pairs) != 1 || pairs[0].Rounds != 3 {
+		t.Errorf("expected Echo1/Echo2 to be reported over 3 rounds, got %+v", pairs)
+	}
+}
@@ -0,0 +1,109 @@
+package orchestrator
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// LatencyStats summarizes response latency across agent messages.
+type LatencyStats struct {
+	// Count is the number of agent responses included in the stats
+	Count int
+	// P50 is the median response latency
+	P50 time.Duration
+	// P90 is the 90th percentile response latency
+	P90 time.Duration
+	// P99 is the 99th percentile response latency
+	P99 time.Duration
+	// Max is the slowest response latency
+	Max time.Duration
+}
+
+// ComputeLatencyStats calculates latency percentiles using the nearest-rank method.
+// The input slice is not modified. Returns zero stats for an empty slice.
+func ComputeLatencyStats(durations []time.Duration) LatencyStats {
+	if len(durations) == 0 {
+		return LatencyStats{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return LatencyStats{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of an ascending, non-empty slice (nearest-rank).
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// GetLatencyStats computes latency percentiles over all agent messages that have metrics.
+// This method is thread-safe.
+func (o *Orchestrator) GetLatencyStats() LatencyStats {
+	messages := o.getMessages()
+
+	durations := make([]time.Duration, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Role == "agent" && msg.Metrics != nil {
+			durations = append(durations, msg.Metrics.Duration)
+		}
+	}
+
+	return ComputeLatencyStats(durations)
+}
+
+// ConversationStats summarizes the volume and throughput of a conversation.
+type ConversationStats struct {
+	// AgentMessages is the number of agent responses in the conversation
+	AgentMessages int
+	// Duration is how long the conversation ran, or has been running so far
+	Duration time.Duration
+	// MessagesPerMinute is the agent response rate over Duration
+	MessagesPerMinute float64
+}
+
+// MessagesPerMinute returns the message rate over duration, or 0 when the duration
+// is zero or negative.
+func MessagesPerMinute(messages int, duration time.Duration) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return float64(messages) / duration.Minutes()
+}
+
+// GetStats returns the conversation's agent message count, duration and throughput.
+// The duration runs from Start until the last turn ends, or until now while the
+// conversation is running; it is zero before Start. This method is thread-safe.
+func (o *Orchestrator) GetStats() ConversationStats {
+	o.mu.RLock()
+	start, end := o.conversationStart, o.conversationEnd
+	o.mu.RUnlock()
+
+	stats := ConversationStats{}
+	for _, msg := range o.getMessages() {
+		if msg.Role == "agent" {
+			stats.AgentMessages++
+		}
+	}
+
+	if !start.IsZero() {
+		if end.IsZero() {
+			end = time.Now()
+		}
+		stats.Duration = end.Sub(start)
+	}
+	stats.MessagesPerMinute = MessagesPerMinute(stats.AgentMessages, stats.Duration)
+	return stats
+}
@@ -0,0 +1,152 @@
+package orchestrator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestComputeLatencyStats(t *testing.T) {
+	ms := func(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+
+	hundred := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		hundred = append(hundred, ms(i))
+	}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		want      LatencyStats
+	}{
+		{
+			name:      "empty",
+			durations: nil,
+			want:      LatencyStats{},
+		},
+		{
+			name:      "single value",
+			durations: []time.Duration{ms(250)},
+			want:      LatencyStats{Count: 1, P50: ms(250), P90: ms(250), P99: ms(250), Max: ms(250)},
+		},
+		{
+			name:      "unsorted small set",
+			durations: []time.Duration{ms(400), ms(100), ms(300), ms(200)},
+			want:      LatencyStats{Count: 4, P50: ms(200), P90: ms(400), P99: ms(400), Max: ms(400)},
+		},
+		{
+			name:      "one to one hundred",
+			durations: hundred,
+			want:      LatencyStats{Count: 100, P50: ms(50), P90: ms(90), P99: ms(99), Max: ms(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeLatencyStats(tt.durations)
+			if got != tt.want {
+				t.Errorf("ComputeLatencyStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	// Input must not be reordered
+	if hundred[0] != ms(100) {
+		t.Error("ComputeLatencyStats modified its input")
+	}
+}
+
+func TestGetLatencyStats(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, nil)
+	orch.messages = []agent.Message{
+		{Role: "system", Content: "joined"},
+		{Role: "agent", Metrics: &agent.ResponseMetrics{Duration: 100 * time.Millisecond}},
+		{Role: "agent", Metrics: &agent.ResponseMetrics{Duration: 300 * time.Millisecond}},
+		{Role: "agent"}, // no metrics
+	}
+
+	stats := orch.GetLatencyStats()
+	if stats.Count != 2 {
+		t.Errorf("expected 2 samples, got %d", stats.Count)
+	}
+	if stats.Max != 300*time.Millisecond {
+		t.Errorf("expected max 300ms, got %v", stats.Max)
+	}
+}
+
+func TestMessagesPerMinute(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages int
+		duration time.Duration
+		want     float64
+	}{
+		{"one minute", 12, time.Minute, 12},
+		{"half a minute", 6, 30 * time.Second, 12},
+		{"two minutes", 3, 2 * time.Minute, 1.5},
+		{"no messages", 0, time.Minute, 0},
+		{"zero duration", 5, 0, 0},
+		{"negative duration", 5, -time.Second, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MessagesPerMinute(tt.messages, tt.duration); got != tt.want {
+				t.Errorf("MessagesPerMinute(%d, %v) = %v, want %v", tt.messages, tt.duration, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetStats(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin}, nil)
+	orch.messages = []agent.Message{
+		{Role: "system", Content: "joined"},
+		{Role: "agent"},
+		{Role: "tool"},
+		{Role: "agent"},
+		{Role: "user"},
+		{Role: "agent"},
+	}
+
+	// Not started yet: no duration, no rate
+	stats := orch.GetStats()
+	if stats.AgentMessages != 3 || stats.Duration != 0 || stats.MessagesPerMinute != 0 {
+		t.Errorf("expected 3 messages and no rate before start, got %+v", stats)
+	}
+
+	start := time.Now()
+	orch.conversationStart = start
+	orch.conversationEnd = start.Add(90 * time.Second)
+	stats = orch.GetStats()
+	if stats.Duration != 90*time.Second {
+		t.Errorf("expected a 90s duration, got %v", stats.Duration)
+	}
+	if stats.MessagesPerMinute != 2 {
+		t.Errorf("expected 2 messages/min, got %v", stats.MessagesPerMinute)
+	}
+}
+
+func TestGetStatsAfterStart(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{Mode: ModeRoundRobin, MaxTurns: 2, ResponseDelay: time.Millisecond}, nil)
+	orch.AddAgent(&MockAgent{id: "a", name: "A", agentType: "mock", available: true, sendMessageResp: "hi"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stats := orch.GetStats()
+	if stats.AgentMessages != 2 {
+		t.Errorf("expected 2 agent messages, got %d", stats.AgentMessages)
+	}
+	if stats.Duration <= 0 || stats.MessagesPerMinute <= 0 {
+		t.Errorf("expected a positive duration and rate, got %+v", stats)
+	}
+
+	// The duration is frozen once the conversation has ended
+	time.Sleep(5 * time.Millisecond)
+	if later := orch.GetStats(); later.Duration != stats.Duration {
+		t.Errorf("expected the duration to stay at %v after the conversation ended, got %v", stats.Duration, later.Duration)
+	}
+}
@@ -0,0 +1,56 @@
+package orchestrator
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// requestResponse asks a for its reply to messages. With StreamResponses enabled, agents
+// that support streaming write their reply to the stream writer as it arrives and the
+// streamed text becomes the response; the rest fall back to SendMessage, and their reply
+// is written to the stream writer in one piece. A retried turn streams again from the start.
+func (o *Orchestrator) requestResponse(ctx context.Context, a agent.Agent, messages []agent.Message) (string, error) {
+	if !o.config.StreamResponses {
+		return a.SendMessage(ctx, messages)
+	}
+
+	o.mu.RLock()
+	streamWriter := o.streamWriter
+	o.mu.RUnlock()
+
+	if s, ok := a.(agent.Streamer); !ok || !s.SupportsStreaming() {
+		log.WithField("agent_name", a.GetName()).Debug("agent does not support streaming, using buffered response")
+		response, err := a.SendMessage(ctx, messages)
+		if err == nil && streamWriter != nil {
+			io.WriteString(observerWriter{streamWriter}, response)
+		}
+		return response, err
+	}
+
+	var buf strings.Builder
+	var w io.Writer = &buf
+	if streamWriter != nil {
+		w = io.MultiWriter(&buf, observerWriter{streamWriter})
+	}
+	if err := a.StreamMessage(ctx, messages, w); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// observerWriter forwards writes to w but never fails, so a broken stream
+// writer can't fail the agent's turn
+type observerWriter struct {
+	w io.Writer
+}
+
+func (ow observerWriter) Write(p []byte) (int, error) {
+	if _, err := ow.w.Write(p); err != nil {
+		log.WithError(err).Debug("stream writer failed")
+	}
+	return len(p), nil
+}
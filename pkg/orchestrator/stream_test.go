@@ -0,0 +1,147 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// streamingAgent streams its response in chunks and records which method was used
+type streamingAgent struct {
+	MockAgent
+	chunks      []string
+	streamCalls int
+	sendCalls   int
+}
+
+func (s *streamingAgent) SupportsStreaming() bool { return true }
+
+func (s *streamingAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	s.sendCalls++
+	return s.MockAgent.SendMessage(ctx, messages)
+}
+
+func (s *streamingAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	s.streamCalls++
+	for _, c := range s.chunks {
+		if _, err := io.WriteString(writer, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bufferedAgent has a StreamMessage that must not be used, since it doesn't claim streaming
+type bufferedAgent struct {
+	MockAgent
+	streamCalls int
+}
+
+func (b *bufferedAgent) StreamMessage(ctx context.Context, messages []agent.Message, writer io.Writer) error {
+	b.streamCalls++
+	return errors.New("not a real stream")
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("closed") }
+
+func streamConfig(stream bool) OrchestratorConfig {
+	return OrchestratorConfig{
+		Mode:            ModeRoundRobin,
+		MaxTurns:        1,
+		TurnTimeout:     5 * time.Second,
+		ResponseDelay:   time.Millisecond,
+		StreamResponses: stream,
+	}
+}
+
+func TestStreamResponsesMixedAgents(t *testing.T) {
+	orch := NewOrchestrator(streamConfig(true), nil)
+	var streamed bytes.Buffer
+	orch.SetStreamWriter(&streamed)
+
+	streamer := &streamingAgent{
+		MockAgent: MockAgent{id: "s", name: "Streamer", agentType: "mock", available: true, sendMessageResp: "unused"},
+		chunks:    []string{"Hello ", "from ", "the stream.\n"},
+	}
+	buffered := &bufferedAgent{
+		MockAgent: MockAgent{id: "b", name: "Buffered", agentType: "mock", available: true, sendMessageResp: "All at once."},
+	}
+	orch.AddAgent(streamer)
+	orch.AddAgent(buffered)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if streamer.streamCalls != 1 || streamer.sendCalls != 0 {
+		t.Errorf("expected the streaming agent to stream, got stream=%d send=%d", streamer.streamCalls, streamer.sendCalls)
+	}
+	if buffered.streamCalls != 0 || buffered.callCount != 1 {
+		t.Errorf("expected the buffered agent to fall back to SendMessage, got stream=%d send=%d", buffered.streamCalls, buffered.callCount)
+	}
+
+	contents := make(map[string]string)
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			contents[msg.AgentName] = msg.Content
+		}
+	}
+	if contents["Streamer"] != "Hello from the stream." {
+		t.Errorf("streamed message content = %q", contents["Streamer"])
+	}
+	if contents["Buffered"] != "All at once." {
+		t.Errorf("buffered message content = %q", contents["Buffered"])
+	}
+	if want := "Hello from the stream.\nAll at once."; streamed.String() != want {
+		t.Errorf("stream writer got %q, want %q", streamed.String(), want)
+	}
+}
+
+func TestStreamResponsesDisabled(t *testing.T) {
+	orch := NewOrchestrator(streamConfig(false), nil)
+	var streamed bytes.Buffer
+	orch.SetStreamWriter(&streamed)
+
+	streamer := &streamingAgent{
+		MockAgent: MockAgent{id: "s", name: "Streamer", agentType: "mock", available: true, sendMessageResp: "Buffered anyway."},
+		chunks:    []string{"never"},
+	}
+	orch.AddAgent(streamer)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+	if streamer.streamCalls != 0 || streamer.sendCalls != 1 {
+		t.Errorf("expected SendMessage without streaming mode, got stream=%d send=%d", streamer.streamCalls, streamer.sendCalls)
+	}
+	if streamed.Len() != 0 {
+		t.Errorf("expected nothing on the stream writer, got %q", streamed.String())
+	}
+}
+
+func TestStreamResponsesWriterFailure(t *testing.T) {
+	orch := NewOrchestrator(streamConfig(true), nil)
+	orch.SetStreamWriter(failingWriter{})
+
+	streamer := &streamingAgent{
+		MockAgent: MockAgent{id: "s", name: "Streamer", agentType: "mock", available: true},
+		chunks:    []string{"Still ", "recorded."},
+	}
+	orch.AddAgent(streamer)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("a failing stream writer must not fail the turn: %v", err)
+	}
+	messages := orch.GetMessages()
+	if last := messages[len(messages)-1]; last.Content != "Still recorded." {
+		t.Errorf("expected the streamed response to be recorded, got %q", last.Content)
+	}
+}
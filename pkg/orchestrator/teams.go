@@ -0,0 +1,65 @@
+package orchestrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// teamNotePrefix starts a response an agent on a team posts only to its team's
+// private scratchpad instead of the public channel
+const teamNotePrefix = "[team]"
+
+// teamOf returns the team of the agent with the given ID, or "" if it plays alone
+func (o *Orchestrator) teamOf(agentID string) string {
+	return o.config.AgentTeams[agentID]
+}
+
+// visibleTo returns the messages an agent on team can see: the public channel plus
+// its own team's scratchpad. Agents without a team only see the public channel.
+func visibleTo(messages []agent.Message, team string) []agent.Message {
+	filtered := messages[:0]
+	for _, msg := range messages {
+		if msg.Team == "" || msg.Team == team {
+			filtered = append(filtered, msg)
+		}
+	}
+	return filtered
+}
+
+// splitTeamNote reports whether response is a scratchpad note, returning it without
+// the note prefix when it is
+func splitTeamNote(response string) (string, bool) {
+	trimmed := strings.TrimSpace(response)
+	if len(trimmed) < len(teamNotePrefix) || !strings.EqualFold(trimmed[:len(teamNotePrefix)], teamNotePrefix) {
+		return response, false
+	}
+	return strings.TrimSpace(trimmed[len(teamNotePrefix):]), true
+}
+
+// teamBriefingMessage returns a private note telling a which team it is on and how to
+// use the team scratchpad, or nil if a has no team. Like the role reminder, it is only
+// added to the agent's request, never to the history.
+func (o *Orchestrator) teamBriefingMessage(a agent.Agent) *agent.Message {
+	team := o.teamOf(a.GetID())
+	if team == "" {
+		return nil
+	}
+
+	o.mu.RLock()
+	var teammates []string
+	for _, other := range o.agents {
+		if other.GetID() != a.GetID() && o.teamOf(other.GetID()) == team {
+			teammates = append(teammates, other.GetName())
+		}
+	}
+	o.mu.RUnlock()
+
+	with := ""
+	if len(teammates) > 0 {
+		with = " with " + strings.Join(teammates, ", ")
+	}
+	return privateDirective(fmt.Sprintf("You are on team %s%s. Start a response with %s to post it only to your team's "+
+		"private scratchpad, which other teams can't see; any other response is public.", team, with, teamNotePrefix))
+}
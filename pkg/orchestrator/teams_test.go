@@ -0,0 +1,187 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+func TestSplitTeamNote(t *testing.T) {
+	tests := []struct {
+		response string
+		want     string
+		wantNote bool
+	}{
+		{"[team] Let's bluff about the budget.", "Let's bluff about the budget.", true},
+		{"  [TEAM]\nPlan B", "Plan B", true},
+		{"Public answer mentioning [team] later", "Public answer mentioning [team] later", false},
+		{"[tea", "[tea", false},
+	}
+
+	for _, tt := range tests {
+		got, note := splitTeamNote(tt.response)
+		if got != tt.want || note != tt.wantNote {
+			t.Errorf("splitTeamNote(%q) = %q, %v; want %q, %v", tt.response, got, note, tt.want, tt.wantNote)
+		}
+	}
+}
+
+func containsContent(messages []agent.Message, content string) bool {
+	for _, msg := range messages {
+		if strings.Contains(msg.Content, content) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTeamScratchpadVisibility(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                  ModeRoundRobin,
+		MaxTurns:              5,
+		TurnTimeout:           time.Second,
+		ResponseDelay:         time.Millisecond,
+		SuppressAnnouncements: true,
+		AgentTeams:            map[string]string{"red-1": "red", "red-2": "red", "blue-1": "blue"},
+	}, nil)
+
+	red1 := &scriptedAgent{
+		MockAgent: MockAgent{id: "red-1", name: "Red One", agentType: "mock", available: true},
+		responses: []string{"[team] We concede nothing on price.", "Our public offer is 100."},
+	}
+	blue1 := &scriptedAgent{
+		MockAgent: MockAgent{id: "blue-1", name: "Blue One", agentType: "mock", available: true},
+		responses: []string{"[team] They will fold at 80.", "What is your offer?"},
+	}
+	red2 := &scriptedAgent{
+		MockAgent: MockAgent{id: "red-2", name: "Red Two", agentType: "mock", available: true},
+		responses: []string{"Agreed."},
+	}
+	observer := &scriptedAgent{
+		MockAgent: MockAgent{id: "observer", name: "Observer", agentType: "mock", available: true},
+		responses: []string{"Noted."},
+	}
+	for _, a := range []agent.Agent{red1, blue1, red2, observer} {
+		orch.AddAgent(a)
+	}
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	redNote, blueNote := "We concede nothing on price.", "They will fold at 80."
+
+	// Turn order: red-1, blue-1, red-2, observer, red-1
+	if seen := blue1.requests[0]; containsContent(seen, redNote) {
+		t.Error("expected the red scratchpad to stay hidden from the blue team")
+	}
+	if seen := red2.requests[0]; !containsContent(seen, redNote) {
+		t.Error("expected the red scratchpad to reach a red teammate")
+	} else if containsContent(seen, blueNote) {
+		t.Error("expected the blue scratchpad to stay hidden from the red team")
+	}
+	if seen := observer.requests[0]; containsContent(seen, redNote) || containsContent(seen, blueNote) {
+		t.Error("expected an agent without a team to see neither scratchpad")
+	}
+	if seen := red1.requests[1]; !containsContent(seen, redNote) || containsContent(seen, blueNote) {
+		t.Error("expected red-1 to see its own team's scratchpad only")
+	}
+
+	// Public messages reach everyone
+	if seen := observer.requests[0]; !containsContent(seen, "Agreed.") {
+		t.Error("expected public messages to reach every agent")
+	}
+
+	// Teams are briefed privately; agents without a team aren't
+	if !containsContent(red2.requests[0], "You are on team red with Red One") {
+		t.Error("expected red-2 to be briefed about its team")
+	}
+	if containsContent(observer.requests[0], "You are on team") {
+		t.Error("expected no team briefing for an agent without a team")
+	}
+
+	var notes int
+	for _, msg := range orch.GetMessages() {
+		if msg.Team != "" {
+			notes++
+			if strings.HasPrefix(msg.Content, teamNotePrefix) {
+				t.Errorf("expected the note prefix to be stripped, got %q", msg.Content)
+			}
+		}
+		if strings.Contains(msg.Content, "You are on team") {
+			t.Error("expected the team briefing to stay out of the history")
+		}
+	}
+	if notes != 2 {
+		t.Errorf("expected 2 scratchpad notes in the history, got %d", notes)
+	}
+
+	if transcript := orch.voteTranscript(); strings.Contains(transcript, redNote) || strings.Contains(transcript, blueNote) {
+		t.Errorf("expected scratchpad notes to stay out of the vote transcript, got %q", transcript)
+	}
+}
+
+func TestNoteWithoutTeamIsPublic(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(newScriptedAgent("[team] nobody to share this with"))
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+	msg := lastAgentMessage(orch)
+	if msg == nil || msg.Team != "" || msg.Content != "[team] nobody to share this with" {
+		t.Errorf("expected the response to stay public and unchanged, got %+v", msg)
+	}
+}
+
+func TestTeamNotesStayOffPublicOutputs(t *testing.T) {
+	var output bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:                  ModeRoundRobin,
+		MaxTurns:              1,
+		TurnTimeout:           time.Second,
+		ResponseDelay:         time.Millisecond,
+		SuppressAnnouncements: true,
+		AgentTeams:            map[string]string{"red-1": "red"},
+	}, &output)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+	var hooked []string
+	orch.AddMessageHook(func(msg agent.Message) { hooked = append(hooked, msg.Content) })
+
+	orch.AddAgent(&scriptedAgent{
+		MockAgent: MockAgent{id: "red-1", name: "Red One", agentType: "mock", available: true},
+		responses: []string{"[team] We concede nothing on price."},
+	})
+	orch.AddAgent(&scriptedAgent{
+		MockAgent: MockAgent{id: "blue-1", name: "Blue One", agentType: "mock", available: true},
+		responses: []string{"What is your offer?"},
+	})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if len(emitter.contents) != 2 || emitter.contents[0] != "We concede nothing on price." || emitter.teams[0] != "red" {
+		t.Errorf("expected the bridge to get the note without its prefix and marked with its team, got %q teams %q",
+			emitter.contents, emitter.teams)
+	}
+	if emitter.teams[1] != "" {
+		t.Errorf("expected public messages to carry no team, got %q", emitter.teams[1])
+	}
+	if strings.Contains(output.String(), "concede") {
+		t.Errorf("expected the note to stay out of the writer, got %q", output.String())
+	}
+	if strings.Contains(strings.Join(hooked, "\n"), "concede") || !strings.Contains(strings.Join(hooked, "\n"), "What is your offer?") {
+		t.Errorf("expected hooks to get public messages only, got %q", hooked)
+	}
+}
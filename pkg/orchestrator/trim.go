@@ -0,0 +1,91 @@
+package orchestrator
+
+import (
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// TrimStrategy selects which messages are dropped when the history sent to agents
+// exceeds MaxHistoryMessages.
+type TrimStrategy string
+
+const (
+	// TrimOldest drops the oldest messages, keeping only the most recent ones
+	TrimOldest TrimStrategy = "oldest"
+	// TrimImportance keeps the initial prompt, the most recent messages and any
+	// message tagged "decision" or "question", dropping everything else
+	TrimImportance TrimStrategy = "importance"
+)
+
+// tagsMetadataKey is the message metadata key holding a message's tags
+const tagsMetadataKey = "tags"
+
+// importantTags are the tags that TrimImportance never drops
+var importantTags = map[string]bool{
+	"decision": true,
+	"question": true,
+}
+
+// trimHistory limits messages to the most recent limit entries using the given strategy.
+// A limit of zero or less disables trimming. Pinned messages outside the recent window are
+// always kept, and with TrimImportance so are the initial prompt and important messages.
+func trimHistory(messages []agent.Message, limit int, strategy TrimStrategy) []agent.Message {
+	if limit <= 0 || len(messages) <= limit {
+		return messages
+	}
+
+	recentStart := len(messages) - limit
+	importance := strategy == TrimImportance
+	prompt := initialPromptIndex(messages)
+
+	trimmed := make([]agent.Message, 0, limit+1)
+	for i, msg := range messages[:recentStart] {
+		if msg.Pinned || (importance && (i == prompt || isImportant(msg))) {
+			trimmed = append(trimmed, msg)
+		}
+	}
+	return append(trimmed, messages[recentStart:]...)
+}
+
+// isHostMessage reports whether msg was posted by the host, such as the initial prompt
+func isHostMessage(msg agent.Message) bool {
+	return msg.AgentID == "host" && msg.Role == "system"
+}
+
+// initialPromptIndex returns the index of the host's opening prompt, the first host
+// message, or -1 if there is none. Join announcements usually come before it.
+func initialPromptIndex(messages []agent.Message) int {
+	for i, msg := range messages {
+		if isHostMessage(msg) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isImportant reports whether msg carries a tag that importance trimming preserves
+func isImportant(msg agent.Message) bool {
+	for _, tag := range messageTags(msg) {
+		if importantTags[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// messageTags returns the tags stored in a message's metadata.
+// Tags may be a []string or, after a JSON round trip, a []interface{} of strings.
+func messageTags(msg agent.Message) []string {
+	switch tags := msg.Metadata[tagsMetadataKey].(type) {
+	case []string:
+		return tags
+	case []interface{}:
+		result := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// trimTestMessages is a conversation where the early decision and question would be lost to oldest-first trimming
+func trimTestMessages() []agent.Message {
+	tagged := func(content string, tags ...string) agent.Message {
+		return agent.Message{AgentID: "a", AgentName: "A", Role: "agent", Content: content,
+			Metadata: map[string]interface{}{tagsMetadataKey: tags}}
+	}
+	return []agent.Message{
+		{AgentID: "host", AgentName: "HOST", Role: "system", Content: "prompt"},
+		{AgentID: "a", AgentName: "A", Role: "agent", Content: "chatter 1"},
+		tagged("we decided on Go", "decision"),
+		{AgentID: "b", AgentName: "B", Role: "agent", Content: "chatter 2"},
+		// Tags decoded from JSON arrive as []interface{}
+		{AgentID: "b", AgentName: "B", Role: "agent", Content: "what about tests?",
+			Metadata: map[string]interface{}{tagsMetadataKey: []interface{}{"question"}}},
+		tagged("just an aside", "aside"),
+		{AgentID: "a", AgentName: "A", Role: "agent", Content: "recent 1"},
+		{AgentID: "b", AgentName: "B", Role: "agent", Content: "recent 2"},
+	}
+}
+
+func contents(messages []agent.Message) []string {
+	result := make([]string, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.Content
+	}
+	return result
+}
+
+func TestTrimHistoryStrategies(t *testing.T) {
+	tests := []struct {
+		strategy TrimStrategy
+		want     []string
+	}{
+		{TrimOldest, []string{"recent 1", "recent 2"}},
+		// Unknown or unset strategies fall back to oldest-first
+		{"", []string{"recent 1", "recent 2"}},
+		{TrimImportance, []string{"prompt", "we decided on Go", "what about tests?", "recent 1", "recent 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			got := contents(trimHistory(trimTestMessages(), 2, tt.strategy))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrimHistoryWithinLimit(t *testing.T) {
+	messages := trimTestMessages()
+	for _, limit := range []int{0, len(messages), len(messages) + 5} {
+		if got := trimHistory(messages, limit, TrimImportance); len(got) != len(messages) {
+			t.Errorf("limit %d: expected all %d messages, got %d", limit, len(messages), len(got))
+		}
+	}
+}
+
+func TestTrimHistoryKeepsPromptAfterAnnouncements(t *testing.T) {
+	// Join announcements are posted before the initial prompt
+	messages := append([]agent.Message{
+		{AgentID: "a", AgentName: "A", Role: "system", Content: "A has joined"},
+		{AgentID: "b", AgentName: "B", Role: "system", Content: "B has joined"},
+	}, trimTestMessages()...)
+
+	got := contents(trimHistory(messages, 2, TrimImportance))
+	want := []string{"prompt", "we decided on Go", "what about tests?", "recent 1", "recent 2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTrimHistoryKeepsPinnedMessages(t *testing.T) {
+	messages := trimTestMessages()
+	messages[1].Pinned = true // "chatter 1" has no tags and would otherwise be dropped
+
+	for _, strategy := range []TrimStrategy{TrimOldest, TrimImportance} {
+		got := contents(trimHistory(messages, 2, strategy))
+		found := false
+		for _, content := range got {
+			if content == "chatter 1" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected pinned message to survive trimming, got %q", strategy, got)
+		}
+	}
+
+	want := []string{"chatter 1", "recent 1", "recent 2"}
+	if got := contents(trimHistory(messages, 2, TrimOldest)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestPinMessage(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:               ModeRoundRobin,
+		MaxTurns:           4,
+		TurnTimeout:        5 * time.Second,
+		ResponseDelay:      1 * time.Millisecond,
+		MaxHistoryMessages: 1,
+	}
+	orch := NewOrchestrator(cfg, nil)
+	orch.InjectMessage(agent.Message{AgentID: "user", AgentName: "User", Content: "the deadline is Friday"})
+
+	if err := orch.PinMessage(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orch.PinMessage(5); !errors.Is(err, ErrMessageNotFound) {
+		t.Errorf("expected ErrMessageNotFound for an out-of-range index, got %v", err)
+	}
+	if !orch.GetMessages()[0].Pinned {
+		t.Fatal("expected message to be pinned")
+	}
+
+	a := &contextRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	orch.AddAgent(a)
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(a.last) != 2 || a.last[0].Content != "the deadline is Friday" {
+		t.Errorf("expected the pinned message plus the latest message, got %q", contents(a.last))
+	}
+
+	if err := orch.UnpinMessage(0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if orch.GetMessages()[0].Pinned {
+		t.Error("expected message to be unpinned")
+	}
+}
+
+// contextRecordingAgent records the context it was sent on its latest turn
+type contextRecordingAgent struct {
+	MockAgent
+	last []agent.Message
+}
+
+func (c *contextRecordingAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	c.last = append([]agent.Message(nil), messages...)
+	return c.MockAgent.SendMessage(ctx, messages)
+}
+
+// hasContent reports whether one of messages has exactly the given content
+func hasContent(messages []agent.Message, content string) bool {
+	for _, msg := range messages {
+		if msg.Content == content {
+			return true
+		}
+	}
+	return false
+}
+
+// historyRecordingAgent records the messages it was sent on each turn
+type historyRecordingAgent struct {
+	MockAgent
+	received [][]agent.Message
+}
+
+func (h *historyRecordingAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	h.received = append(h.received, append([]agent.Message(nil), messages...))
+	return h.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestMaxHistoryMessagesLimitsAgentInput(t *testing.T) {
+	cfg := OrchestratorConfig{
+		Mode:               ModeRoundRobin,
+		MaxTurns:           4,
+		TurnTimeout:        5 * time.Second,
+		ResponseDelay:      1 * time.Millisecond,
+		InitialPrompt:      "topic",
+		MaxHistoryMessages: 2,
+		TrimStrategy:       TrimImportance,
+	}
+	orch := NewOrchestrator(cfg, nil)
+	a := &historyRecordingAgent{MockAgent: MockAgent{id: "agent-1", name: "Agent1", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	b := &historyRecordingAgent{MockAgent: MockAgent{id: "agent-2", name: "Agent2", agentType: "mock", available: true, sendMessageResp: "ok"}}
+	// Both join announcements are posted ahead of the initial prompt
+	orch.AddAgent(a)
+	orch.AddAgent(b)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(a.received) == 0 || len(b.received) == 0 {
+		t.Fatal("expected both agents to be called")
+	}
+	for _, rec := range []*historyRecordingAgent{a, b} {
+		for turn, messages := range rec.received {
+			// The recent window plus the preserved initial prompt
+			if len(messages) > 3 {
+				t.Errorf("%s turn %d: expected at most 3 messages, got %d", rec.name, turn+1, len(messages))
+			}
+			if !hasContent(messages, "topic") {
+				t.Errorf("%s turn %d: expected the initial prompt to survive trimming, got %q", rec.name, turn+1, contents(messages))
+			}
+		}
+	}
+	if len(orch.GetMessages()) <= 3 {
+		t.Errorf("expected the full history to be kept, got %d messages", len(orch.GetMessages()))
+	}
+}
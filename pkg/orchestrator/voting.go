@@ -0,0 +1,341 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shawkym/agentpipe/internal/bridge"
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+const (
+	// defaultVoteTimeout bounds each ballot when the config sets no timeout
+	defaultVoteTimeout = 30 * time.Second
+	// refereeTieBreak is reported when a tied vote is decided by average referee scores
+	refereeTieBreak = "referee_score"
+)
+
+// voteLabelPattern matches "VOTE: Bob", "**Vote:** Bob", "Vote - Bob"
+var voteLabelPattern = regexp.MustCompile(`(?i)\bvote\s*[:=\-]\s*([^\n]+)`)
+
+// ParseVote returns the candidate a ballot votes for. A labeled vote ("VOTE: Name") is
+// preferred; without a label the reply must name exactly one candidate. Names are matched
+// case-insensitively as whole words, and when one candidate's name contains another's
+// ("Claude" and "Claude Opus") the longer name wins.
+func ParseVote(text string, candidates []string) (string, error) {
+	if m := voteLabelPattern.FindStringSubmatch(text); m != nil {
+		value := strings.TrimFunc(m[1], func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, c := range candidates {
+			if strings.EqualFold(value, c) {
+				return c, nil
+			}
+		}
+		switch matches := mentionedCandidates(value, candidates); len(matches) {
+		case 1:
+			return matches[0], nil
+		case 0:
+			return "", fmt.Errorf("vote for unknown participant %q", value)
+		default:
+			return "", fmt.Errorf("vote names several participants: %s", strings.Join(matches, ", "))
+		}
+	}
+
+	switch matches := mentionedCandidates(text, candidates); len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		return "", fmt.Errorf("no vote found in reply")
+	default:
+		return "", fmt.Errorf("vote names several participants: %s", strings.Join(matches, ", "))
+	}
+}
+
+// mentionedCandidates returns the candidates named in text, dropping any whose name
+// only appears as part of a longer candidate's name.
+func mentionedCandidates(text string, candidates []string) []string {
+	lower := strings.ToLower(text)
+	var found []string
+	for _, c := range candidates {
+		if indexWord(lower, strings.ToLower(c)) >= 0 {
+			found = append(found, c)
+		}
+	}
+
+	var matches []string
+	for _, c := range found {
+		contained := false
+		for _, other := range found {
+			if other != c && indexWord(strings.ToLower(other), strings.ToLower(c)) >= 0 {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// TallyVotes counts votes for candidates, listed in candidate order; votes for anyone
+// else are dropped. A single leader wins outright. Leaders tied on votes are separated
+// by their tiebreak score (the orchestrator passes average referee scores) when every
+// one of them has a score and exactly one scores highest; otherwise the vote is a draw
+// and Winner is empty. Tied always lists the leaders when more than one shared the top.
+func TallyVotes(votes []bridge.Vote, candidates []string, tiebreak map[string]float64) *bridge.VoteResult {
+	result := &bridge.VoteResult{
+		Votes: make([]bridge.Vote, 0, len(votes)),
+		Tally: make([]bridge.VoteCount, 0, len(candidates)),
+	}
+
+	counts := make(map[string]int, len(candidates))
+	for _, c := range candidates {
+		counts[c] = 0
+	}
+	for _, v := range votes {
+		if _, ok := counts[v.Choice]; !ok {
+			continue
+		}
+		counts[v.Choice]++
+		result.Votes = append(result.Votes, v)
+	}
+
+	top := 0
+	for _, c := range candidates {
+		result.Tally = append(result.Tally, bridge.VoteCount{AgentName: c, Votes: counts[c]})
+		top = max(top, counts[c])
+	}
+	if top == 0 {
+		return result
+	}
+
+	var leaders []string
+	for _, c := range candidates {
+		if counts[c] == top {
+			leaders = append(leaders, c)
+		}
+	}
+	if len(leaders) == 1 {
+		result.Winner = leaders[0]
+		return result
+	}
+
+	result.Tied = leaders
+	if winner, ok := breakTie(leaders, tiebreak); ok {
+		result.Winner = winner
+		result.TieBreak = refereeTieBreak
+	}
+	return result
+}
+
+// breakTie returns the leader with the single highest tiebreak score. It fails when a
+// leader has no score or the best score is shared.
+func breakTie(leaders []string, tiebreak map[string]float64) (string, bool) {
+	best, bestScore, shared := "", 0.0, false
+	for _, l := range leaders {
+		score, ok := tiebreak[l]
+		if !ok {
+			return "", false
+		}
+		switch {
+		case best == "" || score > bestScore:
+			best, bestScore, shared = l, score, false
+		case score == bestScore:
+			shared = true
+		}
+	}
+	return best, !shared
+}
+
+// DescribeVote renders the outcome of a vote in one line.
+func DescribeVote(result *bridge.VoteResult) string {
+	switch {
+	case len(result.Votes) == 0:
+		return "no valid votes were cast"
+	case result.Winner != "" && result.TieBreak != "":
+		return fmt.Sprintf("%s wins (tie between %s broken by %s)", result.Winner, strings.Join(result.Tied, ", "), result.TieBreak)
+	case result.Winner != "":
+		for _, t := range result.Tally {
+			if t.AgentName == result.Winner {
+				return fmt.Sprintf("%s wins with %d of %d votes", result.Winner, t.Votes, len(result.Votes))
+			}
+		}
+		return result.Winner + " wins"
+	default:
+		return "draw between " + strings.Join(result.Tied, ", ")
+	}
+}
+
+// GetVoteResult returns the end-of-conversation vote.
+// Returns nil if voting is disabled or the conversation has not completed.
+// This method is thread-safe.
+func (o *Orchestrator) GetVoteResult() *bridge.VoteResult {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.voteResult
+}
+
+// voteTimeout returns how long a single ballot may take
+func (o *Orchestrator) voteTimeout() time.Duration {
+	if o.config.Voting.Timeout > 0 {
+		return o.config.Voting.Timeout
+	}
+	return defaultVoteTimeout
+}
+
+// runVote asks the judge, or every agent, to vote for the most convincing participant,
+// then tallies, stores and emits the result. Ballots that fail or can't be parsed are
+// logged and left out. Ties are broken by average referee score when available.
+func (o *Orchestrator) runVote() *bridge.VoteResult {
+	if !o.config.Voting.Enabled {
+		return nil
+	}
+
+	o.mu.RLock()
+	participants := append([]agent.Agent(nil), o.agents...)
+	o.mu.RUnlock()
+
+	candidates := make([]string, 0, len(participants))
+	for _, a := range participants {
+		candidates = append(candidates, a.GetName())
+	}
+
+	voters := participants
+	if o.config.Voting.Judge != "" {
+		judge, err := o.resolveVoteJudge()
+		if err != nil {
+			log.WithField("judge", o.config.Voting.Judge).WithError(err).Warn("failed to create vote judge, skipping vote")
+			return nil
+		}
+		voters = []agent.Agent{judge}
+	}
+
+	transcript := o.voteTranscript()
+	if transcript == "" {
+		return nil
+	}
+
+	var votes []bridge.Vote
+	for _, voter := range voters {
+		var eligible []string
+		for _, c := range candidates {
+			if c != voter.GetName() {
+				eligible = append(eligible, c)
+			}
+		}
+		if len(eligible) == 0 {
+			continue
+		}
+
+		choice, err := o.castBallot(voter, eligible, transcript)
+		if err != nil {
+			log.WithField("voter", voter.GetName()).WithError(err).Warn("ballot discarded")
+			continue
+		}
+		votes = append(votes, bridge.Vote{Voter: voter.GetName(), Choice: choice})
+	}
+
+	tiebreak := make(map[string]float64)
+	for _, s := range o.GetRefereeScores() {
+		tiebreak[s.AgentName] = s.Average
+	}
+	result := TallyVotes(votes, candidates, tiebreak)
+
+	o.mu.Lock()
+	o.voteResult = result
+	bridgeEmitter := o.bridgeEmitter
+	o.mu.Unlock()
+
+	log.WithFields(map[string]interface{}{
+		"votes":  len(result.Votes),
+		"winner": result.Winner,
+		"tied":   strings.Join(result.Tied, ","),
+	}).Info("conversation vote tallied")
+	if o.writer != nil {
+		fmt.Fprintf(o.writer, "\n[Vote] %s\n", DescribeVote(result))
+	}
+	if bridgeEmitter != nil {
+		bridgeEmitter.EmitVoteCompleted(result)
+	}
+	return result
+}
+
+// castBallot asks voter to pick one of candidates and parses its reply
+func (o *Orchestrator) castBallot(voter agent.Agent, candidates []string, transcript string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), o.voteTimeout())
+	defer cancel()
+
+	prompt := fmt.Sprintf(`The conversation below has ended. Vote for the participant who argued most convincingly.
+
+Candidates: %s
+
+Reply with exactly one line in this format and nothing else:
+VOTE: <candidate name>
+
+Conversation:
+%s`, strings.Join(candidates, ", "), transcript)
+
+	reply, err := voter.SendMessage(ctx, []agent.Message{{
+		AgentID:   "system",
+		AgentName: "SYSTEM",
+		Content:   prompt,
+		Timestamp: time.Now().Unix(),
+		Role:      "user",
+	}})
+	if err != nil {
+		return "", err
+	}
+	return ParseVote(reply, candidates)
+}
+
+// voteTranscript renders the public agent and user messages of the conversation for ballots
+func (o *Orchestrator) voteTranscript() string {
+	var b strings.Builder
+	for _, msg := range o.getMessages() {
+		if msg.Role == "system" || msg.Role == "tool" || msg.Team != "" {
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s\n\n", msg.AgentName, msg.Content)
+	}
+	return b.String()
+}
+
+// resolveVoteJudge returns the conversation agent matching Voting.Judge by type, name
+// or ID, or creates a judge agent of that type.
+func (o *Orchestrator) resolveVoteJudge() (agent.Agent, error) {
+	o.mu.RLock()
+	for _, a := range o.agents {
+		if a.GetType() == o.config.Voting.Judge || a.GetName() == o.config.Voting.Judge || a.GetID() == o.config.Voting.Judge {
+			o.mu.RUnlock()
+			return a, nil
+		}
+	}
+	o.mu.RUnlock()
+
+	judgeConfig := agent.AgentConfig{
+		ID:   "vote-judge",
+		Type: o.config.Voting.Judge,
+		Name: "Judge",
+	}
+
+	judge, err := agent.CreateAgent(judgeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if judge == nil {
+		return nil, fmt.Errorf("no agent created for type %s", o.config.Voting.Judge)
+	}
+
+	if err := judge.Initialize(judgeConfig); err != nil {
+		return nil, fmt.Errorf("failed to initialize vote judge: %w", err)
+	}
+
+	return judge, nil
+}
@@ -0,0 +1,264 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/internal/bridge"
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// ballotAgent answers ballots with vote and ordinary turns with its MockAgent response
+type ballotAgent struct {
+	MockAgent
+	vote    string
+	ballots int
+}
+
+func (b *ballotAgent) SendMessage(ctx context.Context, messages []agent.Message) (string, error) {
+	if last := messages[len(messages)-1]; strings.Contains(last.Content, "VOTE: <candidate name>") {
+		b.ballots++
+		return b.vote, nil
+	}
+	return b.MockAgent.SendMessage(ctx, messages)
+}
+
+func TestParseVote(t *testing.T) {
+	candidates := []string{"Alice", "Bob", "Claude", "Claude Opus"}
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr string
+	}{
+		{name: "labeled", text: "VOTE: Bob", want: "Bob"},
+		{name: "labeled lowercase", text: "vote: alice", want: "Alice"},
+		{name: "labeled markdown", text: "**Vote:** **Bob**.", want: "Bob"},
+		{name: "labeled with reason", text: "VOTE: Bob - his evidence was stronger", want: "Bob"},
+		{name: "labeled longer name wins", text: "VOTE: Claude Opus", want: "Claude Opus"},
+		{name: "labeled shorter name", text: "VOTE: Claude", want: "Claude"},
+		{name: "unlabeled single mention", text: "I found Alice the most convincing.", want: "Alice"},
+		{name: "unlabeled contained name", text: "Claude Opus made the best case.", want: "Claude Opus"},
+		{name: "whole words only", text: "Bobby was great, VOTE: Alice", want: "Alice"},
+		{name: "labeled unknown", text: "VOTE: Carol", wantErr: `unknown participant "Carol"`},
+		{name: "unlabeled ambiguous", text: "Alice and Bob were both good.", wantErr: "several participants"},
+		{name: "no vote", text: "I abstain.", wantErr: "no vote found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVote(tt.text, candidates)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseVote(%q) = %q, %v; want error containing %q", tt.text, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVote(%q) unexpected error: %v", tt.text, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVote(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTallyVotes(t *testing.T) {
+	candidates := []string{"Alice", "Bob", "Carol"}
+	vote := func(voter, choice string) bridge.Vote { return bridge.Vote{Voter: voter, Choice: choice} }
+
+	tests := []struct {
+		name         string
+		votes        []bridge.Vote
+		tiebreak     map[string]float64
+		wantWinner   string
+		wantTied     []string
+		wantTieBreak string
+		wantCounts   []int
+	}{
+		{
+			name:       "clear winner",
+			votes:      []bridge.Vote{vote("Alice", "Bob"), vote("Carol", "Bob"), vote("Bob", "Alice")},
+			wantWinner: "Bob",
+			wantCounts: []int{1, 2, 0},
+		},
+		{
+			name:       "no votes",
+			wantCounts: []int{0, 0, 0},
+		},
+		{
+			name:       "votes for non-candidates are dropped",
+			votes:      []bridge.Vote{vote("Alice", "Dave"), vote("Bob", "Carol")},
+			wantWinner: "Carol",
+			wantCounts: []int{0, 0, 1},
+		},
+		{
+			name:       "tie without scores is a draw",
+			votes:      []bridge.Vote{vote("Alice", "Bob"), vote("Bob", "Alice")},
+			wantTied:   []string{"Alice", "Bob"},
+			wantCounts: []int{1, 1, 0},
+		},
+		{
+			name:         "tie broken by score",
+			votes:        []bridge.Vote{vote("Alice", "Bob"), vote("Bob", "Alice")},
+			tiebreak:     map[string]float64{"Alice": 6.5, "Bob": 8, "Carol": 9},
+			wantWinner:   "Bob",
+			wantTied:     []string{"Alice", "Bob"},
+			wantTieBreak: refereeTieBreak,
+			wantCounts:   []int{1, 1, 0},
+		},
+		{
+			name:       "tie with equal scores is a draw",
+			votes:      []bridge.Vote{vote("Alice", "Bob"), vote("Bob", "Alice")},
+			tiebreak:   map[string]float64{"Alice": 7, "Bob": 7},
+			wantTied:   []string{"Alice", "Bob"},
+			wantCounts: []int{1, 1, 0},
+		},
+		{
+			name:       "tie with a missing score is a draw",
+			votes:      []bridge.Vote{vote("Carol", "Alice"), vote("Alice", "Bob")},
+			tiebreak:   map[string]float64{"Alice": 9},
+			wantTied:   []string{"Alice", "Bob"},
+			wantCounts: []int{1, 1, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TallyVotes(tt.votes, candidates, tt.tiebreak)
+			if result.Winner != tt.wantWinner {
+				t.Errorf("Winner = %q, want %q", result.Winner, tt.wantWinner)
+			}
+			if !reflect.DeepEqual(result.Tied, tt.wantTied) {
+				t.Errorf("Tied = %v, want %v", result.Tied, tt.wantTied)
+			}
+			if result.TieBreak != tt.wantTieBreak {
+				t.Errorf("TieBreak = %q, want %q", result.TieBreak, tt.wantTieBreak)
+			}
+			var counts []int
+			for i, c := range result.Tally {
+				if c.AgentName != candidates[i] {
+					t.Errorf("tally %d is for %s, want %s", i, c.AgentName, candidates[i])
+				}
+				counts = append(counts, c.Votes)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("counts = %v, want %v", counts, tt.wantCounts)
+			}
+		})
+	}
+}
+
+func TestDescribeVote(t *testing.T) {
+	tests := []struct {
+		result *bridge.VoteResult
+		want   string
+	}{
+		{&bridge.VoteResult{}, "no valid votes were cast"},
+		{TallyVotes([]bridge.Vote{{Voter: "A", Choice: "Bob"}, {Voter: "C", Choice: "Bob"}}, []string{"Alice", "Bob"}, nil), "Bob wins with 2 of 2 votes"},
+		{TallyVotes([]bridge.Vote{{Voter: "Alice", Choice: "Bob"}, {Voter: "Bob", Choice: "Alice"}}, []string{"Alice", "Bob"}, nil), "draw between Alice, Bob"},
+		{TallyVotes([]bridge.Vote{{Voter: "Alice", Choice: "Bob"}, {Voter: "Bob", Choice: "Alice"}}, []string{"Alice", "Bob"}, map[string]float64{"Alice": 9, "Bob": 3}),
+			"Alice wins (tie between Alice, Bob broken by referee_score)"},
+	}
+	for _, tt := range tests {
+		if got := DescribeVote(tt.result); got != tt.want {
+			t.Errorf("DescribeVote() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestVotingAfterConversation(t *testing.T) {
+	var buf bytes.Buffer
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		InitialPrompt: "Is a hot dog a sandwich?",
+		Voting:        config.VotingConfig{Enabled: true},
+	}, &buf)
+	emitter := &MockBridgeEmitter{}
+	orch.SetBridgeEmitter(emitter)
+
+	alice := &ballotAgent{MockAgent: MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Yes."}, vote: "VOTE: Carol"}
+	bob := &ballotAgent{MockAgent: MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "No."}, vote: "VOTE: Bob"}
+	carol := &ballotAgent{MockAgent: MockAgent{id: "c", name: "Carol", agentType: "mock", available: true, sendMessageResp: "It depends."}, vote: "**Vote:** Carol"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	orch.AddAgent(carol)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	// Bob votes for himself and Carol for herself, so only Alice's ballot counts
+	for _, a := range []*ballotAgent{alice, bob, carol} {
+		if a.ballots != 1 {
+			t.Errorf("%s was asked to vote %d times, want 1", a.name, a.ballots)
+		}
+	}
+	result := orch.GetVoteResult()
+	if result == nil || result.Winner != "Carol" || len(result.Votes) != 1 {
+		t.Fatalf("unexpected vote result: %+v", result)
+	}
+	if emitter.voteCompleted != result {
+		t.Error("expected the vote result to be emitted")
+	}
+	if !strings.Contains(buf.String(), "[Vote] Carol wins with 1 of 1 votes") {
+		t.Errorf("expected the vote outcome in the output, got:\n%s", buf.String())
+	}
+}
+
+func TestVotingWithJudge(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+		Voting:        config.VotingConfig{Enabled: true, Judge: "Moderator"},
+	}, nil)
+
+	alice := &ballotAgent{MockAgent: MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Yes."}, vote: "VOTE: Bob"}
+	bob := &ballotAgent{MockAgent: MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "No."}, vote: "VOTE: Alice"}
+	judge := &ballotAgent{MockAgent: MockAgent{id: "m", name: "Moderator", agentType: "mock", available: true, sendMessageResp: "Go on."}, vote: "VOTE: Bob"}
+	orch.AddAgent(alice)
+	orch.AddAgent(bob)
+	orch.AddAgent(judge)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+
+	if alice.ballots != 0 || bob.ballots != 0 || judge.ballots != 1 {
+		t.Errorf("expected only the judge to vote, got alice=%d bob=%d judge=%d", alice.ballots, bob.ballots, judge.ballots)
+	}
+	if result := orch.GetVoteResult(); result == nil || result.Winner != "Bob" {
+		t.Errorf("unexpected vote result: %+v", result)
+	}
+}
+
+func TestVotingDisabled(t *testing.T) {
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      1,
+		TurnTimeout:   5 * time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	a := &ballotAgent{MockAgent: MockAgent{id: "a", name: "Alice", agentType: "mock", available: true, sendMessageResp: "Hi."}, vote: "VOTE: Bob"}
+	b := &ballotAgent{MockAgent: MockAgent{id: "b", name: "Bob", agentType: "mock", available: true, sendMessageResp: "Hi."}, vote: "VOTE: Alice"}
+	orch.AddAgent(a)
+	orch.AddAgent(b)
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected orchestrator error: %v", err)
+	}
+	if a.ballots != 0 || b.ballots != 0 || orch.GetVoteResult() != nil {
+		t.Error("expected no vote when voting is disabled")
+	}
+}
@@ -0,0 +1,70 @@
+package orchestrator
+
+import (
+	"io"
+	"sync"
+
+	"github.com/shawkym/agentpipe/pkg/log"
+)
+
+// maxWriterErrors is how many writes in a row may fail before the orchestrator gives
+// up on its output writer
+const maxWriterErrors = 3
+
+// guardedWriter wraps the orchestrator's output writer. Once maxWriterErrors writes in
+// a row have failed, e.g. because the reader of a pipe has gone away, it logs a single
+// warning and discards further output instead of repeating the failing writes.
+type guardedWriter struct {
+	mu       sync.Mutex
+	w        io.Writer
+	failures int
+}
+
+// newGuardedWriter wraps w in a guardedWriter. A nil w stays nil, so callers can keep
+// checking for a missing writer.
+func newGuardedWriter(w io.Writer) io.Writer {
+	if w == nil {
+		return nil
+	}
+	if g, ok := w.(*guardedWriter); ok {
+		return g
+	}
+	return &guardedWriter{w: w}
+}
+
+func (g *guardedWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	n, err := g.w.Write(p)
+	if err == nil {
+		g.failures = 0
+		return n, nil
+	}
+
+	g.failures++
+	if g.failures >= maxWriterErrors {
+		log.WithError(err).WithField("failed_writes", g.failures).Warn("output writer keeps failing, discarding further conversation output")
+		g.w = io.Discard
+		g.failures = 0
+	}
+	return n, err
+}
+
+// discarding reports whether the writer has given up on its destination
+func (g *guardedWriter) discarding() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.w == io.Discard
+}
+
+// writing reports whether output written to w still goes somewhere: w is set and,
+// if it is a guardedWriter, hasn't given up on its destination. Callers use it to
+// skip formatting output that would only be discarded.
+func writing(w io.Writer) bool {
+	if w == nil {
+		return false
+	}
+	g, ok := w.(*guardedWriter)
+	return !ok || !g.discarding()
+}
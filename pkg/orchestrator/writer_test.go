@@ -0,0 +1,120 @@
+package orchestrator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// flakyWriter fails the writes listed in failOn (1-based) and records the rest
+type flakyWriter struct {
+	calls  int
+	failOn map[int]bool
+	buf    bytes.Buffer
+}
+
+func (f *flakyWriter) Write(p []byte) (int, error) {
+	f.calls++
+	if f.failOn[f.calls] {
+		return 0, errors.New("broken pipe")
+	}
+	return f.buf.Write(p)
+}
+
+func TestGuardedWriterSwitchesToDiscard(t *testing.T) {
+	broken := &flakyWriter{failOn: map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true}}
+	w := newGuardedWriter(broken).(*guardedWriter)
+
+	for i := 0; i < maxWriterErrors; i++ {
+		if _, err := w.Write([]byte("hello\n")); err == nil {
+			t.Fatalf("expected write %d to report the writer's error", i+1)
+		}
+	}
+	if !w.discarding() {
+		t.Fatalf("expected the writer to switch to discarding after %d failed writes", maxWriterErrors)
+	}
+
+	if n, err := w.Write([]byte("dropped\n")); err != nil || n != len("dropped\n") {
+		t.Errorf("expected discarded writes to succeed, got %d, %v", n, err)
+	}
+	if broken.calls != maxWriterErrors {
+		t.Errorf("expected no further writes to the broken writer, got %d calls", broken.calls)
+	}
+}
+
+func TestWriting(t *testing.T) {
+	if writing(nil) {
+		t.Error("expected a missing writer not to be writing")
+	}
+	if !writing(&bytes.Buffer{}) {
+		t.Error("expected a plain writer to be writing")
+	}
+
+	w := newGuardedWriter(&flakyWriter{failOn: map[int]bool{1: true, 2: true, 3: true}})
+	if !writing(w) {
+		t.Error("expected a healthy guarded writer to be writing")
+	}
+	for i := 0; i < maxWriterErrors; i++ {
+		w.Write([]byte("x"))
+	}
+	if writing(w) {
+		t.Error("expected a discarding writer not to be writing")
+	}
+}
+
+func TestGuardedWriterResetsOnSuccess(t *testing.T) {
+	// Two failures, a success, then two more failures never reach the limit in a row
+	flaky := &flakyWriter{failOn: map[int]bool{1: true, 2: true, 4: true, 5: true}}
+	w := newGuardedWriter(flaky).(*guardedWriter)
+
+	for i := 0; i < 6; i++ {
+		w.Write([]byte("x"))
+	}
+	if w.discarding() {
+		t.Error("expected occasional failures not to discard output")
+	}
+	if flaky.buf.String() != "xx" {
+		t.Errorf("expected the successful writes to reach the writer, got %q", flaky.buf.String())
+	}
+}
+
+func TestNewGuardedWriterNil(t *testing.T) {
+	if w := newGuardedWriter(nil); w != nil {
+		t.Errorf("expected a nil writer to stay nil, got %#v", w)
+	}
+	g := newGuardedWriter(&bytes.Buffer{})
+	if newGuardedWriter(g) != g {
+		t.Error("expected an already guarded writer not to be wrapped again")
+	}
+}
+
+func TestOrchestratorStopsWritingToBrokenWriter(t *testing.T) {
+	broken := &flakyWriter{failOn: map[int]bool{}}
+	for i := 1; i <= 100; i++ {
+		broken.failOn[i] = true
+	}
+
+	orch := NewOrchestrator(OrchestratorConfig{
+		Mode:          ModeRoundRobin,
+		MaxTurns:      3,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, broken)
+	orch.AddAgent(&MockAgent{id: "a1", name: "Alpha", agentType: "mock", available: true, sendMessageResp: "one"})
+	orch.AddAgent(&MockAgent{id: "a2", name: "Beta", agentType: "mock", available: true, sendMessageResp: "two"})
+
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("a broken writer must not fail the conversation: %v", err)
+	}
+	if got := len(orch.GetMessages()); got < 6 {
+		t.Errorf("expected the conversation to continue, got %d messages", got)
+	}
+	if broken.calls != maxWriterErrors {
+		t.Errorf("expected %d writes to the broken writer before discarding, got %d", maxWriterErrors, broken.calls)
+	}
+	if !orch.writer.(*guardedWriter).discarding() {
+		t.Error("expected the orchestrator writer to be discarding output")
+	}
+}
@@ -47,11 +47,8 @@ func NewLimiter(rate float64, burst int) *Limiter {
 
 // Wait blocks until the rate limiter allows the request or the context is canceled.
 // It returns an error if the context is canceled before the request can proceed.
+// Cooldowns set with Pause are honored even when rate limiting is disabled.
 func (l *Limiter) Wait(ctx context.Context) error {
-	if l.disabled {
-		return nil
-	}
-
 	for {
 		// Respect cooldowns (e.g., server Retry-After).
 		if cooldown := l.cooldownRemaining(); cooldown > 0 {
@@ -63,6 +60,10 @@ func (l *Limiter) Wait(ctx context.Context) error {
 			}
 		}
 
+		if l.disabled {
+			return nil
+		}
+
 		// Try to take a token
 		if l.tryTake() {
 			return nil
@@ -85,14 +86,14 @@ func (l *Limiter) Wait(ctx context.Context) error {
 // Allow checks if a request can proceed immediately without waiting.
 // It returns true if a token is available, false otherwise.
 func (l *Limiter) Allow() bool {
-	if l.disabled {
-		return true
-	}
-
 	if l.cooldownRemaining() > 0 {
 		return false
 	}
 
+	if l.disabled {
+		return true
+	}
+
 	return l.tryTake()
 }
 
@@ -169,7 +170,8 @@ func (l *Limiter) SetBurst(burst int) {
 }
 
 // Pause blocks the limiter for at least the provided duration.
-// Used to honor server-side Retry-After responses.
+// Used to honor server-side Retry-After responses, so it applies even when
+// rate limiting is disabled.
 func (l *Limiter) Pause(d time.Duration) {
 	if d <= 0 {
 		return
@@ -216,6 +218,15 @@ type Stats struct {
 	CooldownRemaining time.Duration
 }
 
+// Limited reports whether a request made now would have to wait, either for a
+// token or for a cooldown set with Pause.
+func (s Stats) Limited() bool {
+	if s.CooldownRemaining > 0 {
+		return true
+	}
+	return !s.Disabled && s.AvailableTokens < 1
+}
+
 // GetStats returns current statistics about the rate limiter.
 func (l *Limiter) GetStats() Stats {
 	l.mu.Lock()
@@ -174,6 +174,29 @@ func TestLimiterPause(t *testing.T) {
 	}
 }
 
+func TestLimiterPauseWhenDisabled(t *testing.T) {
+	limiter := NewLimiter(0, 0) // rate limiting disabled
+
+	limiter.Pause(80 * time.Millisecond)
+
+	if limiter.Allow() {
+		t.Error("expected Allow to be false during pause even when disabled")
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("wait during pause should succeed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+		t.Errorf("expected disabled limiter to honor pause, waited %v", elapsed)
+	}
+
+	// Once the pause expires the disabled limiter allows everything again
+	if !limiter.Allow() {
+		t.Error("expected Allow to be true after pause expired")
+	}
+}
+
 func TestLimiterConcurrent(t *testing.T) {
 	limiter := NewLimiter(100.0, 10) // 100 req/s, burst 10
 	ctx := context.Background()
@@ -281,6 +304,28 @@ func TestLimiterGetStats(t *testing.T) {
 	}
 }
 
+func TestStatsLimited(t *testing.T) {
+	limiter := NewLimiter(0.1, 1)
+	if limiter.GetStats().Limited() {
+		t.Error("expected a full bucket not to be limited")
+	}
+	if !limiter.Allow() {
+		t.Fatal("expected the first request to be allowed")
+	}
+	if !limiter.GetStats().Limited() {
+		t.Error("expected an empty bucket to be limited")
+	}
+
+	disabled := NewLimiter(0, 0)
+	if disabled.GetStats().Limited() {
+		t.Error("expected a disabled limiter not to be limited")
+	}
+	disabled.Pause(time.Minute)
+	if !disabled.GetStats().Limited() {
+		t.Error("expected a cooldown to limit even a disabled limiter")
+	}
+}
+
 func TestLimiterString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -13,7 +16,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/rs/zerolog"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/shawkym/agentpipe/internal/branding"
 	"github.com/shawkym/agentpipe/internal/matrix"
@@ -23,6 +26,7 @@ import (
 	"github.com/shawkym/agentpipe/pkg/log"
 	"github.com/shawkym/agentpipe/pkg/logger"
 	"github.com/shawkym/agentpipe/pkg/orchestrator"
+	"github.com/shawkym/agentpipe/pkg/utils"
 )
 
 type panel int
@@ -49,6 +53,9 @@ type EnhancedModel struct {
 	messages      []agent.Message
 	logMessages   []string
 	activePanel   panel
+	hideLogPanel  bool   // log panel hidden with L for the rest of the session
+	showReasoning bool   // reasoning sections expanded with T
+	statusMessage string // result of the last status bar action (e.g. copy)
 	showModal     bool
 	modalContent  string
 	selectedAgent int
@@ -65,15 +72,26 @@ type EnhancedModel struct {
 	initialized   bool
 	initializing  bool
 	activeAgent   string             // Track which agent is currently responding
+	streamPreview string             // Text streamed so far for the reply in progress
 	chatLogger    *logger.ChatLogger // For logging conversations
 	totalCost     float64            // Track total cost of conversation
 	totalTime     time.Duration      // Track total time of agent requests
 
+	// speakerOffsets holds the conversation line offset of each speaker header,
+	// recomputed on every render so [ and ] can jump between speakers
+	speakerOffsets []int
+
 	// Initialization params
 	skipHealthCheck    bool
 	healthCheckTimeout int
 	configPath         string // Path to config file if used
 
+	// Restart (Ctrl+R) support
+	confirmRestart  bool             // Ctrl+R was pressed; y restarts, any other key cancels
+	run             *conversationRun // the running orchestrator goroutine, stopped before a restart
+	runID           int              // incremented on restart so messages from an earlier run are dropped
+	newOrchestrator func(msgChan chan agent.Message) (*orchestrator.Orchestrator, error)
+
 	// Styles
 	agentColors map[string]lipgloss.Color
 }
@@ -129,6 +147,16 @@ var (
 	helpDescStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("248"))
 
+	// Tool step styles (muted so intermediate steps don't compete with responses)
+	toolStepStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			Italic(true)
+
+	// Reasoning style (dimmer than responses, like tool steps)
+	reasoningStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("243")).
+			Italic(true)
+
 	// Logo panel styles
 	logoPanelStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -155,6 +183,14 @@ var agentColors = []lipgloss.Color{
 	lipgloss.Color("201"), // Magenta
 }
 
+// agentColor returns the agent's configured color, falling back to the rotating palette
+func agentColor(a agent.Agent, index int) lipgloss.Color {
+	if appearance, ok := a.(agent.Appearance); ok && appearance.GetColor() != "" {
+		return lipgloss.Color(appearance.GetColor())
+	}
+	return agentColors[index%len(agentColors)]
+}
+
 type agentItem struct {
 	agent agent.Agent
 	color lipgloss.Color
@@ -268,7 +304,7 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 		// Agents already initialized
 		items = make([]list.Item, len(agents))
 		for i, a := range agents {
-			color := agentColors[i%len(agentColors)]
+			color := agentColor(a, i)
 			agentColorMap[a.GetName()] = color
 			items[i] = agentItem{
 				agent: a,
@@ -311,13 +347,7 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 	ta.Focus()
 
 	// Create orchestrator configuration
-	orchConfig := orchestrator.OrchestratorConfig{
-		Mode:          orchestrator.ConversationMode(cfg.Orchestrator.Mode),
-		TurnTimeout:   cfg.Orchestrator.TurnTimeout,
-		MaxTurns:      cfg.Orchestrator.MaxTurns,
-		ResponseDelay: cfg.Orchestrator.ResponseDelay,
-		InitialPrompt: cfg.Orchestrator.InitialPrompt,
-	}
+	orchConfig := orchestrator.ConfigFrom(cfg)
 
 	// Only set a default timeout if none was configured
 	if orchConfig.TurnTimeout == 0 {
@@ -337,15 +367,9 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 	}
 
 	// Reinitialize the logger to use our custom writer in TUI mode
-	// This will capture all log messages and send them to the log panel
-	log.InitLogger(logWriter, zerolog.InfoLevel, false)
-
-	// Create orchestrator with a writer that sends to our channel
-	orch := orchestrator.NewOrchestrator(orchConfig, &messageWriter{
-		msgChan:        msgChan,
-		buffer:         strings.Builder{},
-		currentContent: strings.Builder{},
-	})
+	// This will capture all log messages and send them to the log panel,
+	// keeping the level chosen at startup (--log-level)
+	log.InitLogger(logWriter, log.GetLevel(), false)
 
 	// Set up logging if enabled
 	var chatLogger *logger.ChatLogger
@@ -356,20 +380,65 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 			// Silently continue without logging in TUI mode to avoid stderr interference
 			chatLogger = nil
 		} else {
-			orch.SetLogger(chatLogger)
+			chatLogger.SetPerAgentLogs(cfg.Logging.PerAgentLogs)
 		}
 	}
 
 	// Set up Matrix (Synapse) integration if enabled
+	var matrixBridge *matrix.Bridge
 	if cfg.Matrix.Enabled {
-		matrixBridge, err := matrix.NewBridge(cfg.Matrix, cfg.Agents)
+		var err error
+		matrixBridge, err = matrix.NewBridge(cfg.Matrix, cfg.Agents)
 		if err != nil {
 			return fmt.Errorf("matrix setup failed: %w", err)
 		}
 		defer matrixBridge.Close()
-		orch.AddMessageHook(matrixBridge.Send)
+	}
+
+	// newOrchestrator builds an orchestrator that writes to msgChan. It runs once here
+	// and again for every restart (Ctrl+R), which needs a fresh conversation.
+	var currentOrch atomic.Pointer[orchestrator.Orchestrator]
+	newOrchestrator := func(msgChan chan agent.Message) (*orchestrator.Orchestrator, error) {
+		orch := orchestrator.NewOrchestrator(orchConfig, &messageWriter{
+			msgChan:        msgChan,
+			buffer:         strings.Builder{},
+			currentContent: strings.Builder{},
+		})
+
+		// Add the middleware the config asks for
+		chain, err := orchestrator.MiddlewareFrom(cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range chain {
+			orch.AddMiddleware(m)
+		}
+
+		if chatLogger != nil {
+			orch.SetLogger(chatLogger)
+		}
+		if cfg.Orchestrator.StreamResponses {
+			orch.SetStreamWriter(&streamWriter{msgChan: msgChan})
+		}
+		if matrixBridge != nil {
+			orch.AddContextMessageHook(matrixBridge.Send)
+		}
+
+		currentOrch.Store(orch)
+		return orch, nil
+	}
+
+	orch, err := newOrchestrator(msgChan)
+	if err != nil {
+		if chatLogger != nil {
+			chatLogger.Close()
+		}
+		return err
+	}
+	if matrixBridge != nil {
+		// Matrix messages go to whichever conversation is running, including after a restart
 		matrixBridge.Start(ctx, func(msg agent.Message) {
-			orch.InjectMessage(msg)
+			currentOrch.Load().InjectMessage(msg)
 		})
 	}
 
@@ -392,13 +461,23 @@ func RunEnhanced(ctx context.Context, cfg *config.Config, agents []agent.Agent,
 		healthCheckTimeout: healthCheckTimeout,
 		chatLogger:         chatLogger,
 		configPath:         configPath,
+		run:                &conversationRun{},
+		newOrchestrator:    newOrchestrator,
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
-	_, err := p.Run()
-
-	// Close the message channel to signal cleanup
-	close(msgChan)
+	final, err := p.Run()
+
+	// Stop the conversation and close the message channel of the current run to
+	// signal cleanup; after a restart that is no longer the channel created above.
+	// A conversation that won't stop may still write to it, so it is left open then.
+	var current chan<- agent.Message = msgChan
+	if fm, ok := final.(EnhancedModel); ok {
+		current = fm.msgSendChan
+	}
+	if m.run.stop() {
+		close(current)
+	}
 
 	// Close the log channel
 	close(logChan)
@@ -491,7 +570,7 @@ func (m EnhancedModel) waitForMessage() tea.Cmd {
 		// Check if there's a message waiting
 		select {
 		case msg := <-m.msgChan:
-			return messageUpdate{message: msg}
+			return messageUpdate{message: msg, run: m.runID}
 		case <-time.After(100 * time.Millisecond):
 			// No message, return a tick to check again
 			return tickMsg{}
@@ -541,10 +620,27 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.confirmRestart && msg.String() != "ctrl+c" {
+			m.confirmRestart = false
+			if msg.String() == "y" || msg.String() == "Y" {
+				m.statusMessage = "Restarting conversation..."
+				return m, m.restartConversation()
+			}
+			m.statusMessage = "Restart cancelled"
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 
+		case "ctrl+r":
+			// Restart from the initial prompt with the same agents, after confirmation
+			if m.initialized && m.newOrchestrator != nil {
+				m.confirmRestart = true
+				m.statusMessage = "Restart the conversation from the initial prompt? (y/N)"
+			}
+
 		case "tab":
 			// Cycle through panels
 			m.activePanel = (m.activePanel + 1) % 3
@@ -575,7 +671,11 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if m.activePanel == inputPanel {
 				// Only send if there's actual content (not just the prompt)
 				content := strings.TrimSpace(strings.TrimPrefix(m.userInput.Value(), ">"))
-				if content != "" {
+				if isFocusCommand(content) {
+					m.statusMessage = m.runFocusCommand(content)
+					m.userInput.Reset()
+					m.userInput.CursorStart()
+				} else if content != "" {
 					// Send user message
 					cmds = append(cmds, m.sendUserMessage())
 					// Clear the input and reset cursor
@@ -607,59 +707,51 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.activePanel == conversationPanel {
 				m.conversation.HalfPageDown()
 			}
-		}
 
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-
-		// Calculate panel dimensions with room for borders (swapped: chat on left, agents on right)
-		rightWidth := 33                         // Fixed width for agents/stats panels (reduced)
-		leftWidth := msg.Width - rightWidth - 11 // Chat/input takes remaining width (increased by 1)
-
-		// Account for topic panel if present
-		topicHeight := 0
-		if m.config.Orchestrator.InitialPrompt != "" {
-			topicHeight = 4 // 3 for content + 1 for spacing (reduced by 2)
-		}
-
-		// Log panel height (fixed at 5 lines)
-		logHeight := 5
-
-		// Adjust conversation height to make room for log panel
-		convHeight := msg.Height - 20 - topicHeight - logHeight - 2 // Account for log panel and spacing
-
-		if !m.ready {
-			// Initialize viewports with size (now using leftWidth for conversation)
-			m.conversation = viewport.New(leftWidth-2, convHeight)
-			m.conversation.SetContent(m.renderConversation())
-
-			// Initialize log panel viewport
-			m.logPanel = viewport.New(leftWidth-2, logHeight)
-			m.logPanel.SetContent(m.renderLogPanel())
-
-			m.agentList.SetSize(rightWidth-2, (msg.Height-6)/2)
-
-			m.userInput.SetWidth(leftWidth - 4)
-			m.userInput.SetHeight(2)
+		case "[":
+			if m.activePanel == conversationPanel {
+				if offset, ok := prevSpeakerOffset(m.speakerOffsets, m.conversation.YOffset); ok {
+					m.conversation.SetYOffset(offset)
+				}
+			}
 
-			m.ready = true
-		} else {
-			// Update sizes on resize (swapped dimensions)
-			m.conversation.Width = leftWidth - 2
-			m.conversation.Height = convHeight
-			m.conversation.SetContent(m.renderConversation())
+		case "]":
+			if m.activePanel == conversationPanel {
+				if offset, ok := nextSpeakerOffset(m.speakerOffsets, m.conversation.YOffset); ok {
+					m.conversation.SetYOffset(offset)
+				}
+			}
 
-			// Update log panel size
-			m.logPanel.Width = leftWidth - 2
-			m.logPanel.Height = logHeight
-			m.logPanel.SetContent(m.renderLogPanel())
+		case "Y":
+			// Copy the transcript to the clipboard
+			if m.activePanel != inputPanel {
+				m.statusMessage = copyTranscript(m.messages, resolveTimeFormat(m.config))
+			}
 
-			m.agentList.SetSize(rightWidth-2, (msg.Height-6)/2)
+		case "T":
+			// Expand or collapse the reasoning of reasoning models
+			if m.activePanel != inputPanel {
+				m.showReasoning = !m.showReasoning
+				if m.ready {
+					m.conversation.SetContent(m.renderConversation())
+				}
+			}
 
-			m.userInput.SetWidth(leftWidth - 4)
+		case "L":
+			// Toggle the log panel, giving its space to the conversation while hidden
+			if m.activePanel != inputPanel {
+				m.hideLogPanel = !m.hideLogPanel
+				if m.ready {
+					m.resizePanels()
+				}
+			}
 		}
 
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.resizePanels()
+
 	case agentInitMsg:
 		// Add initialization message to chat
 		initMsg := agent.Message{
@@ -698,7 +790,7 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update agent list
 		items := make([]list.Item, len(m.agents))
 		for i, a := range m.agents {
-			color := agentColors[i%len(agentColors)]
+			color := agentColor(a, i)
 			m.agentColors[a.GetName()] = color
 			items[i] = agentItem{
 				agent: a,
@@ -725,10 +817,23 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Start the conversation
 		cmds = append(cmds, m.startConversation(), m.waitForMessage())
 
+	case conversationRestarted:
+		m.resetConversation(msg)
+		cmds = append(cmds, m.startConversation(), m.waitForMessage())
+
 	case messageUpdate:
+		if msg.run != m.runID {
+			// Left over from a conversation that was restarted
+			break
+		}
 		if msg.message.Role == "active" {
 			// This is just an indicator that an agent is actively typing
 			m.activeAgent = msg.message.AgentName
+		} else if msg.message.Role == "stream" {
+			// Part of a reply still being generated; the full message follows
+			m.streamPreview += msg.message.Content
+			m.conversation.SetContent(m.renderConversation())
+			m.conversation.GotoBottom()
 		} else {
 			// Regular message
 			m.messages = append(m.messages, msg.message)
@@ -740,6 +845,7 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Track turn count and cost for agent messages (not system/error messages)
 			if msg.message.Role == "agent" {
+				m.streamPreview = ""
 				m.turnCount++
 				// Clear active agent when message is complete
 				if msg.message.AgentName == m.activeAgent {
@@ -823,11 +929,115 @@ func (m EnhancedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// logPanelHeight is the number of log lines shown in the log panel
+const logPanelHeight = 5
+
+// conversationHeight returns the conversation viewport height for a window height.
+// Hiding the log panel gives its lines and border to the conversation.
+func conversationHeight(windowHeight, topicHeight int, showLogs bool) int {
+	height := windowHeight - 20 - topicHeight
+	if showLogs {
+		height -= logPanelHeight + 2 // Log panel and its border
+	}
+	if height < 1 {
+		height = 1
+	}
+	return height
+}
+
+const (
+	// minTerminalWidth leaves room for the agents panel and a usable conversation panel
+	minTerminalWidth = 80
+	// minConversationHeight is the fewest conversation lines worth laying out
+	minConversationHeight = 3
+)
+
+// minTerminalSize returns the smallest window the full layout fits in.
+// The topic and log panels each need extra rows.
+func minTerminalSize(hasTopic, showLogs bool) (width, height int) {
+	height = 20 + minConversationHeight
+	if hasTopic {
+		height += 4
+	}
+	if showLogs {
+		height += logPanelHeight + 2
+	}
+	return minTerminalWidth, height
+}
+
+// terminalTooSmall reports whether the window is below the minimum size for the layout
+func (m EnhancedModel) terminalTooSmall() bool {
+	minWidth, minHeight := minTerminalSize(m.config.Orchestrator.InitialPrompt != "", !m.hideLogPanel)
+	return m.width < minWidth || m.height < minHeight
+}
+
+// renderTooSmall explains that the window must grow before the layout can be drawn
+func (m EnhancedModel) renderTooSmall() string {
+	minWidth, minHeight := minTerminalSize(m.config.Orchestrator.InitialPrompt != "", !m.hideLogPanel)
+	msg := fmt.Sprintf("Terminal too small (need at least %dx%d, have %dx%d)", minWidth, minHeight, m.width, m.height)
+	if !m.hideLogPanel {
+		msg += "\nPress L to hide the log panel and save space"
+	}
+	msg += "\nPress q to quit"
+	return msg
+}
+
+// resizePanels lays out the panels for the current window size and log panel visibility
+func (m *EnhancedModel) resizePanels() {
+	// Calculate panel dimensions with room for borders (swapped: chat on left, agents on right)
+	rightWidth := 33                       // Fixed width for agents/stats panels (reduced)
+	leftWidth := m.width - rightWidth - 11 // Chat/input takes remaining width (increased by 1)
+
+	// Account for topic panel if present
+	topicHeight := 0
+	if m.config.Orchestrator.InitialPrompt != "" {
+		topicHeight = 4 // 3 for content + 1 for spacing (reduced by 2)
+	}
+
+	convHeight := conversationHeight(m.height, topicHeight, !m.hideLogPanel)
+
+	if !m.ready {
+		// Initialize viewports with size (now using leftWidth for conversation)
+		m.conversation = viewport.New(leftWidth-2, convHeight)
+		m.conversation.SetContent(m.renderConversation())
+
+		// Initialize log panel viewport
+		m.logPanel = viewport.New(leftWidth-2, logPanelHeight)
+		m.logPanel.SetContent(m.renderLogPanel())
+
+		m.agentList.SetSize(rightWidth-2, (m.height-6)/2)
+
+		m.userInput.SetWidth(leftWidth - 4)
+		m.userInput.SetHeight(2)
+
+		m.ready = true
+	} else {
+		// Update sizes on resize (swapped dimensions)
+		m.conversation.Width = leftWidth - 2
+		m.conversation.Height = convHeight
+		m.conversation.SetContent(m.renderConversation())
+
+		// Update log panel size
+		m.logPanel.Width = leftWidth - 2
+		m.logPanel.Height = logPanelHeight
+		m.logPanel.SetContent(m.renderLogPanel())
+
+		m.agentList.SetSize(rightWidth-2, (m.height-6)/2)
+
+		m.userInput.SetWidth(leftWidth - 4)
+	}
+}
+
 func (m EnhancedModel) View() string {
 	if !m.ready {
 		return "Initializing AgentPipe TUI..."
 	}
 
+	// Negative panel sizes break rendering, so skip the layout on small windows
+	if m.terminalTooSmall() {
+		return m.renderTooSmall()
+	}
+
 	// Show modal if active
 	if m.showModal {
 		return m.renderModal()
@@ -850,7 +1060,7 @@ func (m EnhancedModel) View() string {
 		// Truncate topic to fit in 2 lines (accounting for width)
 		maxWidth := leftWidth - 4 // Account for padding
 		prompt := m.config.Orchestrator.InitialPrompt
-		lines := wrapText(prompt, maxWidth)
+		lines := utils.WrapText(prompt, maxWidth)
 		lineArray := strings.Split(lines, "\n")
 		if len(lineArray) > 2 {
 			// Take first 2 lines and add ellipsis
@@ -873,19 +1083,19 @@ func (m EnhancedModel) View() string {
 		convPanelStyle = activePanelStyle
 	}
 
-	// Log panel height (fixed at 5 lines)
-	logHeight := 5
-
 	convView := convPanelStyle.
 		Width(leftWidth).
-		Height(m.height - 20 - topicHeight - logHeight - 3). // Account for log panel
+		Height(conversationHeight(m.height, topicHeight, !m.hideLogPanel) - 1).
 		Render(m.conversation.View())
 
 	// Render log panel (between conversation and input)
-	logView := logPanelStyle.
-		Width(leftWidth).
-		Height(logHeight).
-		Render(m.logPanel.View())
+	logView := ""
+	if !m.hideLogPanel {
+		logView = logPanelStyle.
+			Width(leftWidth).
+			Height(logPanelHeight).
+			Render(m.logPanel.View())
+	}
 
 	// Render input panel (now on left)
 	inputPanelStyle := inactiveInputPanelStyle
@@ -943,7 +1153,11 @@ func (m EnhancedModel) View() string {
 	if topicView != "" {
 		leftPanels = append(leftPanels, topicView)
 	}
-	leftPanels = append(leftPanels, convView, logView, inputView)
+	leftPanels = append(leftPanels, convView)
+	if logView != "" {
+		leftPanels = append(leftPanels, logView)
+	}
+	leftPanels = append(leftPanels, inputView)
 
 	left := lipgloss.JoinVertical(lipgloss.Top, leftPanels...)
 
@@ -970,6 +1184,35 @@ func (m EnhancedModel) View() string {
 		))
 }
 
+const (
+	// sidePanelContentWidth is the usable text width of the agents, config and stats panels
+	sidePanelContentWidth = 30
+	// maxAgentTypeWidth caps the agent type column so long types leave room for the name
+	maxAgentTypeWidth = 12
+)
+
+// truncateWidth shortens s to at most width terminal cells, ending it with an ellipsis
+// when it was cut short.
+func truncateWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return ansi.Truncate(s, width, "…")
+}
+
+// alignRow lays out label and value on one line of the given width, with the value
+// right-aligned. An overlong value is truncated first, then the label, so the line
+// never exceeds width.
+func alignRow(label, value string, width int) string {
+	value = truncateWidth(value, width-1)
+	label = truncateWidth(label, width-1-lipgloss.Width(value))
+	spaces := width - lipgloss.Width(label) - lipgloss.Width(value)
+	if spaces < 1 {
+		spaces = 1
+	}
+	return label + strings.Repeat(" ", spaces) + value
+}
+
 func (m *EnhancedModel) renderAgentList() string {
 	var b strings.Builder
 
@@ -977,7 +1220,7 @@ func (m *EnhancedModel) renderAgentList() string {
 	b.WriteString("\n\n") // Add blank line after title
 
 	// Calculate available width for alignment
-	availableWidth := 30 // Adjust based on panel width
+	availableWidth := sidePanelContentWidth
 
 	for i, a := range m.agents {
 		color := m.agentColors[a.GetName()]
@@ -1005,14 +1248,16 @@ func (m *EnhancedModel) renderAgentList() string {
 		}
 		statusDot := lipgloss.NewStyle().Foreground(activeColor).Render("●")
 
-		// Create left-aligned name and right-aligned type
-		name := nameStyle.Render(a.GetName())
-		agentType := typeStyle.Render(a.GetType())
+		// Create left-aligned name and right-aligned type, truncating both so the
+		// line fits the panel even for very long names
+		prefixLen := lipgloss.Width(indicator) + 2 // +2 for status dot and space
+		typeText := truncateWidth(a.GetType(), maxAgentTypeWidth)
+		nameText := truncateWidth(a.GetName(), availableWidth-prefixLen-1-lipgloss.Width(typeText))
+		name := nameStyle.Render(nameText)
+		agentType := typeStyle.Render(typeText)
 
 		// Calculate spacing
-		nameLen := len(a.GetName()) + len(indicator) + 2 // +2 for status dot and space
-		typeLen := len(a.GetType())
-		spaces := availableWidth - nameLen - typeLen
+		spaces := availableWidth - prefixLen - lipgloss.Width(nameText) - lipgloss.Width(typeText)
 		if spaces < 1 {
 			spaces = 1
 		}
@@ -1035,7 +1280,7 @@ func (m *EnhancedModel) renderConfig() string {
 	b.WriteString("\n\n") // Add blank line after title
 
 	// Calculate available width for alignment
-	availableWidth := 30
+	availableWidth := sidePanelContentWidth
 
 	// Show config file if used
 	if m.configPath != "" {
@@ -1059,11 +1304,8 @@ func (m *EnhancedModel) renderConfig() string {
 	}
 
 	for _, item := range items {
-		spaces := availableWidth - len(item.label) - len(item.value)
-		if spaces < 1 {
-			spaces = 1
-		}
-		b.WriteString(fmt.Sprintf("%s%s%s\n", item.label, strings.Repeat(" ", spaces), item.value))
+		b.WriteString(alignRow(item.label, item.value, availableWidth))
+		b.WriteString("\n")
 	}
 
 	if m.config.Logging.Enabled {
@@ -1107,7 +1349,7 @@ func (m *EnhancedModel) renderStats() string {
 	b.WriteString("\n\n") // Add blank line after title
 
 	// Calculate available width for alignment
-	availableWidth := 30
+	availableWidth := sidePanelContentWidth
 
 	// Count connected agents (those that are initialized)
 	connectedAgents := len(m.agents)
@@ -1151,13 +1393,12 @@ func (m *EnhancedModel) renderStats() string {
 	}
 
 	for _, item := range items {
-		spaces := availableWidth - len(item.label) - len(item.value)
-		if spaces < 1 {
-			spaces = 1
-		}
-		b.WriteString(fmt.Sprintf("%s%s%s\n", item.label, strings.Repeat(" ", spaces), item.value))
+		b.WriteString(alignRow(item.label, item.value, availableWidth))
+		b.WriteString("\n")
 	}
 
+	b.WriteString(m.renderRateLimits(availableWidth))
+
 	if m.userTurn {
 		b.WriteString("\n👤 User turn enabled")
 	}
@@ -1165,6 +1406,38 @@ func (m *EnhancedModel) renderStats() string {
 	return b.String()
 }
 
+// renderRateLimits lists the configured rate of each rate-limited agent, flagging
+// agents that are currently waiting for a token or a cooldown. Agents without a
+// rate limit are left out, and nothing is shown when none have one.
+func (m *EnhancedModel) renderRateLimits(width int) string {
+	if m.orch == nil {
+		return ""
+	}
+	stats := m.orch.GetRateLimiterStats()
+
+	var b strings.Builder
+	for _, a := range m.agents {
+		s, ok := stats[a.GetID()]
+		if !ok || (s.Disabled && s.CooldownRemaining <= 0) {
+			continue
+		}
+		if b.Len() == 0 {
+			b.WriteString("\nRate limits:\n")
+		}
+		value := "paused"
+		if !s.Disabled {
+			rate := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", s.Rate), "0"), ".")
+			value = rate + "/s"
+		}
+		if s.Limited() {
+			value = "⏳ " + value
+		}
+		b.WriteString(alignRow(a.GetName(), value, width))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
 func (m *EnhancedModel) renderConversation() string {
 	var b strings.Builder
 
@@ -1175,6 +1448,10 @@ func (m *EnhancedModel) renderConversation() string {
 	}
 
 	lastSpeaker := ""
+	lineCount := 0
+	timeFormat := resolveTimeFormat(m.config)
+	now := time.Now()
+	m.speakerOffsets = m.speakerOffsets[:0]
 
 	for i, msg := range m.messages {
 		// Don't show the initial prompt in the conversation since we have a Topic panel
@@ -1193,6 +1470,8 @@ func (m *EnhancedModel) renderConversation() string {
 			} else {
 				displayName = "System Info" // Changed from "System" to "System Info"
 			}
+		} else if msg.Role == "tool" {
+			displayName = msg.AgentName + " · tool"
 		} else if msg.AgentName == "User" {
 			displayName = "User"
 		} else {
@@ -1204,8 +1483,10 @@ func (m *EnhancedModel) renderConversation() string {
 			// Add newline before header (except for first message)
 			if i > 0 {
 				b.WriteString("\n")
+				lineCount++
 			}
-			timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
+			m.speakerOffsets = append(m.speakerOffsets, lineCount)
+			timestamp := formatMessageTime(msg.Timestamp, timeFormat, now)
 
 			// Get color for agent
 			color := lipgloss.Color("244")
@@ -1227,6 +1508,9 @@ func (m *EnhancedModel) renderConversation() string {
 					b.WriteString(fmt.Sprintf("[%s] ", timestamp))
 					b.WriteString(systemStyle.Render(displayName))
 				}
+			} else if msg.Role == "tool" {
+				b.WriteString(fmt.Sprintf("[%s] ", timestamp))
+				b.WriteString(toolStepStyle.Render(displayName))
 			} else if msg.AgentName == "User" {
 				userStyle := lipgloss.NewStyle().
 					Foreground(lipgloss.Color("226")).
@@ -1250,12 +1534,21 @@ func (m *EnhancedModel) renderConversation() string {
 				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(metricsStr))
 			}
 			b.WriteString("\n")
+			lineCount++
 
 			lastSpeaker = displayName
 		}
 
+		// Reasoning comes before the answer it led to
+		if msg.Reasoning != "" {
+			section := m.renderReasoning(msg.Reasoning, textWidth)
+			lineCount += strings.Count(section, "\n")
+			b.WriteString(section)
+		}
+
 		// Add the message content
-		wrappedContent := wrapText(msg.Content, textWidth)
+		wrappedContent := utils.WrapText(msg.Content, textWidth)
+		lineCount += strings.Count(wrappedContent, "\n")
 
 		// Apply color to content for system messages
 		if msg.Role == "system" {
@@ -1268,6 +1561,8 @@ func (m *EnhancedModel) renderConversation() string {
 			} else {
 				b.WriteString(wrappedContent)
 			}
+		} else if msg.Role == "tool" {
+			b.WriteString(toolStepStyle.Render(wrappedContent))
 		} else {
 			b.WriteString(wrappedContent)
 		}
@@ -1276,47 +1571,46 @@ func (m *EnhancedModel) renderConversation() string {
 		// The spacing for different speakers is handled by the header
 		if i < len(m.messages)-1 {
 			b.WriteString("\n")
+			lineCount++
 		}
 	}
 
+	// Show the reply being streamed until the full message arrives
+	if m.streamPreview != "" {
+		b.WriteString("\n\n")
+		b.WriteString(reasoningStyle.Render(utils.WrapText(strings.TrimSpace(m.streamPreview)+" ▍", textWidth)))
+	}
+
 	return b.String()
 }
 
-// wrapText wraps text to fit within the specified width
-func wrapText(text string, width int) string {
-	if width <= 0 {
-		return text
+// renderReasoning renders a message's reasoning section, ending in a newline: a single
+// summary line while collapsed, or the full reasoning once expanded with T
+func (m *EnhancedModel) renderReasoning(reasoning string, width int) string {
+	if !m.showReasoning {
+		words := len(strings.Fields(reasoning))
+		return reasoningStyle.Render(fmt.Sprintf("▸ Reasoning (%d words, T to expand)", words)) + "\n"
 	}
+	wrapped := utils.WrapText(reasoning, width)
+	return reasoningStyle.Render("▾ Reasoning") + "\n" + reasoningStyle.Render(wrapped) + "\n"
+}
 
-	var result []string
-	lines := strings.Split(text, "\n")
-
-	for _, line := range lines {
-		if len(line) <= width {
-			result = append(result, line)
-			continue
-		}
-
-		// Wrap long lines
-		for len(line) > width {
-			// Find last space before width
-			cutPoint := width
-			for i := width - 1; i > 0; i-- {
-				if line[i] == ' ' {
-					cutPoint = i
-					break
-				}
-			}
-
-			result = append(result, line[:cutPoint])
-			line = strings.TrimSpace(line[cutPoint:])
-		}
-		if len(line) > 0 {
-			result = append(result, line)
-		}
+// prevSpeakerOffset returns the last speaker header offset above the current line
+func prevSpeakerOffset(offsets []int, current int) (int, bool) {
+	idx := sort.SearchInts(offsets, current)
+	if idx == 0 {
+		return 0, false
 	}
+	return offsets[idx-1], true
+}
 
-	return strings.Join(result, "\n")
+// nextSpeakerOffset returns the first speaker header offset below the current line
+func nextSpeakerOffset(offsets []int, current int) (int, bool) {
+	idx := sort.SearchInts(offsets, current+1)
+	if idx >= len(offsets) {
+		return 0, false
+	}
+	return offsets[idx], true
 }
 
 func (m *EnhancedModel) renderLogo() string {
@@ -1341,10 +1635,18 @@ func (m *EnhancedModel) renderStatusBar() string {
 	help := []string{
 		helpKeyStyle.Render("Tab") + helpDescStyle.Render(" Switch panel"),
 		helpKeyStyle.Render("↑↓") + helpDescStyle.Render(" Navigate"),
+		helpKeyStyle.Render("[ ]") + helpDescStyle.Render(" Jump speaker"),
 		helpKeyStyle.Render("Enter") + helpDescStyle.Render(" Select/Send"),
 		helpKeyStyle.Render("Ctrl+U") + helpDescStyle.Render(" User mode"),
+		helpKeyStyle.Render("L") + helpDescStyle.Render(" Logs"),
+		helpKeyStyle.Render("Y") + helpDescStyle.Render(" Copy"),
+		helpKeyStyle.Render("T") + helpDescStyle.Render(" Reasoning"),
+		helpKeyStyle.Render("Ctrl+R") + helpDescStyle.Render(" Restart"),
 		helpKeyStyle.Render("Q") + helpDescStyle.Render(" Quit"),
 	}
+	if m.statusMessage != "" {
+		help = append([]string{m.statusMessage}, help...)
+	}
 
 	return statusBarStyle.
 		Width(m.width).
@@ -1410,17 +1712,96 @@ func (m *EnhancedModel) sendUserMessage() tea.Cmd {
 	}
 }
 
-// messageWriter implements io.Writer to capture orchestrator output
+// isFocusCommand reports whether input typed in the user input panel is a /focus command
+func isFocusCommand(input string) bool {
+	return input == "/focus" || strings.HasPrefix(input, "/focus ")
+}
+
+// parseFocusCommand parses "/focus <agent> <n>". The agent name may contain spaces.
+func parseFocusCommand(input string) (string, int, error) {
+	fields := strings.Fields(strings.TrimPrefix(input, "/focus"))
+	if len(fields) < 2 {
+		return "", 0, fmt.Errorf("usage: /focus <agent> <turns>")
+	}
+	turns, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || turns < 0 {
+		return "", 0, fmt.Errorf("turns must be a non-negative integer")
+	}
+	return strings.Join(fields[:len(fields)-1], " "), turns, nil
+}
+
+// runFocusCommand gives an agent, matched by name or ID, the next turns of the
+// conversation and returns a status bar message describing the outcome
+func (m *EnhancedModel) runFocusCommand(input string) string {
+	name, turns, err := parseFocusCommand(input)
+	if err != nil {
+		return "Focus: " + err.Error()
+	}
+	if m.orch == nil {
+		return "Focus: conversation has not started"
+	}
+
+	for _, a := range m.agents {
+		if !strings.EqualFold(a.GetName(), name) && a.GetID() != name {
+			continue
+		}
+		if err := m.orch.FocusAgent(a.GetID(), turns); err != nil {
+			return "Focus failed: " + err.Error()
+		}
+		if turns == 0 {
+			return "Focus cleared"
+		}
+		return fmt.Sprintf("Focusing %s for %d turns", a.GetName(), turns)
+	}
+	return fmt.Sprintf("Focus: agent '%s' not found", name)
+}
+
+// streamWriter forwards text streamed by the orchestrator (StreamResponses) to the TUI
+// as "stream" messages, which preview the reply in progress. Chunks are dropped rather
+// than block the agent when the channel is full.
+type streamWriter struct {
+	msgChan chan<- agent.Message
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	msg := agent.Message{
+		AgentID:   "_stream",
+		Content:   string(p),
+		Timestamp: time.Now().Unix(),
+		Role:      "stream",
+	}
+	select {
+	case w.msgChan <- msg:
+	default:
+	}
+	return len(p), nil
+}
+
+// messageFlushDelay is how long messageWriter waits for more output from the current
+// speaker before sending the accumulated message to the TUI
+const messageFlushDelay = 250 * time.Millisecond
+
+// messageWriter implements io.Writer to capture orchestrator output.
+// An agent's message is sent once the next "[Speaker]" header arrives or the agent has
+// been quiet for flushDelay, so streamed responses with blank lines stay in one message.
 type messageWriter struct {
+	mu             sync.Mutex
+	flushDelay     time.Duration // Inactivity before flushing (0 = messageFlushDelay)
+	flushTimer     *time.Timer   // Pending inactivity flush
+	flushGen       uint64        // Bumped on every (re)schedule and flush, to spot stale timer callbacks
 	msgChan        chan<- agent.Message
 	buffer         strings.Builder
 	currentAgent   string                 // Track current speaking agent
 	currentContent strings.Builder        // Accumulate content for current agent
 	currentMetrics *agent.ResponseMetrics // Metrics for current message
+	currentRole    string                 // Role for current message ("agent" or "tool")
 	droppedCount   int                    // Track number of dropped messages
 }
 
 func (w *messageWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	content := string(p)
 	w.buffer.WriteString(content)
 
@@ -1440,7 +1821,7 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 		// Check if this line starts a new message
 		if strings.HasPrefix(line, "[") && strings.Contains(line, "]") {
 			// First, send any accumulated content from previous agent
-			w.flushCurrentMessage()
+			w.flushLocked()
 
 			idx := strings.Index(line, "]")
 			if idx > 0 {
@@ -1450,10 +1831,14 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 				// Parse agent name and metrics if present (format: "AgentName|XXXms|XXXt|X.XXXX")
 				var agentName string
 				var metrics *agent.ResponseMetrics
+				role := "agent"
 				if strings.Contains(agentInfo, "|") {
 					parts := strings.Split(agentInfo, "|")
 					agentName = parts[0]
-					if len(parts) >= 3 {
+					if len(parts) == 2 && parts[1] == "tool" {
+						// Intermediate tool step (format: "AgentName|tool")
+						role = "tool"
+					} else if len(parts) >= 3 {
 						// Parse metrics
 						metrics = &agent.ResponseMetrics{}
 						// Parse duration (e.g., "123ms")
@@ -1532,6 +1917,7 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 					// This is an agent message, start accumulating
 					w.currentAgent = agentName
 					w.currentMetrics = metrics
+					w.currentRole = role
 					w.currentContent.Reset()
 					if messageContent != "" {
 						w.currentContent.WriteString(messageContent)
@@ -1560,31 +1946,75 @@ func (w *messageWriter) Write(p []byte) (n int, err error) {
 				}
 			}
 		} else if line == "" && w.currentAgent != "" {
-			// Empty line within an agent's message - preserve it
-			if w.currentContent.Len() > 0 {
-				w.currentContent.WriteString("\n\n")
+			// Empty line within an agent's message - preserve it as a paragraph break
+			// (the next line adds its own newline)
+			if w.currentContent.Len() > 0 && !strings.HasSuffix(w.currentContent.String(), "\n") {
+				w.currentContent.WriteString("\n")
 			}
 		}
 	}
 
-	// Check if we should flush (e.g., if we see certain patterns that indicate end of message)
-	// This helps ensure messages are sent promptly
-	if strings.Contains(content, "\n\n") || strings.HasSuffix(content, "\n") {
-		w.flushCurrentMessage()
-	}
+	// Blank lines don't end a message: wait for the next header or a quiet period
+	w.scheduleFlush()
 
 	return len(p), nil
 }
 
+// scheduleFlush (re)starts the inactivity timer for the current agent's message.
+// The caller must hold w.mu.
+func (w *messageWriter) scheduleFlush() {
+	if w.currentAgent == "" {
+		return
+	}
+	delay := w.flushDelay
+	if delay <= 0 {
+		delay = messageFlushDelay
+	}
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+	}
+	w.flushGen++
+	gen := w.flushGen
+	w.flushTimer = time.AfterFunc(delay, func() { w.flushIdle(gen) })
+}
+
+// flushIdle is the inactivity timer's callback. Stop can't cancel a callback that has
+// already fired and is waiting for w.mu, so it only flushes when no write rescheduled
+// or flushed since its timer was started; otherwise it would cut a newer message short.
+func (w *messageWriter) flushIdle(gen uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if gen != w.flushGen {
+		return
+	}
+	w.flushLocked()
+}
+
 // flushCurrentMessage sends the accumulated message for the current agent
 func (w *messageWriter) flushCurrentMessage() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.flushLocked()
+}
+
+// flushLocked is flushCurrentMessage for callers that already hold w.mu
+func (w *messageWriter) flushLocked() {
+	w.flushGen++
+	if w.flushTimer != nil {
+		w.flushTimer.Stop()
+		w.flushTimer = nil
+	}
 	if w.currentAgent != "" && w.currentContent.Len() > 0 {
+		role := w.currentRole
+		if role == "" {
+			role = "agent"
+		}
 		msg := agent.Message{
 			AgentID:   w.currentAgent,
 			AgentName: w.currentAgent,
 			Content:   strings.TrimSpace(w.currentContent.String()),
 			Timestamp: time.Now().Unix(),
-			Role:      "agent",
+			Role:      role,
 			Metrics:   w.currentMetrics,
 		}
 
@@ -1598,10 +2028,12 @@ func (w *messageWriter) flushCurrentMessage() {
 		w.currentAgent = ""
 		w.currentContent.Reset()
 		w.currentMetrics = nil
+		w.currentRole = ""
 	}
 }
 
 func (m *EnhancedModel) startConversation() tea.Cmd {
+	orch, agents, run, runID, msgSendChan := m.orch, m.agents, m.run, m.runID, m.msgSendChan
 	return func() tea.Msg {
 		// Add initial system message
 		startMsg := agent.Message{
@@ -1613,18 +2045,25 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 		}
 
 		// Add agents to orchestrator and announce them
-		for _, a := range m.agents {
-			m.orch.AddAgent(a)
+		for _, a := range agents {
+			orch.AddAgent(a)
 		}
 
+		// Use a longer timeout context for the entire conversation; a restart cancels it
+		// and skips the summary, since that conversation is being thrown away
+		orchCtx, cancel := context.WithTimeout(m.ctx, 10*time.Minute)
+		done := run.track(func() {
+			orch.SkipSummary()
+			cancel()
+		})
+
 		// Start the orchestrator in a background goroutine
 		// It will write to msgChan through the messageWriter
 		go func() {
-			// Use a longer timeout context for the entire conversation
-			orchCtx, cancel := context.WithTimeout(m.ctx, 10*time.Minute)
+			defer close(done)
 			defer cancel()
 
-			convErr := m.orch.Start(orchCtx)
+			convErr := orch.Start(orchCtx)
 
 			// Send a done message when orchestrator finishes
 			doneMsg := agent.Message{
@@ -1643,7 +2082,7 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 			// Try to send the done message
 			// Use a select to avoid blocking if channel is full/closed
 			select {
-			case m.msgSendChan <- doneMsg:
+			case msgSendChan <- doneMsg:
 				// Message sent successfully
 			default:
 				// Channel full or closed, ignore
@@ -1651,6 +2090,6 @@ func (m *EnhancedModel) startConversation() tea.Cmd {
 		}()
 
 		// Return the initial startup message
-		return messageUpdate{message: startMsg}
+		return messageUpdate{message: startMsg, run: runID}
 	}
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
 )
 
 // MockAgent for testing
@@ -222,6 +223,106 @@ func TestEnhancedModel_Update_WindowSize(t *testing.T) {
 	}
 }
 
+func TestConversationHeight(t *testing.T) {
+	shown := conversationHeight(50, 4, true)
+	hidden := conversationHeight(50, 4, false)
+
+	if shown != 50-20-4-logPanelHeight-2 {
+		t.Errorf("expected conversation height %d with log panel, got %d", 50-20-4-logPanelHeight-2, shown)
+	}
+	if hidden-shown != logPanelHeight+2 {
+		t.Errorf("expected hiding the log panel to reclaim %d lines, got %d", logPanelHeight+2, hidden-shown)
+	}
+}
+
+func TestEnhancedModel_ToggleLogPanel(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{Mode: "round-robin", InitialPrompt: "Test prompt"},
+	}
+	m := createTestEnhancedModel(cfg, conversationPanel, false)
+	m.ready = false
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(EnhancedModel)
+	shownHeight := m.conversation.Height
+	if !strings.Contains(m.View(), "System Logs") {
+		t.Fatal("expected log panel to be visible by default")
+	}
+
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")}
+	updated, _ = m.Update(toggle)
+	m = updated.(EnhancedModel)
+	if !m.hideLogPanel {
+		t.Fatal("expected L to hide the log panel")
+	}
+	if m.conversation.Height != shownHeight+logPanelHeight+2 {
+		t.Errorf("expected conversation height %d with log panel hidden, got %d", shownHeight+logPanelHeight+2, m.conversation.Height)
+	}
+	if strings.Contains(m.View(), "System Logs") {
+		t.Error("expected log panel to be hidden")
+	}
+
+	// The preference survives resizes
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 60})
+	m = updated.(EnhancedModel)
+	if !m.hideLogPanel || m.conversation.Height != conversationHeight(60, 4, false) {
+		t.Errorf("expected log panel to stay hidden after resize, height %d", m.conversation.Height)
+	}
+
+	updated, _ = m.Update(toggle)
+	m = updated.(EnhancedModel)
+	if m.hideLogPanel || m.conversation.Height != conversationHeight(60, 4, true) {
+		t.Errorf("expected L to show the log panel again, height %d", m.conversation.Height)
+	}
+
+	// Typing L in the input panel doesn't toggle
+	m.activePanel = inputPanel
+	updated, _ = m.Update(toggle)
+	if updated.(EnhancedModel).hideLogPanel {
+		t.Error("expected L in the input panel not to toggle the log panel")
+	}
+}
+
+func TestEnhancedModel_TerminalTooSmall(t *testing.T) {
+	tests := []struct {
+		name     string
+		topic    string
+		hideLogs bool
+		width    int
+		height   int
+		want     bool
+	}{
+		{"exact minimum with topic and logs", "Test prompt", false, 80, 34, false},
+		{"one row short", "Test prompt", false, 80, 33, true},
+		{"one column short", "Test prompt", false, 79, 34, true},
+		{"no topic needs fewer rows", "", false, 80, 30, false},
+		{"hidden logs need fewer rows", "Test prompt", true, 80, 27, false},
+		{"hidden logs one row short", "Test prompt", true, 80, 26, true},
+		{"tiny window", "", false, 20, 10, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Orchestrator: config.OrchestratorConfig{Mode: "round-robin", InitialPrompt: tt.topic},
+			}
+			m := createTestEnhancedModel(cfg, conversationPanel, false)
+			m.hideLogPanel = tt.hideLogs
+			m.ready = false
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: tt.width, Height: tt.height})
+			m = updated.(EnhancedModel)
+
+			if got := m.terminalTooSmall(); got != tt.want {
+				t.Fatalf("terminalTooSmall() at %dx%d = %v, want %v", tt.width, tt.height, got, tt.want)
+			}
+			view := m.View()
+			if shown := strings.Contains(view, "Terminal too small"); shown != tt.want {
+				t.Errorf("expected too-small message shown=%v at %dx%d, got view:\n%s", tt.want, tt.width, tt.height, view)
+			}
+		})
+	}
+}
+
 // TestEnhancedModel_Update_MessageUpdate tests message handling
 func TestEnhancedModel_Update_MessageUpdate(t *testing.T) {
 	cfg := &config.Config{
@@ -428,63 +529,6 @@ func TestEnhancedModel_PanelNavigation(t *testing.T) {
 	}
 }
 
-// TestWrapText tests text wrapping
-func TestWrapText(t *testing.T) {
-	tests := []struct {
-		name  string
-		text  string
-		width int
-		want  int // number of lines expected
-	}{
-		{
-			name:  "Short text no wrap",
-			text:  "Hello",
-			width: 20,
-			want:  1,
-		},
-		{
-			name:  "Text exactly at width",
-			text:  "Hello World Here",
-			width: 16,
-			want:  1,
-		},
-		{
-			name:  "Text wraps once",
-			text:  "Hello World This Is A Long Line",
-			width: 20,
-			want:  2,
-		},
-		{
-			name:  "Text with newlines",
-			text:  "Line 1\nLine 2\nLine 3",
-			width: 50,
-			want:  3,
-		},
-		{
-			name:  "Very long word",
-			text:  "Supercalifragilisticexpialidocious",
-			width: 10,
-			want:  4,
-		},
-		{
-			name:  "Zero width",
-			text:  "Hello",
-			width: 0,
-			want:  1,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := wrapText(tt.text, tt.width)
-			lines := strings.Split(result, "\n")
-			if len(lines) != tt.want {
-				t.Errorf("Expected %d lines, got %d\nInput: %q\nResult: %q", tt.want, len(lines), tt.text, result)
-			}
-		})
-	}
-}
-
 // TestEnhancedModel_RenderMethods tests various render methods
 func TestEnhancedModel_RenderAgentList(t *testing.T) {
 	cfg := &config.Config{
@@ -602,6 +646,62 @@ func TestEnhancedModel_RenderStats(t *testing.T) {
 }
 
 // TestEnhancedModel_RenderConversation tests conversation rendering
+func TestEnhancedModel_ToggleReasoning(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{Mode: "round-robin", InitialPrompt: "Test prompt"},
+	}
+	m := createTestEnhancedModel(cfg, conversationPanel, false)
+	m.ready = false
+	m.messages = []agent.Message{{
+		AgentID:   "agent-1",
+		AgentName: "Thinker",
+		Content:   "The answer is tabs.",
+		Reasoning: "Tabs let each reader pick an indent width.",
+		Timestamp: time.Now().Unix(),
+		Role:      "agent",
+	}}
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 50})
+	m = updated.(EnhancedModel)
+
+	collapsed := m.renderConversation()
+	if !strings.Contains(collapsed, "Reasoning (8 words, T to expand)") {
+		t.Errorf("expected a collapsed reasoning summary, got:\n%s", collapsed)
+	}
+	if strings.Contains(collapsed, "pick an indent width") {
+		t.Error("expected the reasoning to be collapsed by default")
+	}
+	if !strings.Contains(collapsed, "The answer is tabs.") {
+		t.Error("expected the answer to be shown")
+	}
+
+	toggle := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")}
+	updated, _ = m.Update(toggle)
+	m = updated.(EnhancedModel)
+	if !m.showReasoning {
+		t.Fatal("expected T to expand the reasoning")
+	}
+	expanded := m.renderConversation()
+	if !strings.Contains(expanded, "pick an indent width") || !strings.Contains(expanded, "The answer is tabs.") {
+		t.Errorf("expected the reasoning and the answer, got:\n%s", expanded)
+	}
+	if strings.Index(expanded, "pick an indent width") > strings.Index(expanded, "The answer is tabs.") {
+		t.Error("expected the reasoning before the answer")
+	}
+
+	updated, _ = m.Update(toggle)
+	if updated.(EnhancedModel).showReasoning {
+		t.Error("expected T to collapse the reasoning again")
+	}
+
+	// Typing T in the input panel doesn't toggle
+	m.activePanel = inputPanel
+	updated, _ = m.Update(toggle)
+	if !updated.(EnhancedModel).showReasoning {
+		t.Error("expected T in the input panel not to toggle the reasoning")
+	}
+}
+
 func TestEnhancedModel_RenderConversation(t *testing.T) {
 	cfg := &config.Config{
 		Orchestrator: config.OrchestratorConfig{
@@ -664,6 +764,85 @@ func TestEnhancedModel_RenderConversation(t *testing.T) {
 	}
 }
 
+// TestEnhancedModel_SpeakerOffsets tests that speaker header offsets point at header lines
+func TestEnhancedModel_SpeakerOffsets(t *testing.T) {
+	now := time.Now().Unix()
+	messages := []agent.Message{
+		{AgentID: "a", AgentName: "Alice", Content: "first", Timestamp: now, Role: "agent"},
+		{AgentID: "a", AgentName: "Alice", Content: "second\nline", Timestamp: now, Role: "agent"},
+		{AgentID: "b", AgentName: "Bob", Content: "reply", Timestamp: now, Role: "agent"},
+		{AgentID: "info", AgentName: "System", Content: "notice", Timestamp: now, Role: "system"},
+		{AgentID: "a", AgentName: "Alice", Content: "back again", Timestamp: now, Role: "agent"},
+	}
+
+	m := EnhancedModel{
+		config:      &config.Config{},
+		messages:    messages,
+		agentColors: map[string]lipgloss.Color{},
+	}
+	m.conversation.Width = 80
+
+	rendered := m.renderConversation()
+	lines := strings.Split(rendered, "\n")
+
+	expected := []int{0, 5, 8, 11}
+	if len(m.speakerOffsets) != len(expected) {
+		t.Fatalf("Expected %d speaker offsets, got %v", len(expected), m.speakerOffsets)
+	}
+
+	names := []string{"Alice", "Bob", "System Info", "Alice"}
+	for i, offset := range m.speakerOffsets {
+		if offset != expected[i] {
+			t.Errorf("Offset %d: expected %d, got %d", i, expected[i], offset)
+		}
+		if offset >= len(lines) || !strings.Contains(lines[offset], names[i]) {
+			t.Errorf("Expected line %d to be the %s header, got %q", offset, names[i], lines[offset])
+		}
+	}
+
+	// Re-rendering must not accumulate offsets
+	m.renderConversation()
+	if len(m.speakerOffsets) != len(expected) {
+		t.Errorf("Expected offsets to be recomputed on render, got %v", m.speakerOffsets)
+	}
+}
+
+// TestSpeakerOffsetNavigation tests jumping between speaker boundaries
+func TestSpeakerOffsetNavigation(t *testing.T) {
+	offsets := []int{0, 5, 8, 11}
+
+	tests := []struct {
+		name     string
+		current  int
+		wantPrev int
+		prevOK   bool
+		wantNext int
+		nextOK   bool
+	}{
+		{name: "at top", current: 0, prevOK: false, wantNext: 5, nextOK: true},
+		{name: "between boundaries", current: 6, wantPrev: 5, prevOK: true, wantNext: 8, nextOK: true},
+		{name: "on a boundary", current: 8, wantPrev: 5, prevOK: true, wantNext: 11, nextOK: true},
+		{name: "past last boundary", current: 20, wantPrev: 11, prevOK: true, nextOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev, ok := prevSpeakerOffset(offsets, tt.current)
+			if ok != tt.prevOK || (ok && prev != tt.wantPrev) {
+				t.Errorf("prevSpeakerOffset(%d) = %d, %v; want %d, %v", tt.current, prev, ok, tt.wantPrev, tt.prevOK)
+			}
+			next, ok := nextSpeakerOffset(offsets, tt.current)
+			if ok != tt.nextOK || (ok && next != tt.wantNext) {
+				t.Errorf("nextSpeakerOffset(%d) = %d, %v; want %d, %v", tt.current, next, ok, tt.wantNext, tt.nextOK)
+			}
+		})
+	}
+
+	if _, ok := nextSpeakerOffset(nil, 0); ok {
+		t.Error("Expected no next offset for an empty conversation")
+	}
+}
+
 // TestMessageWriter tests the messageWriter implementation
 func TestMessageWriter_Write(t *testing.T) {
 	msgChan := make(chan agent.Message, 100)
@@ -809,6 +988,51 @@ Third line
 	}
 }
 
+// TestMessageWriter_ToolStep tests that tool steps are parsed with the tool role
+func TestMessageWriter_ToolStep(t *testing.T) {
+	msgChan := make(chan agent.Message, 10)
+	w := &messageWriter{msgChan: msgChan}
+
+	if _, err := w.Write([]byte("\n[Amp|tool] Bash {\"cmd\":\"ls\"}\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := w.Write([]byte("\n[Amp|120ms|50t|0.0010] Found one file\n")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tool := <-msgChan
+	if tool.Role != "tool" || tool.AgentName != "Amp" || !strings.Contains(tool.Content, "Bash") {
+		t.Errorf("Expected Amp tool step, got %+v", tool)
+	}
+
+	response := <-msgChan
+	if response.Role != "agent" || response.Content != "Found one file" {
+		t.Errorf("Expected agent response after tool step, got %+v", response)
+	}
+}
+
+// TestEnhancedModel_RenderToolStep tests that tool steps get their own muted header
+func TestEnhancedModel_RenderToolStep(t *testing.T) {
+	now := time.Now().Unix()
+	m := EnhancedModel{
+		config: &config.Config{},
+		messages: []agent.Message{
+			{AgentID: "amp-1", AgentName: "Amp", Role: "tool", Content: "Bash ls", Timestamp: now},
+			{AgentID: "amp-1", AgentName: "Amp", Role: "agent", Content: "Found one file", Timestamp: now},
+		},
+		agentColors: map[string]lipgloss.Color{},
+	}
+	m.conversation.Width = 80
+
+	rendered := m.renderConversation()
+	if !strings.Contains(rendered, "Amp · tool") {
+		t.Errorf("Expected tool step header, got:\n%s", rendered)
+	}
+	if len(m.speakerOffsets) != 2 {
+		t.Errorf("Expected the response to start a new speaker block after the tool step, got %v", m.speakerOffsets)
+	}
+}
+
 // TestEnhancedModel_View tests the main view rendering
 func TestEnhancedModel_View(t *testing.T) {
 	tests := []struct {
@@ -902,14 +1126,6 @@ func TestMessageWriter_BufferHandling(t *testing.T) {
 }
 
 // Benchmark tests
-func BenchmarkWrapText(b *testing.B) {
-	text := strings.Repeat("Hello World ", 100)
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		wrapText(text, 80)
-	}
-}
-
 func BenchmarkMessageWriter_Write(b *testing.B) {
 	msgChan := make(chan agent.Message, 1000)
 	w := &messageWriter{
@@ -995,3 +1211,323 @@ func TestMessageWriter_FlushOnDoubleNewline(t *testing.T) {
 		t.Error("Expected message to be flushed on double newline")
 	}
 }
+
+// TestMessageWriter_StaleFlushTimerIsIgnored tests that an inactivity timer that fired while
+// a write held the lock doesn't flush the message that write rescheduled
+func TestMessageWriter_StaleFlushTimerIsIgnored(t *testing.T) {
+	msgChan := make(chan agent.Message, 100)
+	w := &messageWriter{msgChan: msgChan, flushDelay: time.Millisecond}
+
+	w.mu.Lock()
+	w.currentAgent = "Agent1"
+	w.currentContent.WriteString("First part")
+	w.scheduleFlush()
+	// Let the timer fire; its callback now waits for the lock
+	time.Sleep(20 * time.Millisecond)
+	w.flushDelay = time.Hour
+	w.currentContent.WriteString(" and more")
+	w.scheduleFlush()
+	w.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	if len(msgChan) != 0 {
+		t.Fatalf("expected the stale timer not to flush, got %+v", <-msgChan)
+	}
+
+	w.flushCurrentMessage()
+	if msg := <-msgChan; msg.Content != "First part and more" {
+		t.Errorf("expected the whole message, got %q", msg.Content)
+	}
+}
+
+// TestMessageWriter_StreamedBlankLinesStayInOneMessage tests that a response streamed across
+// several writes with internal blank lines is sent as one message
+func TestMessageWriter_StreamedBlankLinesStayInOneMessage(t *testing.T) {
+	msgChan := make(chan agent.Message, 100)
+	w := &messageWriter{msgChan: msgChan, flushDelay: time.Hour}
+
+	for _, chunk := range []string{
+		"[Agent1] Paragraph one\n\n",
+		"Paragraph two\n\n",
+		"- item\n",
+		"[Agent2] Reply\n",
+	} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	var got []agent.Message
+	for len(msgChan) > 0 {
+		if msg := <-msgChan; msg.AgentID != "_active" {
+			got = append(got, msg)
+		}
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected exactly one flushed message, got %d: %+v", len(got), got)
+	}
+	if got[0].AgentName != "Agent1" {
+		t.Errorf("Expected Agent1, got %s", got[0].AgentName)
+	}
+	if want := "Paragraph one\n\nParagraph two\n\n- item"; got[0].Content != want {
+		t.Errorf("Expected content %q, got %q", want, got[0].Content)
+	}
+}
+
+// TestMessageWriter_FlushAfterInactivity tests that the last message is sent once the agent goes quiet
+func TestMessageWriter_FlushAfterInactivity(t *testing.T) {
+	msgChan := make(chan agent.Message, 100)
+	w := &messageWriter{msgChan: msgChan, flushDelay: 20 * time.Millisecond}
+
+	w.Write([]byte("[Agent1] First part\n\n"))
+	w.Write([]byte("Second part\n"))
+
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case msg := <-msgChan:
+			if msg.AgentID == "_active" {
+				continue
+			}
+			if msg.Content != "First part\n\nSecond part" {
+				t.Errorf("Unexpected content %q", msg.Content)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Expected message to be flushed after inactivity")
+		}
+	}
+}
+
+// coloredMockAgent is a MockAgent with a configured display color
+type coloredMockAgent struct {
+	MockAgent
+	color string
+}
+
+func (m *coloredMockAgent) GetAvatar() string { return "" }
+func (m *coloredMockAgent) GetColor() string  { return m.color }
+
+func TestAgentColorPrefersConfiguredColor(t *testing.T) {
+	plain := &MockAgent{id: "a1", name: "Plain"}
+	if got := agentColor(plain, 1); got != agentColors[1] {
+		t.Errorf("expected palette color %v, got %v", agentColors[1], got)
+	}
+
+	colored := &coloredMockAgent{MockAgent: MockAgent{id: "a2", name: "Colored"}, color: "#ff87d7"}
+	if got := agentColor(colored, 1); got != lipgloss.Color("#ff87d7") {
+		t.Errorf("expected configured color, got %v", got)
+	}
+
+	unset := &coloredMockAgent{MockAgent: MockAgent{id: "a3", name: "Unset"}}
+	if got := agentColor(unset, 2); got != agentColors[2] {
+		t.Errorf("expected palette fallback when no color is configured, got %v", got)
+	}
+}
+
+func TestEnhancedModel_LongAgentNamesFitPanel(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{Mode: "round-robin", MaxTurns: 10},
+	}
+	m := createTestEnhancedModel(cfg, agentsPanel, false)
+	m.agents = []agent.Agent{
+		&MockAgent{id: "1", name: "An Extraordinarily Verbose Agent Name That Never Ends", agentType: "claude", available: true},
+		&MockAgent{id: "2", name: "Short", agentType: "a-very-long-custom-agent-type", available: true},
+		&MockAgent{id: "3", name: "Bob", agentType: "gemini", available: true},
+	}
+	m.selectedAgent = 0
+
+	list := m.renderAgentList()
+	for _, line := range strings.Split(list, "\n") {
+		if w := lipgloss.Width(line); w > sidePanelContentWidth {
+			t.Errorf("agent line is %d cells wide, want at most %d: %q", w, sidePanelContentWidth, line)
+		}
+	}
+	if !strings.Contains(list, "An Extraordinarily") || !strings.Contains(list, "…") {
+		t.Errorf("expected the long name to be truncated with an ellipsis, got:\n%s", list)
+	}
+	if !strings.Contains(list, "Bob") || !strings.Contains(list, "gemini") {
+		t.Errorf("expected short names and types to be untouched, got:\n%s", list)
+	}
+
+	for _, line := range strings.Split(m.renderStats(), "\n") {
+		if w := lipgloss.Width(line); w > sidePanelContentWidth {
+			t.Errorf("stats line is %d cells wide, want at most %d: %q", w, sidePanelContentWidth, line)
+		}
+	}
+}
+
+func TestAlignRow(t *testing.T) {
+	tests := []struct {
+		name, label, value string
+		width              int
+		want               string
+	}{
+		{"fits", "Mode:", "round-robin", 20, "Mode:    round-robin"},
+		{"long value", "Mode:", "an-extremely-long-mode-name", 20, " an-extremely-long-…"},
+		{"long label", "A very long label:", "42", 12, "A very l… 42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alignRow(tt.label, tt.value, tt.width); got != tt.want {
+				t.Errorf("alignRow(%q, %q, %d) = %q, want %q", tt.label, tt.value, tt.width, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFocusCommand(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantName  string
+		wantTurns int
+		wantErr   bool
+	}{
+		{input: "/focus Expert 3", wantName: "Expert", wantTurns: 3},
+		{input: "/focus Senior Expert 2", wantName: "Senior Expert", wantTurns: 2},
+		{input: "/focus Expert 0", wantName: "Expert", wantTurns: 0},
+		{input: "/focus", wantErr: true},
+		{input: "/focus Expert", wantErr: true},
+		{input: "/focus Expert many", wantErr: true},
+		{input: "/focus Expert -1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			name, turns, err := parseFocusCommand(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFocusCommand(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && (name != tt.wantName || turns != tt.wantTurns) {
+				t.Errorf("parseFocusCommand(%q) = %q, %d; want %q, %d", tt.input, name, turns, tt.wantName, tt.wantTurns)
+			}
+		})
+	}
+}
+
+func TestEnhancedModel_FocusCommand(t *testing.T) {
+	expert := &MockAgent{id: "expert-1", name: "Senior Expert", agentType: "mock", available: true}
+	other := &MockAgent{id: "other-1", name: "Other", agentType: "mock", available: true}
+
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+		Mode:          orchestrator.ModeReactive,
+		MaxTurns:      2,
+		TurnTimeout:   time.Second,
+		ResponseDelay: time.Millisecond,
+	}, nil)
+	orch.AddAgent(expert)
+	orch.AddAgent(other)
+
+	m := createTestEnhancedModel(config.NewDefaultConfig(), inputPanel, false)
+	m.agents = []agent.Agent{expert, other}
+	m.orch = orch
+
+	m.userInput.SetValue("/focus senior expert 2")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(EnhancedModel)
+
+	if m.statusMessage != "Focusing Senior Expert for 2 turns" {
+		t.Errorf("unexpected status message %q", m.statusMessage)
+	}
+	if m.userInput.Value() != "" {
+		t.Errorf("expected input to be cleared, got %q", m.userInput.Value())
+	}
+
+	// The reactive selector never picks the last speaker twice, so two turns in a
+	// row from the same agent can only come from the focus
+	if err := orch.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var speakers []string
+	for _, msg := range orch.GetMessages() {
+		if msg.Role == "agent" {
+			speakers = append(speakers, msg.AgentID)
+		}
+	}
+	if strings.Join(speakers, ",") != "expert-1,expert-1" {
+		t.Errorf("expected the focused agent to take both turns, got %v", speakers)
+	}
+
+	m.userInput.SetValue("/focus Nobody 2")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if status := updated.(EnhancedModel).statusMessage; status != "Focus: agent 'Nobody' not found" {
+		t.Errorf("unexpected status message %q", status)
+	}
+}
+
+func TestEnhancedModel_RenderRateLimits(t *testing.T) {
+	limited := &MockAgent{id: "limited", name: "Limited", agentType: "mock", available: true}
+	free := &MockAgent{id: "free", name: "Free", agentType: "mock", available: true}
+
+	orch := orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{Mode: orchestrator.ModeRoundRobin}, nil)
+	orch.AddAgent(&rateLimitedMockAgent{MockAgent: limited, rate: 0.5})
+	orch.AddAgent(free)
+
+	m := createTestEnhancedModel(config.NewDefaultConfig(), conversationPanel, false)
+	m.agents = []agent.Agent{limited, free}
+	m.orch = orch
+
+	out := m.renderRateLimits(sidePanelContentWidth)
+	if !strings.Contains(out, "Rate limits:") || !strings.Contains(out, "Limited") || !strings.Contains(out, "0.5/s") {
+		t.Errorf("expected the limited agent's rate, got %q", out)
+	}
+	if strings.Contains(out, "Free") {
+		t.Errorf("expected agents without a rate limit to be left out, got %q", out)
+	}
+	if strings.Contains(out, "⏳") {
+		t.Errorf("expected no waiting marker before any request, got %q", out)
+	}
+	if !strings.Contains(m.renderStats(), "0.5/s") {
+		t.Error("expected the stats panel to include rate limits")
+	}
+
+	m.orch = nil
+	if out := m.renderRateLimits(sidePanelContentWidth); out != "" {
+		t.Errorf("expected nothing before the conversation starts, got %q", out)
+	}
+}
+
+// rateLimitedMockAgent is a MockAgent with a configured rate limit
+type rateLimitedMockAgent struct {
+	*MockAgent
+	rate float64
+}
+
+func (m *rateLimitedMockAgent) GetRateLimit() float64 { return m.rate }
+
+func TestEnhancedModel_StreamPreview(t *testing.T) {
+	cfg := &config.Config{Orchestrator: config.OrchestratorConfig{StreamResponses: true}}
+	m := EnhancedModel{
+		ctx:         context.Background(),
+		config:      cfg,
+		messages:    make([]agent.Message, 0),
+		agentColors: make(map[string]lipgloss.Color),
+		agentList:   list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0),
+		userInput:   textarea.New(),
+	}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	m = updated.(EnhancedModel)
+
+	msgChan := make(chan agent.Message, 10)
+	w := &streamWriter{msgChan: msgChan}
+	io.WriteString(w, "Streaming ")
+	io.WriteString(w, "in progress")
+	close(msgChan)
+	for msg := range msgChan {
+		updated, _ = m.Update(messageUpdate{message: msg})
+		m = updated.(EnhancedModel)
+	}
+
+	if len(m.messages) != 0 {
+		t.Errorf("expected streamed chunks not to be added as messages, got %v", m.messages)
+	}
+	if !strings.Contains(m.renderConversation(), "Streaming in progress") {
+		t.Error("expected the streamed text to be previewed")
+	}
+
+	updated, _ = m.Update(messageUpdate{message: agent.Message{AgentName: "Alice", Content: "Streaming in progress", Role: "agent"}})
+	m = updated.(EnhancedModel)
+	if m.streamPreview != "" || len(m.messages) != 1 {
+		t.Errorf("expected the full message to replace the preview, got preview %q and %d messages", m.streamPreview, len(m.messages))
+	}
+}
@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/shawkym/agentpipe/internal/registry"
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// ErrPickerCancelled is returned by RunAgentPicker when the user quits without confirming
+var ErrPickerCancelled = errors.New("agent selection cancelled")
+
+// pickerEntry is one registry agent shown in the picker
+type pickerEntry struct {
+	Type      string // agent type, the lowercase registry name
+	Label     string // display name from the registry
+	Command   string
+	Installed bool
+	Selected  bool
+	Name      string // optional name override
+	Model     string // optional model override
+}
+
+// pickerField identifies which entry field is being edited
+type pickerField int
+
+const (
+	pickerFieldNone pickerField = iota
+	pickerFieldName
+	pickerFieldModel
+)
+
+// pickerModel is the Bubble Tea model for the pre-conversation agent picker
+type pickerModel struct {
+	entries   []pickerEntry
+	cursor    int
+	editing   pickerField
+	input     textinput.Model
+	err       error
+	confirmed bool
+	cancelled bool
+	result    []agent.AgentConfig
+}
+
+var (
+	pickerCursorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("63")).
+				Bold(true)
+
+	pickerMissingStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("240"))
+
+	pickerErrorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("196"))
+)
+
+// newPickerEntries builds picker entries from registry definitions, sorted by name.
+// Definitions without an adapter (isSupported false) are left out since they can't be
+// started. Installed agents are listed first since they're the ones that can actually run.
+func newPickerEntries(defs []*registry.AgentDefinition, isSupported func(agentType string) bool, isInstalled func(command string) bool) []pickerEntry {
+	entries := make([]pickerEntry, 0, len(defs))
+	for _, def := range defs {
+		agentType := strings.ToLower(def.Name)
+		if !isSupported(agentType) {
+			continue
+		}
+		entries = append(entries, pickerEntry{
+			Type:      agentType,
+			Label:     def.Name,
+			Command:   def.Command,
+			Installed: isInstalled(def.Command),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Installed != entries[j].Installed {
+			return entries[i].Installed
+		}
+		return entries[i].Label < entries[j].Label
+	})
+	return entries
+}
+
+// buildAgentConfigs translates the selected picker entries into agent configs.
+// IDs follow the "<type>-<index>" scheme used for --agents specs.
+func buildAgentConfigs(entries []pickerEntry) ([]agent.AgentConfig, error) {
+	configs := make([]agent.AgentConfig, 0, len(entries))
+	names := make(map[string]bool)
+
+	for _, e := range entries {
+		if !e.Selected {
+			continue
+		}
+
+		name := strings.TrimSpace(e.Name)
+		if name == "" {
+			name = e.Label
+		}
+		if names[name] {
+			return nil, fmt.Errorf("duplicate agent name %q; give each agent a unique name", name)
+		}
+		names[name] = true
+
+		configs = append(configs, agent.AgentConfig{
+			ID:    fmt.Sprintf("%s-%d", e.Type, len(configs)),
+			Type:  e.Type,
+			Name:  name,
+			Model: strings.TrimSpace(e.Model),
+		})
+	}
+
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("select at least one agent")
+	}
+	return configs, nil
+}
+
+func newPickerModel(entries []pickerEntry) pickerModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.CharLimit = 64
+	return pickerModel{entries: entries, input: ti}
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.editing != pickerFieldNone {
+		return m.updateEditing(keyMsg)
+	}
+
+	switch keyMsg.String() {
+	case "ctrl+c", "q", "esc":
+		m.cancelled = true
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.entries)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if len(m.entries) > 0 {
+			m.entries[m.cursor].Selected = !m.entries[m.cursor].Selected
+			m.err = nil
+		}
+	case "n":
+		return m.startEditing(pickerFieldName)
+	case "m":
+		return m.startEditing(pickerFieldModel)
+	case "enter":
+		configs, err := buildAgentConfigs(m.entries)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.result = configs
+		m.confirmed = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// startEditing opens the text input for the entry under the cursor
+func (m pickerModel) startEditing(field pickerField) (tea.Model, tea.Cmd) {
+	if len(m.entries) == 0 {
+		return m, nil
+	}
+
+	entry := m.entries[m.cursor]
+	m.editing = field
+	if field == pickerFieldName {
+		m.input.Placeholder = entry.Label
+		m.input.SetValue(entry.Name)
+	} else {
+		m.input.Placeholder = "default model"
+		m.input.SetValue(entry.Model)
+	}
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m pickerModel) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		m.cancelled = true
+		return m, tea.Quit
+	case "esc":
+		m.editing = pickerFieldNone
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		if m.editing == pickerFieldName {
+			m.entries[m.cursor].Name = value
+		} else {
+			m.entries[m.cursor].Model = value
+		}
+		// Naming or choosing a model implies the agent should take part
+		if value != "" {
+			m.entries[m.cursor].Selected = true
+		}
+		m.editing = pickerFieldNone
+		m.input.Blur()
+		m.err = nil
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m pickerModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(enhancedTitleStyle.Render("Select agents for this conversation"))
+	b.WriteString("\n\n")
+
+	for i, e := range m.entries {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = pickerCursorStyle.Render("> ")
+		}
+
+		check := "[ ]"
+		if e.Selected {
+			check = "[x]"
+		}
+
+		status := "✅"
+		if !e.Installed {
+			status = "❌"
+		}
+
+		line := fmt.Sprintf("%s %s %s (%s)", check, status, e.Label, e.Command)
+		if e.Name != "" {
+			line += fmt.Sprintf("  name: %s", e.Name)
+		}
+		if e.Model != "" {
+			line += fmt.Sprintf("  model: %s", e.Model)
+		}
+		if !e.Installed {
+			line = pickerMissingStyle.Render(line + "  not installed")
+		}
+
+		b.WriteString(cursor + line + "\n")
+	}
+
+	b.WriteString("\n")
+	switch m.editing {
+	case pickerFieldName:
+		b.WriteString(fmt.Sprintf("Name for %s:\n%s\n", m.entries[m.cursor].Label, m.input.View()))
+	case pickerFieldModel:
+		b.WriteString(fmt.Sprintf("Model for %s:\n%s\n", m.entries[m.cursor].Label, m.input.View()))
+	}
+
+	if m.err != nil {
+		b.WriteString(pickerErrorStyle.Render("Error: "+m.err.Error()) + "\n")
+	}
+
+	help := []string{
+		helpKeyStyle.Render("↑/↓") + " " + helpDescStyle.Render("Move"),
+		helpKeyStyle.Render("Space") + " " + helpDescStyle.Render("Toggle"),
+		helpKeyStyle.Render("n") + " " + helpDescStyle.Render("Name"),
+		helpKeyStyle.Render("m") + " " + helpDescStyle.Render("Model"),
+		helpKeyStyle.Render("Enter") + " " + helpDescStyle.Render("Start"),
+		helpKeyStyle.Render("q") + " " + helpDescStyle.Render("Quit"),
+	}
+	if m.editing != pickerFieldNone {
+		help = []string{
+			helpKeyStyle.Render("Enter") + " " + helpDescStyle.Render("Save"),
+			helpKeyStyle.Render("Esc") + " " + helpDescStyle.Render("Cancel"),
+		}
+	}
+	b.WriteString("\n" + strings.Join(help, "  "))
+
+	return b.String()
+}
+
+// RunAgentPicker shows the interactive agent picker and returns the selected agents.
+// It returns ErrPickerCancelled if the user quits without confirming.
+func RunAgentPicker() ([]agent.AgentConfig, error) {
+	entries := newPickerEntries(registry.GetAll(), agent.HasFactory, func(command string) bool {
+		_, err := exec.LookPath(command)
+		return err == nil
+	})
+
+	final, err := tea.NewProgram(newPickerModel(entries), tea.WithAltScreen()).Run()
+	if err != nil {
+		return nil, fmt.Errorf("agent picker failed: %w", err)
+	}
+
+	m, ok := final.(pickerModel)
+	if !ok || !m.confirmed {
+		return nil, ErrPickerCancelled
+	}
+	return m.result, nil
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shawkym/agentpipe/internal/registry"
+)
+
+func TestNewPickerEntries(t *testing.T) {
+	defs := []*registry.AgentDefinition{
+		{Name: "Gemini", Command: "gemini"},
+		{Name: "Claude", Command: "claude"},
+		{Name: "Amp", Command: "amp"},
+	}
+	installed := map[string]bool{"claude": true, "gemini": true}
+
+	entries := newPickerEntries(defs, func(string) bool { return true }, func(command string) bool { return installed[command] })
+
+	want := []string{"Claude", "Gemini", "Amp"}
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(entries))
+	}
+	for i, label := range want {
+		if entries[i].Label != label {
+			t.Errorf("entry %d: expected %s, got %s", i, label, entries[i].Label)
+		}
+	}
+	if entries[0].Type != "claude" || !entries[0].Installed {
+		t.Errorf("unexpected Claude entry: %+v", entries[0])
+	}
+	if entries[2].Installed {
+		t.Error("expected Amp to be reported as not installed")
+	}
+}
+
+func TestNewPickerEntriesSkipsUnsupportedAgents(t *testing.T) {
+	defs := []*registry.AgentDefinition{
+		{Name: "Claude", Command: "claude"},
+		{Name: "Ollama", Command: "ollama"},
+	}
+	supported := map[string]bool{"claude": true}
+
+	entries := newPickerEntries(defs, func(agentType string) bool { return supported[agentType] }, func(string) bool { return true })
+	if len(entries) != 1 || entries[0].Type != "claude" {
+		t.Errorf("expected only agents with an adapter to be offered, got %+v", entries)
+	}
+}
+
+func TestBuildAgentConfigs(t *testing.T) {
+	entries := []pickerEntry{
+		{Type: "claude", Label: "Claude", Selected: true},
+		{Type: "amp", Label: "Amp"},
+		{Type: "gemini", Label: "Gemini", Selected: true, Name: " Critic ", Model: "gemini-2.5-pro"},
+	}
+
+	configs, err := buildAgentConfigs(entries)
+	if err != nil {
+		t.Fatalf("buildAgentConfigs() error: %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 configs, got %d", len(configs))
+	}
+	if configs[0].ID != "claude-0" || configs[0].Name != "Claude" || configs[0].Model != "" {
+		t.Errorf("unexpected first config: %+v", configs[0])
+	}
+	if configs[1].ID != "gemini-1" || configs[1].Type != "gemini" || configs[1].Name != "Critic" || configs[1].Model != "gemini-2.5-pro" {
+		t.Errorf("unexpected second config: %+v", configs[1])
+	}
+}
+
+func TestBuildAgentConfigsErrors(t *testing.T) {
+	if _, err := buildAgentConfigs([]pickerEntry{{Type: "claude", Label: "Claude"}}); err == nil {
+		t.Error("expected error when nothing is selected")
+	}
+
+	dupes := []pickerEntry{
+		{Type: "claude", Label: "Claude", Selected: true, Name: "Bot"},
+		{Type: "gemini", Label: "Gemini", Selected: true, Name: "Bot"},
+	}
+	if _, err := buildAgentConfigs(dupes); err == nil || !strings.Contains(err.Error(), "duplicate") {
+		t.Errorf("expected duplicate name error, got %v", err)
+	}
+}
+
+func TestPickerModelSelection(t *testing.T) {
+	m := newPickerModel([]pickerEntry{
+		{Type: "claude", Label: "Claude", Installed: true},
+		{Type: "gemini", Label: "Gemini", Installed: true},
+	})
+
+	press := func(keys ...string) {
+		for _, k := range keys {
+			var msg tea.KeyMsg
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "down":
+				msg = tea.KeyMsg{Type: tea.KeyDown}
+			default:
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			}
+			updated, _ := m.Update(msg)
+			m = updated.(pickerModel)
+		}
+	}
+
+	// Confirming with nothing selected keeps the picker open with an error
+	press("enter")
+	if m.confirmed || m.err == nil {
+		t.Fatal("expected confirmation to fail without a selection")
+	}
+
+	// Name the second agent, which also selects it, then confirm
+	press("down", "n", "R", "e", "v", "enter", "enter")
+	if !m.confirmed {
+		t.Fatalf("expected picker to confirm, err: %v", m.err)
+	}
+	if len(m.result) != 1 || m.result[0].Type != "gemini" || m.result[0].Name != "Rev" {
+		t.Errorf("unexpected result: %+v", m.result)
+	}
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/log"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+// stopTimeout bounds how long a restart or quit waits for the old orchestrator
+// goroutine, in case an agent ignores cancellation
+var stopTimeout = 5 * time.Second
+
+// conversationRun tracks the orchestrator goroutine of the enhanced TUI so a restart
+// can stop it. It is shared by every copy of the model.
+type conversationRun struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// track records a newly started orchestrator goroutine, cancelled with cancel, and
+// returns the channel the goroutine must close when it exits.
+func (r *conversationRun) track(cancel context.CancelFunc) chan struct{} {
+	done := make(chan struct{})
+	if r == nil {
+		return done
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancel, r.done = cancel, done
+	return done
+}
+
+// stop cancels the tracked orchestrator and waits up to stopTimeout for its
+// goroutine to exit. The cancel func passed to track is expected to skip the
+// summary, so a discarded conversation doesn't hold the restart up. It reports
+// whether no orchestrator goroutine is left running.
+func (r *conversationRun) stop() bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	r.mu.Unlock()
+
+	if cancel == nil {
+		return true
+	}
+	cancel()
+	select {
+	case <-done:
+		return true
+	case <-time.After(stopTimeout):
+		log.WithField("timeout", stopTimeout.String()).Warn("conversation did not stop in time, continuing without it")
+		return false
+	}
+}
+
+// conversationRestarted carries the fresh orchestrator and message channel for a restart
+type conversationRestarted struct {
+	orch    *orchestrator.Orchestrator
+	msgChan chan agent.Message
+}
+
+// restartConversation stops the running conversation and builds a new orchestrator
+// for the same agents. The agents are reset when the new orchestrator starts.
+func (m EnhancedModel) restartConversation() tea.Cmd {
+	run, build := m.run, m.newOrchestrator
+	return func() tea.Msg {
+		run.stop()
+
+		msgChan := make(chan agent.Message, 100)
+		orch, err := build(msgChan)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("failed to restart conversation: %w", err)}
+		}
+		return conversationRestarted{orch: orch, msgChan: msgChan}
+	}
+}
+
+// resetConversation switches the model to the restarted conversation and clears
+// everything shown about the previous one.
+func (m *EnhancedModel) resetConversation(restart conversationRestarted) {
+	m.orch = restart.orch
+	m.msgChan = restart.msgChan
+	m.msgSendChan = restart.msgChan
+	m.runID++
+
+	m.messages = make([]agent.Message, 0)
+	m.turnCount = 0
+	m.totalCost = 0
+	m.totalTime = 0
+	m.activeAgent = ""
+	m.streamPreview = ""
+	m.speakerOffsets = nil
+	m.err = nil
+	m.running = true
+	m.statusMessage = "Conversation restarted"
+
+	if m.chatLogger != nil {
+		m.chatLogger.LogSystem(fmt.Sprintf("Conversation restarted at %s", time.Now().Format(time.RFC3339)))
+	}
+
+	if m.ready {
+		m.conversation.SetContent(m.renderConversation())
+		m.conversation.GotoTop()
+	}
+}
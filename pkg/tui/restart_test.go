@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/orchestrator"
+)
+
+// newRestartableModel returns an initialized model whose orchestrators come from a test builder
+func newRestartableModel(t *testing.T) (EnhancedModel, *int) {
+	t.Helper()
+	builds := 0
+	m := createTestEnhancedModel(config.NewDefaultConfig(), conversationPanel, false)
+	m.initialized = true
+	m.running = true
+	m.agents = []agent.Agent{&MockAgent{id: "a", name: "Alice", agentType: "mock", available: true}}
+	m.run = &conversationRun{}
+	m.newOrchestrator = func(msgChan chan agent.Message) (*orchestrator.Orchestrator, error) {
+		builds++
+		return orchestrator.NewOrchestrator(orchestrator.OrchestratorConfig{
+			Mode:          orchestrator.ModeRoundRobin,
+			MaxTurns:      1,
+			TurnTimeout:   time.Second,
+			ResponseDelay: time.Millisecond,
+		}, nil), nil
+	}
+	return m, &builds
+}
+
+func TestEnhancedModel_RestartNeedsConfirmation(t *testing.T) {
+	m, builds := newRestartableModel(t)
+	m.messages = []agent.Message{{AgentName: "Alice", Content: "Hello", Role: "agent"}}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = updated.(EnhancedModel)
+	if !m.confirmRestart || cmd != nil {
+		t.Fatalf("expected Ctrl+R to ask for confirmation, got confirm=%v cmd=%v", m.confirmRestart, cmd != nil)
+	}
+
+	// Any key other than y cancels
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(EnhancedModel)
+	if m.confirmRestart || cmd != nil || m.statusMessage != "Restart cancelled" {
+		t.Errorf("expected the restart to be cancelled, got confirm=%v status=%q", m.confirmRestart, m.statusMessage)
+	}
+	if *builds != 0 || len(m.messages) != 1 {
+		t.Error("expected a cancelled restart to leave the conversation alone")
+	}
+}
+
+func TestEnhancedModel_RestartResetsState(t *testing.T) {
+	m, builds := newRestartableModel(t)
+	oldChan := make(chan agent.Message, 1)
+	m.msgChan = oldChan
+	m.messages = []agent.Message{{AgentName: "Alice", Content: "Hello", Role: "agent"}}
+	m.turnCount = 3
+	m.totalCost = 0.25
+	m.totalTime = time.Second
+	m.activeAgent = "Alice"
+
+	// A running conversation that must be stopped before the restart
+	ctx, cancel := context.WithCancel(context.Background())
+	done := m.run.track(cancel)
+	stopped := false
+	go func() {
+		<-ctx.Done()
+		stopped = true
+		close(done)
+	}()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	m = updated.(EnhancedModel)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(EnhancedModel)
+	if cmd == nil {
+		t.Fatal("expected y to start the restart")
+	}
+
+	restart, ok := cmd().(conversationRestarted)
+	if !ok {
+		t.Fatal("expected a conversationRestarted message")
+	}
+	if !stopped {
+		t.Error("expected the old conversation to be stopped before restarting")
+	}
+	if *builds != 1 {
+		t.Errorf("expected one new orchestrator, got %d", *builds)
+	}
+
+	updated, _ = m.Update(restart)
+	m = updated.(EnhancedModel)
+	if m.orch != restart.orch || m.msgChan == (<-chan agent.Message)(oldChan) {
+		t.Error("expected the model to switch to the new orchestrator and channel")
+	}
+	if len(m.messages) != 0 || m.turnCount != 0 || m.totalCost != 0 || m.totalTime != 0 || m.activeAgent != "" {
+		t.Errorf("expected conversation state to be cleared, got messages=%d turns=%d cost=%v time=%v active=%q",
+			len(m.messages), m.turnCount, m.totalCost, m.totalTime, m.activeAgent)
+	}
+	if !m.running || m.runID != 1 || m.statusMessage != "Conversation restarted" {
+		t.Errorf("unexpected state after restart: running=%v run=%d status=%q", m.running, m.runID, m.statusMessage)
+	}
+
+	// Messages still in flight from the old run are dropped
+	updated, _ = m.Update(messageUpdate{message: agent.Message{AgentName: "Alice", Content: "stale", Role: "agent"}, run: 0})
+	if got := updated.(EnhancedModel).messages; len(got) != 0 {
+		t.Errorf("expected a message from the old run to be dropped, got %v", got)
+	}
+	updated, _ = m.Update(messageUpdate{message: agent.Message{AgentName: "Alice", Content: "fresh", Role: "agent"}, run: 1})
+	if got := updated.(EnhancedModel).messages; len(got) != 1 || got[0].Content != "fresh" {
+		t.Errorf("expected a message from the new run to be shown, got %v", got)
+	}
+}
+
+func TestEnhancedModel_RestartIgnoredBeforeInitialization(t *testing.T) {
+	m, _ := newRestartableModel(t)
+	m.initialized = false
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if updated.(EnhancedModel).confirmRestart {
+		t.Error("expected Ctrl+R to be ignored while agents are initializing")
+	}
+}
+
+func TestConversationRunStopIsBounded(t *testing.T) {
+	defer func(timeout time.Duration) { stopTimeout = timeout }(stopTimeout)
+	stopTimeout = 20 * time.Millisecond
+
+	// A conversation that never exits, e.g. an agent ignoring cancellation
+	run := &conversationRun{}
+	cancelled := false
+	run.track(func() { cancelled = true })
+
+	start := time.Now()
+	if run.stop() {
+		t.Error("expected stop to report that the conversation is still running")
+	}
+	if !cancelled {
+		t.Error("expected the conversation to be cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected stop to give up after its timeout, took %v", elapsed)
+	}
+	if !run.stop() {
+		t.Error("expected nothing left to stop after the first call")
+	}
+}
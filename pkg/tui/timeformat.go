@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// resolveTimeFormat returns the configured timestamp layout, falling back to the
+// default when none is configured or the configured layout is invalid
+func resolveTimeFormat(cfg *config.Config) string {
+	if cfg == nil || cfg.TUI.TimeFormat == "" {
+		return config.DefaultTimeFormat
+	}
+	if err := config.ValidateTimeFormat(cfg.TUI.TimeFormat); err != nil {
+		return config.DefaultTimeFormat
+	}
+	return cfg.TUI.TimeFormat
+}
+
+// formatMessageTime renders a message timestamp using layout.
+// The "relative" layout renders the age of the message at now (e.g. "3s ago").
+func formatMessageTime(timestamp int64, layout string, now time.Time) string {
+	t := time.Unix(timestamp, 0)
+	if layout == config.RelativeTimeFormat {
+		return formatRelativeTime(now.Sub(t))
+	}
+	return t.Format(layout)
+}
+
+// formatRelativeTime renders an age using its largest whole unit
+func formatRelativeTime(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	}
+}
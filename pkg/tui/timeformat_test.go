@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+func TestResolveTimeFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want string
+	}{
+		{name: "nil config", cfg: nil, want: "15:04:05"},
+		{name: "unset", cfg: &config.Config{}, want: "15:04:05"},
+		{name: "custom layout", cfg: &config.Config{TUI: config.TUIConfig{TimeFormat: "3:04PM"}}, want: "3:04PM"},
+		{name: "relative", cfg: &config.Config{TUI: config.TUIConfig{TimeFormat: "relative"}}, want: "relative"},
+		{name: "invalid layout falls back", cfg: &config.Config{TUI: config.TUIConfig{TimeFormat: "HH:MM"}}, want: "15:04:05"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveTimeFormat(tt.cfg); got != tt.want {
+				t.Errorf("resolveTimeFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMessageTime(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name   string
+		ts     time.Time
+		layout string
+		want   string
+	}{
+		{name: "clock layout", ts: now, layout: "15:04:05", want: "12:00:00"},
+		{name: "custom layout", ts: now, layout: "Jan 2 15:04", want: "Jun 1 12:00"},
+		{name: "just now", ts: now, layout: "relative", want: "just now"},
+		{name: "seconds", ts: now.Add(-3 * time.Second), layout: "relative", want: "3s ago"},
+		{name: "minutes", ts: now.Add(-5*time.Minute - 10*time.Second), layout: "relative", want: "5m ago"},
+		{name: "hours", ts: now.Add(-2 * time.Hour), layout: "relative", want: "2h ago"},
+		{name: "days", ts: now.Add(-50 * time.Hour), layout: "relative", want: "2d ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatMessageTime(tt.ts.Unix(), tt.layout, now); got != tt.want {
+				t.Errorf("formatMessageTime() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+)
+
+// clipboardWrite copies text to the system clipboard. Tests replace it.
+var clipboardWrite = clipboard.WriteAll
+
+// visibleMessages returns the messages shown while filterAgent is active.
+// System messages stay visible under a filter; an empty filter shows everything.
+func visibleMessages(messages []agent.Message, filterAgent string) []agent.Message {
+	if filterAgent == "" {
+		return messages
+	}
+	visible := make([]agent.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg.AgentName == filterAgent || msg.Role == "system" {
+			visible = append(visible, msg)
+		}
+	}
+	return visible
+}
+
+// messageHeader returns the "[time] Speaker" line that introduces a message
+func messageHeader(msg agent.Message, timestamp string) string {
+	switch msg.Role {
+	case "system":
+		return fmt.Sprintf("[%s] System", timestamp)
+	case "tool":
+		return fmt.Sprintf("[%s] %s · tool", timestamp, msg.AgentName)
+	default:
+		return fmt.Sprintf("[%s] %s", timestamp, msg.AgentName)
+	}
+}
+
+// renderTranscript renders messages as plain text, one header and body per message
+func renderTranscript(messages []agent.Message, timeFormat string, now time.Time) string {
+	var b strings.Builder
+	for _, msg := range messages {
+		b.WriteString(messageHeader(msg, formatMessageTime(msg.Timestamp, timeFormat, now)))
+		b.WriteString("\n")
+		b.WriteString(strings.TrimRight(msg.Content, "\n"))
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// copyTranscript copies the messages to the clipboard as a plain-text transcript
+// and returns a status message describing the outcome
+func copyTranscript(messages []agent.Message, timeFormat string) string {
+	if len(messages) == 0 {
+		return "Nothing to copy"
+	}
+	if err := clipboardWrite(renderTranscript(messages, timeFormat, time.Now())); err != nil {
+		return fmt.Sprintf("Copy failed: %v", err)
+	}
+	return fmt.Sprintf("Copied %d messages to clipboard", len(messages))
+}
@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/shawkym/agentpipe/pkg/agent"
+	"github.com/shawkym/agentpipe/pkg/config"
+)
+
+// stubClipboard replaces the clipboard for the test and records what was copied
+func stubClipboard(t *testing.T, err error) *string {
+	t.Helper()
+	var copied string
+	original := clipboardWrite
+	clipboardWrite = func(text string) error {
+		copied = text
+		return err
+	}
+	t.Cleanup(func() { clipboardWrite = original })
+	return &copied
+}
+
+func transcriptTestMessages() []agent.Message {
+	ts := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local).Unix()
+	return []agent.Message{
+		{AgentName: "System", Role: "system", Content: "Conversation started", Timestamp: ts},
+		{AgentName: "Alice", Role: "agent", Content: "First point\nwith two lines\n", Timestamp: ts},
+		{AgentName: "Bob", Role: "agent", Content: "Counterpoint", Timestamp: ts},
+		{AgentName: "Alice", Role: "tool", Content: "search(query)", Timestamp: ts},
+	}
+}
+
+func TestRenderTranscript(t *testing.T) {
+	got := renderTranscript(transcriptTestMessages(), "15:04:05", time.Now())
+
+	want := "[15:04:05] System\nConversation started\n\n" +
+		"[15:04:05] Alice\nFirst point\nwith two lines\n\n" +
+		"[15:04:05] Bob\nCounterpoint\n\n" +
+		"[15:04:05] Alice · tool\nsearch(query)\n\n"
+	if got != want {
+		t.Errorf("unexpected transcript:\n%q\nwant:\n%q", got, want)
+	}
+	if strings.Contains(got, "\x1b[") {
+		t.Error("expected a plain-text transcript without ANSI styling")
+	}
+}
+
+func TestVisibleMessages(t *testing.T) {
+	messages := transcriptTestMessages()
+
+	if got := visibleMessages(messages, ""); len(got) != len(messages) {
+		t.Errorf("expected all %d messages without a filter, got %d", len(messages), len(got))
+	}
+
+	got := visibleMessages(messages, "Bob")
+	if len(got) != 2 || got[0].Role != "system" || got[1].AgentName != "Bob" {
+		t.Errorf("expected system message and Bob's message under filter, got %+v", got)
+	}
+}
+
+func TestCopyTranscript(t *testing.T) {
+	copied := stubClipboard(t, nil)
+	if status := copyTranscript(transcriptTestMessages(), "15:04:05"); status != "Copied 4 messages to clipboard" {
+		t.Errorf("unexpected status %q", status)
+	}
+	if !strings.Contains(*copied, "Counterpoint") {
+		t.Errorf("expected transcript on the clipboard, got %q", *copied)
+	}
+
+	if status := copyTranscript(nil, "15:04:05"); status != "Nothing to copy" {
+		t.Errorf("unexpected status for empty transcript %q", status)
+	}
+
+	stubClipboard(t, errors.New("no clipboard utility"))
+	if status := copyTranscript(transcriptTestMessages(), "15:04:05"); status != "Copy failed: no clipboard utility" {
+		t.Errorf("unexpected status on clipboard error %q", status)
+	}
+}
+
+func TestModel_ExecuteCopyCommandRespectsFilter(t *testing.T) {
+	copied := stubClipboard(t, nil)
+
+	m := Model{
+		ctx:         context.Background(),
+		config:      &config.Config{},
+		messages:    transcriptTestMessages(),
+		filterAgent: "Bob",
+	}
+	m.commandInput.SetValue("copy")
+	m.executeCommand()
+
+	if m.statusMessage != "Copied 2 messages to clipboard" {
+		t.Errorf("unexpected status %q", m.statusMessage)
+	}
+	if strings.Contains(*copied, "First point") {
+		t.Errorf("expected filtered-out messages to be left out, got %q", *copied)
+	}
+}
+
+func TestEnhancedModel_CopyKey(t *testing.T) {
+	copied := stubClipboard(t, nil)
+
+	m := createTestEnhancedModel(&config.Config{}, conversationPanel, false)
+	m.messages = transcriptTestMessages()
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Y")})
+	m = updated.(EnhancedModel)
+	if m.statusMessage != "Copied 4 messages to clipboard" {
+		t.Errorf("unexpected status %q", m.statusMessage)
+	}
+	if !strings.Contains(*copied, "Counterpoint") {
+		t.Errorf("expected transcript on the clipboard, got %q", *copied)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/conversation"
 	"github.com/shawkym/agentpipe/pkg/orchestrator"
 )
 
@@ -74,10 +75,12 @@ type Model struct {
 	running            bool
 	err                error
 	statusMessage      string // Temporary status message
+	startedAt          time.Time
 }
 
 type messageUpdate struct {
 	message agent.Message
+	run     int // EnhancedModel run the message belongs to (see EnhancedModel.runID)
 }
 
 type conversationDone struct{}
@@ -92,7 +95,7 @@ func Run(ctx context.Context, cfg *config.Config, agents []agent.Agent) error {
 	searchInput.CharLimit = 100
 
 	commandInput := textinput.New()
-	commandInput.Placeholder = "Enter command (filter <agent> | clear)..."
+	commandInput.Placeholder = "Enter command (filter <agent> | clear | save [path] | copy)..."
 	commandInput.CharLimit = 100
 
 	m := Model{
@@ -108,6 +111,7 @@ func Run(ctx context.Context, cfg *config.Config, agents []agent.Agent) error {
 		searchResults:      make([]int, 0),
 		currentSearchIndex: -1,
 		filterAgent:        "",
+		startedAt:          time.Now(),
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
@@ -260,7 +264,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Initialize command input
 			commandInput := textinput.New()
-			commandInput.Placeholder = "Enter command (filter <agent> | clear)..."
+			commandInput.Placeholder = "Enter command (filter <agent> | clear | save [path] | copy)..."
 			commandInput.CharLimit = 100
 			commandInput, _ = commandInput.Update(nil)
 			m.commandInput = commandInput
@@ -377,24 +381,15 @@ func (m Model) View() string {
 
 func (m Model) renderMessages() string {
 	var b strings.Builder
+	timeFormat := resolveTimeFormat(m.config)
+	now := time.Now()
 
-	for _, msg := range m.messages {
-		// Apply filter if active
-		if m.filterAgent != "" && msg.AgentName != m.filterAgent && msg.Role != "system" {
-			continue
-		}
-
-		timestamp := time.Unix(msg.Timestamp, 0).Format("15:04:05")
-
-		var prefix string
-		var style lipgloss.Style
+	for _, msg := range visibleMessages(m.messages, m.filterAgent) {
+		prefix := messageHeader(msg, formatMessageTime(msg.Timestamp, timeFormat, now))
 
-		if msg.Role == "system" {
-			prefix = fmt.Sprintf("[%s] System", timestamp)
+		style := agentStyle
+		if msg.Role == "system" || msg.Role == "tool" {
 			style = systemStyle
-		} else {
-			prefix = fmt.Sprintf("[%s] %s", timestamp, msg.AgentName)
-			style = agentStyle
 		}
 
 		b.WriteString(style.Render(prefix))
@@ -457,11 +452,51 @@ func (m *Model) executeCommand() {
 			m.viewport.SetContent(m.renderMessages())
 		}
 
+	case "copy":
+		m.statusMessage = copyTranscript(visibleMessages(m.messages, m.filterAgent), resolveTimeFormat(m.config))
+
+	case "save":
+		path := ""
+		if len(parts) > 1 {
+			path = parts[1]
+		}
+		savedPath, err := m.saveState(path)
+		if err != nil {
+			m.statusMessage = fmt.Sprintf("Save failed: %v", err)
+			return
+		}
+		m.statusMessage = fmt.Sprintf("Conversation saved to %s", savedPath)
+
 	default:
 		m.statusMessage = fmt.Sprintf("Unknown command: %s", parts[0])
 	}
 }
 
+// saveState snapshots the messages received so far to a conversation state file
+// without stopping the conversation. An empty path saves to the default state directory.
+func (m *Model) saveState(path string) (string, error) {
+	if path == "" {
+		var err error
+		path, err = conversation.DefaultStatePath()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	messages := make([]agent.Message, len(m.messages))
+	copy(messages, m.messages)
+
+	startedAt := m.startedAt
+	if startedAt.IsZero() {
+		startedAt = time.Now()
+	}
+
+	if err := conversation.NewState(messages, m.config, startedAt).Save(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
 // renderHelp displays the help modal with all keybindings
 func (m Model) renderHelp() string {
 	var b strings.Builder
@@ -523,6 +558,8 @@ func (m Model) renderHelp() string {
 				{"/", "Enter command mode"},
 				{"filter <agent>", "Filter messages by agent name"},
 				{"clear", "Clear active filter"},
+				{"save [path]", "Save the conversation so far"},
+				{"copy", "Copy the visible transcript to the clipboard"},
 				{"Esc", "Exit command mode"},
 			},
 		},
@@ -612,13 +649,7 @@ func (m *Model) scrollToSearchResult() {
 
 func (m Model) startConversation() tea.Cmd {
 	return func() tea.Msg {
-		orchConfig := orchestrator.OrchestratorConfig{
-			Mode:          orchestrator.ConversationMode(m.config.Orchestrator.Mode),
-			TurnTimeout:   m.config.Orchestrator.TurnTimeout,
-			MaxTurns:      m.config.Orchestrator.MaxTurns,
-			ResponseDelay: m.config.Orchestrator.ResponseDelay,
-			InitialPrompt: m.config.Orchestrator.InitialPrompt,
-		}
+		orchConfig := orchestrator.ConfigFrom(m.config)
 
 		writer := &tuiWriter{
 			messageChan: make(chan agent.Message, 100),
@@ -626,6 +657,15 @@ func (m Model) startConversation() tea.Cmd {
 
 		orch := orchestrator.NewOrchestrator(orchConfig, writer)
 
+		// Add the middleware the config asks for
+		chain, err := orchestrator.MiddlewareFrom(m.config)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		for _, mw := range chain {
+			orch.AddMiddleware(mw)
+		}
+
 		for _, a := range m.agents {
 			orch.AddAgent(a)
 		}
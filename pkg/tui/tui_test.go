@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"io"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/shawkym/agentpipe/pkg/agent"
 	"github.com/shawkym/agentpipe/pkg/config"
+	"github.com/shawkym/agentpipe/pkg/conversation"
 )
 
 // TestModel_Init tests the initialization of the simple TUI model
@@ -718,6 +720,78 @@ func TestModel_ExecuteClearCommand(t *testing.T) {
 	}
 }
 
+func TestModel_ExecuteSaveCommand(t *testing.T) {
+	cfg := &config.Config{
+		Orchestrator: config.OrchestratorConfig{Mode: "round-robin"},
+	}
+
+	m := Model{
+		ctx:    context.Background(),
+		config: cfg,
+		messages: []agent.Message{
+			{AgentName: "Agent1", Role: "agent", Content: "Hello"},
+			{AgentName: "Agent2", Role: "agent", Content: "Hi there"},
+		},
+		ready:     true,
+		running:   true,
+		startedAt: time.Now().Add(-time.Minute),
+	}
+
+	sizeMsg := tea.WindowSizeMsg{Width: 100, Height: 40}
+	updatedModel, _ := m.Update(sizeMsg)
+	m = updatedModel.(Model)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	m.commandInput.SetValue("save " + path)
+	m.executeCommand()
+
+	if !strings.Contains(m.statusMessage, path) {
+		t.Errorf("Expected status message to contain the saved path, got '%s'", m.statusMessage)
+	}
+	if !m.running {
+		t.Error("Expected conversation to keep running after save")
+	}
+
+	state, err := conversation.LoadState(path)
+	if err != nil {
+		t.Fatalf("Expected state file to be written: %v", err)
+	}
+	if len(state.Messages) != 2 || state.Messages[1].Content != "Hi there" {
+		t.Errorf("Expected saved state to contain the current messages, got %+v", state.Messages)
+	}
+
+	// Messages arriving after the save must not alter the snapshot
+	m.messages = append(m.messages, agent.Message{AgentName: "Agent1", Role: "agent", Content: "Later"})
+	state, err = conversation.LoadState(path)
+	if err != nil {
+		t.Fatalf("Failed to reload state: %v", err)
+	}
+	if len(state.Messages) != 2 {
+		t.Errorf("Expected snapshot to keep 2 messages, got %d", len(state.Messages))
+	}
+}
+
+func TestModel_ExecuteSaveCommandDefaultPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	m := Model{
+		ctx:      context.Background(),
+		config:   &config.Config{},
+		messages: []agent.Message{{AgentName: "Agent1", Role: "agent", Content: "Hello"}},
+	}
+
+	m.commandInput.SetValue("save")
+	m.executeCommand()
+
+	if !strings.HasPrefix(m.statusMessage, "Conversation saved to ") {
+		t.Fatalf("Expected save confirmation, got '%s'", m.statusMessage)
+	}
+	path := strings.TrimPrefix(m.statusMessage, "Conversation saved to ")
+	if _, err := conversation.LoadState(path); err != nil {
+		t.Errorf("Expected state file at %s: %v", path, err)
+	}
+}
+
 func TestModel_FilterMessages(t *testing.T) {
 	cfg := &config.Config{
 		Orchestrator: config.OrchestratorConfig{Mode: "round-robin"},
@@ -920,6 +994,7 @@ func TestModel_HelpModalContent(t *testing.T) {
 		"?",
 		"filter <agent>",
 		"clear",
+		"save [path]",
 	}
 
 	for _, key := range expectedKeys {
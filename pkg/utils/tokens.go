@@ -61,6 +61,20 @@ func EstimateCost(model string, inputTokens, outputTokens int) float64 {
 	return totalCost
 }
 
+// ContextWindow returns the context window of model in tokens, as listed in the provider
+// registry, or 0 if the model is unknown or its window isn't recorded.
+func ContextWindow(model string) int {
+	if model == "" {
+		return 0
+	}
+	modelInfo, _, err := providers.GetRegistry().GetModel(model)
+	if err != nil {
+		log.WithField("model", model).Debug("model not found in provider registry, context window unknown")
+		return 0
+	}
+	return modelInfo.ContextWindow
+}
+
 // EstimateCostLegacy is the old hardcoded cost estimation function.
 // Deprecated: Use EstimateCost which uses the provider registry instead.
 func EstimateCostLegacy(model string, inputTokens, outputTokens int) float64 {
@@ -171,3 +171,17 @@ func TestEstimateCostLegacy(t *testing.T) {
 		})
 	}
 }
+
+func TestContextWindow(t *testing.T) {
+	if got := ContextWindow("claude-sonnet-4-5-20250929"); got != 200000 {
+		t.Errorf("ContextWindow(claude-sonnet-4-5-20250929) = %d, want 200000", got)
+	}
+	if got := ContextWindow("gpt-4o"); got != 128000 {
+		t.Errorf("ContextWindow(gpt-4o) = %d, want 128000", got)
+	}
+	for _, model := range []string{"", "no-such-model-xyz"} {
+		if got := ContextWindow(model); got != 0 {
+			t.Errorf("ContextWindow(%q) = %d, want 0 for an unknown model", model, got)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// WrapText wraps each line of text to at most width terminal cells, breaking at the
+// last space that fits and splitting words longer than width. Widths are measured in
+// display cells, so multi-byte and wide (e.g. CJK or emoji) characters are never cut
+// in half. A width of 0 or less returns text unchanged.
+func WrapText(text string, width int) string {
+	if width <= 0 {
+		return text
+	}
+
+	var result []string
+	for _, line := range strings.Split(text, "\n") {
+		if runewidth.StringWidth(line) <= width {
+			result = append(result, line)
+			continue
+		}
+
+		for runewidth.StringWidth(line) > width {
+			head, tail := splitAtWidth(line, width)
+			result = append(result, strings.TrimRight(head, " "))
+			line = strings.TrimLeft(tail, " ")
+		}
+		if len(line) > 0 {
+			result = append(result, line)
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// splitAtWidth splits line before the last space within width cells, or at width
+// itself when no space fits. The head always holds at least one rune.
+func splitAtWidth(line string, width int) (head, tail string) {
+	cells := 0
+	cut := 0
+	lastSpace := -1
+	for cut < len(line) {
+		r, size := utf8.DecodeRuneInString(line[cut:])
+		w := runewidth.RuneWidth(r)
+		if cells+w > width {
+			break
+		}
+		if r == ' ' && cut > 0 {
+			lastSpace = cut
+		}
+		cells += w
+		cut += size
+	}
+
+	if lastSpace > 0 {
+		return line[:lastSpace], line[lastSpace:]
+	}
+	if cut == 0 {
+		// A single rune wider than width still has to go somewhere
+		_, cut = utf8.DecodeRuneInString(line)
+	}
+	return line[:cut], line[cut:]
+}
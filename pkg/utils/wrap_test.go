@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// TestWrapText tests text wrapping
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  int // number of lines expected
+	}{
+		{
+			name:  "Short text no wrap",
+			text:  "Hello",
+			width: 20,
+			want:  1,
+		},
+		{
+			name:  "Text exactly at width",
+			text:  "Hello World Here",
+			width: 16,
+			want:  1,
+		},
+		{
+			name:  "Text wraps once",
+			text:  "Hello World This Is A Long Line",
+			width: 20,
+			want:  2,
+		},
+		{
+			name:  "Text with newlines",
+			text:  "Line 1\nLine 2\nLine 3",
+			width: 50,
+			want:  3,
+		},
+		{
+			name:  "Very long word",
+			text:  "Supercalifragilisticexpialidocious",
+			width: 10,
+			want:  4,
+		},
+		{
+			name:  "Zero width",
+			text:  "Hello",
+			width: 0,
+			want:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WrapText(tt.text, tt.width)
+			lines := strings.Split(result, "\n")
+			if len(lines) != tt.want {
+				t.Errorf("Expected %d lines, got %d\nInput: %q\nResult: %q", tt.want, len(lines), tt.text, result)
+			}
+		})
+	}
+}
+
+func TestWrapTextWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+	}{
+		{"ascii", "The quick brown fox jumps over the lazy dog and keeps on running", 12},
+		{"accented", "Ça déménage énormément à Zürich, où l'été s'éternise", 10},
+		{"wide characters", "日本語のテキストは空白なしで折り返す必要があります", 9},
+		{"emoji", "🚀🚀🚀 launch 🚀🚀🚀 again", 5},
+		{"long word", "Supercalifragilisticexpialidocious", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WrapText(tt.text, tt.width)
+			for _, line := range strings.Split(result, "\n") {
+				if !utf8.ValidString(line) {
+					t.Errorf("line %q splits a multi-byte character", line)
+				}
+				if w := runewidth.StringWidth(line); w > tt.width {
+					t.Errorf("line %q is %d cells wide, want at most %d", line, w, tt.width)
+				}
+			}
+			if got, want := strings.Join(strings.Fields(result), ""), strings.Join(strings.Fields(tt.text), ""); got != want {
+				t.Errorf("wrapping lost text: got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestWrapTextSingleWideRune(t *testing.T) {
+	// A character wider than the width gets a line of its own rather than looping forever
+	if got := WrapText("日本", 1); got != "日\n本" {
+		t.Errorf("expected one character per line, got %q", got)
+	}
+}
+
+func BenchmarkWrapText(b *testing.B) {
+	text := strings.Repeat("Hello World ", 100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		WrapText(text, 80)
+	}
+}